@@ -3,16 +3,20 @@ package main
 import (
 	"Education_Dashboard/internal/application"
 	"Education_Dashboard/internal/application/handlers"
+	"Education_Dashboard/internal/helper"
 	"Education_Dashboard/internal/infrastructure/db/postgresql/repo"
 
 	"Education_Dashboard/internal/infrastructure/http"
 	"Education_Dashboard/internal/infrastructure/http/handler"
 	"Education_Dashboard/internal/infrastructure/http/middleware"
 	"Education_Dashboard/internal/infrastructure/keycloak"
+	"Education_Dashboard/internal/models"
 	"context"
 	"fmt"
 	"log"
 	"os"
+	"time"
+	_ "time/tzdata"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -34,6 +38,15 @@ var (
 	db_user          string
 	db_password      string
 	app_frontend_url string
+
+	// School time zone, e.g. Europe/Istanbul
+	school_timezone string
+
+	// How often attendance alerts are evaluated for every student
+	attendance_alert_interval string
+
+	// How often ended lessons are checked for students without attendance
+	roll_call_check_interval string
 )
 
 func init() {
@@ -54,9 +67,41 @@ func init() {
 	db_name = os.Getenv("DB_POSTGRES_NAME")
 	db_user = os.Getenv("DB_POSTGRES_USER")
 	db_password = os.Getenv("DB_POSTGRES_PASSWORD")
+
+	school_timezone = os.Getenv("SCHOOL_TIMEZONE")
+	if school_timezone == "" {
+		school_timezone = "UTC" // Default to UTC if SCHOOL_TIMEZONE is not set
+	}
+
+	attendance_alert_interval = os.Getenv("ATTENDANCE_ALERT_INTERVAL")
+	if attendance_alert_interval == "" {
+		attendance_alert_interval = "24h" // Default to once a day if ATTENDANCE_ALERT_INTERVAL is not set
+	}
+
+	roll_call_check_interval = os.Getenv("ROLL_CALL_CHECK_INTERVAL")
+	if roll_call_check_interval == "" {
+		roll_call_check_interval = "5m" // Default to every five minutes if ROLL_CALL_CHECK_INTERVAL is not set
+	}
 }
 
 func main() {
+	// Dates and times are interpreted in the school time zone
+	schoolLocation, err := time.LoadLocation(school_timezone)
+	if err != nil {
+		log.Fatal("Invalid SCHOOL_TIMEZONE:", err)
+	}
+	helper.SetSchoolLocation(schoolLocation)
+
+	alertInterval, err := time.ParseDuration(attendance_alert_interval)
+	if err != nil || alertInterval <= 0 {
+		log.Fatal("Invalid ATTENDANCE_ALERT_INTERVAL:", attendance_alert_interval)
+	}
+
+	rollCallInterval, err := time.ParseDuration(roll_call_check_interval)
+	if err != nil || rollCallInterval <= 0 {
+		log.Fatal("Invalid ROLL_CALL_CHECK_INTERVAL:", roll_call_check_interval)
+	}
+
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
 		ErrorHandler: func(c *fiber.Ctx, err error) error {
@@ -92,12 +137,30 @@ func main() {
 	homeworkRepo := repo.NewHomeworkRepository(dbPool)
 	lessonRepo := repo.NewLessonRepository(dbPool)
 	scheduleRepo := repo.NewSchuedleRepository(dbPool)
+	scheduleSeriesRepo := repo.NewScheduleSeriesRepository(dbPool)
+	roomRepo := repo.NewRoomRepository(dbPool)
+	calendarFeedRepo := repo.NewCalendarFeedRepository(dbPool)
+	academicCalendarRepo := repo.NewAcademicCalendarRepository(dbPool)
+	teacherAbsenceRepo := repo.NewTeacherAbsenceRepository(dbPool)
+	availabilityRepo := repo.NewAvailabilityRepository(dbPool)
+	workloadLimitRepo := repo.NewWorkloadLimitRepository(dbPool)
+	bellScheduleRepo := repo.NewBellScheduleRepository(dbPool)
+	examRepo := repo.NewExamRepository(dbPool)
+	excuseRepo := repo.NewExcuseRepository(dbPool)
+	attendanceAlertRepo := repo.NewAttendanceAlertRepository(dbPool)
+	checkInRepo := repo.NewCheckInRepository(dbPool)
+	attendanceReportRepo := repo.NewAttendanceReportRepository(dbPool)
+	rollCallRepo := repo.NewRollCallRepository(dbPool)
 
 	// Initialize application services
-	attendanceService := application.NewAttendanceService(attendanceRepo, scheduleRepo)
 	homeworkService := application.NewHomeworkService(homeworkRepo, lessonRepo)
 	lessonService := application.NewLessonService(lessonRepo, homeworkRepo, scheduleRepo)
-	scheduleService := application.NewScheduleService(scheduleRepo, lessonRepo, attendanceRepo)
+	roomService := application.NewRoomService(roomRepo, scheduleRepo, scheduleSeriesRepo)
+	scheduleService := application.NewScheduleService(scheduleRepo, scheduleSeriesRepo, roomRepo, lessonRepo, attendanceRepo, academicCalendarRepo, availabilityRepo, workloadLimitRepo, bellScheduleRepo)
+	academicCalendarService := application.NewAcademicCalendarService(academicCalendarRepo)
+	availabilityService := application.NewAvailabilityService(availabilityRepo)
+	bellScheduleService := application.NewBellScheduleService(bellScheduleRepo)
+	timetableService := application.NewTimetableService(scheduleService, scheduleRepo, lessonRepo, roomRepo, availabilityRepo)
 
 	// Initialize Keycloak service
 	keycloakAuthService, keycloakClassService := keycloak.NewKeycloakAuthService(
@@ -106,6 +169,19 @@ func main() {
 		keycloak_client_secret,
 		keycloak_realm,
 	)
+	attendanceAlertService := application.NewAttendanceAlertService(attendanceAlertRepo, attendanceRepo, scheduleRepo, lessonRepo, keycloakClassService, keycloakAuthService)
+	attendanceService := application.NewAttendanceService(attendanceRepo, scheduleRepo, excuseRepo, keycloakClassService, attendanceAlertService)
+	checkInService := application.NewCheckInService(checkInRepo, scheduleRepo, attendanceService, keycloakClassService)
+	importService := application.NewImportService(scheduleService, scheduleRepo, lessonRepo, roomRepo, keycloakAuthService, keycloakClassService)
+	calendarService := application.NewCalendarService(calendarFeedRepo, scheduleRepo, scheduleSeriesRepo, lessonRepo, roomRepo, homeworkRepo, academicCalendarRepo, keycloakClassService)
+	substitutionService := application.NewSubstitutionService(scheduleService, scheduleRepo, scheduleSeriesRepo, teacherAbsenceRepo, keycloakAuthService)
+	workloadService := application.NewWorkloadService(scheduleService, workloadLimitRepo, academicCalendarRepo, keycloakAuthService)
+	studentTimetableService := application.NewStudentTimetableService(scheduleService, keycloakClassService, lessonRepo, attendanceRepo, keycloakAuthService)
+	examService := application.NewExamService(examRepo, scheduleService, lessonRepo, roomRepo, keycloakClassService, keycloakAuthService)
+	excuseService := application.NewExcuseService(excuseRepo, attendanceRepo, scheduleRepo, keycloakClassService, attendanceAlertService)
+	attendanceReportService := application.NewAttendanceReportService(attendanceReportRepo, lessonRepo, keycloakClassService, keycloakAuthService)
+	attendanceExportService := application.NewAttendanceExportService(attendanceRepo, scheduleRepo, lessonRepo, keycloakClassService)
+	rollCallService := application.NewRollCallService(rollCallRepo, excuseRepo, scheduleService, keycloakClassService, attendanceAlertService)
 
 	// Initialize handlers
 	authHandler := handler.NewKeycloakHandler(keycloakAuthService)
@@ -114,12 +190,36 @@ func main() {
 	homeworkHandler := handlers.NewHomeworkHandler(homeworkService)
 	lessonHandler := handlers.NewLessonHandler(lessonService)
 	scheduleHandler := handlers.NewScheduleHandler(scheduleService)
+	roomHandler := handlers.NewRoomHandler(roomService)
+	timetableHandler := handlers.NewTimetableHandler(timetableService)
+	calendarHandler := handlers.NewCalendarHandler(calendarService)
+	importHandler := handlers.NewImportHandler(importService)
+	academicCalendarHandler := handlers.NewAcademicCalendarHandler(academicCalendarService)
+	substitutionHandler := handlers.NewSubstitutionHandler(substitutionService)
+	availabilityHandler := handlers.NewAvailabilityHandler(availabilityService)
+	workloadHandler := handlers.NewWorkloadHandler(workloadService)
+	bellScheduleHandler := handlers.NewBellScheduleHandler(bellScheduleService)
+	studentTimetableHandler := handlers.NewStudentTimetableHandler(studentTimetableService)
+	examHandler := handlers.NewExamHandler(examService)
+	excuseHandler := handlers.NewExcuseHandler(excuseService)
+	attendanceAlertHandler := handlers.NewAttendanceAlertHandler(attendanceAlertService)
+	checkInHandler := handlers.NewCheckInHandler(checkInService)
+	attendanceReportHandler := handlers.NewAttendanceReportHandler(attendanceReportService)
+	attendanceExportHandler := handlers.NewAttendanceExportHandler(attendanceExportService)
+	rollCallHandler := handlers.NewRollCallHandler(rollCallService)
 
 	// Initialize Auth Middleware
 	authMiddleware := middleware.NewAuthMiddleware(keycloakAuthService)
 
 	// Setup routes
-	http.SetupRoutes(app, authHandler, classHandler, scheduleHandler, attendanceHandler, lessonHandler, homeworkHandler, authMiddleware)
+	http.SetupRoutes(app, authHandler, classHandler, scheduleHandler, attendanceHandler, lessonHandler, homeworkHandler, roomHandler, timetableHandler, calendarHandler, importHandler, academicCalendarHandler, substitutionHandler, availabilityHandler, workloadHandler, bellScheduleHandler, studentTimetableHandler, examHandler, excuseHandler, attendanceAlertHandler, checkInHandler, attendanceReportHandler, attendanceExportHandler, rollCallHandler, authMiddleware)
+
+	// Evaluate attendance alerts on a schedule, so threshold changes reach
+	// students whose attendance did not change
+	go runAttendanceAlerts(attendanceAlertService, alertInterval)
+
+	// Mark students without attendance absent once their lessons ended
+	go runRollCallChecks(rollCallService, rollCallInterval)
 
 	// Health check endpoint
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -136,6 +236,36 @@ func main() {
 	}
 }
 
+func runAttendanceAlerts(alertService models.AttendanceAlertService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		alerts, err := alertService.EvaluateAll()
+		if err != nil {
+			log.Println("Failed to evaluate attendance alerts:", err)
+			continue
+		}
+		log.Printf("Attendance alerts evaluated, %d raised", len(alerts))
+	}
+}
+
+func runRollCallChecks(rollCallService models.RollCallService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		reminders, err := rollCallService.CheckEndedLessons()
+		if err != nil {
+			log.Println("Failed to check ended lessons:", err)
+			continue
+		}
+		if len(reminders) > 0 {
+			log.Printf("Ended lessons checked, %d roll calls incomplete", len(reminders))
+		}
+	}
+}
+
 func initializeDatabase() (*pgxpool.Pool, error) {
 	// Build connection string
 	connStr := fmt.Sprintf(
@@ -153,6 +283,9 @@ func initializeDatabase() (*pgxpool.Pool, error) {
 	config.MaxConns = 10
 	config.MinConns = 2
 
+	// Keep NOW() and CURRENT_DATE on the school clock
+	config.ConnConfig.RuntimeParams["timezone"] = school_timezone
+
 	// Connect to database
 	ctx := context.Background()
 	pool, err := pgxpool.NewWithConfig(ctx, config)
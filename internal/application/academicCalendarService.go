@@ -0,0 +1,285 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type AcademicCalendarService struct {
+	calendarRepo models.AcademicCalendarRepository
+}
+
+func NewAcademicCalendarService(calendarRepo models.AcademicCalendarRepository) models.AcademicCalendarService {
+	return &AcademicCalendarService{
+		calendarRepo: calendarRepo,
+	}
+}
+
+func (acs *AcademicCalendarService) CreateTerm(term *models.Term) error {
+	if err := validateTerm(term); err != nil {
+		return err
+	}
+
+	if err := acs.checkTermOverlap(term); err != nil {
+		return err
+	}
+
+	return acs.calendarRepo.CreateTerm(term)
+}
+
+func (acs *AcademicCalendarService) GetTermByID(id string) (*models.Term, error) {
+	if id == "" {
+		return nil, fmt.Errorf("term ID is required")
+	}
+
+	return acs.calendarRepo.GetTermByID(id)
+}
+
+func (acs *AcademicCalendarService) UpdateTerm(term *models.Term) error {
+	// Validate term exists
+	if _, err := acs.calendarRepo.GetTermByID(term.ID); err != nil {
+		return fmt.Errorf("term not found: %w", err)
+	}
+
+	if err := validateTerm(term); err != nil {
+		return err
+	}
+
+	if err := acs.checkTermOverlap(term); err != nil {
+		return err
+	}
+
+	return acs.calendarRepo.UpdateTerm(term)
+}
+
+func (acs *AcademicCalendarService) DeleteTerm(id string) error {
+	if id == "" {
+		return fmt.Errorf("term ID is required")
+	}
+
+	// Validate term exists
+	if _, err := acs.calendarRepo.GetTermByID(id); err != nil {
+		return fmt.Errorf("term not found: %w", err)
+	}
+
+	return acs.calendarRepo.DeleteTerm(id)
+}
+
+func (acs *AcademicCalendarService) GetAllTerms() ([]models.Term, error) {
+	return acs.calendarRepo.GetAllTerms()
+}
+
+func (acs *AcademicCalendarService) CreateClosure(closure *models.Closure) error {
+	if err := validateClosure(closure); err != nil {
+		return err
+	}
+
+	return acs.calendarRepo.CreateClosure(closure)
+}
+
+func (acs *AcademicCalendarService) GetClosureByID(id string) (*models.Closure, error) {
+	if id == "" {
+		return nil, fmt.Errorf("closure ID is required")
+	}
+
+	return acs.calendarRepo.GetClosureByID(id)
+}
+
+func (acs *AcademicCalendarService) UpdateClosure(closure *models.Closure) error {
+	// Validate closure exists
+	if _, err := acs.calendarRepo.GetClosureByID(closure.ID); err != nil {
+		return fmt.Errorf("closure not found: %w", err)
+	}
+
+	if err := validateClosure(closure); err != nil {
+		return err
+	}
+
+	return acs.calendarRepo.UpdateClosure(closure)
+}
+
+func (acs *AcademicCalendarService) DeleteClosure(id string) error {
+	if id == "" {
+		return fmt.Errorf("closure ID is required")
+	}
+
+	// Validate closure exists
+	if _, err := acs.calendarRepo.GetClosureByID(id); err != nil {
+		return fmt.Errorf("closure not found: %w", err)
+	}
+
+	return acs.calendarRepo.DeleteClosure(id)
+}
+
+func (acs *AcademicCalendarService) GetAllClosures() ([]models.Closure, error) {
+	return acs.calendarRepo.GetAllClosures()
+}
+
+func (acs *AcademicCalendarService) CheckDate(date time.Time) error {
+	return checkAcademicDate(acs.calendarRepo, date)
+}
+
+func (acs *AcademicCalendarService) GetClosureDays(from, to time.Time) ([]models.ClosureDay, error) {
+	return closureDays(acs.calendarRepo, from, to)
+}
+
+func (acs *AcademicCalendarService) checkTermOverlap(term *models.Term) error {
+	terms, err := acs.calendarRepo.GetAllTerms()
+	if err != nil {
+		return fmt.Errorf("failed to check existing terms: %w", err)
+	}
+
+	for _, existing := range terms {
+		if existing.ID == term.ID {
+			continue
+		}
+		if !term.StartDate.After(dateOnly(existing.EndDate)) && !term.EndDate.Before(dateOnly(existing.StartDate)) {
+			return fmt.Errorf("term overlaps with term '%s'", existing.Name)
+		}
+	}
+
+	return nil
+}
+
+func validateTerm(term *models.Term) error {
+	term.Name = strings.TrimSpace(term.Name)
+	if term.Name == "" {
+		return fmt.Errorf("term name is required")
+	}
+
+	if term.StartDate.IsZero() || term.EndDate.IsZero() {
+		return fmt.Errorf("term start and end dates are required")
+	}
+
+	term.StartDate = dateOnly(term.StartDate)
+	term.EndDate = dateOnly(term.EndDate)
+	if term.EndDate.Before(term.StartDate) {
+		return fmt.Errorf("term end date must not be before its start date")
+	}
+
+	return nil
+}
+
+func validateClosure(closure *models.Closure) error {
+	closure.Name = strings.TrimSpace(closure.Name)
+	if closure.Name == "" {
+		return fmt.Errorf("closure name is required")
+	}
+
+	if closure.Kind == "" {
+		closure.Kind = models.ClosureHoliday
+	}
+	if closure.Kind != models.ClosureHoliday && closure.Kind != models.ClosureSchool {
+		return fmt.Errorf("closure kind must be %s or %s", models.ClosureHoliday, models.ClosureSchool)
+	}
+
+	if closure.StartDate.IsZero() {
+		return fmt.Errorf("closure start date is required")
+	}
+
+	// A single day closure only needs the start date
+	if closure.EndDate.IsZero() {
+		closure.EndDate = closure.StartDate
+	}
+
+	closure.StartDate = dateOnly(closure.StartDate)
+	closure.EndDate = dateOnly(closure.EndDate)
+	if closure.EndDate.Before(closure.StartDate) {
+		return fmt.Errorf("closure end date must not be before its start date")
+	}
+
+	return nil
+}
+
+// checkAcademicDate rejects closure days and, once terms are defined, days
+// outside every term
+func checkAcademicDate(calendarRepo models.AcademicCalendarRepository, date time.Time) error {
+	date = dateOnly(date)
+
+	closures, err := calendarRepo.GetClosuresBetween(date, date)
+	if err != nil {
+		return fmt.Errorf("failed to check closures: %w", err)
+	}
+	if len(closures) > 0 {
+		return fmt.Errorf("cannot schedule on %s: %s (%s)", date.Format("2006-01-02"), closures[0].Name, closures[0].Kind)
+	}
+
+	terms, err := calendarRepo.GetAllTerms()
+	if err != nil {
+		return fmt.Errorf("failed to check terms: %w", err)
+	}
+	if len(terms) == 0 {
+		return nil
+	}
+
+	for _, term := range terms {
+		if !date.Before(dateOnly(term.StartDate)) && !date.After(dateOnly(term.EndDate)) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot schedule on %s: date is outside of the academic terms", date.Format("2006-01-02"))
+}
+
+// academicClosedDates returns the days in [from, to) no lesson can be held
+// on: closure days and, once terms are defined, days outside every term
+func academicClosedDates(calendarRepo models.AcademicCalendarRepository, from, to time.Time) (map[time.Time]bool, error) {
+	days, err := closureDays(calendarRepo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	closed := make(map[time.Time]bool, len(days))
+	for _, day := range days {
+		closed[day.Date] = true
+	}
+
+	terms, err := calendarRepo.GetAllTerms()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check terms: %w", err)
+	}
+	if len(terms) == 0 {
+		return closed, nil
+	}
+
+	for date := dateOnly(from); date.Before(dateOnly(to)); date = date.AddDate(0, 0, 1) {
+		inTerm := slices.ContainsFunc(terms, func(term models.Term) bool {
+			return !date.Before(dateOnly(term.StartDate)) && !date.After(dateOnly(term.EndDate))
+		})
+		if !inTerm {
+			closed[date] = true
+		}
+	}
+
+	return closed, nil
+}
+
+// closureDays lists every closed day in [from, to), one entry per day
+func closureDays(calendarRepo models.AcademicCalendarRepository, from, to time.Time) ([]models.ClosureDay, error) {
+	from = dateOnly(from)
+	to = dateOnly(to)
+	if !from.Before(to) {
+		return nil, nil
+	}
+
+	closures, err := calendarRepo.GetClosuresBetween(from, to.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get closures: %w", err)
+	}
+
+	days := []models.ClosureDay{}
+	for date := from; date.Before(to); date = date.AddDate(0, 0, 1) {
+		for _, closure := range closures {
+			if date.Before(dateOnly(closure.StartDate)) || date.After(dateOnly(closure.EndDate)) {
+				continue
+			}
+			days = append(days, models.ClosureDay{Date: date, Name: closure.Name, Kind: closure.Kind})
+			break
+		}
+	}
+
+	return days, nil
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateScheduleRespectsTermsAndClosures(t *testing.T) {
+	date := futureDate()
+	calendarRepo := &fakeCalendarRepo{
+		terms: []models.Term{{ID: "term-1", Name: "Fall", StartDate: date, EndDate: date.AddDate(0, 0, 30)}},
+		closures: []models.Closure{{
+			ID: "closure-1", Name: "Republic Day", Kind: models.ClosureHoliday,
+			StartDate: date.AddDate(0, 0, 2), EndDate: date.AddDate(0, 0, 3),
+		}},
+	}
+	repo := newFakeScheduleRepo()
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, calendarRepo, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	newSchedule := func(day int) *models.Schedule {
+		return &models.Schedule{
+			Date: date.AddDate(0, 0, day), TeacherID: "t1", LessonID: "l1", ClassID: "c1",
+			Time: clock(9, 0), EndTime: clock(9, 40),
+		}
+	}
+
+	if err := service.CreateSchedule(newSchedule(3)); err == nil || !strings.Contains(err.Error(), "Republic Day") {
+		t.Fatalf("expected the holiday to be rejected, got %v", err)
+	}
+
+	if err := service.CreateSchedule(newSchedule(31)); err == nil || !strings.Contains(err.Error(), "outside of the academic terms") {
+		t.Fatalf("expected a date after the term to be rejected, got %v", err)
+	}
+
+	schedule := newSchedule(1)
+	if err := service.CreateSchedule(schedule); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := service.RescheduleSchedule(schedule.ID, date.AddDate(0, 0, 2), clock(9, 0)); err == nil {
+		t.Fatal("expected rescheduling onto the holiday to be rejected")
+	}
+
+	days, err := service.GetClosureDays(date, date.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(days) != 2 || !isSameDay(days[0].Date, date.AddDate(0, 0, 2)) || days[1].Name != "Republic Day" {
+		t.Fatalf("expected both days of the holiday to be annotated, got %+v", days)
+	}
+}
+
+func TestScheduleSeriesSkipsClosures(t *testing.T) {
+	date := futureDate()
+	calendarRepo := &fakeCalendarRepo{
+		closures: []models.Closure{{
+			ID: "closure-1", Name: "Spring Break", Kind: models.ClosureHoliday,
+			StartDate: date.AddDate(0, 0, 7), EndDate: date.AddDate(0, 0, 7),
+		}},
+	}
+	repo := newFakeScheduleRepo()
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, calendarRepo, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	series := &models.ScheduleSeries{
+		TeacherID: "t1", LessonID: "l1", ClassID: "c1",
+		StartDate: date, Time: clock(9, 0), EndTime: clock(9, 40),
+		Weekdays: []time.Weekday{date.Weekday()}, Count: 4,
+	}
+	if err := service.CreateScheduleSeries(series); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(series.ExceptionDates) != 1 || !isSameDay(series.ExceptionDates[0], date.AddDate(0, 0, 7)) {
+		t.Fatalf("expected the break to become an exception date, got %v", series.ExceptionDates)
+	}
+
+	// A closure added after the series was created is skipped as well
+	calendarRepo.closures = append(calendarRepo.closures, models.Closure{
+		ID: "closure-2", Name: "Teacher Training", Kind: models.ClosureSchool,
+		StartDate: date.AddDate(0, 0, 14), EndDate: date.AddDate(0, 0, 14),
+	})
+
+	schedules, err := service.GetSchedulesBetween(date, date.AddDate(0, 0, 28))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(schedules) != 2 || !isSameDay(schedules[0].Date, date) || !isSameDay(schedules[1].Date, date.AddDate(0, 0, 21)) {
+		t.Fatalf("expected only the occurrences outside the closures, got %+v", schedules)
+	}
+}
+
+func TestCreateTermRejectsOverlap(t *testing.T) {
+	start := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	service := NewAcademicCalendarService(&fakeCalendarRepo{})
+
+	if err := service.CreateTerm(&models.Term{Name: "Fall", StartDate: start, EndDate: start.AddDate(0, 4, 0)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := service.CreateTerm(&models.Term{Name: "Spring", StartDate: start.AddDate(0, 4, 0), EndDate: start.AddDate(0, 9, 0)})
+	if err == nil {
+		t.Fatal("expected a term starting on the last day of another term to be rejected")
+	}
+}
@@ -0,0 +1,410 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"log"
+	"slices"
+)
+
+// alertTrendDays is how far back the trend of an at-risk student looks
+const alertTrendDays = 14
+
+// steadyTrendPoints is the change in rate, in percentage points, still
+// reported as a steady trend
+const steadyTrendPoints = 2.0
+
+type AttendanceAlertService struct {
+	alertRepo      models.AttendanceAlertRepository
+	attendanceRepo models.AttendanceRepository
+	scheduleRepo   models.ScheduleRepository
+	lessonRepo     models.LessonRepository
+	classService   models.ClassService
+	userService    models.KeycloakService
+}
+
+func NewAttendanceAlertService(alertRepo models.AttendanceAlertRepository, attendanceRepo models.AttendanceRepository, scheduleRepo models.ScheduleRepository, lessonRepo models.LessonRepository, classService models.ClassService, userService models.KeycloakService) models.AttendanceAlertService {
+	return &AttendanceAlertService{
+		alertRepo:      alertRepo,
+		attendanceRepo: attendanceRepo,
+		scheduleRepo:   scheduleRepo,
+		lessonRepo:     lessonRepo,
+		classService:   classService,
+		userService:    userService,
+	}
+}
+
+func (aas *AttendanceAlertService) CreateThreshold(threshold *models.AttendanceThreshold) error {
+	if err := validateAttendanceThreshold(threshold); err != nil {
+		return err
+	}
+
+	if threshold.LessonID != "" {
+		if _, err := aas.lessonRepo.GetLessonByID(threshold.LessonID); err != nil {
+			return fmt.Errorf("lesson not found: %w", err)
+		}
+	}
+
+	thresholds, err := aas.alertRepo.GetThresholds()
+	if err != nil {
+		return err
+	}
+	for _, existing := range thresholds {
+		if existing.LessonID != threshold.LessonID {
+			continue
+		}
+		if threshold.LessonID == "" {
+			return fmt.Errorf("an overall threshold already exists, update it instead")
+		}
+		return fmt.Errorf("lesson %s already has a threshold, update it instead", threshold.LessonID)
+	}
+
+	return aas.alertRepo.CreateThreshold(threshold)
+}
+
+func (aas *AttendanceAlertService) GetThresholds() ([]models.AttendanceThreshold, error) {
+	return aas.alertRepo.GetThresholds()
+}
+
+// UpdateThreshold changes the rate and lesson count of a threshold; the
+// lesson it applies to stays the same
+func (aas *AttendanceAlertService) UpdateThreshold(threshold *models.AttendanceThreshold) error {
+	if threshold.ID == "" {
+		return fmt.Errorf("threshold ID is required")
+	}
+
+	existing, err := aas.alertRepo.GetThresholdByID(threshold.ID)
+	if err != nil {
+		return fmt.Errorf("threshold not found: %w", err)
+	}
+	threshold.LessonID = existing.LessonID
+
+	if err := validateAttendanceThreshold(threshold); err != nil {
+		return err
+	}
+
+	return aas.alertRepo.UpdateThreshold(threshold)
+}
+
+func (aas *AttendanceAlertService) DeleteThreshold(id string) error {
+	if id == "" {
+		return fmt.Errorf("threshold ID is required")
+	}
+
+	if _, err := aas.alertRepo.GetThresholdByID(id); err != nil {
+		return fmt.Errorf("threshold not found: %w", err)
+	}
+
+	return aas.alertRepo.DeleteThreshold(id)
+}
+
+func (aas *AttendanceAlertService) EvaluateStudent(studentID string) ([]models.AttendanceAlert, error) {
+	return aas.EvaluateStudents(studentID)
+}
+
+func (aas *AttendanceAlertService) EvaluateStudents(studentIDs ...string) ([]models.AttendanceAlert, error) {
+	// Loaded with the first alert raised and shared by the students
+	var users []models.User
+
+	raised := []models.AttendanceAlert{}
+	for _, studentID := range studentIDs {
+		alerts, err := aas.evaluateStudent(studentID, &users)
+		if err != nil {
+			return nil, fmt.Errorf("student %s: %w", studentID, err)
+		}
+		raised = append(raised, alerts...)
+	}
+	return raised, nil
+}
+
+func (aas *AttendanceAlertService) evaluateStudent(studentID string, users *[]models.User) ([]models.AttendanceAlert, error) {
+	if studentID == "" {
+		return nil, fmt.Errorf("student ID is required")
+	}
+
+	thresholds, err := aas.alertRepo.GetThresholds()
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := aas.alertRepo.GetOpenAlertsByStudentID(studentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(thresholds) == 0 && len(open) == 0 {
+		return []models.AttendanceAlert{}, nil
+	}
+
+	// Alerts are kept per lesson, "" being the overall rate
+	openByLesson := make(map[string]models.AttendanceAlert, len(open))
+	for _, alert := range open {
+		openByLesson[alert.LessonID] = alert
+	}
+
+	attendances, schedules, policy, err := aas.studentAttendance(studentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipientIDs []string
+	var guardianPhone string
+	raised := []models.AttendanceAlert{}
+	evaluated := map[string]bool{}
+	for _, threshold := range thresholds {
+		evaluated[threshold.LessonID] = true
+
+		var include func(models.Schedule) bool
+		if threshold.LessonID != "" {
+			include = func(schedule models.Schedule) bool { return schedule.LessonID == threshold.LessonID }
+		}
+		summary := summarizeAttendance(studentID, attendances, schedules, *policy, include)
+
+		// Too few lessons to judge; an open alert stays as it is
+		if summary.Lessons < threshold.MinLessons {
+			continue
+		}
+
+		alert, isOpen := openByLesson[threshold.LessonID]
+		if summary.Rate >= threshold.MinRate {
+			if isOpen {
+				if err := aas.alertRepo.ResolveAlert(alert.ID); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		if isOpen {
+			continue
+		}
+
+		if recipientIDs == nil {
+			recipientIDs, guardianPhone, err = aas.alertRecipients(studentID, users)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		alert = models.AttendanceAlert{
+			StudentID:     studentID,
+			LessonID:      threshold.LessonID,
+			ThresholdID:   threshold.ID,
+			Rate:          summary.Rate,
+			MinRate:       threshold.MinRate,
+			RecipientIDs:  recipientIDs,
+			GuardianPhone: guardianPhone,
+		}
+		if err := aas.alertRepo.CreateAlert(&alert); err != nil {
+			return nil, err
+		}
+		raised = append(raised, alert)
+	}
+
+	// Alerts of deleted thresholds no longer apply
+	for lessonID, alert := range openByLesson {
+		if evaluated[lessonID] {
+			continue
+		}
+		if err := aas.alertRepo.ResolveAlert(alert.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return raised, nil
+}
+
+// EvaluateAll also evaluates students with open alerts who are no longer in
+// a class, so their alerts can be resolved
+func (aas *AttendanceAlertService) EvaluateAll() ([]models.AttendanceAlert, error) {
+	classes, err := aas.classService.GetAllClasses()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get classes: %w", err)
+	}
+
+	var studentIDs []string
+	for _, class := range classes {
+		students, err := aas.classService.GetStudentsByClassID(class.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get class students: %w", err)
+		}
+		for _, student := range students {
+			studentIDs = append(studentIDs, student.ID)
+		}
+	}
+
+	open, err := aas.alertRepo.GetOpenAlerts()
+	if err != nil {
+		return nil, err
+	}
+	for _, alert := range open {
+		studentIDs = append(studentIDs, alert.StudentID)
+	}
+
+	slices.Sort(studentIDs)
+	studentIDs = slices.Compact(studentIDs)
+
+	return aas.EvaluateStudents(studentIDs...)
+}
+
+// GetAtRiskStudents lists the students with open alerts, lowest overall rate
+// first, with the trend of their rate over the last alertTrendDays days
+func (aas *AttendanceAlertService) GetAtRiskStudents() ([]models.AtRiskStudent, error) {
+	alerts, err := aas.alertRepo.GetOpenAlerts()
+	if err != nil {
+		return nil, err
+	}
+
+	var studentIDs []string
+	byStudent := map[string][]models.AttendanceAlert{}
+	for _, alert := range alerts {
+		if _, ok := byStudent[alert.StudentID]; !ok {
+			studentIDs = append(studentIDs, alert.StudentID)
+		}
+		byStudent[alert.StudentID] = append(byStudent[alert.StudentID], alert)
+	}
+
+	cutoff := helper.SchoolToday().AddDate(0, 0, -alertTrendDays)
+	before := func(schedule models.Schedule) bool { return dateOnly(schedule.Date).Before(cutoff) }
+
+	students := []models.AtRiskStudent{}
+	for _, studentID := range studentIDs {
+		attendances, schedules, policy, err := aas.studentAttendance(studentID)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err := aas.userService.GetUserByID(studentID)
+		if err != nil {
+			return nil, fmt.Errorf("student not found: %w", err)
+		}
+
+		current := summarizeAttendance(studentID, attendances, schedules, *policy, nil)
+		student := models.AtRiskStudent{
+			StudentID: studentID,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Rate:      current.Rate,
+			Trend:     models.TrendSteady,
+			Alerts:    byStudent[studentID],
+		}
+
+		previous := summarizeAttendance(studentID, attendances, schedules, *policy, before)
+		if previous.Lessons > 0 {
+			student.PreviousRate = &previous.Rate
+			switch change := current.Rate - previous.Rate; {
+			case change > steadyTrendPoints:
+				student.Trend = models.TrendImproving
+			case change < -steadyTrendPoints:
+				student.Trend = models.TrendDeclining
+			}
+		}
+
+		students = append(students, student)
+	}
+
+	slices.SortStableFunc(students, func(a, b models.AtRiskStudent) int {
+		switch {
+		case a.Rate < b.Rate:
+			return -1
+		case a.Rate > b.Rate:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return students, nil
+}
+
+func (aas *AttendanceAlertService) GetAlertsByRecipientID(recipientID string) ([]models.AttendanceAlert, error) {
+	if recipientID == "" {
+		return nil, fmt.Errorf("recipient ID is required")
+	}
+
+	return aas.alertRepo.GetOpenAlertsByRecipientID(recipientID)
+}
+
+func (aas *AttendanceAlertService) studentAttendance(studentID string) ([]models.Attendance, map[string]*models.Schedule, *models.AttendancePolicy, error) {
+	attendances, err := aas.attendanceRepo.GetAttendanceByStudentID(studentID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get student attendances: %w", err)
+	}
+
+	schedules, err := attendanceSchedules(aas.scheduleRepo, attendances)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	policy, err := aas.attendanceRepo.GetAttendancePolicy()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return attendances, schedules, policy, nil
+}
+
+// alertRecipients returns the homeroom teachers of the student's classes and
+// the admins, and the family phone for the guardian. The users are fetched
+// into users on the first call of an evaluation.
+func (aas *AttendanceAlertService) alertRecipients(studentID string, users *[]models.User) ([]string, string, error) {
+	classes, err := aas.classService.GetClassesByStudentID(studentID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get student classes: %w", err)
+	}
+
+	recipientIDs := []string{}
+	for _, class := range classes {
+		if class.TeacherID != "" {
+			recipientIDs = append(recipientIDs, class.TeacherID)
+		}
+	}
+
+	if *users == nil {
+		all, err := aas.userService.GetAllUsers()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get users: %w", err)
+		}
+		*users = append([]models.User{}, all...)
+	}
+
+	guardianPhone := ""
+	for _, user := range *users {
+		if user.Role == "admin" {
+			recipientIDs = append(recipientIDs, user.ID)
+		}
+		if user.ID == studentID {
+			guardianPhone = user.FamilyPhone
+		}
+	}
+
+	slices.Sort(recipientIDs)
+	return slices.Compact(recipientIDs), guardianPhone, nil
+}
+
+// evaluateAttendanceAlerts re-checks the thresholds of students whose
+// attendance changed. The change is already saved, so a failure is logged
+// and left to the periodic evaluation instead of failing the change.
+func evaluateAttendanceAlerts(alertService models.AttendanceAlertService, studentIDs ...string) {
+	if len(studentIDs) == 0 {
+		return
+	}
+	if _, err := alertService.EvaluateStudents(studentIDs...); err != nil {
+		log.Printf("attendance alert evaluation failed: %v", err)
+	}
+}
+
+func validateAttendanceThreshold(threshold *models.AttendanceThreshold) error {
+	if threshold.MinRate <= 0 || threshold.MinRate > 100 {
+		return fmt.Errorf("minimum rate must be above 0 and at most 100")
+	}
+
+	if threshold.MinLessons < 0 {
+		return fmt.Errorf("minimum lessons must not be negative")
+	}
+
+	if threshold.MinLessons == 0 {
+		threshold.MinLessons = 1
+	}
+
+	return nil
+}
@@ -0,0 +1,304 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type fakeAlertService struct{ models.AttendanceAlertService }
+
+func (fakeAlertService) EvaluateStudent(studentID string) ([]models.AttendanceAlert, error) {
+	return nil, nil
+}
+
+func (fakeAlertService) EvaluateStudents(studentIDs ...string) ([]models.AttendanceAlert, error) {
+	return nil, nil
+}
+
+type memoryAlertRepo struct {
+	thresholds []models.AttendanceThreshold
+	alerts     []models.AttendanceAlert
+}
+
+func (r *memoryAlertRepo) CreateThreshold(threshold *models.AttendanceThreshold) error {
+	threshold.ID = fmt.Sprintf("threshold-%d", len(r.thresholds)+1)
+	r.thresholds = append(r.thresholds, *threshold)
+	return nil
+}
+
+func (r *memoryAlertRepo) GetThresholdByID(id string) (*models.AttendanceThreshold, error) {
+	for _, threshold := range r.thresholds {
+		if threshold.ID == id {
+			return &threshold, nil
+		}
+	}
+	return nil, fmt.Errorf("threshold %s not found", id)
+}
+
+func (r *memoryAlertRepo) GetThresholds() ([]models.AttendanceThreshold, error) {
+	return slices.Clone(r.thresholds), nil
+}
+
+func (r *memoryAlertRepo) UpdateThreshold(threshold *models.AttendanceThreshold) error {
+	for i := range r.thresholds {
+		if r.thresholds[i].ID == threshold.ID {
+			r.thresholds[i] = *threshold
+		}
+	}
+	return nil
+}
+
+func (r *memoryAlertRepo) DeleteThreshold(id string) error {
+	r.thresholds = slices.DeleteFunc(r.thresholds, func(threshold models.AttendanceThreshold) bool { return threshold.ID == id })
+	return nil
+}
+
+func (r *memoryAlertRepo) CreateAlert(alert *models.AttendanceAlert) error {
+	alert.ID = fmt.Sprintf("alert-%d", len(r.alerts)+1)
+	alert.Status = models.AlertOpen
+	r.alerts = append(r.alerts, *alert)
+	return nil
+}
+
+func (r *memoryAlertRepo) GetOpenAlerts() ([]models.AttendanceAlert, error) {
+	return r.openAlerts(func(models.AttendanceAlert) bool { return true }), nil
+}
+
+func (r *memoryAlertRepo) GetOpenAlertsByStudentID(studentID string) ([]models.AttendanceAlert, error) {
+	return r.openAlerts(func(alert models.AttendanceAlert) bool { return alert.StudentID == studentID }), nil
+}
+
+func (r *memoryAlertRepo) GetOpenAlertsByRecipientID(recipientID string) ([]models.AttendanceAlert, error) {
+	return r.openAlerts(func(alert models.AttendanceAlert) bool { return slices.Contains(alert.RecipientIDs, recipientID) }), nil
+}
+
+func (r *memoryAlertRepo) ResolveAlert(id string) error {
+	for i := range r.alerts {
+		if r.alerts[i].ID == id {
+			r.alerts[i].Status = models.AlertResolved
+		}
+	}
+	return nil
+}
+
+func (r *memoryAlertRepo) openAlerts(match func(models.AttendanceAlert) bool) []models.AttendanceAlert {
+	var alerts []models.AttendanceAlert
+	for _, alert := range r.alerts {
+		if alert.Status == models.AlertOpen && match(alert) {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+type alertClassService struct{ rosterClassService }
+
+func (alertClassService) GetAllClasses() ([]models.Class, error) {
+	return []models.Class{{ID: "c1", ClassName: "5A", TeacherID: "t1"}}, nil
+}
+
+func (alertClassService) GetClassesByStudentID(studentID string) ([]models.Class, error) {
+	return []models.Class{{ID: "c1", ClassName: "5A", TeacherID: "t1"}}, nil
+}
+
+type alertUserService struct{ models.KeycloakService }
+
+var alertUsers = []models.User{
+	{ID: "a1", FirstName: "Aylin", LastName: "Admin", Role: "admin"},
+	{ID: "t1", FirstName: "Ada", LastName: "Homeroom", Role: "teacher"},
+	{ID: "s1", FirstName: "Ela", LastName: "Student", Role: "student", FamilyPhone: "+905550000000"},
+	{ID: "s2", FirstName: "Mert", LastName: "Student", Role: "student"},
+}
+
+func (alertUserService) GetAllUsers() ([]models.User, error) {
+	return alertUsers, nil
+}
+
+func (alertUserService) GetUserByID(id string) (models.User, error) {
+	for _, user := range alertUsers {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return models.User{}, fmt.Errorf("user %s not found", id)
+}
+
+func TestAttendanceAlertsRaiseAndResolve(t *testing.T) {
+	date := futureDate()
+	var schedules []models.Schedule
+	for i := range 5 {
+		schedules = append(schedules, models.Schedule{ID: fmt.Sprintf("maths-%d", i+1), Date: date.AddDate(0, 0, i), TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)})
+	}
+	schedules = append(schedules, models.Schedule{ID: "art", Date: date, TeacherID: "t1", LessonID: "l2", ClassID: "c1", Time: clock(11, 0), EndTime: clock(11, 40)})
+	repo := newFakeScheduleRepo(schedules...)
+	attendanceRepo := &memoryAttendanceRepo{}
+	alertRepo := &memoryAlertRepo{}
+	alertService := NewAttendanceAlertService(alertRepo, attendanceRepo, repo, fakeLessonRepo{}, alertClassService{}, alertUserService{})
+	service := NewAttendanceService(attendanceRepo, repo, &fakeExcuseRepo{}, alertClassService{}, alertService)
+
+	if err := alertService.CreateThreshold(&models.AttendanceThreshold{MinRate: 60, MinLessons: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := alertService.CreateThreshold(&models.AttendanceThreshold{MinRate: 90}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected a second overall threshold to be rejected, got %v", err)
+	}
+	if err := alertService.CreateThreshold(&models.AttendanceThreshold{LessonID: "l2", MinRate: 110}); err == nil {
+		t.Fatal("expected a rate above 100 to be rejected")
+	}
+	if err := alertService.CreateThreshold(&models.AttendanceThreshold{LessonID: "l2", MinRate: 100}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Art alone is below its threshold, the overall rate needs three lessons
+	for _, mark := range []struct {
+		scheduleID string
+		status     string
+	}{{"art", models.AttendanceAbsent}, {"maths-1", models.AttendanceAbsent}} {
+		if err := service.MarkAttendance("s1", mark.scheduleID, models.AttendanceMark{Status: mark.status}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(alertRepo.alerts) != 1 || alertRepo.alerts[0].LessonID != "l2" {
+		t.Fatalf("expected only the art alert, got %+v", alertRepo.alerts)
+	}
+
+	alert := alertRepo.alerts[0]
+	if !slices.Equal(alert.RecipientIDs, []string{"a1", "t1"}) || alert.GuardianPhone != "+905550000000" {
+		t.Fatalf("expected the homeroom teacher, the admin and the guardian to be alerted, got %+v", alert)
+	}
+
+	if err := service.MarkAttendance("s1", "maths-2", models.AttendanceMark{Status: models.AttendancePresent}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	open, _ := alertRepo.GetOpenAlertsByStudentID("s1")
+	if len(open) != 2 || open[1].LessonID != "" || open[1].Rate >= 60 {
+		t.Fatalf("expected an overall alert once three lessons count, got %+v", open)
+	}
+
+	// Marking the same lesson again does not raise a second alert
+	if err := service.MarkAttendance("s1", "maths-2", models.AttendanceMark{Status: models.AttendancePresent}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alertRepo.alerts) != 2 {
+		t.Fatalf("expected open alerts to be kept, got %+v", alertRepo.alerts)
+	}
+
+	mine, err := alertService.GetAlertsByRecipientID("t1")
+	if err != nil || len(mine) != 2 {
+		t.Fatalf("expected the homeroom teacher to see both alerts, got %+v, %v", mine, err)
+	}
+
+	for _, scheduleID := range []string{"maths-3", "maths-4", "maths-5"} {
+		if err := service.MarkAttendance("s1", scheduleID, models.AttendanceMark{Status: models.AttendancePresent}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	open, _ = alertRepo.GetOpenAlertsByStudentID("s1")
+	if len(open) != 1 || open[0].LessonID != "l2" {
+		t.Fatalf("expected the overall alert to be resolved above 60%%, got %+v", open)
+	}
+
+	if err := alertService.DeleteThreshold(alertRepo.thresholds[1].ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := alertService.EvaluateAll(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if open, _ = alertRepo.GetOpenAlerts(); len(open) != 0 {
+		t.Fatalf("expected the alert of a deleted threshold to be resolved, got %+v", open)
+	}
+}
+
+// countingUserService counts the user lookups and fails them with err
+type countingUserService struct {
+	alertUserService
+	calls int
+	err   error
+}
+
+func (s *countingUserService) GetAllUsers() ([]models.User, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return alertUsers, nil
+}
+
+func TestRollCallAlertsLookUpUsersOnce(t *testing.T) {
+	repo := newFakeScheduleRepo(models.Schedule{ID: "art", Date: futureDate(), TeacherID: "t1", LessonID: "l2", ClassID: "c1", Time: clock(11, 0), EndTime: clock(11, 40)})
+	attendanceRepo := &memoryAttendanceRepo{}
+	alertRepo := &memoryAlertRepo{}
+	userService := &countingUserService{}
+	alertService := NewAttendanceAlertService(alertRepo, attendanceRepo, repo, fakeLessonRepo{}, alertClassService{}, userService)
+	service := NewAttendanceService(attendanceRepo, repo, &fakeExcuseRepo{}, alertClassService{}, alertService)
+
+	if err := alertService.CreateThreshold(&models.AttendanceThreshold{LessonID: "l2", MinRate: 100}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	marks := []models.RollCallMark{
+		{StudentID: "s1", AttendanceMark: models.AttendanceMark{Status: models.AttendanceAbsent}},
+		{StudentID: "s2", AttendanceMark: models.AttendanceMark{Status: models.AttendanceAbsent}},
+	}
+	if _, err := service.TakeRollCall("art", marks); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(alertRepo.alerts) != 2 || userService.calls != 1 {
+		t.Fatalf("expected both alerts raised with one user lookup, got %+v after %d lookups", alertRepo.alerts, userService.calls)
+	}
+
+	// The attendance is saved even when the alerts cannot be evaluated
+	alertRepo.alerts = nil
+	userService.err = fmt.Errorf("keycloak unavailable")
+	if _, err := service.TakeRollCall("art", marks); err != nil {
+		t.Fatalf("expected the roll call to succeed without alerts, got %v", err)
+	}
+}
+
+func TestAtRiskStudentsShowTrend(t *testing.T) {
+	today := helper.SchoolToday()
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "old-1", Date: today.AddDate(0, 0, -30), TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "old-2", Date: today.AddDate(0, 0, -29), TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "recent-1", Date: today.AddDate(0, 0, -3), TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "recent-2", Date: today.AddDate(0, 0, -2), TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+	)
+	attendanceRepo := &memoryAttendanceRepo{attendances: []models.Attendance{
+		{ID: "a1", StudentID: "s1", ScheduleID: "old-1", Status: models.AttendancePresent},
+		{ID: "a2", StudentID: "s1", ScheduleID: "old-2", Status: models.AttendancePresent},
+		{ID: "a3", StudentID: "s1", ScheduleID: "recent-1", Status: models.AttendanceAbsent},
+		{ID: "a4", StudentID: "s1", ScheduleID: "recent-2", Status: models.AttendanceAbsent},
+		{ID: "a5", StudentID: "s2", ScheduleID: "recent-1", Status: models.AttendanceAbsent},
+		{ID: "a6", StudentID: "s2", ScheduleID: "recent-2", Status: models.AttendanceAbsent},
+	}}
+	alertRepo := &memoryAlertRepo{thresholds: []models.AttendanceThreshold{{ID: "overall", MinRate: 75, MinLessons: 2}}}
+	service := NewAttendanceAlertService(alertRepo, attendanceRepo, repo, fakeLessonRepo{}, alertClassService{}, alertUserService{})
+
+	raised, err := service.EvaluateAll()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(raised) != 2 {
+		t.Fatalf("expected both students to be alerted, got %+v", raised)
+	}
+
+	students, err := service.GetAtRiskStudents()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(students) != 2 || students[0].StudentID != "s2" || students[0].Rate != 0 {
+		t.Fatalf("expected the lowest rate first, got %+v", students)
+	}
+	if students[0].PreviousRate != nil || students[0].Trend != models.TrendSteady {
+		t.Fatalf("expected no trend without earlier lessons, got %+v", students[0])
+	}
+
+	declining := students[1]
+	if declining.FirstName != "Ela" || declining.Rate != 50 || declining.PreviousRate == nil || *declining.PreviousRate != 100 || declining.Trend != models.TrendDeclining {
+		t.Fatalf("expected a declining trend from 100%% to 50%%, got %+v", declining)
+	}
+}
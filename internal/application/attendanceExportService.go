@@ -0,0 +1,128 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+type AttendanceExportService struct {
+	attendanceRepo models.AttendanceRepository
+	scheduleRepo   models.ScheduleRepository
+	lessonRepo     models.LessonRepository
+	classService   models.ClassService
+}
+
+func NewAttendanceExportService(attendanceRepo models.AttendanceRepository, scheduleRepo models.ScheduleRepository, lessonRepo models.LessonRepository, classService models.ClassService) models.AttendanceExportService {
+	return &AttendanceExportService{
+		attendanceRepo: attendanceRepo,
+		scheduleRepo:   scheduleRepo,
+		lessonRepo:     lessonRepo,
+		classService:   classService,
+	}
+}
+
+func (es *AttendanceExportService) GetAttendanceSheet(classID string, from, to time.Time) (*models.AttendanceSheet, error) {
+	if classID == "" {
+		return nil, fmt.Errorf("class ID is required")
+	}
+
+	if from.IsZero() || to.IsZero() {
+		return nil, fmt.Errorf("from and to dates are required")
+	}
+	from, to = dateOnly(from), dateOnly(to)
+	if to.Before(from) {
+		return nil, fmt.Errorf("from date must not be after to date")
+	}
+	if to.Sub(from).Hours()/24 >= maxReportDays {
+		return nil, fmt.Errorf("date range must not exceed %d days", maxReportDays)
+	}
+
+	classes, err := es.classService.GetAllClasses()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get classes: %w", err)
+	}
+	index := slices.IndexFunc(classes, func(class models.Class) bool { return class.ID == classID })
+	if index < 0 {
+		return nil, fmt.Errorf("class not found")
+	}
+
+	schedules, err := es.scheduleRepo.GetSchedulesByClassID(classID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class schedules: %w", err)
+	}
+
+	sheet := &models.AttendanceSheet{
+		ClassID:     classID,
+		ClassName:   classes[index].ClassName,
+		From:        from,
+		To:          to,
+		LessonNames: map[string]string{},
+		Attendances: map[string]map[string]models.Attendance{},
+	}
+	for _, schedule := range schedules {
+		date := dateOnly(schedule.Date)
+		if schedule.Status == models.ScheduleCancelled || date.Before(from) || date.After(to) {
+			continue
+		}
+		sheet.Sessions = append(sheet.Sessions, schedule)
+	}
+	slices.SortStableFunc(sheet.Sessions, func(a, b models.Schedule) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		return a.Time.Compare(b.Time)
+	})
+
+	lessons, err := es.lessonRepo.GetAllLessons()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lessons: %w", err)
+	}
+	for _, lesson := range lessons {
+		sheet.LessonNames[lesson.ID] = lesson.LessonName
+	}
+
+	students, err := es.classService.GetStudentsByClassID(classID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class students: %w", err)
+	}
+	slices.SortStableFunc(students, func(a, b models.User) int {
+		if c := strings.Compare(a.LastName, b.LastName); c != 0 {
+			return c
+		}
+		return strings.Compare(a.FirstName, b.FirstName)
+	})
+	sheet.Students = students
+
+	attendances, err := es.attendanceRepo.GetAttendanceByClassID(classID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, attendance := range attendances {
+		if sheet.Attendances[attendance.StudentID] == nil {
+			sheet.Attendances[attendance.StudentID] = map[string]models.Attendance{}
+		}
+		sheet.Attendances[attendance.StudentID][attendance.ScheduleID] = attendance
+	}
+
+	policy, err := es.attendanceRepo.GetAttendancePolicy()
+	if err != nil {
+		return nil, err
+	}
+	sheet.Policy = *policy
+
+	return sheet, nil
+}
+
+func (es *AttendanceExportService) WriteAttendanceSheet(w io.Writer, sheet *models.AttendanceSheet, format string) error {
+	switch format {
+	case models.ExportCSV:
+		return writeSheetCSV(w, sheet)
+	case models.ExportXLSX:
+		return writeSheetXLSX(w, sheet)
+	}
+	return fmt.Errorf("format must be csv or xlsx")
+}
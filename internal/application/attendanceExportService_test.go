@@ -0,0 +1,76 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAttendanceSheetExport(t *testing.T) {
+	monday := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "tue", Date: monday.AddDate(0, 0, 1), ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "mon", Date: monday, ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "cancelled", Date: monday, ClassID: "c1", Time: clock(11, 0), EndTime: clock(11, 40), Status: models.ScheduleCancelled},
+		models.Schedule{ID: "later", Date: monday.AddDate(0, 1, 0), ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+	)
+	attendanceRepo := &memoryAttendanceRepo{attendances: []models.Attendance{
+		{StudentID: "s1", ScheduleID: "mon", Status: models.AttendancePresent},
+		{StudentID: "s1", ScheduleID: "tue", Status: models.AttendanceLate, MinutesLate: 10},
+		{StudentID: "s2", ScheduleID: "mon", Status: models.AttendanceAbsent},
+		{StudentID: "s2", ScheduleID: "cancelled", Status: models.AttendanceAbsent},
+	}}
+	service := NewAttendanceExportService(attendanceRepo, repo, fakeLessonRepo{}, alertClassService{})
+
+	if _, err := service.GetAttendanceSheet("c1", monday.AddDate(0, 0, 7), monday); err == nil {
+		t.Fatal("expected a reversed range to be refused")
+	}
+
+	sheet, err := service.GetAttendanceSheet("c1", monday, monday.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sheet.Sessions) != 2 || sheet.Sessions[0].ID != "mon" {
+		t.Fatalf("expected the two lessons of the week in order, got %+v", sheet.Sessions)
+	}
+
+	var out bytes.Buffer
+	if err := service.WriteAttendanceSheet(&out, sheet, models.ExportCSV); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(strings.TrimPrefix(out.String(), "\ufeff")), "\n")
+	expected := []string{
+		"Student,2025-03-10 09:00,2025-03-11 09:00,Present,Late,Remote,Excused,Absent,Minutes late,Rate %",
+		"Ela Student,P,L,1,1,0,0,0,10,100",
+		"Mert Student,A,,0,0,0,0,1,0,0",
+		"Nil Student,,,0,0,0,0,0,0,0",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("unexpected CSV:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := service.WriteAttendanceSheet(&out, sheet, models.ExportXLSX); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("expected a zip archive: %v", err)
+	}
+	for _, file := range archive.File {
+		if file.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+		reader, _ := file.Open()
+		content, _ := io.ReadAll(reader)
+		if !strings.Contains(string(content), `<c r="C2" t="inlineStr"><is><t>L</t></is></c>`) || !strings.Contains(string(content), `<c r="J2"><v>100</v></c>`) {
+			t.Fatalf("unexpected worksheet %s", content)
+		}
+		return
+	}
+	t.Fatal("expected a worksheet in the workbook")
+}
@@ -0,0 +1,117 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"strings"
+)
+
+const (
+	// defaultReportDays is the range reported when no dates are given
+	defaultReportDays = 28
+	maxReportDays     = 366
+)
+
+type AttendanceReportService struct {
+	reportRepo   models.AttendanceReportRepository
+	lessonRepo   models.LessonRepository
+	classService models.ClassService
+	userService  models.KeycloakService
+}
+
+func NewAttendanceReportService(reportRepo models.AttendanceReportRepository, lessonRepo models.LessonRepository, classService models.ClassService, userService models.KeycloakService) models.AttendanceReportService {
+	return &AttendanceReportService{
+		reportRepo:   reportRepo,
+		lessonRepo:   lessonRepo,
+		classService: classService,
+		userService:  userService,
+	}
+}
+
+// GetAttendanceReport returns one row per group and period. Without dates it
+// reports the last four weeks.
+func (ars *AttendanceReportService) GetAttendanceReport(filter models.AttendanceReportFilter) (*models.AttendanceReport, error) {
+	switch filter.GroupBy {
+	case models.ReportByStudent, models.ReportByClass, models.ReportByLesson, models.ReportByTeacher:
+	default:
+		return nil, fmt.Errorf("group by must be one of student, class, lesson or teacher")
+	}
+
+	if filter.Period == "" {
+		filter.Period = models.ReportPeriodNone
+	}
+	switch filter.Period {
+	case models.ReportPeriodWeek, models.ReportPeriodMonth, models.ReportPeriodNone:
+	default:
+		return nil, fmt.Errorf("period must be one of week, month or none")
+	}
+
+	if filter.To.IsZero() {
+		filter.To = helper.SchoolToday()
+	}
+	if filter.From.IsZero() {
+		filter.From = filter.To.AddDate(0, 0, -defaultReportDays+1)
+	}
+	filter.From, filter.To = dateOnly(filter.From), dateOnly(filter.To)
+
+	if filter.To.Before(filter.From) {
+		return nil, fmt.Errorf("from date must not be after to date")
+	}
+	if filter.To.Sub(filter.From).Hours()/24 >= maxReportDays {
+		return nil, fmt.Errorf("date range must not exceed %d days", maxReportDays)
+	}
+
+	rows, err := ars.reportRepo.GetAttendanceReport(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := ars.groupNames(filter.GroupBy)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].Name = names[rows[i].GroupID]
+	}
+
+	if rows == nil {
+		rows = []models.AttendanceReportRow{}
+	}
+	return &models.AttendanceReport{
+		AttendanceReportFilter: filter,
+		Rows:                   rows,
+	}, nil
+}
+
+// groupNames maps the IDs of the grouping to display names
+func (ars *AttendanceReportService) groupNames(groupBy string) (map[string]string, error) {
+	names := make(map[string]string)
+	switch groupBy {
+	case models.ReportByClass:
+		classes, err := ars.classService.GetAllClasses()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get classes: %w", err)
+		}
+		for _, class := range classes {
+			names[class.ID] = class.ClassName
+		}
+	case models.ReportByLesson:
+		lessons, err := ars.lessonRepo.GetAllLessons()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get lessons: %w", err)
+		}
+		for _, lesson := range lessons {
+			names[lesson.ID] = lesson.LessonName
+		}
+	default:
+		users, err := ars.userService.GetAllUsers()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get users: %w", err)
+		}
+		for _, user := range users {
+			names[user.ID] = strings.TrimSpace(user.FirstName + " " + user.LastName)
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,64 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeReportRepo struct {
+	filter models.AttendanceReportFilter
+	rows   []models.AttendanceReportRow
+}
+
+func (r *fakeReportRepo) GetAttendanceReport(filter models.AttendanceReportFilter) ([]models.AttendanceReportRow, error) {
+	r.filter = filter
+	return r.rows, nil
+}
+
+func TestAttendanceReportDefaultsAndNames(t *testing.T) {
+	restore := helper.SetNow(func() time.Time { return time.Date(2025, 3, 14, 10, 0, 0, 0, time.UTC) })
+	defer restore()
+
+	repo := &fakeReportRepo{rows: []models.AttendanceReportRow{{GroupID: "s1", Rate: 75, Lessons: 4}}}
+	service := NewAttendanceReportService(repo, fakeLessonRepo{}, alertClassService{}, alertUserService{})
+
+	if _, err := service.GetAttendanceReport(models.AttendanceReportFilter{GroupBy: "room"}); err == nil {
+		t.Fatal("expected an unknown grouping to be refused")
+	}
+	if _, err := service.GetAttendanceReport(models.AttendanceReportFilter{GroupBy: models.ReportByStudent, Period: "day"}); err == nil {
+		t.Fatal("expected an unknown period to be refused")
+	}
+	if _, err := service.GetAttendanceReport(models.AttendanceReportFilter{
+		GroupBy: models.ReportByStudent,
+		From:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+	}); err == nil || !strings.Contains(err.Error(), "must not exceed") {
+		t.Fatalf("expected a range over a year to be refused, got %v", err)
+	}
+
+	report, err := service.GetAttendanceReport(models.AttendanceReportFilter{GroupBy: models.ReportByStudent})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Period != models.ReportPeriodNone {
+		t.Fatalf("expected no period by default, got %q", report.Period)
+	}
+	if !repo.filter.From.Equal(time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)) || !repo.filter.To.Equal(time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the last four weeks, got %s to %s", repo.filter.From, repo.filter.To)
+	}
+	if len(report.Rows) != 1 || report.Rows[0].Name != "Ela Student" {
+		t.Fatalf("expected the student's name on the row, got %+v", report.Rows)
+	}
+
+	repo.rows = []models.AttendanceReportRow{{GroupID: "c1"}}
+	report, err = service.GetAttendanceReport(models.AttendanceReportFilter{GroupBy: models.ReportByClass, Period: models.ReportPeriodMonth})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Rows[0].Name != "5A" {
+		t.Fatalf("expected the class name on the row, got %+v", report.Rows)
+	}
+}
@@ -3,27 +3,39 @@ package application
 import (
 	"Education_Dashboard/internal/models"
 	"fmt"
+	"slices"
+	"strings"
 )
 
 type AttendanceService struct {
 	attendanceRepo models.AttendanceRepository
 	scheduleRepo   models.ScheduleRepository
+	excuseRepo     models.ExcuseRepository
+	classService   models.ClassService
+	alertService   models.AttendanceAlertService
 }
 
-func NewAttendanceService(attendanceRepo models.AttendanceRepository, scheduleRepo models.ScheduleRepository) models.AttendanceService {
+func NewAttendanceService(attendanceRepo models.AttendanceRepository, scheduleRepo models.ScheduleRepository, excuseRepo models.ExcuseRepository, classService models.ClassService, alertService models.AttendanceAlertService) models.AttendanceService {
 	return &AttendanceService{
 		attendanceRepo: attendanceRepo,
 		scheduleRepo:   scheduleRepo,
+		excuseRepo:     excuseRepo,
+		classService:   classService,
+		alertService:   alertService,
 	}
 }
 
 func (as *AttendanceService) CreateAttendance(attendance *models.Attendance) error {
 	// Validate schedule exists
-	_, err := as.scheduleRepo.GetScheduleByID(attendance.ScheduleID)
+	schedule, err := as.scheduleRepo.GetScheduleByID(attendance.ScheduleID)
 	if err != nil {
 		return fmt.Errorf("schedule not found: %w", err)
 	}
 
+	if schedule.Status == models.ScheduleCancelled {
+		return fmt.Errorf("cannot take attendance for a cancelled schedule")
+	}
+
 	// Validate attendance data
 	if attendance.StudentID == "" {
 		return fmt.Errorf("student ID is required")
@@ -33,6 +45,10 @@ func (as *AttendanceService) CreateAttendance(attendance *models.Attendance) err
 		return fmt.Errorf("schedule ID is required")
 	}
 
+	if err := validateAttendanceStatus(attendance); err != nil {
+		return err
+	}
+
 	// Check if attendance already exists for this student and schedule
 	existingAttendances, err := as.attendanceRepo.GetAttendanceByStudentID(attendance.StudentID)
 	if err != nil {
@@ -45,7 +61,27 @@ func (as *AttendanceService) CreateAttendance(attendance *models.Attendance) err
 		}
 	}
 
-	return as.attendanceRepo.CreateAttendance(attendance)
+	excused := []models.Attendance{*attendance}
+	audits, err := applyApprovedExcuses(as.excuseRepo, *schedule, excused)
+	if err != nil {
+		return err
+	}
+	if excused[0].Status != attendance.Status {
+		attendance.Status = excused[0].Status
+		attendance.MinutesLate = 0
+		attendance.Counter = attendanceCounter(nil, attendance.Status)
+	}
+
+	if err := as.attendanceRepo.CreateAttendance(attendance, excuseAudit(audits, attendance.StudentID)); err != nil {
+		return err
+	}
+
+	if err := as.completeSchedule(schedule); err != nil {
+		return err
+	}
+
+	evaluateAttendanceAlerts(as.alertService, attendance.StudentID)
+	return nil
 }
 
 func (as *AttendanceService) GetAttendanceByID(id string) (*models.Attendance, error) {
@@ -63,14 +99,6 @@ func (as *AttendanceService) UpdateAttendance(attendance *models.Attendance) err
 		return fmt.Errorf("attendance not found: %w", err)
 	}
 
-	// Validate schedule exists if changed
-	if existing.ScheduleID != attendance.ScheduleID {
-		_, err := as.scheduleRepo.GetScheduleByID(attendance.ScheduleID)
-		if err != nil {
-			return fmt.Errorf("schedule not found: %w", err)
-		}
-	}
-
 	// Validate required fields
 	if attendance.StudentID == "" {
 		return fmt.Errorf("student ID is required")
@@ -80,19 +108,37 @@ func (as *AttendanceService) UpdateAttendance(attendance *models.Attendance) err
 		return fmt.Errorf("schedule ID is required")
 	}
 
-	// Update counter logic - increment if marking as present
-	if !existing.Here && attendance.Here {
-		attendance.Counter = existing.Counter + 1
-	} else if existing.Here && !attendance.Here {
-		attendance.Counter = existing.Counter - 1
-		if attendance.Counter < 0 {
-			attendance.Counter = 0
-		}
-	} else {
-		attendance.Counter = existing.Counter
+	schedule, err := as.scheduleRepo.GetScheduleByID(attendance.ScheduleID)
+	if err != nil {
+		return fmt.Errorf("schedule not found: %w", err)
 	}
 
-	return as.attendanceRepo.UpdateAttendance(attendance)
+	if err := validateAttendanceStatus(attendance); err != nil {
+		return err
+	}
+
+	// Re-marking an excused student absent keeps the approved excuse
+	excused := []models.Attendance{*attendance}
+	audits, err := applyApprovedExcuses(as.excuseRepo, *schedule, excused)
+	if err != nil {
+		return err
+	}
+	attendance.Status = excused[0].Status
+	attendance.MinutesLate = excused[0].MinutesLate
+
+	attendance.Counter = attendanceCounter(existing, attendance.Status)
+
+	if err := as.attendanceRepo.UpdateAttendance(attendance, excuseAudit(audits, attendance.StudentID)); err != nil {
+		return err
+	}
+
+	// Moving the attendance to another student changes both rates
+	studentIDs := []string{attendance.StudentID}
+	if existing.StudentID != attendance.StudentID {
+		studentIDs = append(studentIDs, existing.StudentID)
+	}
+	evaluateAttendanceAlerts(as.alertService, studentIDs...)
+	return nil
 }
 
 func (as *AttendanceService) DeleteAttendance(id string) error {
@@ -101,12 +147,17 @@ func (as *AttendanceService) DeleteAttendance(id string) error {
 	}
 
 	// Validate attendance exists
-	_, err := as.attendanceRepo.GetAttendanceByID(id)
+	existing, err := as.attendanceRepo.GetAttendanceByID(id)
 	if err != nil {
 		return fmt.Errorf("attendance not found: %w", err)
 	}
 
-	return as.attendanceRepo.DeleteAttendance(id)
+	if err := as.attendanceRepo.DeleteAttendance(id); err != nil {
+		return err
+	}
+
+	evaluateAttendanceAlerts(as.alertService, existing.StudentID)
+	return nil
 }
 
 func (as *AttendanceService) GetAttendanceByStudentID(studentID string) ([]models.Attendance, error) {
@@ -134,33 +185,44 @@ func (as *AttendanceService) GetAttendanceByScheduleID(scheduleID string) ([]mod
 // Additional business methods
 
 func (as *AttendanceService) GetAttendanceRateByStudent(studentID string) (float64, error) {
+	summary, err := as.GetAttendanceSummaryByStudent(studentID)
+	if err != nil {
+		return 0, err
+	}
+
+	return summary.Rate, nil
+}
+
+// GetAttendanceSummaryByStudent weights each lesson by the attendance policy
+// and counts the lessons in each status
+func (as *AttendanceService) GetAttendanceSummaryByStudent(studentID string) (*models.AttendanceSummary, error) {
 	if studentID == "" {
-		return 0, fmt.Errorf("student ID is required")
+		return nil, fmt.Errorf("student ID is required")
 	}
 
 	attendances, err := as.attendanceRepo.GetAttendanceByStudentID(studentID)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get student attendances: %w", err)
+		return nil, fmt.Errorf("failed to get student attendances: %w", err)
 	}
 
-	if len(attendances) == 0 {
-		return 0, nil
+	policy, err := as.attendanceRepo.GetAttendancePolicy()
+	if err != nil {
+		return nil, err
 	}
 
-	totalPresent := 0
-	for _, attendance := range attendances {
-		if attendance.Here {
-			totalPresent++
-		}
+	schedules, err := attendanceSchedules(as.scheduleRepo, attendances)
+	if err != nil {
+		return nil, err
 	}
 
-	return float64(totalPresent) / float64(len(attendances)) * 100, nil
+	return summarizeAttendance(studentID, attendances, schedules, *policy, nil), nil
 }
 
-func (as *AttendanceService) MarkAttendance(studentID, scheduleID string, isPresent bool) error {
+func (as *AttendanceService) MarkAttendance(studentID, scheduleID string, mark models.AttendanceMark) error {
 	if studentID == "" || scheduleID == "" {
 		return fmt.Errorf("student ID and schedule ID are required")
 	}
+	mark.Status = strings.ToLower(strings.TrimSpace(mark.Status))
 
 	// Check if attendance already exists
 	existingAttendances, err := as.attendanceRepo.GetAttendanceByStudentID(studentID)
@@ -171,22 +233,327 @@ func (as *AttendanceService) MarkAttendance(studentID, scheduleID string, isPres
 	for _, existing := range existingAttendances {
 		if existing.ScheduleID == scheduleID {
 			// Update existing attendance
-			existing.Here = isPresent
+			existing.Status = mark.Status
+			existing.MinutesLate = mark.MinutesLate
+			existing.Note = mark.Note
 			return as.UpdateAttendance(&existing)
 		}
 	}
 
 	// Create new attendance record
 	newAttendance := &models.Attendance{
-		StudentID:  studentID,
-		ScheduleID: scheduleID,
-		Here:       isPresent,
-		Counter:    0,
+		StudentID:   studentID,
+		ScheduleID:  scheduleID,
+		Status:      mark.Status,
+		MinutesLate: mark.MinutesLate,
+		Note:        mark.Note,
+		Counter:     0,
 	}
 
-	if isPresent {
+	if attended(mark.Status) {
 		newAttendance.Counter = 1
 	}
 
 	return as.CreateAttendance(newAttendance)
-}
\ No newline at end of file
+}
+
+// TakeRollCall validates every mark before saving any, so a roll call is
+// stored completely or not at all
+func (as *AttendanceService) TakeRollCall(scheduleID string, marks []models.RollCallMark) (*models.Roster, error) {
+	if scheduleID == "" {
+		return nil, fmt.Errorf("schedule ID is required")
+	}
+
+	if len(marks) == 0 {
+		return nil, fmt.Errorf("at least one student is required")
+	}
+
+	schedule, err := as.scheduleRepo.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("schedule not found: %w", err)
+	}
+
+	if schedule.Status == models.ScheduleCancelled {
+		return nil, fmt.Errorf("cannot take attendance for a cancelled schedule")
+	}
+
+	students, err := as.classService.GetStudentsByClassID(schedule.ClassID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class students: %w", err)
+	}
+	inClass := make(map[string]bool, len(students))
+	for _, student := range students {
+		inClass[student.ID] = true
+	}
+
+	existing, err := as.attendanceRepo.GetAttendanceByScheduleID(scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing attendance: %w", err)
+	}
+	previous := make(map[string]*models.Attendance, len(existing))
+	for i := range existing {
+		previous[existing[i].StudentID] = &existing[i]
+	}
+
+	attendances := make([]models.Attendance, 0, len(marks))
+	marked := map[string]bool{}
+	for i, mark := range marks {
+		if mark.StudentID == "" {
+			return nil, fmt.Errorf("student %d: student ID is required", i+1)
+		}
+		if !inClass[mark.StudentID] {
+			return nil, fmt.Errorf("student %s is not in the lesson's class", mark.StudentID)
+		}
+		if marked[mark.StudentID] {
+			return nil, fmt.Errorf("student %s is listed more than once", mark.StudentID)
+		}
+		marked[mark.StudentID] = true
+
+		attendance := models.Attendance{
+			StudentID:   mark.StudentID,
+			ScheduleID:  scheduleID,
+			Status:      mark.Status,
+			MinutesLate: mark.MinutesLate,
+			Note:        mark.Note,
+		}
+		if err := validateAttendanceStatus(&attendance); err != nil {
+			return nil, fmt.Errorf("student %s: %w", mark.StudentID, err)
+		}
+		attendances = append(attendances, attendance)
+	}
+
+	audits, err := applyApprovedExcuses(as.excuseRepo, *schedule, attendances)
+	if err != nil {
+		return nil, err
+	}
+	for i := range attendances {
+		attendances[i].Counter = attendanceCounter(previous[attendances[i].StudentID], attendances[i].Status)
+	}
+
+	if err := as.attendanceRepo.SaveAttendances(attendances, audits); err != nil {
+		return nil, err
+	}
+
+	if err := as.completeSchedule(schedule); err != nil {
+		return nil, err
+	}
+
+	studentIDs := make([]string, 0, len(attendances))
+	for i := range attendances {
+		previous[attendances[i].StudentID] = &attendances[i]
+		studentIDs = append(studentIDs, attendances[i].StudentID)
+	}
+	evaluateAttendanceAlerts(as.alertService, studentIDs...)
+
+	return roster(*schedule, students, previous), nil
+}
+
+// GetRoster lists the students of the lesson's class with the attendance
+// taken so far
+func (as *AttendanceService) GetRoster(scheduleID string) (*models.Roster, error) {
+	if scheduleID == "" {
+		return nil, fmt.Errorf("schedule ID is required")
+	}
+
+	schedule, err := as.scheduleRepo.GetScheduleByID(scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("schedule not found: %w", err)
+	}
+
+	students, err := as.classService.GetStudentsByClassID(schedule.ClassID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class students: %w", err)
+	}
+
+	attendances, err := as.attendanceRepo.GetAttendanceByScheduleID(scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance: %w", err)
+	}
+	byStudent := make(map[string]*models.Attendance, len(attendances))
+	for i := range attendances {
+		byStudent[attendances[i].StudentID] = &attendances[i]
+	}
+
+	return roster(*schedule, students, byStudent), nil
+}
+
+// completeSchedule marks the lesson as held once attendance is taken
+func (as *AttendanceService) completeSchedule(schedule *models.Schedule) error {
+	if schedule.Status == models.ScheduleCompleted {
+		return nil
+	}
+
+	schedule.Status = models.ScheduleCompleted
+	if err := as.scheduleRepo.UpdateSchedule(schedule); err != nil {
+		return fmt.Errorf("failed to complete schedule: %w", err)
+	}
+	return nil
+}
+
+func (as *AttendanceService) GetAttendancePolicy() (*models.AttendancePolicy, error) {
+	return as.attendanceRepo.GetAttendancePolicy()
+}
+
+func (as *AttendanceService) SetAttendancePolicy(policy *models.AttendancePolicy) error {
+	weights := map[string]float64{
+		models.AttendancePresent: policy.PresentWeight,
+		models.AttendanceLate:    policy.LateWeight,
+		models.AttendanceRemote:  policy.RemoteWeight,
+		models.AttendanceExcused: policy.ExcusedWeight,
+	}
+	for status, weight := range weights {
+		if weight < 0 || weight > 1 {
+			return fmt.Errorf("%s weight must be between 0 and 1", status)
+		}
+	}
+
+	return as.attendanceRepo.UpdateAttendancePolicy(policy)
+}
+
+// validateAttendanceStatus normalizes the status and checks the minutes late
+// go with it
+func validateAttendanceStatus(attendance *models.Attendance) error {
+	attendance.Status = strings.ToLower(strings.TrimSpace(attendance.Status))
+	if attendance.Status == "" {
+		return fmt.Errorf("attendance status is required")
+	}
+
+	if !slices.Contains(models.AttendanceStatuses, attendance.Status) {
+		return fmt.Errorf("invalid attendance status %q, use one of %s", attendance.Status, strings.Join(models.AttendanceStatuses, ", "))
+	}
+
+	if attendance.MinutesLate < 0 {
+		return fmt.Errorf("minutes late must not be negative")
+	}
+
+	if attendance.MinutesLate > 0 && attendance.Status != models.AttendanceLate {
+		return fmt.Errorf("minutes late only apply to late students")
+	}
+
+	attendance.Note = strings.TrimSpace(attendance.Note)
+	return nil
+}
+
+// attendanceCounter keeps the counter of the student's attendance in step
+// with the new status; it goes up when the student is marked as attended
+func attendanceCounter(existing *models.Attendance, status string) int {
+	if existing == nil {
+		if attended(status) {
+			return 1
+		}
+		return 0
+	}
+
+	switch {
+	case !attended(existing.Status) && attended(status):
+		return existing.Counter + 1
+	case attended(existing.Status) && !attended(status):
+		return max(existing.Counter-1, 0)
+	default:
+		return existing.Counter
+	}
+}
+
+func roster(schedule models.Schedule, students []models.User, attendances map[string]*models.Attendance) *models.Roster {
+	roster := &models.Roster{ScheduleID: schedule.ID, ClassID: schedule.ClassID, Students: []models.RosterEntry{}}
+	for _, student := range students {
+		roster.Students = append(roster.Students, models.RosterEntry{
+			StudentID:  student.ID,
+			FirstName:  student.FirstName,
+			LastName:   student.LastName,
+			Attendance: attendances[student.ID],
+		})
+	}
+	return roster
+}
+
+// attended reports whether the student took part in the lesson
+func attended(status string) bool {
+	return status == models.AttendancePresent || status == models.AttendanceLate || status == models.AttendanceRemote
+}
+
+// attendanceWeight returns how much a lesson in the status counts towards the
+// rate, and false when the lesson is left out of the rate
+func attendanceWeight(policy models.AttendancePolicy, status string) (float64, bool) {
+	switch status {
+	case models.AttendancePresent:
+		return policy.PresentWeight, true
+	case models.AttendanceLate:
+		return policy.LateWeight, true
+	case models.AttendanceRemote:
+		return policy.RemoteWeight, true
+	case models.AttendanceExcused:
+		return policy.ExcusedWeight, policy.CountExcused
+	default:
+		return 0, true
+	}
+}
+
+// attendanceSchedules loads the lessons of the attendances in one query
+func attendanceSchedules(scheduleRepo models.ScheduleRepository, attendances []models.Attendance) (map[string]*models.Schedule, error) {
+	var ids []string
+	seen := map[string]bool{}
+	for _, attendance := range attendances {
+		if !seen[attendance.ScheduleID] {
+			seen[attendance.ScheduleID] = true
+			ids = append(ids, attendance.ScheduleID)
+		}
+	}
+
+	schedules := make(map[string]*models.Schedule, len(ids))
+	if len(ids) == 0 {
+		return schedules, nil
+	}
+
+	results, err := scheduleRepo.GetSchedulesByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results {
+		schedules[results[i].ID] = &results[i]
+	}
+
+	for _, id := range ids {
+		if schedules[id] == nil {
+			return nil, fmt.Errorf("schedule not found: %s", id)
+		}
+	}
+	return schedules, nil
+}
+
+// summarizeAttendance weights the lessons include accepts, or every lesson
+// when include is nil, by the policy. Cancelled lessons do not count towards
+// the rate.
+func summarizeAttendance(studentID string, attendances []models.Attendance, schedules map[string]*models.Schedule, policy models.AttendancePolicy, include func(models.Schedule) bool) *models.AttendanceSummary {
+	summary := &models.AttendanceSummary{StudentID: studentID, Breakdown: map[string]int{}}
+	for _, status := range models.AttendanceStatuses {
+		summary.Breakdown[status] = 0
+	}
+
+	attendedLessons := 0.0
+	for _, attendance := range attendances {
+		schedule := schedules[attendance.ScheduleID]
+		if schedule.Status == models.ScheduleCancelled {
+			continue
+		}
+		if include != nil && !include(*schedule) {
+			continue
+		}
+
+		summary.Breakdown[attendance.Status]++
+		summary.MinutesLate += attendance.MinutesLate
+
+		weight, counted := attendanceWeight(policy, attendance.Status)
+		if !counted {
+			continue
+		}
+		summary.Lessons++
+		attendedLessons += weight
+	}
+
+	if summary.Lessons > 0 {
+		summary.Rate = attendedLessons / float64(summary.Lessons) * 100
+	}
+
+	return summary
+}
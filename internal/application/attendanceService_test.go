@@ -0,0 +1,257 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryAttendanceRepo struct {
+	attendances []models.Attendance
+	audits      []models.AttendanceAudit
+	policy      *models.AttendancePolicy
+}
+
+func (r *memoryAttendanceRepo) CreateAttendance(attendance *models.Attendance, audit *models.AttendanceAudit) error {
+	attendance.ID = fmt.Sprintf("attendance-%d", len(r.attendances)+1)
+	r.attendances = append(r.attendances, *attendance)
+	r.saveAudit(attendance.ID, audit)
+	return nil
+}
+
+func (r *memoryAttendanceRepo) saveAudit(attendanceID string, audit *models.AttendanceAudit) {
+	if audit == nil {
+		return
+	}
+	audit.AttendanceID = attendanceID
+	r.audits = append(r.audits, *audit)
+}
+
+func (r *memoryAttendanceRepo) GetAttendanceByID(id string) (*models.Attendance, error) {
+	for _, attendance := range r.attendances {
+		if attendance.ID == id {
+			return &attendance, nil
+		}
+	}
+	return nil, fmt.Errorf("attendance %s not found", id)
+}
+
+func (r *memoryAttendanceRepo) UpdateAttendance(attendance *models.Attendance, audit *models.AttendanceAudit) error {
+	for i := range r.attendances {
+		if r.attendances[i].ID == attendance.ID {
+			r.attendances[i] = *attendance
+		}
+	}
+	r.saveAudit(attendance.ID, audit)
+	return nil
+}
+
+func (r *memoryAttendanceRepo) DeleteAttendance(id string) error {
+	return nil
+}
+
+func (r *memoryAttendanceRepo) GetAttendanceByStudentID(studentID string) ([]models.Attendance, error) {
+	var attendances []models.Attendance
+	for _, attendance := range r.attendances {
+		if attendance.StudentID == studentID {
+			attendances = append(attendances, attendance)
+		}
+	}
+	return attendances, nil
+}
+
+func (r *memoryAttendanceRepo) GetAttendanceByScheduleID(scheduleID string) ([]models.Attendance, error) {
+	var attendances []models.Attendance
+	for _, attendance := range r.attendances {
+		if attendance.ScheduleID == scheduleID {
+			attendances = append(attendances, attendance)
+		}
+	}
+	return attendances, nil
+}
+
+func (r *memoryAttendanceRepo) GetAttendanceByClassID(classID string, from, to time.Time) ([]models.Attendance, error) {
+	return r.attendances, nil
+}
+
+func (r *memoryAttendanceRepo) SaveAttendances(attendances []models.Attendance, audits map[string]models.AttendanceAudit) error {
+	for i := range attendances {
+		audit := excuseAudit(audits, attendances[i].StudentID)
+		existing := slices.IndexFunc(r.attendances, func(a models.Attendance) bool {
+			return a.StudentID == attendances[i].StudentID && a.ScheduleID == attendances[i].ScheduleID
+		})
+		if existing >= 0 {
+			attendances[i].ID = r.attendances[existing].ID
+			r.attendances[existing] = attendances[i]
+			r.saveAudit(attendances[i].ID, audit)
+			continue
+		}
+		if err := r.CreateAttendance(&attendances[i], audit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *memoryAttendanceRepo) GetAttendancePolicy() (*models.AttendancePolicy, error) {
+	if r.policy == nil {
+		return fakeAttendanceRepo{}.GetAttendancePolicy()
+	}
+	policy := *r.policy
+	return &policy, nil
+}
+
+func (r *memoryAttendanceRepo) UpdateAttendancePolicy(policy *models.AttendancePolicy) error {
+	r.policy = policy
+	return nil
+}
+
+type rosterClassService struct{ models.ClassService }
+
+func (rosterClassService) GetStudentsByClassID(classID string) ([]models.User, error) {
+	return []models.User{
+		{ID: "s1", FirstName: "Ela", LastName: "Student", Role: "student"},
+		{ID: "s2", FirstName: "Mert", LastName: "Student", Role: "student"},
+		{ID: "s3", FirstName: "Nil", LastName: "Student", Role: "student"},
+	}, nil
+}
+
+func TestAttendanceRateSkipsCancelledSchedules(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "held", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40), Status: models.ScheduleScheduled},
+		models.Schedule{ID: "missed", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(10, 0), EndTime: clock(10, 40), Status: models.ScheduleScheduled},
+		models.Schedule{ID: "cancelled", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(11, 0), EndTime: clock(11, 40), Status: models.ScheduleScheduled},
+	)
+	attendanceRepo := &memoryAttendanceRepo{}
+	service := NewAttendanceService(attendanceRepo, repo, &fakeExcuseRepo{}, rosterClassService{}, fakeAlertService{})
+
+	for _, mark := range []struct {
+		scheduleID string
+		status     string
+	}{{"held", models.AttendancePresent}, {"missed", models.AttendanceAbsent}, {"cancelled", models.AttendanceAbsent}} {
+		if err := service.MarkAttendance("s1", mark.scheduleID, models.AttendanceMark{Status: mark.status}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if repo.schedules["held"].Status != models.ScheduleCompleted {
+		t.Fatalf("expected taking attendance to complete the lesson, got %q", repo.schedules["held"].Status)
+	}
+
+	cancelled := repo.schedules["cancelled"]
+	cancelled.Status = models.ScheduleCancelled
+	repo.schedules["cancelled"] = cancelled
+
+	rate, err := service.GetAttendanceRateByStudent("s1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rate != 50 {
+		t.Fatalf("expected the cancelled lesson to be left out of the rate, got %.1f", rate)
+	}
+
+	if err := service.MarkAttendance("s2", "cancelled", models.AttendanceMark{Status: models.AttendancePresent}); err == nil {
+		t.Fatal("expected attendance for a cancelled lesson to be rejected")
+	}
+}
+
+func TestAttendanceRateWeightsStatuses(t *testing.T) {
+	date := futureDate()
+	var schedules []models.Schedule
+	for i, id := range []string{"present", "late", "remote", "excused", "absent"} {
+		schedules = append(schedules, models.Schedule{ID: id, Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(8+i, 0), EndTime: clock(8+i, 40), Status: models.ScheduleScheduled})
+	}
+	repo := newFakeScheduleRepo(schedules...)
+	attendanceRepo := &memoryAttendanceRepo{}
+	service := NewAttendanceService(attendanceRepo, repo, &fakeExcuseRepo{}, rosterClassService{}, fakeAlertService{})
+
+	if err := service.MarkAttendance("s1", "present", models.AttendanceMark{Status: "on time"}); err == nil || !strings.Contains(err.Error(), "invalid attendance status") {
+		t.Fatalf("expected an unknown status to be rejected, got %v", err)
+	}
+	if err := service.MarkAttendance("s1", "present", models.AttendanceMark{Status: models.AttendancePresent, MinutesLate: 5}); err == nil {
+		t.Fatal("expected minutes late on a present student to be rejected")
+	}
+
+	for _, mark := range []models.AttendanceMark{
+		{Status: models.AttendancePresent},
+		{Status: " Late ", MinutesLate: 20, Note: "bus delay"},
+		{Status: models.AttendanceRemote},
+		{Status: models.AttendanceExcused, Note: "doctor"},
+		{Status: models.AttendanceAbsent},
+	} {
+		scheduleID := strings.ToLower(strings.TrimSpace(mark.Status))
+		if err := service.MarkAttendance("s1", scheduleID, mark); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Excused lessons are left out by default
+	summary, err := service.GetAttendanceSummaryByStudent("s1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.Lessons != 4 || summary.Rate != 75 || summary.MinutesLate != 20 || summary.Breakdown[models.AttendanceLate] != 1 || summary.Breakdown[models.AttendanceExcused] != 1 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+
+	if err := service.SetAttendancePolicy(&models.AttendancePolicy{PresentWeight: 1, LateWeight: 1.5, RemoteWeight: 1}); err == nil {
+		t.Fatal("expected a weight above 1 to be rejected")
+	}
+	if err := service.SetAttendancePolicy(&models.AttendancePolicy{PresentWeight: 1, LateWeight: 0.5, RemoteWeight: 1, ExcusedWeight: 1, CountExcused: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rate, err := service.GetAttendanceRateByStudent("s1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rate != 70 {
+		t.Fatalf("expected (1 + 0.5 + 1 + 1 + 0) / 5 = 70%%, got %.1f", rate)
+	}
+}
+
+func TestRollCallSavesWholeRoster(t *testing.T) {
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "maths", Date: futureDate(), TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40), Status: models.ScheduleScheduled},
+	)
+	attendanceRepo := &memoryAttendanceRepo{attendances: []models.Attendance{
+		{ID: "a1", StudentID: "s1", ScheduleID: "maths", Status: models.AttendanceAbsent},
+	}}
+	service := NewAttendanceService(attendanceRepo, repo, &fakeExcuseRepo{}, rosterClassService{}, fakeAlertService{})
+
+	_, err := service.TakeRollCall("maths", []models.RollCallMark{
+		{StudentID: "s1", AttendanceMark: models.AttendanceMark{Status: models.AttendancePresent}},
+		{StudentID: "s9", AttendanceMark: models.AttendanceMark{Status: models.AttendancePresent}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "not in the lesson's class") {
+		t.Fatalf("expected a student of another class to be rejected, got %v", err)
+	}
+	if attendanceRepo.attendances[0].Status != models.AttendanceAbsent {
+		t.Fatal("expected nothing to be saved when a mark is rejected")
+	}
+
+	roster, err := service.TakeRollCall("maths", []models.RollCallMark{
+		{StudentID: "s1", AttendanceMark: models.AttendanceMark{Status: models.AttendancePresent}},
+		{StudentID: "s2", AttendanceMark: models.AttendanceMark{Status: models.AttendanceLate, MinutesLate: 10}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(roster.Students) != 3 || len(attendanceRepo.attendances) != 2 {
+		t.Fatalf("expected three students with two attendances, got %+v", roster)
+	}
+	ela, mert, untaken := roster.Students[0], roster.Students[1], roster.Students[2]
+	if ela.Attendance == nil || ela.Attendance.ID != "a1" || ela.Attendance.Status != models.AttendancePresent || ela.Attendance.Counter != 1 {
+		t.Fatalf("expected the existing attendance to be updated, got %+v", ela.Attendance)
+	}
+	if mert.Attendance == nil || mert.Attendance.MinutesLate != 10 || untaken.Attendance != nil {
+		t.Fatalf("unexpected roster %+v", roster.Students)
+	}
+	if repo.schedules["maths"].Status != models.ScheduleCompleted {
+		t.Fatalf("expected the roll call to complete the lesson, got %q", repo.schedules["maths"].Status)
+	}
+}
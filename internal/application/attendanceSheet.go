@@ -0,0 +1,209 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// sheetCell is a cell of an exported sheet; numeric cells are written as
+// numbers in XLSX so they can be summed
+type sheetCell struct {
+	value   string
+	numeric bool
+}
+
+func textCell(value string) sheetCell {
+	return sheetCell{value: value}
+}
+
+func numberCell(value float64) sheetCell {
+	return sheetCell{value: strconv.FormatFloat(value, 'f', -1, 64), numeric: true}
+}
+
+// attendanceSheetRows passes the header and then one row per student to
+// emit. Each row ends with the student's totals over the sheet's lessons.
+func attendanceSheetRows(sheet *models.AttendanceSheet, emit func([]sheetCell) error) error {
+	schedules := make(map[string]*models.Schedule, len(sheet.Sessions))
+	header := []sheetCell{textCell("Student")}
+	for i, session := range sheet.Sessions {
+		schedules[session.ID] = &sheet.Sessions[i]
+		label := session.Date.Format("2006-01-02") + " " + session.Time.Format("15:04")
+		if name := sheet.LessonNames[session.LessonID]; name != "" {
+			label += " " + name
+		}
+		header = append(header, textCell(label))
+	}
+	for _, status := range models.AttendanceStatuses {
+		header = append(header, textCell(strings.ToUpper(status[:1])+status[1:]))
+	}
+	header = append(header, textCell("Minutes late"), textCell("Rate %"))
+	if err := emit(header); err != nil {
+		return err
+	}
+
+	for _, student := range sheet.Students {
+		row := []sheetCell{textCell(strings.TrimSpace(student.FirstName + " " + student.LastName))}
+		var attendances []models.Attendance
+		for _, session := range sheet.Sessions {
+			attendance, ok := sheet.Attendances[student.ID][session.ID]
+			if !ok {
+				row = append(row, textCell(""))
+				continue
+			}
+			row = append(row, textCell(models.AttendanceStatusCodes[attendance.Status]))
+			attendances = append(attendances, attendance)
+		}
+
+		summary := summarizeAttendance(student.ID, attendances, schedules, sheet.Policy, nil)
+		for _, status := range models.AttendanceStatuses {
+			row = append(row, numberCell(float64(summary.Breakdown[status])))
+		}
+		row = append(row, numberCell(float64(summary.MinutesLate)), numberCell(roundRate(summary.Rate)))
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func roundRate(rate float64) float64 {
+	return math.Round(rate*10) / 10
+}
+
+// writeSheetCSV writes the sheet with a byte order mark, so spreadsheet
+// programs read the names as UTF-8
+func writeSheetCSV(w io.Writer, sheet *models.AttendanceSheet) error {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	err := attendanceSheetRows(sheet, func(cells []sheetCell) error {
+		record := make([]string, len(cells))
+		for i, cell := range cells {
+			record[i] = cell.value
+			// Keep names like "=1+1" from being read as formulas
+			if !cell.numeric && cell.value != "" && strings.ContainsRune("=+-@", rune(cell.value[0])) {
+				record[i] = "'" + cell.value
+			}
+		}
+		return writer.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// writeSheetXLSX writes a single worksheet workbook. The worksheet is
+// compressed into the archive row by row instead of being built in memory.
+func writeSheetXLSX(w io.Writer, sheet *models.AttendanceSheet) error {
+	archive := zip.NewWriter(w)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xmlEscape(xlsxSheetName(sheet.ClassName)))},
+	}
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+
+	file, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(file, xlsxSheetStart); err != nil {
+		return err
+	}
+
+	rowNumber := 0
+	err = attendanceSheetRows(sheet, func(cells []sheetCell) error {
+		rowNumber++
+		var row strings.Builder
+		fmt.Fprintf(&row, `<row r="%d">`, rowNumber)
+		for i, cell := range cells {
+			ref := xlsxColumn(i) + strconv.Itoa(rowNumber)
+			switch {
+			case cell.numeric:
+				fmt.Fprintf(&row, `<c r="%s"><v>%s</v></c>`, ref, cell.value)
+			case cell.value != "":
+				fmt.Fprintf(&row, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, xmlEscape(cell.value))
+			}
+		}
+		row.WriteString(`</row>`)
+		_, err := io.WriteString(file, row.String())
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(file, xlsxSheetEnd); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// xlsxColumn returns the letters of the zero based column, A to Z, then AA
+func xlsxColumn(index int) string {
+	var letters []byte
+	for n := index + 1; n > 0; n = (n - 1) / 26 {
+		letters = append([]byte{byte('A' + (n-1)%26)}, letters...)
+	}
+	return string(letters)
+}
+
+// xlsxSheetName drops the characters worksheet names may not contain and
+// keeps the 31 character limit
+func xlsxSheetName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, name)
+	if runes := []rune(strings.TrimSpace(name)); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if strings.TrimSpace(name) == "" {
+		return "Attendance"
+	}
+	return strings.TrimSpace(name)
+}
+
+func xmlEscape(value string) string {
+	var escaped strings.Builder
+	xml.EscapeText(&escaped, []byte(value))
+	return escaped.String()
+}
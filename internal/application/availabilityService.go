@@ -0,0 +1,167 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type AvailabilityService struct {
+	availabilityRepo models.AvailabilityRepository
+}
+
+func NewAvailabilityService(availabilityRepo models.AvailabilityRepository) models.AvailabilityService {
+	return &AvailabilityService{
+		availabilityRepo: availabilityRepo,
+	}
+}
+
+func (as *AvailabilityService) CreateAvailabilityWindow(window *models.AvailabilityWindow) error {
+	if err := validateAvailabilityWindow(window); err != nil {
+		return err
+	}
+
+	return as.availabilityRepo.CreateAvailabilityWindow(window)
+}
+
+func (as *AvailabilityService) UpdateAvailabilityWindow(window *models.AvailabilityWindow) error {
+	existing, err := as.availabilityRepo.GetAvailabilityWindowByID(window.ID)
+	if err != nil {
+		return fmt.Errorf("availability window not found: %w", err)
+	}
+
+	if window.TeacherID != "" && existing.TeacherID != window.TeacherID {
+		return fmt.Errorf("availability window belongs to another teacher")
+	}
+	window.TeacherID = existing.TeacherID
+
+	if err := validateAvailabilityWindow(window); err != nil {
+		return err
+	}
+
+	return as.availabilityRepo.UpdateAvailabilityWindow(window)
+}
+
+// DeleteAvailabilityWindow removes the window; an empty teacherID skips the
+// ownership check for admins
+func (as *AvailabilityService) DeleteAvailabilityWindow(teacherID, id string) error {
+	if id == "" {
+		return fmt.Errorf("availability window ID is required")
+	}
+
+	existing, err := as.availabilityRepo.GetAvailabilityWindowByID(id)
+	if err != nil {
+		return fmt.Errorf("availability window not found: %w", err)
+	}
+
+	if teacherID != "" && existing.TeacherID != teacherID {
+		return fmt.Errorf("availability window belongs to another teacher")
+	}
+
+	return as.availabilityRepo.DeleteAvailabilityWindow(id)
+}
+
+func (as *AvailabilityService) GetAvailabilityWindows(teacherID string) ([]models.AvailabilityWindow, error) {
+	if teacherID == "" {
+		return nil, fmt.Errorf("teacher ID is required")
+	}
+
+	return as.availabilityRepo.GetAvailabilityWindowsByTeacherID(teacherID)
+}
+
+func validateAvailabilityWindow(window *models.AvailabilityWindow) error {
+	if window.TeacherID == "" {
+		return fmt.Errorf("teacher ID is required")
+	}
+
+	if window.Kind == "" {
+		window.Kind = models.AvailabilityAvailable
+	}
+	if window.Kind != models.AvailabilityAvailable && window.Kind != models.AvailabilityUnavailable {
+		return fmt.Errorf("availability kind must be %s or %s", models.AvailabilityAvailable, models.AvailabilityUnavailable)
+	}
+
+	if (window.Weekday == nil) == (window.Date == nil) {
+		return fmt.Errorf("either weekday or date is required, but not both")
+	}
+
+	if window.Weekday != nil && (*window.Weekday < time.Sunday || *window.Weekday > time.Saturday) {
+		return fmt.Errorf("weekday must be between 0 (Sunday) and 6 (Saturday)")
+	}
+
+	if window.Date != nil {
+		date := dateOnly(*window.Date)
+		window.Date = &date
+	}
+
+	// Windows only keep the time of day
+	anchor := time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)
+	window.Time = atClock(anchor, clockOffset(window.Time))
+	window.EndTime = atClock(anchor, clockOffset(window.EndTime))
+	if !window.EndTime.After(window.Time) {
+		return fmt.Errorf("availability end time must be after its start time")
+	}
+
+	window.Note = strings.TrimSpace(window.Note)
+	return nil
+}
+
+// checkTeacherAvailability rejects a lesson the teacher cannot give because of
+// their availability windows
+func checkTeacherAvailability(availabilityRepo models.AvailabilityRepository, teacherID string, schedule models.Schedule) error {
+	windows, err := availabilityRepo.GetAvailabilityWindowsByTeacherID(teacherID)
+	if err != nil {
+		return fmt.Errorf("failed to check teacher availability: %w", err)
+	}
+
+	return availabilityError(windows, schedule)
+}
+
+// availabilityError checks the lesson against the windows of its teacher.
+// Unavailable windows block any overlap; once weekly available windows exist
+// the lesson must fit entirely inside an available window on its day. One-off
+// available windows only widen a day, they never restrict it.
+func availabilityError(windows []models.AvailabilityWindow, schedule models.Schedule) error {
+	start := clockOffset(schedule.Time)
+	end := scheduleEnd(schedule)
+
+	restricted := false
+	fits := false
+	for _, window := range windows {
+		if window.Weekday != nil && window.Kind == models.AvailabilityAvailable {
+			restricted = true
+		}
+
+		if !windowAppliesOn(window, schedule.Date) {
+			continue
+		}
+
+		windowStart := clockOffset(window.Time)
+		windowEnd := clockOffset(window.EndTime)
+
+		switch window.Kind {
+		case models.AvailabilityUnavailable:
+			if start < windowEnd && windowStart < end {
+				return fmt.Errorf("teacher is unavailable %s-%s on this date", window.Time.Format("15:04"), window.EndTime.Format("15:04"))
+			}
+		case models.AvailabilityAvailable:
+			if windowStart <= start && end <= windowEnd {
+				fits = true
+			}
+		}
+	}
+
+	if restricted && !fits {
+		return fmt.Errorf("lesson is outside of the teacher's availability on %s", schedule.Date.Weekday())
+	}
+
+	return nil
+}
+
+func windowAppliesOn(window models.AvailabilityWindow, date time.Time) bool {
+	if window.Date != nil {
+		return isSameDay(*window.Date, date)
+	}
+	return window.Weekday != nil && *window.Weekday == date.Weekday()
+}
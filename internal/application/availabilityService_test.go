@@ -0,0 +1,145 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeAvailabilityRepo struct {
+	windows []models.AvailabilityWindow
+}
+
+func (r *fakeAvailabilityRepo) CreateAvailabilityWindow(window *models.AvailabilityWindow) error {
+	window.ID = fmt.Sprintf("window-%d", len(r.windows)+1)
+	r.windows = append(r.windows, *window)
+	return nil
+}
+
+func (r *fakeAvailabilityRepo) GetAvailabilityWindowByID(id string) (*models.AvailabilityWindow, error) {
+	for _, window := range r.windows {
+		if window.ID == id {
+			return &window, nil
+		}
+	}
+	return nil, fmt.Errorf("availability window %s not found", id)
+}
+
+func (r *fakeAvailabilityRepo) UpdateAvailabilityWindow(window *models.AvailabilityWindow) error {
+	return nil
+}
+
+func (r *fakeAvailabilityRepo) DeleteAvailabilityWindow(id string) error {
+	return nil
+}
+
+func (r *fakeAvailabilityRepo) GetAvailabilityWindowsByTeacherID(teacherID string) ([]models.AvailabilityWindow, error) {
+	var windows []models.AvailabilityWindow
+	for _, window := range r.windows {
+		if window.TeacherID == teacherID {
+			windows = append(windows, window)
+		}
+	}
+	return windows, nil
+}
+
+func TestScheduleRespectsTeacherAvailability(t *testing.T) {
+	monday := nextWeekday(futureDate(), time.Monday)
+	weekday := time.Monday
+	oneOff := monday.AddDate(0, 0, 7)
+
+	availabilityRepo := &fakeAvailabilityRepo{}
+	availabilityService := NewAvailabilityService(availabilityRepo)
+	for _, window := range []models.AvailabilityWindow{
+		{TeacherID: "t1", Weekday: &weekday, Time: clock(8, 0), EndTime: clock(12, 0)},
+		{TeacherID: "t1", Kind: models.AvailabilityUnavailable, Date: &oneOff, Time: clock(9, 0), EndTime: clock(10, 0)},
+	} {
+		if err := availabilityService.CreateAvailabilityWindow(&window); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	repo := newFakeScheduleRepo()
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, availabilityRepo, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	newSchedule := func(date time.Time, hour, minute int) *models.Schedule {
+		return &models.Schedule{
+			Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1",
+			Time: clock(hour, minute), EndTime: clock(hour, minute+40),
+		}
+	}
+
+	if err := service.CreateSchedule(newSchedule(monday.AddDate(0, 0, 1), 9, 0)); err == nil || !strings.Contains(err.Error(), "outside of the teacher's availability") {
+		t.Fatalf("expected a lesson on a day without availability to be rejected, got %v", err)
+	}
+
+	if err := service.CreateSchedule(newSchedule(monday, 11, 30)); err == nil {
+		t.Fatal("expected a lesson running past the availability window to be rejected")
+	}
+
+	if err := service.CreateSchedule(newSchedule(oneOff, 9, 30)); err == nil || !strings.Contains(err.Error(), "unavailable 09:00-10:00") {
+		t.Fatalf("expected the one-off unavailability to be rejected, got %v", err)
+	}
+
+	schedule := newSchedule(monday, 9, 0)
+	if err := service.CreateSchedule(schedule); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := service.RescheduleSchedule(schedule.ID, oneOff, clock(9, 0)); err == nil {
+		t.Fatal("expected rescheduling into the unavailable window to be rejected")
+	}
+
+	if err := service.RescheduleSchedule(schedule.ID, oneOff, clock(10, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOneOffAvailableWindowOnlyWidensTheDay(t *testing.T) {
+	monday := nextWeekday(futureDate(), time.Monday)
+	weekday := time.Monday
+	tuesday := monday.AddDate(0, 0, 1)
+	lesson := func(date time.Time, hour int) models.Schedule {
+		return models.Schedule{Date: date, Time: clock(hour, 0), EndTime: clock(hour, 40)}
+	}
+
+	// Without weekly windows the teacher is free all day
+	windows := []models.AvailabilityWindow{
+		{TeacherID: "t1", Kind: models.AvailabilityAvailable, Date: &tuesday, Time: clock(14, 0), EndTime: clock(16, 0)},
+	}
+	if err := availabilityError(windows, lesson(tuesday, 9)); err != nil {
+		t.Fatalf("expected a one-off available window not to restrict the day, got %v", err)
+	}
+
+	// With weekly windows it opens an otherwise unavailable day
+	windows = append(windows, models.AvailabilityWindow{TeacherID: "t1", Kind: models.AvailabilityAvailable, Weekday: &weekday, Time: clock(8, 0), EndTime: clock(12, 0)})
+	if err := availabilityError(windows, lesson(tuesday, 14)); err != nil {
+		t.Fatalf("expected the one-off window to widen the day, got %v", err)
+	}
+	if err := availabilityError(windows, lesson(tuesday, 9)); err == nil {
+		t.Fatal("expected a lesson outside every available window to be rejected")
+	}
+}
+
+func TestCreateAvailabilityWindowRequiresWeekdayOrDate(t *testing.T) {
+	weekday := time.Friday
+	date := futureDate()
+	service := NewAvailabilityService(&fakeAvailabilityRepo{})
+
+	err := service.CreateAvailabilityWindow(&models.AvailabilityWindow{TeacherID: "t1", Time: clock(8, 0), EndTime: clock(12, 0)})
+	if err == nil {
+		t.Fatal("expected a window without weekday or date to be rejected")
+	}
+
+	err = service.CreateAvailabilityWindow(&models.AvailabilityWindow{TeacherID: "t1", Weekday: &weekday, Date: &date, Time: clock(8, 0), EndTime: clock(12, 0)})
+	if err == nil {
+		t.Fatal("expected a window with both weekday and date to be rejected")
+	}
+
+	err = service.CreateAvailabilityWindow(&models.AvailabilityWindow{TeacherID: "t1", Weekday: &weekday, Time: clock(12, 0), EndTime: clock(8, 0)})
+	if err == nil {
+		t.Fatal("expected a window ending before it starts to be rejected")
+	}
+}
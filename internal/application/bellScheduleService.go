@@ -0,0 +1,349 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// schoolWeek is used for periods sent without weekdays
+var schoolWeek = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+type BellScheduleService struct {
+	bellRepo models.BellScheduleRepository
+}
+
+func NewBellScheduleService(bellRepo models.BellScheduleRepository) models.BellScheduleService {
+	return &BellScheduleService{
+		bellRepo: bellRepo,
+	}
+}
+
+func (bs *BellScheduleService) CreateBellSchedule(bellSchedule *models.BellSchedule) error {
+	for _, period := range bellSchedule.Periods {
+		if period.ID != "" {
+			return fmt.Errorf("new periods must not have an ID")
+		}
+	}
+
+	if err := validateBellSchedule(bellSchedule); err != nil {
+		return err
+	}
+
+	return bs.bellRepo.CreateBellSchedule(bellSchedule)
+}
+
+func (bs *BellScheduleService) GetBellScheduleByID(id string) (*models.BellSchedule, error) {
+	if id == "" {
+		return nil, fmt.Errorf("bell schedule ID is required")
+	}
+
+	return bs.bellRepo.GetBellScheduleByID(id)
+}
+
+// UpdateBellSchedule replaces the name, the active flag and the periods.
+// Periods sent with their ID are updated in place, so lessons already held
+// in them stay linked; lessons keep their stored times either way.
+func (bs *BellScheduleService) UpdateBellSchedule(bellSchedule *models.BellSchedule) error {
+	existing, err := bs.bellRepo.GetBellScheduleByID(bellSchedule.ID)
+	if err != nil {
+		return fmt.Errorf("bell schedule not found: %w", err)
+	}
+
+	for _, period := range bellSchedule.Periods {
+		if period.ID == "" {
+			continue
+		}
+		if !slices.ContainsFunc(existing.Periods, func(p models.BellPeriod) bool { return p.ID == period.ID }) {
+			return fmt.Errorf("period %s does not belong to the bell schedule", period.ID)
+		}
+	}
+
+	if err := validateBellSchedule(bellSchedule); err != nil {
+		return err
+	}
+
+	bellSchedule.CreatedAt = existing.CreatedAt
+	return bs.bellRepo.UpdateBellSchedule(bellSchedule)
+}
+
+func (bs *BellScheduleService) DeleteBellSchedule(id string) error {
+	if id == "" {
+		return fmt.Errorf("bell schedule ID is required")
+	}
+
+	if _, err := bs.bellRepo.GetBellScheduleByID(id); err != nil {
+		return fmt.Errorf("bell schedule not found: %w", err)
+	}
+
+	return bs.bellRepo.DeleteBellSchedule(id)
+}
+
+func (bs *BellScheduleService) GetAllBellSchedules() ([]models.BellSchedule, error) {
+	return bs.bellRepo.GetAllBellSchedules()
+}
+
+func (bs *BellScheduleService) GetActiveBellSchedule() (*models.BellSchedule, error) {
+	return activeBellSchedule(bs.bellRepo)
+}
+
+func validateBellSchedule(bellSchedule *models.BellSchedule) error {
+	bellSchedule.Name = strings.TrimSpace(bellSchedule.Name)
+	if bellSchedule.Name == "" {
+		return fmt.Errorf("bell schedule name is required")
+	}
+
+	if len(bellSchedule.Periods) == 0 {
+		return fmt.Errorf("at least one period is required")
+	}
+
+	for i := range bellSchedule.Periods {
+		period := &bellSchedule.Periods[i]
+
+		if period.Position == 0 {
+			period.Position = i + 1
+		}
+		if period.Position < 0 {
+			return fmt.Errorf("period %d: position must be positive", i+1)
+		}
+
+		period.Name = strings.TrimSpace(period.Name)
+		if period.Name == "" {
+			period.Name = fmt.Sprintf("Period %d", period.Position)
+		}
+
+		if len(period.Weekdays) == 0 {
+			period.Weekdays = schoolWeek
+		}
+		for _, weekday := range period.Weekdays {
+			if weekday < time.Sunday || weekday > time.Saturday {
+				return fmt.Errorf("period %d: invalid weekday %d", i+1, weekday)
+			}
+		}
+		period.Weekdays = sortedWeekdays(period.Weekdays)
+
+		slot := models.Schedule{Time: period.Time, EndTime: period.EndTime}
+		if err := normalizeScheduleTimes(&slot, defaultLessonDuration); err != nil {
+			return fmt.Errorf("period %d: %w", i+1, err)
+		}
+		period.EndTime = slot.EndTime
+	}
+
+	// Periods held on the same day must not overlap or share a grid row
+	for i, a := range bellSchedule.Periods {
+		for j := i + 1; j < len(bellSchedule.Periods); j++ {
+			b := bellSchedule.Periods[j]
+			if !slices.ContainsFunc(a.Weekdays, func(weekday time.Weekday) bool { return slices.Contains(b.Weekdays, weekday) }) {
+				continue
+			}
+			if a.Position == b.Position {
+				return fmt.Errorf("periods %d and %d share position %d on the same weekday", i+1, j+1, a.Position)
+			}
+			if periodsOverlap(models.TimetablePeriod{Time: a.Time, EndTime: a.EndTime}, models.TimetablePeriod{Time: b.Time, EndTime: b.EndTime}) {
+				return fmt.Errorf("periods %d and %d overlap on the same weekday", i+1, j+1)
+			}
+		}
+	}
+
+	return nil
+}
+
+// activeBellSchedule returns nil when no bell schedule is active
+func activeBellSchedule(bellRepo models.BellScheduleRepository) (*models.BellSchedule, error) {
+	bellSchedules, err := bellRepo.GetAllBellSchedules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bell schedules: %w", err)
+	}
+
+	for i := range bellSchedules {
+		if bellSchedules[i].Active {
+			return &bellSchedules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// placeInPeriod fits the lesson into the active bell schedule: it takes the
+// start and end time of the requested period, or of the period starting at
+// its time on that weekday. Without an active bell schedule lessons keep
+// free-form times.
+func (ss *ScheduleService) placeInPeriod(schedule *models.Schedule) error {
+	bellSchedule, err := activeBellSchedule(ss.bellRepo)
+	if err != nil {
+		return err
+	}
+
+	if bellSchedule == nil {
+		if schedule.PeriodID != "" {
+			return fmt.Errorf("no bell schedule is active, lessons cannot be placed into periods")
+		}
+		return nil
+	}
+
+	period, err := lessonPeriod(*bellSchedule, *schedule)
+	if err != nil {
+		return err
+	}
+
+	schedule.PeriodID = period.ID
+	schedule.Time = period.Time
+	schedule.EndTime = period.EndTime
+	return nil
+}
+
+// PlaceInPeriod fits the lesson into the active bell schedule, if any
+func (ss *ScheduleService) PlaceInPeriod(schedule *models.Schedule) error {
+	return ss.placeInPeriod(schedule)
+}
+
+// placeSeriesInPeriods makes a series start at the same period on each of its
+// weekdays and takes that period's end time
+func (ss *ScheduleService) placeSeriesInPeriods(series *models.ScheduleSeries) error {
+	bellSchedule, err := activeBellSchedule(ss.bellRepo)
+	if err != nil || bellSchedule == nil {
+		return err
+	}
+
+	var endTime time.Time
+	for i, weekday := range series.Weekdays {
+		date := series.StartDate.AddDate(0, 0, (int(weekday)-int(series.StartDate.Weekday())+7)%7)
+		period, err := lessonPeriod(*bellSchedule, models.Schedule{Date: date, Time: series.Time})
+		if err != nil {
+			return err
+		}
+		if i > 0 && !isSameTime(period.EndTime, endTime) {
+			return fmt.Errorf("the periods starting at %s end at different times on the series' weekdays", series.Time.Format("15:04"))
+		}
+		endTime = period.EndTime
+	}
+
+	series.EndTime = endTime
+	return nil
+}
+
+// GetBellPeriods returns the periods of the active bell schedule, one entry
+// per weekday, or nil when no bell schedule is active
+func (ss *ScheduleService) GetBellPeriods() ([]models.TimetablePeriod, error) {
+	bellSchedule, err := activeBellSchedule(ss.bellRepo)
+	if err != nil || bellSchedule == nil {
+		return nil, err
+	}
+
+	var periods []models.TimetablePeriod
+	for _, period := range bellSchedule.Periods {
+		for _, weekday := range period.Weekdays {
+			periods = append(periods, models.TimetablePeriod{Weekday: weekday, Time: period.Time, EndTime: period.EndTime})
+		}
+	}
+	return periods, nil
+}
+
+// GetWeekGrid lays the week's lessons out by period and day. It returns nil
+// when no bell schedule is active.
+func (ss *ScheduleService) GetWeekGrid(startDate time.Time) (*models.WeekGrid, error) {
+	bellSchedule, err := activeBellSchedule(ss.bellRepo)
+	if err != nil || bellSchedule == nil {
+		return nil, err
+	}
+
+	schedules, err := ss.GetWeekSchedules(startDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return weekGrid(*bellSchedule, dateOnly(startDate), schedules), nil
+}
+
+// weekGrid builds one row per period position and one column per day of the
+// week that has periods
+func weekGrid(bellSchedule models.BellSchedule, startDate time.Time, schedules []models.Schedule) *models.WeekGrid {
+	grid := &models.WeekGrid{BellScheduleID: bellSchedule.ID, Days: []time.Time{}, Rows: []models.WeekGridRow{}, Unplaced: []models.Schedule{}}
+
+	held := map[time.Weekday]bool{}
+	var positions []int
+	names := map[int]string{}
+	for _, period := range bellSchedule.Periods {
+		for _, weekday := range period.Weekdays {
+			held[weekday] = true
+		}
+		if _, ok := names[period.Position]; !ok {
+			names[period.Position] = period.Name
+			positions = append(positions, period.Position)
+		}
+	}
+	sort.Ints(positions)
+
+	for i := 0; i < 7; i++ {
+		if date := startDate.AddDate(0, 0, i); held[date.Weekday()] {
+			grid.Days = append(grid.Days, date)
+		}
+	}
+
+	rows := map[int]int{}
+	for _, position := range positions {
+		row := models.WeekGridRow{Position: position, Name: names[position], Cells: []models.WeekGridCell{}}
+		for _, date := range grid.Days {
+			cell := models.WeekGridCell{Date: date, Schedules: []models.Schedule{}}
+			for i := range bellSchedule.Periods {
+				period := bellSchedule.Periods[i]
+				if period.Position == position && slices.Contains(period.Weekdays, date.Weekday()) {
+					cell.Period = &period
+				}
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		rows[position] = len(grid.Rows)
+		grid.Rows = append(grid.Rows, row)
+	}
+
+	sortSchedules(schedules)
+	for _, schedule := range schedules {
+		period, err := lessonPeriod(bellSchedule, schedule)
+		if err != nil && schedule.PeriodID != "" {
+			// The linked period belongs to another bell schedule
+			schedule.PeriodID = ""
+			period, err = lessonPeriod(bellSchedule, schedule)
+		}
+		day := slices.IndexFunc(grid.Days, func(date time.Time) bool { return isSameDay(date, schedule.Date) })
+		if err != nil || day < 0 {
+			grid.Unplaced = append(grid.Unplaced, schedule)
+			continue
+		}
+
+		cell := &grid.Rows[rows[period.Position]].Cells[day]
+		cell.Schedules = append(cell.Schedules, schedule)
+	}
+
+	return grid
+}
+
+// lessonPeriod finds the period the lesson is held in: the period it
+// references, or else the period starting at its time on its weekday
+func lessonPeriod(bellSchedule models.BellSchedule, schedule models.Schedule) (*models.BellPeriod, error) {
+	weekday := schedule.Date.Weekday()
+
+	for i := range bellSchedule.Periods {
+		period := &bellSchedule.Periods[i]
+		if schedule.PeriodID != "" {
+			if period.ID != schedule.PeriodID {
+				continue
+			}
+			if !slices.Contains(period.Weekdays, weekday) {
+				return nil, fmt.Errorf("period %s is not held on %s", period.Name, weekday)
+			}
+			return period, nil
+		}
+
+		if slices.Contains(period.Weekdays, weekday) && isSameTime(period.Time, schedule.Time) {
+			return period, nil
+		}
+	}
+
+	if schedule.PeriodID != "" {
+		return nil, fmt.Errorf("period not found in the active bell schedule")
+	}
+	return nil, fmt.Errorf("lessons must start at a period of the bell schedule, %s on %s matches none", schedule.Time.Format("15:04"), weekday)
+}
@@ -0,0 +1,136 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeBellRepo struct {
+	bellSchedules []models.BellSchedule
+}
+
+func (r *fakeBellRepo) CreateBellSchedule(bellSchedule *models.BellSchedule) error {
+	bellSchedule.ID = fmt.Sprintf("bell-%d", len(r.bellSchedules)+1)
+	for i := range bellSchedule.Periods {
+		bellSchedule.Periods[i].ID = fmt.Sprintf("%s-period-%d", bellSchedule.ID, i+1)
+	}
+	if bellSchedule.Active {
+		for i := range r.bellSchedules {
+			r.bellSchedules[i].Active = false
+		}
+	}
+	r.bellSchedules = append(r.bellSchedules, *bellSchedule)
+	return nil
+}
+
+func (r *fakeBellRepo) GetBellScheduleByID(id string) (*models.BellSchedule, error) {
+	for _, bellSchedule := range r.bellSchedules {
+		if bellSchedule.ID == id {
+			return &bellSchedule, nil
+		}
+	}
+	return nil, fmt.Errorf("bell schedule %s not found", id)
+}
+
+func (r *fakeBellRepo) UpdateBellSchedule(bellSchedule *models.BellSchedule) error {
+	return nil
+}
+
+func (r *fakeBellRepo) DeleteBellSchedule(id string) error {
+	return nil
+}
+
+func (r *fakeBellRepo) GetAllBellSchedules() ([]models.BellSchedule, error) {
+	return r.bellSchedules, nil
+}
+
+func TestBellScheduleRejectsOverlappingPeriods(t *testing.T) {
+	service := NewBellScheduleService(&fakeBellRepo{})
+
+	err := service.CreateBellSchedule(&models.BellSchedule{Name: "Regular", Periods: []models.BellPeriod{
+		{Time: clock(8, 0), EndTime: clock(8, 40)},
+		{Time: clock(8, 30), EndTime: clock(9, 10)},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "overlap") {
+		t.Fatalf("expected overlapping periods to be rejected, got %v", err)
+	}
+
+	// The same position may be reused on other weekdays
+	bellSchedule := &models.BellSchedule{Name: "Regular", Periods: []models.BellPeriod{
+		{Position: 1, Time: clock(8, 0), EndTime: clock(8, 40), Weekdays: []time.Weekday{time.Monday, time.Tuesday}},
+		{Position: 1, Time: clock(8, 0), EndTime: clock(8, 30), Weekdays: []time.Weekday{time.Friday}},
+	}}
+	if err := service.CreateBellSchedule(bellSchedule); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bellSchedule.Periods[0].Name != "Period 1" {
+		t.Fatalf("expected a default period name, got %q", bellSchedule.Periods[0].Name)
+	}
+}
+
+func TestSchedulesArePlacedIntoBellPeriods(t *testing.T) {
+	monday := nextWeekday(futureDate(), time.Monday)
+	friday := monday.AddDate(0, 0, 4)
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "old", Date: monday, TeacherID: "t2", LessonID: "l1", ClassID: "c2", Time: clock(9, 7), EndTime: clock(9, 47)},
+	)
+	bellRepo := &fakeBellRepo{}
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, bellRepo)
+
+	err := NewBellScheduleService(bellRepo).CreateBellSchedule(&models.BellSchedule{Name: "Regular", Active: true, Periods: []models.BellPeriod{
+		{Name: "1st", Position: 1, Time: clock(8, 0), EndTime: clock(8, 40), Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday}},
+		{Name: "1st", Position: 1, Time: clock(8, 0), EndTime: clock(8, 30), Weekdays: []time.Weekday{time.Friday}},
+		{Name: "2nd", Position: 2, Time: clock(8, 50), EndTime: clock(9, 30)},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = service.CreateSchedule(&models.Schedule{Date: monday, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 7)})
+	if err == nil || !strings.Contains(err.Error(), "matches none") {
+		t.Fatalf("expected free-form times to be rejected, got %v", err)
+	}
+
+	// A lesson starting with a period takes its end time
+	byTime := &models.Schedule{Date: monday, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(8, 50), EndTime: clock(9, 45)}
+	if err := service.CreateSchedule(byTime); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if byTime.PeriodID != "bell-1-period-3" || !isSameTime(byTime.EndTime, clock(9, 30)) {
+		t.Fatalf("expected the lesson in the 2nd period, got %+v", byTime)
+	}
+
+	// The Monday period is not held on Friday, the Friday one is shorter
+	err = service.CreateSchedule(&models.Schedule{Date: friday, TeacherID: "t1", LessonID: "l1", ClassID: "c1", PeriodID: "bell-1-period-1"})
+	if err == nil || !strings.Contains(err.Error(), "not held on Friday") {
+		t.Fatalf("expected the period to be rejected on Friday, got %v", err)
+	}
+
+	byPeriod := &models.Schedule{Date: friday, TeacherID: "t1", LessonID: "l1", ClassID: "c1", PeriodID: "bell-1-period-2"}
+	if err := service.CreateSchedule(byPeriod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !isSameTime(byPeriod.Time, clock(8, 0)) || !isSameTime(byPeriod.EndTime, clock(8, 30)) {
+		t.Fatalf("expected the Friday period times, got %s-%s", byPeriod.Time.Format("15:04"), byPeriod.EndTime.Format("15:04"))
+	}
+
+	grid, err := service.GetWeekGrid(monday)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(grid.Days) != 5 || len(grid.Rows) != 2 {
+		t.Fatalf("expected 2 periods over 5 days, got %d rows and %d days", len(grid.Rows), len(grid.Days))
+	}
+	if cell := grid.Rows[1].Cells[0]; len(cell.Schedules) != 1 || cell.Schedules[0].ID != byTime.ID {
+		t.Fatalf("expected the Monday 2nd period lesson, got %+v", cell)
+	}
+	if cell := grid.Rows[0].Cells[4]; len(cell.Schedules) != 1 || !isSameTime(cell.Period.EndTime, clock(8, 30)) {
+		t.Fatalf("expected the Friday 1st period lesson, got %+v", cell)
+	}
+	if len(grid.Unplaced) != 1 || grid.Unplaced[0].ID != "old" {
+		t.Fatalf("expected the lesson from before the bell schedule to be unplaced, got %+v", grid.Unplaced)
+	}
+}
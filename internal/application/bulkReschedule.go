@@ -0,0 +1,185 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"time"
+)
+
+// BulkReschedule moves every lesson in the requested date range by the same
+// number of days, keeping its start time. Each move is checked against the academic calendar, the
+// teacher's availability and GetScheduleConflicts; lessons that move away
+// themselves do not count as conflicts. The moves are stored in one
+// transaction, and only if none of them is blocked.
+func (ss *ScheduleService) BulkReschedule(request models.BulkRescheduleRequest) (*models.BulkRescheduleReport, error) {
+	if request.FromDate.IsZero() || request.TargetDate.IsZero() {
+		return nil, fmt.Errorf("from date and target date are required")
+	}
+
+	from := dateOnly(request.FromDate)
+	to := from
+	if request.ToDate != nil {
+		to = dateOnly(*request.ToDate)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("to date must not be before from date")
+	}
+
+	target := dateOnly(request.TargetDate)
+	days := shiftDays(from, target)
+	if days == 0 {
+		return nil, fmt.Errorf("target date must differ from from date")
+	}
+
+	today := helper.SchoolToday()
+	if from.Before(today) {
+		return nil, fmt.Errorf("cannot reschedule past schedules")
+	}
+	if target.Before(today) {
+		return nil, fmt.Errorf("cannot schedule for past dates")
+	}
+
+	schedules, err := ss.schedulesBetween(from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedules: %w", err)
+	}
+
+	var lessons []models.Schedule
+	moving := map[string]bool{}
+	for _, schedule := range schedules {
+		if schedule.Status == models.ScheduleCancelled {
+			continue
+		}
+		if request.ClassID != "" && schedule.ClassID != request.ClassID {
+			continue
+		}
+		if request.TeacherID != "" && schedule.TeacherID != request.TeacherID && effectiveTeacherID(schedule) != request.TeacherID {
+			continue
+		}
+		lessons = append(lessons, schedule)
+		moving[scheduleKey(schedule)] = true
+	}
+	sortSchedules(lessons)
+
+	if len(lessons) == 0 {
+		return nil, fmt.Errorf("no schedules found to reschedule")
+	}
+
+	report := &models.BulkRescheduleReport{DryRun: request.DryRun, Total: len(lessons), Moves: []models.ScheduleMove{}}
+	planned := make([]models.Schedule, 0, len(lessons))
+	for _, lesson := range lessons {
+		move, moved, err := ss.planMove(lesson, days, moving)
+		if err != nil {
+			return nil, err
+		}
+		report.Moves = append(report.Moves, move)
+		planned = append(planned, moved)
+	}
+
+	// The workload is checked once every lesson of the batch has its new slot
+	for i := range report.Moves {
+		move := &report.Moves[i]
+		if err := ss.checkWorkload(&planned[i], planned...); err != nil {
+			move.Errors = append(move.Errors, err.Error())
+		}
+		if len(move.Errors) > 0 || len(move.Conflicts) > 0 {
+			report.Conflicting++
+		}
+	}
+
+	if request.DryRun || report.Conflicting > 0 {
+		return report, nil
+	}
+
+	if err := ss.applyMoves(report.Moves); err != nil {
+		return nil, err
+	}
+
+	report.Applied = true
+	return report, nil
+}
+
+// planMove checks one lesson at its new date, ignoring conflicts with
+// lessons that move as well, and returns the lesson at its new slot
+func (ss *ScheduleService) planMove(lesson models.Schedule, days int, moving map[string]bool) (models.ScheduleMove, models.Schedule, error) {
+	moved := lesson
+	moved.Date = dateOnly(lesson.Date).AddDate(0, 0, days)
+	move := models.ScheduleMove{Schedule: lesson, NewDate: moved.Date}
+
+	// The lesson keeps its start time, in whichever period holds it that day
+	moved.PeriodID = ""
+	if err := ss.placeInPeriod(&moved); err != nil {
+		move.Errors = append(move.Errors, err.Error())
+	}
+	move.NewTime = moved.Time
+	move.NewEndTime = moved.EndTime
+	move.NewPeriodID = moved.PeriodID
+
+	if err := checkAcademicDate(ss.calendarRepo, moved.Date); err != nil {
+		move.Errors = append(move.Errors, err.Error())
+	}
+
+	if err := checkTeacherAvailability(ss.availabilityRepo, effectiveTeacherID(moved), moved); err != nil {
+		move.Errors = append(move.Errors, err.Error())
+	}
+
+	conflicts, err := ss.GetScheduleConflicts(effectiveTeacherID(moved), moved.ClassID, moved.RoomID, moved.Date, moved.Time, moved.EndTime)
+	if err != nil {
+		return move, moved, fmt.Errorf("failed to check conflicts: %w", err)
+	}
+
+	for _, conflict := range conflicts {
+		if !moving[scheduleKey(conflict.Schedule)] {
+			move.Conflicts = append(move.Conflicts, conflict)
+		}
+	}
+
+	return move, moved, nil
+}
+
+// applyMoves stores the new dates. Series occurrences are detached from their
+// series, keeping the original date as occurrence date.
+func (ss *ScheduleService) applyMoves(moves []models.ScheduleMove) error {
+	var schedules, occurrences []models.Schedule
+	series := map[string]*models.ScheduleSeries{}
+	var seriesIDs []string
+
+	for _, move := range moves {
+		lesson := move.Schedule
+		lesson.Date = move.NewDate
+		lesson.Time = move.NewTime
+		lesson.EndTime = move.NewEndTime
+		lesson.PeriodID = move.NewPeriodID
+
+		if lesson.ID != "" {
+			schedules = append(schedules, lesson)
+			continue
+		}
+
+		s, ok := series[lesson.SeriesID]
+		if !ok {
+			var err error
+			s, err = ss.seriesRepo.GetScheduleSeriesByID(lesson.SeriesID)
+			if err != nil {
+				return fmt.Errorf("schedule series not found: %w", err)
+			}
+			series[lesson.SeriesID] = s
+			seriesIDs = append(seriesIDs, lesson.SeriesID)
+		}
+		s.ExceptionDates = append(s.ExceptionDates, dateOnly(*lesson.OccurrenceDate))
+		occurrences = append(occurrences, lesson)
+	}
+
+	var updated []models.ScheduleSeries
+	for _, id := range seriesIDs {
+		updated = append(updated, *series[id])
+	}
+
+	return ss.seriesRepo.MoveSchedules(schedules, updated, occurrences)
+}
+
+// shiftDays returns the whole number of days between two dates
+func shiftDays(from, to time.Time) int {
+	return int(dateOnly(to).Sub(dateOnly(from)).Hours() / 24)
+}
@@ -0,0 +1,275 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// feedTokenBytes is the amount of randomness in a feed token
+const feedTokenBytes = 32
+
+type CalendarService struct {
+	feedRepo     models.CalendarFeedRepository
+	scheduleRepo models.ScheduleRepository
+	seriesRepo   models.ScheduleSeriesRepository
+	lessonRepo   models.LessonRepository
+	roomRepo     models.RoomRepository
+	homeworkRepo models.HomeworkRepository
+	calendarRepo models.AcademicCalendarRepository
+	classService models.ClassService
+}
+
+func NewCalendarService(feedRepo models.CalendarFeedRepository, scheduleRepo models.ScheduleRepository, seriesRepo models.ScheduleSeriesRepository, lessonRepo models.LessonRepository, roomRepo models.RoomRepository, homeworkRepo models.HomeworkRepository, calendarRepo models.AcademicCalendarRepository, classService models.ClassService) models.CalendarService {
+	return &CalendarService{
+		feedRepo:     feedRepo,
+		scheduleRepo: scheduleRepo,
+		seriesRepo:   seriesRepo,
+		lessonRepo:   lessonRepo,
+		roomRepo:     roomRepo,
+		homeworkRepo: homeworkRepo,
+		calendarRepo: calendarRepo,
+		classService: classService,
+	}
+}
+
+func (cs *CalendarService) CreateFeed(ownerType, ownerID, createdBy string) (*models.CalendarFeed, error) {
+	switch ownerType {
+	case models.FeedOwnerTeacher, models.FeedOwnerClass, models.FeedOwnerStudent:
+	default:
+		return nil, fmt.Errorf("owner type must be one of %s, %s, %s", models.FeedOwnerTeacher, models.FeedOwnerClass, models.FeedOwnerStudent)
+	}
+
+	if ownerID == "" {
+		return nil, fmt.Errorf("owner ID is required")
+	}
+
+	if createdBy == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	token, err := newFeedToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate feed token: %w", err)
+	}
+
+	feed := &models.CalendarFeed{
+		Token:     token,
+		OwnerType: ownerType,
+		OwnerID:   ownerID,
+		CreatedBy: createdBy,
+	}
+
+	if err := cs.feedRepo.CreateCalendarFeed(feed); err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
+
+func (cs *CalendarService) GetFeedsByUser(userID string) ([]models.CalendarFeed, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	return cs.feedRepo.GetCalendarFeedsByCreator(userID)
+}
+
+func (cs *CalendarService) RevokeFeed(feedID, userID string) error {
+	feed, err := cs.feedRepo.GetCalendarFeedByID(feedID)
+	if err != nil {
+		return fmt.Errorf("calendar feed not found: %w", err)
+	}
+
+	if feed.CreatedBy != userID {
+		return fmt.Errorf("calendar feed belongs to another user")
+	}
+
+	return cs.feedRepo.DeleteCalendarFeed(feedID)
+}
+
+func (cs *CalendarService) RenderFeed(token, homeworkStyle string) (string, error) {
+	switch homeworkStyle {
+	case "":
+		homeworkStyle = models.HomeworkAsEvent
+	case models.HomeworkAsEvent, models.HomeworkAsTodo:
+	default:
+		return "", fmt.Errorf("homework style must be %s or %s", models.HomeworkAsEvent, models.HomeworkAsTodo)
+	}
+
+	feed, err := cs.feedRepo.GetCalendarFeedByToken(token)
+	if err != nil {
+		return "", fmt.Errorf("calendar feed not found: %w", err)
+	}
+
+	data := calendarData{
+		Name:          "Timetable",
+		HomeworkStyle: homeworkStyle,
+		Location:      helper.SchoolLocation(),
+	}
+
+	switch feed.OwnerType {
+	case models.FeedOwnerTeacher:
+		data.Name = "Teacher timetable"
+		if err := cs.collectTeacher(&data, feed.OwnerID); err != nil {
+			return "", err
+		}
+	case models.FeedOwnerClass:
+		data.Name = "Class timetable"
+		if err := cs.collectClass(&data, feed.OwnerID); err != nil {
+			return "", err
+		}
+	case models.FeedOwnerStudent:
+		data.Name = "My timetable"
+		classes, err := cs.classService.GetClassesByStudentID(feed.OwnerID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get student classes: %w", err)
+		}
+		for _, class := range classes {
+			if err := cs.collectClass(&data, class.ID); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if data.LessonNames, err = cs.lessonNames(); err != nil {
+		return "", err
+	}
+
+	if data.RoomNames, err = cs.roomNames(); err != nil {
+		return "", err
+	}
+
+	now := helper.SchoolNow()
+	if data.ClosedDates, err = cs.seriesClosedDates(data.Series, now); err != nil {
+		return "", err
+	}
+
+	sortSchedules(data.Schedules)
+
+	return renderCalendar(data, now), nil
+}
+
+// seriesClosedDates returns the closed dates from the start of the first
+// series to the end of the last one. Series without an end are covered up to
+// the end of the last term, or the coming year without terms.
+func (cs *CalendarService) seriesClosedDates(allSeries []models.ScheduleSeries, now time.Time) (map[time.Time]bool, error) {
+	if len(allSeries) == 0 {
+		return nil, nil
+	}
+
+	terms, err := cs.calendarRepo.GetAllTerms()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check terms: %w", err)
+	}
+	openEnd := helper.SchoolDate(now).AddDate(1, 0, 0)
+	if len(terms) > 0 {
+		openEnd = time.Time{}
+		for _, term := range terms {
+			if term.EndDate.After(openEnd) {
+				openEnd = dateOnly(term.EndDate)
+			}
+		}
+	}
+
+	from, to := dateOnly(allSeries[0].StartDate), time.Time{}
+	for _, series := range allSeries {
+		if series.StartDate.Before(from) {
+			from = dateOnly(series.StartDate)
+		}
+		last := openEnd
+		if series.Until != nil || series.Count > 0 {
+			last = lastOccurrenceDate(series)
+		}
+		if last.After(to) {
+			to = last
+		}
+	}
+	if to.Before(from) {
+		return nil, nil
+	}
+
+	return academicClosedDates(cs.calendarRepo, from, to.AddDate(0, 0, 1))
+}
+
+func (cs *CalendarService) collectTeacher(data *calendarData, teacherID string) error {
+	schedules, err := teachingSchedules(cs.scheduleRepo, teacherID)
+	if err != nil {
+		return fmt.Errorf("failed to get teacher schedules: %w", err)
+	}
+
+	series, err := cs.seriesRepo.GetScheduleSeriesByTeacherID(teacherID)
+	if err != nil {
+		return fmt.Errorf("failed to get teacher schedule series: %w", err)
+	}
+
+	homeworks, err := cs.homeworkRepo.GetHomeworksByTeacherID(teacherID)
+	if err != nil {
+		return fmt.Errorf("failed to get teacher homeworks: %w", err)
+	}
+
+	data.Schedules = append(data.Schedules, schedules...)
+	data.Series = append(data.Series, series...)
+	data.Homeworks = append(data.Homeworks, homeworks...)
+	return nil
+}
+
+func (cs *CalendarService) collectClass(data *calendarData, classID string) error {
+	schedules, err := cs.scheduleRepo.GetSchedulesByClassID(classID)
+	if err != nil {
+		return fmt.Errorf("failed to get class schedules: %w", err)
+	}
+
+	series, err := cs.seriesRepo.GetScheduleSeriesByClassID(classID)
+	if err != nil {
+		return fmt.Errorf("failed to get class schedule series: %w", err)
+	}
+
+	homeworks, err := cs.homeworkRepo.GetHomeworksByClassID(classID)
+	if err != nil {
+		return fmt.Errorf("failed to get class homeworks: %w", err)
+	}
+
+	data.Schedules = append(data.Schedules, schedules...)
+	data.Series = append(data.Series, series...)
+	data.Homeworks = append(data.Homeworks, homeworks...)
+	return nil
+}
+
+func (cs *CalendarService) lessonNames() (map[string]string, error) {
+	lessons, err := cs.lessonRepo.GetAllLessons()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lessons: %w", err)
+	}
+
+	names := make(map[string]string, len(lessons))
+	for _, lesson := range lessons {
+		names[lesson.ID] = lesson.LessonName
+	}
+	return names, nil
+}
+
+func (cs *CalendarService) roomNames() (map[string]string, error) {
+	rooms, err := cs.roomRepo.GetAllRooms()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rooms: %w", err)
+	}
+
+	names := make(map[string]string, len(rooms))
+	for _, room := range rooms {
+		names[room.ID] = room.Name
+	}
+	return names, nil
+}
+
+// newFeedToken returns a URL safe random token
+func newFeedToken() (string, error) {
+	buf := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
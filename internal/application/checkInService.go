@@ -0,0 +1,340 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	defaultCheckInCodeSeconds   = 30
+	defaultCheckInWindowMinutes = 15
+	defaultLateAfterMinutes     = 5
+	maxCheckInWindowMinutes     = 60
+
+	// checkInEarlyMinutes is how long before the lesson a session may open
+	checkInEarlyMinutes = 10
+
+	// checkInPayloadPrefix starts the QR payload, followed by the session ID
+	// and the code
+	checkInPayloadPrefix = "checkin"
+)
+
+type CheckInService struct {
+	checkInRepo       models.CheckInRepository
+	scheduleRepo      models.ScheduleRepository
+	attendanceService models.AttendanceService
+	classService      models.ClassService
+}
+
+func NewCheckInService(checkInRepo models.CheckInRepository, scheduleRepo models.ScheduleRepository, attendanceService models.AttendanceService, classService models.ClassService) models.CheckInService {
+	return &CheckInService{
+		checkInRepo:       checkInRepo,
+		scheduleRepo:      scheduleRepo,
+		attendanceService: attendanceService,
+		classService:      classService,
+	}
+}
+
+func (cs *CheckInService) OpenSession(session *models.CheckInSession, teacherID string) error {
+	if session.ScheduleID == "" {
+		return fmt.Errorf("schedule ID is required")
+	}
+
+	if session.OpenedBy == "" {
+		return fmt.Errorf("teacher ID is required")
+	}
+
+	if session.CodeSeconds == 0 {
+		session.CodeSeconds = defaultCheckInCodeSeconds
+	}
+	if session.CodeSeconds < 10 || session.CodeSeconds > 300 {
+		return fmt.Errorf("code seconds must be between 10 and 300")
+	}
+
+	if session.WindowMinutes == 0 {
+		session.WindowMinutes = defaultCheckInWindowMinutes
+	}
+	if session.WindowMinutes < 1 || session.WindowMinutes > maxCheckInWindowMinutes {
+		return fmt.Errorf("window minutes must be between 1 and %d", maxCheckInWindowMinutes)
+	}
+
+	if session.LateAfterMinutes == nil {
+		lateAfter := defaultLateAfterMinutes
+		session.LateAfterMinutes = &lateAfter
+	}
+	if *session.LateAfterMinutes < 0 {
+		return fmt.Errorf("late after minutes must not be negative")
+	}
+
+	schedule, err := cs.scheduleRepo.GetScheduleByID(session.ScheduleID)
+	if err != nil {
+		return fmt.Errorf("schedule not found: %w", err)
+	}
+
+	if err := checkLessonTeacher(schedule, teacherID); err != nil {
+		return err
+	}
+
+	if schedule.Status == models.ScheduleCancelled {
+		return fmt.Errorf("cannot take attendance for a cancelled schedule")
+	}
+
+	now := helper.SchoolNow()
+	start := helper.SchoolDateTime(schedule.Date, schedule.Time)
+	end := helper.SchoolDateTime(schedule.Date, schedule.EndTime)
+	if now.Before(start.Add(-checkInEarlyMinutes*time.Minute)) || !now.Before(end) {
+		return fmt.Errorf("check-in opens %d minutes before the lesson and closes when it ends", checkInEarlyMinutes)
+	}
+
+	sessions, err := cs.checkInRepo.GetSessionsByScheduleID(session.ScheduleID)
+	if err != nil {
+		return err
+	}
+	for _, existing := range sessions {
+		if checkInSessionOpen(existing, now) {
+			return fmt.Errorf("a check-in session is already open for this lesson")
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return fmt.Errorf("failed to create check-in secret: %w", err)
+	}
+
+	session.Secret = hex.EncodeToString(secret)
+	session.OpenedAt = now
+	session.ClosesAt = now.Add(time.Duration(session.WindowMinutes) * time.Minute)
+	// Check-in never outlasts the lesson
+	if session.ClosesAt.After(end) {
+		session.ClosesAt = end
+	}
+	session.ClosedAt = nil
+	return cs.checkInRepo.CreateSession(session)
+}
+
+// GetCurrentCode returns the code of the current step, to be shown to the
+// class and fetched again when it expires
+func (cs *CheckInService) GetCurrentCode(sessionID, teacherID string) (*models.CheckInCode, error) {
+	session, err := cs.lessonSession(sessionID, teacherID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := helper.SchoolNow()
+	if !checkInSessionOpen(*session, now) {
+		return nil, fmt.Errorf("check-in session is closed")
+	}
+
+	step := checkInStep(*session, now)
+	code := checkInCode(session.Secret, step)
+	expiresAt := time.Unix((step+1)*int64(session.CodeSeconds), 0).In(now.Location())
+	if expiresAt.After(session.ClosesAt) {
+		expiresAt = session.ClosesAt
+	}
+
+	return &models.CheckInCode{
+		SessionID: session.ID,
+		Code:      code,
+		QRPayload: strings.Join([]string{checkInPayloadPrefix, session.ID, code}, ":"),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (cs *CheckInService) CloseSession(sessionID, teacherID string) (*models.CheckInSession, error) {
+	session, err := cs.lessonSession(sessionID, teacherID)
+	if err != nil {
+		return nil, err
+	}
+
+	if session.ClosedAt != nil {
+		return nil, fmt.Errorf("check-in session is already closed")
+	}
+
+	now := helper.SchoolNow()
+	session.ClosedAt = &now
+	if err := cs.checkInRepo.CloseSession(session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (cs *CheckInService) GetCheckIns(sessionID, teacherID string) ([]models.CheckIn, error) {
+	if _, err := cs.lessonSession(sessionID, teacherID); err != nil {
+		return nil, err
+	}
+
+	return cs.checkInRepo.GetCheckInsBySessionID(sessionID)
+}
+
+// CheckIn accepts the code of the current step or the one before it, so a
+// code typed as it rotates still counts. Each student checks in once per
+// session; the check-in is saved before the attendance so a replayed request
+// is refused even when both arrive at once.
+func (cs *CheckInService) CheckIn(studentID string, submission models.CheckInSubmission) (*models.CheckIn, error) {
+	if studentID == "" {
+		return nil, fmt.Errorf("student ID is required")
+	}
+
+	if submission.QRPayload != "" {
+		prefix, rest, _ := strings.Cut(strings.TrimSpace(submission.QRPayload), ":")
+		sessionID, code, found := strings.Cut(rest, ":")
+		if prefix != checkInPayloadPrefix || !found {
+			return nil, fmt.Errorf("invalid check-in QR payload")
+		}
+		submission.SessionID, submission.Code = sessionID, code
+	}
+
+	submission.Code = strings.TrimSpace(submission.Code)
+	if submission.SessionID == "" || submission.Code == "" {
+		return nil, fmt.Errorf("session ID and code are required")
+	}
+
+	session, err := cs.checkInRepo.GetSessionByID(submission.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("check-in session not found: %w", err)
+	}
+
+	now := helper.SchoolNow()
+	if !checkInSessionOpen(*session, now) {
+		return nil, fmt.Errorf("check-in session is closed")
+	}
+
+	step := checkInStep(*session, now)
+	valid := false
+	for _, candidate := range []int64{step, step - 1} {
+		if hmac.Equal([]byte(submission.Code), []byte(checkInCode(session.Secret, candidate))) {
+			valid = true
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("invalid or expired check-in code")
+	}
+
+	schedule, err := cs.scheduleRepo.GetScheduleByID(session.ScheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("schedule not found: %w", err)
+	}
+
+	students, err := cs.classService.GetStudentsByClassID(schedule.ClassID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class students: %w", err)
+	}
+	if !slices.ContainsFunc(students, func(student models.User) bool { return student.ID == studentID }) {
+		return nil, fmt.Errorf("student is not in the lesson's class")
+	}
+
+	// A student checks in once per lesson, whichever session it was
+	sessions, err := cs.checkInRepo.GetSessionsByScheduleID(session.ScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	for _, lessonSession := range sessions {
+		checkIns, err := cs.checkInRepo.GetCheckInsBySessionID(lessonSession.ID)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(checkIns, func(checkIn models.CheckIn) bool { return checkIn.StudentID == studentID }) {
+			return nil, fmt.Errorf("student already checked in to this lesson")
+		}
+	}
+
+	checkIn := &models.CheckIn{
+		SessionID:   session.ID,
+		StudentID:   studentID,
+		Code:        submission.Code,
+		Status:      models.AttendancePresent,
+		CheckedInAt: now,
+	}
+
+	// Late counts from the start of the lesson, not from the session
+	start := helper.SchoolDateTime(schedule.Date, schedule.Time)
+	if now.After(start.Add(time.Duration(*session.LateAfterMinutes) * time.Minute)) {
+		checkIn.Status = models.AttendanceLate
+		checkIn.MinutesLate = int(math.Ceil(now.Sub(start).Minutes()))
+	}
+
+	if err := cs.checkInRepo.CreateCheckIn(checkIn); err != nil {
+		return nil, err
+	}
+
+	err = cs.attendanceService.MarkAttendance(studentID, schedule.ID, models.AttendanceMark{
+		Status:      checkIn.Status,
+		MinutesLate: checkIn.MinutesLate,
+		Note:        "self check-in",
+	})
+	if err != nil {
+		// Let the student try again once the attendance can be saved
+		if deleteErr := cs.checkInRepo.DeleteCheckIn(checkIn.ID); deleteErr != nil {
+			return nil, fmt.Errorf("%w; failed to undo check-in: %v", err, deleteErr)
+		}
+		return nil, err
+	}
+
+	return checkIn, nil
+}
+
+// lessonSession returns the session when the teacher teaches its lesson
+func (cs *CheckInService) lessonSession(sessionID, teacherID string) (*models.CheckInSession, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("session ID is required")
+	}
+
+	session, err := cs.checkInRepo.GetSessionByID(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("check-in session not found: %w", err)
+	}
+
+	schedule, err := cs.scheduleRepo.GetScheduleByID(session.ScheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("schedule not found: %w", err)
+	}
+
+	if err := checkLessonTeacher(schedule, teacherID); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// checkLessonTeacher allows the lesson's teacher and substitute; an empty
+// teacherID skips the check
+func checkLessonTeacher(schedule *models.Schedule, teacherID string) error {
+	if teacherID == "" || schedule.TeacherID == teacherID || schedule.SubstituteTeacherID == teacherID {
+		return nil
+	}
+	return fmt.Errorf("only the lesson's teacher can manage its check-in")
+}
+
+func checkInSessionOpen(session models.CheckInSession, now time.Time) bool {
+	return session.ClosedAt == nil && now.Before(session.ClosesAt)
+}
+
+func checkInStep(session models.CheckInSession, now time.Time) int64 {
+	return now.Unix() / int64(session.CodeSeconds)
+}
+
+// checkInCode derives the six digit code of a step from the session secret,
+// the way one-time password apps do
+func checkInCode(secret string, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
@@ -0,0 +1,220 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryCheckInRepo struct {
+	sessions []models.CheckInSession
+	checkIns []models.CheckIn
+}
+
+func (r *memoryCheckInRepo) CreateSession(session *models.CheckInSession) error {
+	session.ID = fmt.Sprintf("session-%d", len(r.sessions)+1)
+	r.sessions = append(r.sessions, *session)
+	return nil
+}
+
+func (r *memoryCheckInRepo) GetSessionByID(id string) (*models.CheckInSession, error) {
+	for _, session := range r.sessions {
+		if session.ID == id {
+			return &session, nil
+		}
+	}
+	return nil, fmt.Errorf("session %s not found", id)
+}
+
+func (r *memoryCheckInRepo) GetSessionsByScheduleID(scheduleID string) ([]models.CheckInSession, error) {
+	var sessions []models.CheckInSession
+	for _, session := range r.sessions {
+		if session.ScheduleID == scheduleID {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *memoryCheckInRepo) CloseSession(session *models.CheckInSession) error {
+	for i := range r.sessions {
+		if r.sessions[i].ID == session.ID {
+			r.sessions[i] = *session
+		}
+	}
+	return nil
+}
+
+func (r *memoryCheckInRepo) CreateCheckIn(checkIn *models.CheckIn) error {
+	for _, existing := range r.checkIns {
+		if existing.SessionID == checkIn.SessionID && existing.StudentID == checkIn.StudentID {
+			return fmt.Errorf("duplicate check-in")
+		}
+	}
+	checkIn.ID = fmt.Sprintf("check-in-%d", len(r.checkIns)+1)
+	r.checkIns = append(r.checkIns, *checkIn)
+	return nil
+}
+
+func (r *memoryCheckInRepo) DeleteCheckIn(id string) error {
+	return nil
+}
+
+func (r *memoryCheckInRepo) GetCheckInsBySessionID(sessionID string) ([]models.CheckIn, error) {
+	var checkIns []models.CheckIn
+	for _, checkIn := range r.checkIns {
+		if checkIn.SessionID == sessionID {
+			checkIns = append(checkIns, checkIn)
+		}
+	}
+	return checkIns, nil
+}
+
+func TestCheckInWithRotatingCode(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "maths", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+	)
+	attendanceRepo := &memoryAttendanceRepo{}
+	checkInRepo := &memoryCheckInRepo{}
+	attendanceService := NewAttendanceService(attendanceRepo, repo, &fakeExcuseRepo{}, rosterClassService{}, fakeAlertService{})
+	service := NewCheckInService(checkInRepo, repo, attendanceService, rosterClassService{})
+
+	current := helper.SchoolDateTime(date, clock(8, 40))
+	restore := helper.SetNow(func() time.Time { return current })
+	defer restore()
+
+	session := &models.CheckInSession{ScheduleID: "maths", OpenedBy: "t1"}
+	if err := service.OpenSession(session, "t1"); err == nil {
+		t.Fatal("expected a session twenty minutes before the lesson to be refused")
+	}
+
+	current = helper.SchoolDateTime(date, clock(8, 58))
+	if err := service.OpenSession(&models.CheckInSession{ScheduleID: "maths", OpenedBy: "t2"}, "t2"); err == nil || !strings.Contains(err.Error(), "lesson's teacher") {
+		t.Fatalf("expected another teacher to be refused, got %v", err)
+	}
+	if err := service.OpenSession(session, "t1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.CodeSeconds != 30 || !session.ClosesAt.Equal(current.Add(15*time.Minute)) || session.Secret == "" {
+		t.Fatalf("expected the default window and code rotation, got %+v", session)
+	}
+	if err := service.OpenSession(&models.CheckInSession{ScheduleID: "maths", OpenedBy: "t1"}, "t1"); err == nil || !strings.Contains(err.Error(), "already open") {
+		t.Fatalf("expected a second open session to be refused, got %v", err)
+	}
+
+	code, err := service.GetCurrentCode(session.ID, "t1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(code.Code) != 6 || code.QRPayload != "checkin:"+session.ID+":"+code.Code {
+		t.Fatalf("unexpected code %+v", code)
+	}
+
+	if _, err := service.CheckIn("s1", models.CheckInSubmission{SessionID: session.ID, Code: "000000"}); err == nil && code.Code != "000000" {
+		t.Fatal("expected a wrong code to be refused")
+	}
+	if _, err := service.CheckIn("s9", models.CheckInSubmission{QRPayload: code.QRPayload}); err == nil || !strings.Contains(err.Error(), "not in the lesson's class") {
+		t.Fatalf("expected a student of another class to be refused, got %v", err)
+	}
+
+	checkIn, err := service.CheckIn("s1", models.CheckInSubmission{QRPayload: code.QRPayload})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checkIn.Status != models.AttendancePresent {
+		t.Fatalf("expected an early check-in to be present, got %+v", checkIn)
+	}
+	if _, err := service.CheckIn("s1", models.CheckInSubmission{QRPayload: code.QRPayload}); err == nil || !strings.Contains(err.Error(), "already checked in") {
+		t.Fatalf("expected a replayed check-in to be refused, got %v", err)
+	}
+
+	// The code of the previous step still counts, older codes do not
+	current = current.Add(30 * time.Second)
+	if _, err := service.CheckIn("s2", models.CheckInSubmission{SessionID: session.ID, Code: code.Code}); err != nil {
+		t.Fatalf("expected the previous code to be accepted, got %v", err)
+	}
+	current = helper.SchoolDateTime(date, clock(9, 8))
+	if _, err := service.CheckIn("s3", models.CheckInSubmission{SessionID: session.ID, Code: code.Code}); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("expected an old code to be refused, got %v", err)
+	}
+
+	latest, err := service.GetCurrentCode(session.ID, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	late, err := service.CheckIn("s3", models.CheckInSubmission{SessionID: session.ID, Code: latest.Code})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if late.Status != models.AttendanceLate || late.MinutesLate != 8 {
+		t.Fatalf("expected eight minutes late, got %+v", late)
+	}
+
+	attendances, _ := attendanceRepo.GetAttendanceByStudentID("s3")
+	if len(attendances) != 1 || attendances[0].Status != models.AttendanceLate || attendances[0].MinutesLate != 8 {
+		t.Fatalf("expected the check-in to be recorded as attendance, got %+v", attendances)
+	}
+
+	if _, err := service.CloseSession(session.ID, "t1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.GetCurrentCode(session.ID, "t1"); err == nil {
+		t.Fatal("expected no code once the session is closed")
+	}
+
+	// A later session closes with the lesson and takes no second check-in
+	current = helper.SchoolDateTime(date, clock(9, 30))
+	second := &models.CheckInSession{ScheduleID: "maths", OpenedBy: "t1"}
+	if err := service.OpenSession(second, "t1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !second.ClosesAt.Equal(helper.SchoolDateTime(date, clock(9, 40))) {
+		t.Fatalf("expected the session to close when the lesson ends, got %v", second.ClosesAt)
+	}
+	secondCode, err := service.GetCurrentCode(second.ID, "t1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.CheckIn("s1", models.CheckInSubmission{QRPayload: secondCode.QRPayload}); err == nil || !strings.Contains(err.Error(), "already checked in") {
+		t.Fatalf("expected a check-in to a second session of the lesson to be refused, got %v", err)
+	}
+}
+
+func TestCheckInWithoutGracePeriod(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "maths", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+	)
+	attendanceService := NewAttendanceService(&memoryAttendanceRepo{}, repo, &fakeExcuseRepo{}, rosterClassService{}, fakeAlertService{})
+	service := NewCheckInService(&memoryCheckInRepo{}, repo, attendanceService, rosterClassService{})
+
+	current := helper.SchoolDateTime(date, clock(9, 0))
+	restore := helper.SetNow(func() time.Time { return current })
+	defer restore()
+
+	lateAfter := 0
+	session := &models.CheckInSession{ScheduleID: "maths", OpenedBy: "t1", LateAfterMinutes: &lateAfter}
+	if err := service.OpenSession(session, "t1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *session.LateAfterMinutes != 0 {
+		t.Fatalf("expected no grace period to be kept, got %d", *session.LateAfterMinutes)
+	}
+
+	current = current.Add(time.Minute)
+	code, err := service.GetCurrentCode(session.ID, "t1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkIn, err := service.CheckIn("s1", models.CheckInSubmission{QRPayload: code.QRPayload})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if checkIn.Status != models.AttendanceLate || checkIn.MinutesLate != 1 {
+		t.Fatalf("expected a check-in after the start to be late, got %+v", checkIn)
+	}
+}
@@ -0,0 +1,336 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// maxExamCalendarDays bounds the date range of an exam calendar
+	maxExamCalendarDays = 180
+	// defaultExamCalendarDays is used when no end date is given
+	defaultExamCalendarDays = 28
+)
+
+type ExamService struct {
+	examRepo        models.ExamRepository
+	scheduleService models.ScheduleService
+	lessonRepo      models.LessonRepository
+	roomRepo        models.RoomRepository
+	classService    models.ClassService
+	userService     models.KeycloakService
+}
+
+func NewExamService(examRepo models.ExamRepository, scheduleService models.ScheduleService, lessonRepo models.LessonRepository, roomRepo models.RoomRepository, classService models.ClassService, userService models.KeycloakService) models.ExamService {
+	return &ExamService{
+		examRepo:        examRepo,
+		scheduleService: scheduleService,
+		lessonRepo:      lessonRepo,
+		roomRepo:        roomRepo,
+		classService:    classService,
+		userService:     userService,
+	}
+}
+
+func (es *ExamService) CreateExam(exam *models.Exam) error {
+	if err := es.validateExam(exam); err != nil {
+		return err
+	}
+
+	return es.examRepo.CreateExam(exam)
+}
+
+func (es *ExamService) GetExamByID(id string) (*models.Exam, error) {
+	if id == "" {
+		return nil, fmt.Errorf("exam ID is required")
+	}
+
+	return es.examRepo.GetExamByID(id)
+}
+
+func (es *ExamService) UpdateExam(exam *models.Exam) error {
+	existing, err := es.examRepo.GetExamByID(exam.ID)
+	if err != nil {
+		return fmt.Errorf("exam not found: %w", err)
+	}
+
+	if err := es.validateExam(exam); err != nil {
+		return err
+	}
+
+	exam.CreatedAt = existing.CreatedAt
+	return es.examRepo.UpdateExam(exam)
+}
+
+func (es *ExamService) DeleteExam(id string) error {
+	if id == "" {
+		return fmt.Errorf("exam ID is required")
+	}
+
+	if _, err := es.examRepo.GetExamByID(id); err != nil {
+		return fmt.Errorf("exam not found: %w", err)
+	}
+
+	return es.examRepo.DeleteExam(id)
+}
+
+func (es *ExamService) GetExamRules() (*models.ExamRules, error) {
+	return es.examRepo.GetExamRules()
+}
+
+// SetExamRules only applies to exams created or updated afterwards
+func (es *ExamService) SetExamRules(rules *models.ExamRules) error {
+	if rules.MaxExamsPerDay < 0 {
+		return fmt.Errorf("max exams per day must not be negative")
+	}
+
+	if rules.MinGapMinutes < 0 {
+		return fmt.Errorf("min gap minutes must not be negative")
+	}
+
+	return es.examRepo.UpdateExamRules(rules)
+}
+
+func (es *ExamService) GetClassExamCalendar(classID string, from, to time.Time) (*models.ExamCalendar, error) {
+	if classID == "" {
+		return nil, fmt.Errorf("class ID is required")
+	}
+
+	return es.examCalendar([]string{classID}, from, to)
+}
+
+// GetStudentExamCalendar lists the exams of every class the student belongs to
+func (es *ExamService) GetStudentExamCalendar(studentID string, from, to time.Time) (*models.ExamCalendar, error) {
+	if studentID == "" {
+		return nil, fmt.Errorf("student ID is required")
+	}
+
+	classes, err := es.classService.GetClassesByStudentID(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student classes: %w", err)
+	}
+
+	classIDs := make([]string, 0, len(classes))
+	for _, class := range classes {
+		classIDs = append(classIDs, class.ID)
+	}
+
+	return es.examCalendar(classIDs, from, to)
+}
+
+func (es *ExamService) examCalendar(classIDs []string, from, to time.Time) (*models.ExamCalendar, error) {
+	if from.IsZero() {
+		from = helper.SchoolToday()
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, defaultExamCalendarDays-1)
+	}
+
+	from, to = dateOnly(from), dateOnly(to)
+	if to.Before(from) {
+		return nil, fmt.Errorf("to date must not be before from date")
+	}
+	if to.After(from.AddDate(0, 0, maxExamCalendarDays-1)) {
+		return nil, fmt.Errorf("date range must not exceed %d days", maxExamCalendarDays)
+	}
+
+	calendar := &models.ExamCalendar{From: from, To: to, Exams: []models.ExamEntry{}}
+	if len(classIDs) == 0 {
+		return calendar, nil
+	}
+
+	exams, err := es.examRepo.GetExamsBetween(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	lessons, err := es.lessonRepo.GetAllLessons()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lessons: %w", err)
+	}
+	lessonNames := make(map[string]string, len(lessons))
+	for _, lesson := range lessons {
+		lessonNames[lesson.ID] = lesson.LessonName
+	}
+
+	sortExams(exams)
+	for _, exam := range exams {
+		if !slices.Contains(classIDs, exam.ClassID) {
+			continue
+		}
+		calendar.Exams = append(calendar.Exams, models.ExamEntry{Exam: exam, LessonName: lessonNames[exam.LessonID]})
+	}
+
+	return calendar, nil
+}
+
+// validateExam checks the exam against the exam rules and against the
+// lessons and exams of its room and invigilators. Lessons of the class itself
+// may overlap: the exam is sat in their place.
+func (es *ExamService) validateExam(exam *models.Exam) error {
+	exam.Title = strings.TrimSpace(exam.Title)
+	if exam.Title == "" {
+		return fmt.Errorf("exam title is required")
+	}
+
+	if exam.LessonID == "" {
+		return fmt.Errorf("lesson ID is required")
+	}
+
+	if exam.ClassID == "" {
+		return fmt.Errorf("class ID is required")
+	}
+
+	if _, err := es.lessonRepo.GetLessonByID(exam.LessonID); err != nil {
+		return fmt.Errorf("lesson not found: %w", err)
+	}
+
+	if exam.RoomID != "" {
+		if _, err := es.roomRepo.GetRoomByID(exam.RoomID); err != nil {
+			return fmt.Errorf("room not found: %w", err)
+		}
+	}
+
+	if exam.Duration <= 0 {
+		return fmt.Errorf("exam duration must be positive")
+	}
+	exam.EndTime = exam.Time.Add(time.Duration(exam.Duration) * time.Minute)
+	if clockOffset(exam.EndTime) <= clockOffset(exam.Time) {
+		return fmt.Errorf("exam must end on the day it starts")
+	}
+
+	exam.Date = dateOnly(exam.Date)
+	if exam.Date.Before(helper.SchoolToday()) {
+		return fmt.Errorf("cannot schedule exams for past dates")
+	}
+
+	if err := es.scheduleService.CheckScheduleDate(exam.Date); err != nil {
+		return err
+	}
+
+	rules, err := es.examRepo.GetExamRules()
+	if err != nil {
+		return err
+	}
+
+	sameDay, err := es.examRepo.GetExamsBetween(exam.Date, exam.Date)
+	if err != nil {
+		return err
+	}
+	sameDay = slices.DeleteFunc(sameDay, func(other models.Exam) bool { return other.ID != "" && other.ID == exam.ID })
+
+	if err := checkExamRules(*exam, sameDay, *rules); err != nil {
+		return err
+	}
+
+	slot := examSlot(*exam)
+
+	if exam.RoomID != "" {
+		for _, other := range sameDay {
+			if other.RoomID == exam.RoomID && examsOverlap(*exam, other) {
+				return fmt.Errorf("room is already booked for the exam %q at %s", other.Title, other.Time.Format("15:04"))
+			}
+		}
+
+		conflicts, err := es.scheduleService.GetScheduleConflicts("", "", exam.RoomID, exam.Date, exam.Time, exam.EndTime)
+		if err != nil {
+			return fmt.Errorf("failed to check conflicts: %w", err)
+		}
+		if err := conflictError(conflicts, slot); err != nil {
+			return err
+		}
+	}
+
+	exam.InvigilatorIDs = slices.Clone(exam.InvigilatorIDs)
+	slices.Sort(exam.InvigilatorIDs)
+	exam.InvigilatorIDs = slices.Compact(exam.InvigilatorIDs)
+	for _, invigilatorID := range exam.InvigilatorIDs {
+		if err := es.checkInvigilator(invigilatorID, *exam, slot, sameDay); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkExamRules applies the per day limit and the minimum gap to the class's
+// other exams of the day
+func checkExamRules(exam models.Exam, sameDay []models.Exam, rules models.ExamRules) error {
+	gap := time.Duration(rules.MinGapMinutes) * time.Minute
+
+	count := 0
+	for _, other := range sameDay {
+		if other.ClassID != exam.ClassID {
+			continue
+		}
+		count++
+
+		if examsOverlap(exam, other) {
+			return fmt.Errorf("class already sits the exam %q at %s", other.Title, other.Time.Format("15:04"))
+		}
+		if clockOffset(exam.Time) < clockOffset(other.EndTime)+gap && clockOffset(other.Time) < clockOffset(exam.EndTime)+gap {
+			return fmt.Errorf("exams of a class must be at least %d minutes apart, %q is at %s-%s",
+				rules.MinGapMinutes, other.Title, other.Time.Format("15:04"), other.EndTime.Format("15:04"))
+		}
+	}
+
+	if rules.MaxExamsPerDay > 0 && count >= rules.MaxExamsPerDay {
+		return fmt.Errorf("class already has %d exam(s) on %s, the limit is %d per day", count, exam.Date.Format("2006-01-02"), rules.MaxExamsPerDay)
+	}
+
+	return nil
+}
+
+// checkInvigilator makes sure the invigilator is staff, is free of lessons and
+// other exams during the exam, and is available at that time
+func (es *ExamService) checkInvigilator(invigilatorID string, exam models.Exam, slot models.Schedule, sameDay []models.Exam) error {
+	user, err := es.userService.GetUserByID(invigilatorID)
+	if err != nil {
+		return fmt.Errorf("invigilator not found: %w", err)
+	}
+
+	if user.Role != "teacher" && user.Role != "admin" {
+		return fmt.Errorf("user %s cannot invigilate exams", invigilatorID)
+	}
+
+	for _, other := range sameDay {
+		if slices.Contains(other.InvigilatorIDs, invigilatorID) && examsOverlap(exam, other) {
+			return fmt.Errorf("invigilator %s %s already invigilates the exam %q at %s",
+				user.FirstName, user.LastName, other.Title, other.Time.Format("15:04"))
+		}
+	}
+
+	if err := es.scheduleService.CheckTeacherAvailability(invigilatorID, slot); err != nil {
+		return err
+	}
+
+	conflicts, err := es.scheduleService.GetScheduleConflicts(invigilatorID, "", "", exam.Date, exam.Time, exam.EndTime)
+	if err != nil {
+		return fmt.Errorf("failed to check conflicts: %w", err)
+	}
+	return conflictError(conflicts, slot)
+}
+
+// examSlot describes the exam as a schedule for the lesson conflict checks
+func examSlot(exam models.Exam) models.Schedule {
+	return models.Schedule{Date: exam.Date, Time: exam.Time, EndTime: exam.EndTime, ClassID: exam.ClassID, RoomID: exam.RoomID}
+}
+
+func examsOverlap(a, b models.Exam) bool {
+	_, _, ok := overlapWindow(examSlot(a), examSlot(b))
+	return ok
+}
+
+func sortExams(exams []models.Exam) {
+	sort.SliceStable(exams, func(i, j int) bool {
+		if !isSameDay(exams[i].Date, exams[j].Date) {
+			return exams[i].Date.Before(exams[j].Date)
+		}
+		return clockOffset(exams[i].Time) < clockOffset(exams[j].Time)
+	})
+}
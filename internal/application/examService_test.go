@@ -0,0 +1,123 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeExamRepo struct {
+	exams []models.Exam
+	rules models.ExamRules
+}
+
+func (r *fakeExamRepo) CreateExam(exam *models.Exam) error {
+	exam.ID = fmt.Sprintf("exam-%d", len(r.exams)+1)
+	r.exams = append(r.exams, *exam)
+	return nil
+}
+
+func (r *fakeExamRepo) GetExamByID(id string) (*models.Exam, error) {
+	for _, exam := range r.exams {
+		if exam.ID == id {
+			return &exam, nil
+		}
+	}
+	return nil, fmt.Errorf("exam %s not found", id)
+}
+
+func (r *fakeExamRepo) UpdateExam(exam *models.Exam) error {
+	for i := range r.exams {
+		if r.exams[i].ID == exam.ID {
+			r.exams[i] = *exam
+		}
+	}
+	return nil
+}
+
+func (r *fakeExamRepo) DeleteExam(id string) error { return nil }
+
+func (r *fakeExamRepo) GetExamsBetween(from, to time.Time) ([]models.Exam, error) {
+	var exams []models.Exam
+	for _, exam := range r.exams {
+		if !exam.Date.Before(from) && !exam.Date.After(to) {
+			exams = append(exams, exam)
+		}
+	}
+	return exams, nil
+}
+
+func (r *fakeExamRepo) GetExamRules() (*models.ExamRules, error) {
+	rules := r.rules
+	return &rules, nil
+}
+
+func (r *fakeExamRepo) UpdateExamRules(rules *models.ExamRules) error {
+	r.rules = *rules
+	return nil
+}
+
+func TestExamRulesAndInvigilatorConflicts(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "busy", Date: date, TeacherID: "t3", LessonID: "l1", ClassID: "c2", Time: clock(9, 0), EndTime: clock(9, 40)},
+	)
+	scheduleService := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+	examRepo := &fakeExamRepo{rules: models.ExamRules{MaxExamsPerDay: 1, MinGapMinutes: 30}}
+	service := NewExamService(examRepo, scheduleService, importLessonRepo{}, fakeRoomRepo{}, studentClassService{}, substitutionUserService{})
+
+	maths := &models.Exam{Title: "Maths midterm", LessonID: "l1", ClassID: "c1", Date: date, Time: clock(9, 0), Duration: 45, InvigilatorIDs: []string{"t2", "t2"}}
+	if err := service.CreateExam(maths); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !isSameTime(maths.EndTime, clock(9, 45)) || len(maths.InvigilatorIDs) != 1 {
+		t.Fatalf("expected the end time from the duration and one invigilator, got %+v", maths)
+	}
+
+	art := &models.Exam{Title: "Art", LessonID: "l2", ClassID: "c1", Date: date, Time: clock(13, 0), Duration: 40}
+	if err := service.CreateExam(art); err == nil || !strings.Contains(err.Error(), "limit is 1") {
+		t.Fatalf("expected the per day limit to be enforced, got %v", err)
+	}
+
+	if err := service.SetExamRules(&models.ExamRules{MaxExamsPerDay: 2, MinGapMinutes: 30}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	art.Time = clock(10, 0)
+	if err := service.CreateExam(art); err == nil || !strings.Contains(err.Error(), "at least 30 minutes apart") {
+		t.Fatalf("expected the minimum gap to be enforced, got %v", err)
+	}
+	art.Time = clock(10, 15)
+	if err := service.CreateExam(art); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	other := &models.Exam{Title: "Physics", LessonID: "l1", ClassID: "c2", Date: date, Time: clock(9, 30), Duration: 40, InvigilatorIDs: []string{"t2"}}
+	if err := service.CreateExam(other); err == nil || !strings.Contains(err.Error(), "already invigilates") {
+		t.Fatalf("expected the double invigilation to be rejected, got %v", err)
+	}
+
+	other.InvigilatorIDs = []string{"t3"}
+	if err := service.CreateExam(other); err == nil || !strings.Contains(err.Error(), "overlapping") {
+		t.Fatalf("expected the invigilator's lesson to conflict, got %v", err)
+	}
+
+	other.InvigilatorIDs = []string{"s1"}
+	if err := service.CreateExam(other); err == nil || !strings.Contains(err.Error(), "cannot invigilate") {
+		t.Fatalf("expected a student invigilator to be rejected, got %v", err)
+	}
+
+	other.InvigilatorIDs = []string{"t4"}
+	if err := service.CreateExam(other); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calendar, err := service.GetStudentExamCalendar("s1", date, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(calendar.Exams) != 2 || calendar.Exams[0].ID != maths.ID || calendar.Exams[1].LessonName != "Art" {
+		t.Fatalf("expected the two exams of class 5A, got %+v", calendar.Exams)
+	}
+}
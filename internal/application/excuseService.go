@@ -0,0 +1,285 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// maxExcuseDays bounds the date range a single excuse may cover
+const maxExcuseDays = 62
+
+type ExcuseService struct {
+	excuseRepo     models.ExcuseRepository
+	attendanceRepo models.AttendanceRepository
+	scheduleRepo   models.ScheduleRepository
+	classService   models.ClassService
+	alertService   models.AttendanceAlertService
+}
+
+func NewExcuseService(excuseRepo models.ExcuseRepository, attendanceRepo models.AttendanceRepository, scheduleRepo models.ScheduleRepository, classService models.ClassService, alertService models.AttendanceAlertService) models.ExcuseService {
+	return &ExcuseService{
+		excuseRepo:     excuseRepo,
+		attendanceRepo: attendanceRepo,
+		scheduleRepo:   scheduleRepo,
+		classService:   classService,
+		alertService:   alertService,
+	}
+}
+
+func (es *ExcuseService) SubmitExcuse(excuse *models.Excuse) error {
+	if excuse.StudentID == "" {
+		return fmt.Errorf("student ID is required")
+	}
+
+	if excuse.SubmittedBy == "" {
+		return fmt.Errorf("submitter ID is required")
+	}
+
+	excuse.Reason = strings.TrimSpace(excuse.Reason)
+	if excuse.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+
+	excuse.DocumentURL = strings.TrimSpace(excuse.DocumentURL)
+	if excuse.DocumentURL != "" {
+		document, err := url.ParseRequestURI(excuse.DocumentURL)
+		if err != nil || (document.Scheme != "http" && document.Scheme != "https") {
+			return fmt.Errorf("document URL must be an http or https link")
+		}
+	}
+
+	if (excuse.FromDate == nil) != (excuse.ToDate == nil) {
+		return fmt.Errorf("from date and to date must be given together")
+	}
+	if excuse.FromDate != nil {
+		from, to := dateOnly(*excuse.FromDate), dateOnly(*excuse.ToDate)
+		if to.Before(from) {
+			return fmt.Errorf("to date must not be before from date")
+		}
+		if to.After(from.AddDate(0, 0, maxExcuseDays-1)) {
+			return fmt.Errorf("date range must not exceed %d days", maxExcuseDays)
+		}
+		excuse.FromDate, excuse.ToDate = &from, &to
+	}
+
+	excuse.ScheduleIDs = slices.Clone(excuse.ScheduleIDs)
+	slices.Sort(excuse.ScheduleIDs)
+	excuse.ScheduleIDs = slices.Compact(excuse.ScheduleIDs)
+	if len(excuse.ScheduleIDs) == 0 && excuse.FromDate == nil {
+		return fmt.Errorf("list the excused lessons or give a date range")
+	}
+
+	if len(excuse.ScheduleIDs) > 0 {
+		classes, err := es.classService.GetClassesByStudentID(excuse.StudentID)
+		if err != nil {
+			return fmt.Errorf("failed to get student classes: %w", err)
+		}
+
+		for _, scheduleID := range excuse.ScheduleIDs {
+			schedule, err := es.scheduleRepo.GetScheduleByID(scheduleID)
+			if err != nil {
+				return fmt.Errorf("schedule not found: %w", err)
+			}
+			if !slices.ContainsFunc(classes, func(class models.Class) bool { return class.ID == schedule.ClassID }) {
+				return fmt.Errorf("schedule %s is not a lesson of the student's classes", scheduleID)
+			}
+		}
+	}
+
+	excuse.Status = models.ExcusePending
+	return es.excuseRepo.CreateExcuse(excuse)
+}
+
+func (es *ExcuseService) GetExcuseByID(id string) (*models.Excuse, error) {
+	if id == "" {
+		return nil, fmt.Errorf("excuse ID is required")
+	}
+
+	return es.excuseRepo.GetExcuseByID(id)
+}
+
+func (es *ExcuseService) GetExcusesByStudentID(studentID string) ([]models.Excuse, error) {
+	if studentID == "" {
+		return nil, fmt.Errorf("student ID is required")
+	}
+
+	return es.excuseRepo.GetExcusesByStudentID(studentID)
+}
+
+func (es *ExcuseService) GetPendingExcuses() ([]models.Excuse, error) {
+	return es.excuseRepo.GetExcusesByStatus(models.ExcusePending)
+}
+
+func (es *ExcuseService) ApproveExcuse(id, reviewerID, note string) (*models.ExcuseReview, error) {
+	excuse, err := es.pendingExcuse(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	attendances, err := es.attendanceRepo.GetAttendanceByStudentID(excuse.StudentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student attendances: %w", err)
+	}
+
+	var excused []models.Attendance
+	audits := []models.AttendanceAudit{}
+	for _, attendance := range attendances {
+		if attendance.Status != models.AttendanceAbsent && attendance.Status != models.AttendanceLate {
+			continue
+		}
+
+		covered, err := es.covers(*excuse, attendance.ScheduleID)
+		if err != nil {
+			return nil, err
+		}
+		if !covered {
+			continue
+		}
+
+		audits = append(audits, models.AttendanceAudit{
+			AttendanceID: attendance.ID,
+			ChangedBy:    reviewerID,
+			OldStatus:    attendance.Status,
+			NewStatus:    models.AttendanceExcused,
+		})
+
+		attendance.Counter = attendanceCounter(&attendance, models.AttendanceExcused)
+		attendance.Status = models.AttendanceExcused
+		attendance.MinutesLate = 0
+		excused = append(excused, attendance)
+	}
+
+	excuse.Status = models.ExcuseApproved
+	excuse.ReviewedBy = reviewerID
+	excuse.ReviewNote = strings.TrimSpace(note)
+	if err := es.excuseRepo.ReviewExcuse(excuse, excused, audits); err != nil {
+		return nil, err
+	}
+
+	if len(excused) > 0 {
+		evaluateAttendanceAlerts(es.alertService, excuse.StudentID)
+	}
+
+	return &models.ExcuseReview{Excuse: *excuse, Audits: audits}, nil
+}
+
+func (es *ExcuseService) RejectExcuse(id, reviewerID, note string) (*models.ExcuseReview, error) {
+	excuse, err := es.pendingExcuse(id, reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return nil, fmt.Errorf("a note is required to reject an excuse")
+	}
+
+	excuse.Status = models.ExcuseRejected
+	excuse.ReviewedBy = reviewerID
+	excuse.ReviewNote = note
+	if err := es.excuseRepo.ReviewExcuse(excuse, nil, nil); err != nil {
+		return nil, err
+	}
+
+	return &models.ExcuseReview{Excuse: *excuse, Audits: []models.AttendanceAudit{}}, nil
+}
+
+func (es *ExcuseService) GetAttendanceAudits(attendanceID string) ([]models.AttendanceAudit, error) {
+	if attendanceID == "" {
+		return nil, fmt.Errorf("attendance ID is required")
+	}
+
+	return es.excuseRepo.GetAttendanceAudits(attendanceID)
+}
+
+func (es *ExcuseService) pendingExcuse(id, reviewerID string) (*models.Excuse, error) {
+	if id == "" {
+		return nil, fmt.Errorf("excuse ID is required")
+	}
+
+	if reviewerID == "" {
+		return nil, fmt.Errorf("reviewer ID is required")
+	}
+
+	excuse, err := es.excuseRepo.GetExcuseByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("excuse not found: %w", err)
+	}
+
+	if excuse.Status != models.ExcusePending {
+		return nil, fmt.Errorf("excuse is already %s", excuse.Status)
+	}
+
+	return excuse, nil
+}
+
+// covers reports whether the excuse lists the lesson or its date range
+// includes the lesson's date
+func (es *ExcuseService) covers(excuse models.Excuse, scheduleID string) (bool, error) {
+	if slices.Contains(excuse.ScheduleIDs, scheduleID) {
+		return true, nil
+	}
+
+	if excuse.FromDate == nil {
+		return false, nil
+	}
+
+	schedule, err := es.scheduleRepo.GetScheduleByID(scheduleID)
+	if err != nil {
+		return false, fmt.Errorf("schedule not found: %w", err)
+	}
+
+	date := dateOnly(schedule.Date)
+	return !date.Before(dateOnly(*excuse.FromDate)) && !date.After(dateOnly(*excuse.ToDate)), nil
+}
+
+// applyApprovedExcuses records the absent and late attendance of students
+// with an approved excuse for the lesson as excused, so an excuse approved
+// before the attendance was taken still applies. It returns the audits of
+// the changed attendances keyed by student ID, to be saved with them.
+func applyApprovedExcuses(excuseRepo models.ExcuseRepository, schedule models.Schedule, attendances []models.Attendance) (map[string]models.AttendanceAudit, error) {
+	excuses, err := excuseRepo.GetApprovedExcusesForLesson(schedule.ID, schedule.Date)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check excuses: %w", err)
+	}
+
+	excused := make(map[string]models.Excuse, len(excuses))
+	for _, excuse := range excuses {
+		excused[excuse.StudentID] = excuse
+	}
+
+	audits := make(map[string]models.AttendanceAudit)
+	for i := range attendances {
+		attendance := &attendances[i]
+		excuse, ok := excused[attendance.StudentID]
+		if !ok {
+			continue
+		}
+		if attendance.Status != models.AttendanceAbsent && attendance.Status != models.AttendanceLate {
+			continue
+		}
+
+		audits[attendance.StudentID] = models.AttendanceAudit{
+			ExcuseID:  excuse.ID,
+			ChangedBy: excuse.ReviewedBy,
+			OldStatus: attendance.Status,
+			NewStatus: models.AttendanceExcused,
+		}
+		attendance.Status = models.AttendanceExcused
+		attendance.MinutesLate = 0
+	}
+	return audits, nil
+}
+
+// excuseAudit returns the audit of the single attendance the excuses were
+// applied to, or nil when no excuse changed it
+func excuseAudit(audits map[string]models.AttendanceAudit, studentID string) *models.AttendanceAudit {
+	audit, ok := audits[studentID]
+	if !ok {
+		return nil
+	}
+	return &audit
+}
@@ -0,0 +1,204 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeExcuseRepo struct {
+	excuses        []models.Excuse
+	attendanceRepo *memoryAttendanceRepo
+}
+
+func (r *fakeExcuseRepo) CreateExcuse(excuse *models.Excuse) error {
+	excuse.ID = fmt.Sprintf("excuse-%d", len(r.excuses)+1)
+	r.excuses = append(r.excuses, *excuse)
+	return nil
+}
+
+func (r *fakeExcuseRepo) GetExcuseByID(id string) (*models.Excuse, error) {
+	for _, excuse := range r.excuses {
+		if excuse.ID == id {
+			return &excuse, nil
+		}
+	}
+	return nil, fmt.Errorf("excuse %s not found", id)
+}
+
+func (r *fakeExcuseRepo) GetExcusesByStudentID(studentID string) ([]models.Excuse, error) {
+	return nil, nil
+}
+
+func (r *fakeExcuseRepo) GetExcusesByStatus(status string) ([]models.Excuse, error) {
+	return nil, nil
+}
+
+func (r *fakeExcuseRepo) GetApprovedExcusesForLesson(scheduleID string, date time.Time) ([]models.Excuse, error) {
+	var excuses []models.Excuse
+	for _, excuse := range r.excuses {
+		if excuse.Status != models.ExcuseApproved {
+			continue
+		}
+		inRange := excuse.FromDate != nil && !dateOnly(date).Before(*excuse.FromDate) && !dateOnly(date).After(*excuse.ToDate)
+		if slices.Contains(excuse.ScheduleIDs, scheduleID) || inRange {
+			excuses = append(excuses, excuse)
+		}
+	}
+	return excuses, nil
+}
+
+func (r *fakeExcuseRepo) ReviewExcuse(excuse *models.Excuse, attendances []models.Attendance, audits []models.AttendanceAudit) error {
+	for i := range r.excuses {
+		if r.excuses[i].ID == excuse.ID {
+			r.excuses[i] = *excuse
+		}
+	}
+	for i := range attendances {
+		if err := r.attendanceRepo.UpdateAttendance(&attendances[i], nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fakeExcuseRepo) GetAttendanceAudits(attendanceID string) ([]models.AttendanceAudit, error) {
+	return nil, nil
+}
+
+func TestApprovedExcuseMarksAbsencesExcused(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "day1", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "day2", Date: date.AddDate(0, 0, 1), TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "day4", Date: date.AddDate(0, 0, 3), TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "other-class", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c2", Time: clock(10, 0), EndTime: clock(10, 40)},
+	)
+	attendanceRepo := &memoryAttendanceRepo{attendances: []models.Attendance{
+		{ID: "a1", StudentID: "s1", ScheduleID: "day1", Status: models.AttendanceAbsent},
+		{ID: "a2", StudentID: "s1", ScheduleID: "day2", Status: models.AttendanceLate, MinutesLate: 10, Counter: 1},
+		{ID: "a3", StudentID: "s1", ScheduleID: "day4", Status: models.AttendanceAbsent},
+	}}
+	excuseRepo := &fakeExcuseRepo{attendanceRepo: attendanceRepo}
+	service := NewExcuseService(excuseRepo, attendanceRepo, repo, studentClassService{}, fakeAlertService{})
+
+	err := service.SubmitExcuse(&models.Excuse{StudentID: "s1", SubmittedBy: "s1", Reason: "Flu", ScheduleIDs: []string{"other-class"}})
+	if err == nil || !strings.Contains(err.Error(), "not a lesson of the student's classes") {
+		t.Fatalf("expected a lesson of another class to be rejected, got %v", err)
+	}
+
+	err = service.SubmitExcuse(&models.Excuse{StudentID: "s1", SubmittedBy: "s1", Reason: "Flu"})
+	if err == nil {
+		t.Fatal("expected an excuse without lessons or dates to be rejected")
+	}
+
+	to := date.AddDate(0, 0, 1)
+	excuse := &models.Excuse{StudentID: "s1", SubmittedBy: "t2", Reason: " Flu, called in by mother ", FromDate: &date, ToDate: &to}
+	if err := service.SubmitExcuse(excuse); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if excuse.Status != models.ExcusePending || excuse.Reason != "Flu, called in by mother" {
+		t.Fatalf("unexpected excuse %+v", excuse)
+	}
+
+	review, err := service.ApproveExcuse(excuse.ID, "t1", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if review.Excuse.Status != models.ExcuseApproved || review.Excuse.ReviewedBy != "t1" || len(review.Audits) != 2 {
+		t.Fatalf("expected two attendances changed, got %+v", review)
+	}
+	if review.Audits[1].OldStatus != models.AttendanceLate || review.Audits[1].NewStatus != models.AttendanceExcused {
+		t.Fatalf("unexpected audit %+v", review.Audits[1])
+	}
+
+	late := attendanceRepo.attendances[1]
+	if late.Status != models.AttendanceExcused || late.MinutesLate != 0 || late.Counter != 0 {
+		t.Fatalf("expected the late lesson to be excused, got %+v", late)
+	}
+	if attendanceRepo.attendances[2].Status != models.AttendanceAbsent {
+		t.Fatal("expected the lesson outside the date range to stay absent")
+	}
+
+	if _, err := service.RejectExcuse(excuse.ID, "t1", "too late"); err == nil || !strings.Contains(err.Error(), "already approved") {
+		t.Fatalf("expected a reviewed excuse to stay as decided, got %v", err)
+	}
+
+	second := &models.Excuse{StudentID: "s1", SubmittedBy: "s1", Reason: "Trip", ScheduleIDs: []string{"day4"}}
+	if err := service.SubmitExcuse(second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := service.RejectExcuse(second.ID, "t1", " "); err == nil {
+		t.Fatal("expected a rejection without a note to be refused")
+	}
+}
+
+func TestApprovedExcuseAppliesToAttendanceTakenLater(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "day1", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "day2", Date: date.AddDate(0, 0, 1), TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+	)
+	attendanceRepo := &memoryAttendanceRepo{}
+	excuseRepo := &fakeExcuseRepo{attendanceRepo: attendanceRepo}
+	excuseService := NewExcuseService(excuseRepo, attendanceRepo, repo, studentClassService{}, fakeAlertService{})
+	attendanceService := NewAttendanceService(attendanceRepo, repo, excuseRepo, rosterClassService{}, fakeAlertService{})
+
+	to := date.AddDate(0, 0, 1)
+	excuse := &models.Excuse{StudentID: "s1", SubmittedBy: "s1", Reason: "Surgery", FromDate: &date, ToDate: &to}
+	if err := excuseService.SubmitExcuse(excuse); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := excuseService.ApproveExcuse(excuse.ID, "t1", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := attendanceService.MarkAttendance("s1", "day1", models.AttendanceMark{Status: models.AttendanceAbsent}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	roster, err := attendanceService.TakeRollCall("day2", []models.RollCallMark{
+		{StudentID: "s1", AttendanceMark: models.AttendanceMark{Status: models.AttendanceLate, MinutesLate: 15}},
+		{StudentID: "s2", AttendanceMark: models.AttendanceMark{Status: models.AttendanceAbsent}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	attendances, _ := attendanceRepo.GetAttendanceByStudentID("s1")
+	if len(attendances) != 2 {
+		t.Fatalf("expected both lessons recorded, got %+v", attendances)
+	}
+	for _, attendance := range attendances {
+		if attendance.Status != models.AttendanceExcused || attendance.MinutesLate != 0 {
+			t.Fatalf("expected the approved excuse to apply to %s, got %+v", attendance.ScheduleID, attendance)
+		}
+	}
+	for _, entry := range roster.Students {
+		if entry.StudentID == "s2" && entry.Attendance.Status != models.AttendanceAbsent {
+			t.Fatalf("expected the student without excuse to stay absent, got %+v", entry.Attendance)
+		}
+	}
+	if len(attendanceRepo.audits) != 2 {
+		t.Fatalf("expected an audit for each excused attendance, got %+v", attendanceRepo.audits)
+	}
+	for _, audit := range attendanceRepo.audits {
+		if audit.ExcuseID != excuse.ID || audit.ChangedBy != "t1" || audit.NewStatus != models.AttendanceExcused {
+			t.Fatalf("expected the audit to name the approved excuse, got %+v", audit)
+		}
+	}
+
+	// Re-marking the excused student absent keeps the approved excuse
+	if err := attendanceService.MarkAttendance("s1", "day1", models.AttendanceMark{Status: models.AttendanceAbsent}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	attendances, _ = attendanceRepo.GetAttendanceByStudentID("s1")
+	for _, attendance := range attendances {
+		if attendance.ScheduleID == "day1" && attendance.Status != models.AttendanceExcused {
+			t.Fatalf("expected the re-marked absence to stay excused, got %+v", attendance)
+		}
+	}
+}
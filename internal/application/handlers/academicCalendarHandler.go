@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AcademicCalendarHandler struct {
+	academicCalendarService models.AcademicCalendarService
+}
+
+func NewAcademicCalendarHandler(acs models.AcademicCalendarService) *AcademicCalendarHandler {
+	return &AcademicCalendarHandler{
+		academicCalendarService: acs,
+	}
+}
+
+func (ach *AcademicCalendarHandler) CreateTermHandler(c *fiber.Ctx) error {
+	var term models.Term
+	if err := c.BodyParser(&term); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	err := ach.academicCalendarService.CreateTerm(&term)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Term created successfully",
+		"data":    term,
+	})
+}
+
+func (ach *AcademicCalendarHandler) GetTermByIDHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "term ID is required",
+		})
+	}
+
+	term, err := ach.academicCalendarService.GetTermByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": term,
+	})
+}
+
+func (ach *AcademicCalendarHandler) UpdateTermHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "term ID is required",
+		})
+	}
+
+	var term models.Term
+	if err := c.BodyParser(&term); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	term.ID = id
+
+	err := ach.academicCalendarService.UpdateTerm(&term)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Term updated successfully",
+		"data":    term,
+	})
+}
+
+func (ach *AcademicCalendarHandler) DeleteTermHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "term ID is required",
+		})
+	}
+
+	err := ach.academicCalendarService.DeleteTerm(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Term deleted successfully",
+	})
+}
+
+func (ach *AcademicCalendarHandler) GetAllTermsHandler(c *fiber.Ctx) error {
+	terms, err := ach.academicCalendarService.GetAllTerms()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": terms,
+	})
+}
+
+func (ach *AcademicCalendarHandler) CreateClosureHandler(c *fiber.Ctx) error {
+	var closure models.Closure
+	if err := c.BodyParser(&closure); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	err := ach.academicCalendarService.CreateClosure(&closure)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Closure created successfully",
+		"data":    closure,
+	})
+}
+
+func (ach *AcademicCalendarHandler) GetClosureByIDHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "closure ID is required",
+		})
+	}
+
+	closure, err := ach.academicCalendarService.GetClosureByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": closure,
+	})
+}
+
+func (ach *AcademicCalendarHandler) UpdateClosureHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "closure ID is required",
+		})
+	}
+
+	var closure models.Closure
+	if err := c.BodyParser(&closure); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	closure.ID = id
+
+	err := ach.academicCalendarService.UpdateClosure(&closure)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Closure updated successfully",
+		"data":    closure,
+	})
+}
+
+func (ach *AcademicCalendarHandler) DeleteClosureHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "closure ID is required",
+		})
+	}
+
+	err := ach.academicCalendarService.DeleteClosure(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Closure deleted successfully",
+	})
+}
+
+func (ach *AcademicCalendarHandler) GetAllClosuresHandler(c *fiber.Ctx) error {
+	closures, err := ach.academicCalendarService.GetAllClosures()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": closures,
+	})
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AttendanceAlertHandler struct {
+	alertService models.AttendanceAlertService
+}
+
+func NewAttendanceAlertHandler(as models.AttendanceAlertService) *AttendanceAlertHandler {
+	return &AttendanceAlertHandler{
+		alertService: as,
+	}
+}
+
+// CreateThresholdHandler adds a threshold for a lesson, or the overall
+// threshold when no lesson_id is sent
+func (ah *AttendanceAlertHandler) CreateThresholdHandler(c *fiber.Ctx) error {
+	var threshold models.AttendanceThreshold
+	if err := c.BodyParser(&threshold); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	err := ah.alertService.CreateThreshold(&threshold)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Attendance threshold created successfully",
+		"data":    threshold,
+	})
+}
+
+func (ah *AttendanceAlertHandler) GetThresholdsHandler(c *fiber.Ctx) error {
+	thresholds, err := ah.alertService.GetThresholds()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": thresholds,
+	})
+}
+
+func (ah *AttendanceAlertHandler) UpdateThresholdHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "threshold ID is required",
+		})
+	}
+
+	var threshold models.AttendanceThreshold
+	if err := c.BodyParser(&threshold); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+	threshold.ID = id
+
+	err := ah.alertService.UpdateThreshold(&threshold)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Attendance threshold updated successfully",
+		"data":    threshold,
+	})
+}
+
+func (ah *AttendanceAlertHandler) DeleteThresholdHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "threshold ID is required",
+		})
+	}
+
+	err := ah.alertService.DeleteThreshold(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Attendance threshold deleted successfully",
+	})
+}
+
+// EvaluateAlertsHandler evaluates every student now instead of waiting for
+// the scheduled run, e.g. after the thresholds changed
+func (ah *AttendanceAlertHandler) EvaluateAlertsHandler(c *fiber.Ctx) error {
+	alerts, err := ah.alertService.EvaluateAll()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Attendance alerts evaluated successfully",
+		"data":    alerts,
+	})
+}
+
+func (ah *AttendanceAlertHandler) GetAtRiskStudentsHandler(c *fiber.Ctx) error {
+	students, err := ah.alertService.GetAtRiskStudents()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": students,
+	})
+}
+
+// GetMyAlertsHandler lists the open alerts sent to the user in the token
+func (ah *AttendanceAlertHandler) GetMyAlertsHandler(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(string)
+
+	alerts, err := ah.alertService.GetAlertsByRecipientID(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": alerts,
+	})
+}
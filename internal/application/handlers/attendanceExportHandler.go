@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+	"bufio"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AttendanceExportHandler struct {
+	exportService models.AttendanceExportService
+}
+
+func NewAttendanceExportHandler(es models.AttendanceExportService) *AttendanceExportHandler {
+	return &AttendanceExportHandler{
+		exportService: es,
+	}
+}
+
+var exportContentTypes = map[string]string{
+	models.ExportCSV:  "text/csv; charset=utf-8",
+	models.ExportXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// ExportAttendanceHandler streams the attendance sheet of the class in the
+// format query parameter, csv by default, from one date to another
+func (eh *AttendanceExportHandler) ExportAttendanceHandler(c *fiber.Ctx) error {
+	classID := c.Params("classID")
+	if classID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "class ID is required",
+		})
+	}
+
+	format := c.Query("format", models.ExportCSV)
+	contentType, ok := exportContentTypes[format]
+	if !ok {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "format must be csv or xlsx",
+		})
+	}
+
+	var from, to time.Time
+	for param, date := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": "invalid " + param + " format, use YYYY-MM-DD",
+			})
+		}
+		*date = parsed
+	}
+
+	sheet, err := eh.exportService.GetAttendanceSheet(classID, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	filename := fmt.Sprintf("attendance-%s-%s.%s", sheet.From.Format("2006-01-02"), sheet.To.Format("2006-01-02"), format)
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	c.Status(fiber.StatusOK)
+
+	// The status is already sent when the body is written, so a failure
+	// part way through can only be logged
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		if err := eh.exportService.WriteAttendanceSheet(w, sheet, format); err != nil {
+			log.Printf("attendance export of class %s failed: %v", classID, err)
+			return
+		}
+		if err := w.Flush(); err != nil {
+			log.Printf("attendance export of class %s failed: %v", classID, err)
+		}
+	})
+	return nil
+}
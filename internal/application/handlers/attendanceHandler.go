@@ -171,7 +171,7 @@ func (ah *AttendanceHandler) MarkAttendanceHandler(c *fiber.Ctx) error {
 	var req struct {
 		StudentID  string `json:"student_id"`
 		ScheduleID string `json:"schedule_id"`
-		IsPresent  bool   `json:"is_present"`
+		models.AttendanceMark
 	}
 
 	if err := c.BodyParser(&req); err != nil {
@@ -188,7 +188,7 @@ func (ah *AttendanceHandler) MarkAttendanceHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	err := ah.attendanceService.MarkAttendance(req.StudentID, req.ScheduleID, req.IsPresent)
+	err := ah.attendanceService.MarkAttendance(req.StudentID, req.ScheduleID, req.AttendanceMark)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Internal Server Error",
@@ -200,3 +200,121 @@ func (ah *AttendanceHandler) MarkAttendanceHandler(c *fiber.Ctx) error {
 		"message": "Attendance marked successfully",
 	})
 }
+
+// TakeRollCallHandler records the attendance of the listed students of the
+// lesson and returns the whole roster
+func (ah *AttendanceHandler) TakeRollCallHandler(c *fiber.Ctx) error {
+	scheduleID := c.Params("scheduleID")
+	if scheduleID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "schedule ID is required",
+		})
+	}
+
+	var req struct {
+		Students []models.RollCallMark `json:"students"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	roster, err := ah.attendanceService.TakeRollCall(scheduleID, req.Students)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Roll call taken successfully",
+		"data":    roster,
+	})
+}
+
+func (ah *AttendanceHandler) GetRosterHandler(c *fiber.Ctx) error {
+	scheduleID := c.Params("scheduleID")
+	if scheduleID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "schedule ID is required",
+		})
+	}
+
+	roster, err := ah.attendanceService.GetRoster(scheduleID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": roster,
+	})
+}
+
+// GetAttendanceSummaryHandler returns the student's weighted attendance rate
+// with the number of lessons in each status
+func (ah *AttendanceHandler) GetAttendanceSummaryHandler(c *fiber.Ctx) error {
+	studentID := c.Params("studentID")
+	if studentID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "student ID is required",
+		})
+	}
+
+	summary, err := ah.attendanceService.GetAttendanceSummaryByStudent(studentID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": summary,
+	})
+}
+
+func (ah *AttendanceHandler) GetAttendancePolicyHandler(c *fiber.Ctx) error {
+	policy, err := ah.attendanceService.GetAttendancePolicy()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": policy,
+	})
+}
+
+func (ah *AttendanceHandler) SetAttendancePolicyHandler(c *fiber.Ctx) error {
+	var policy models.AttendancePolicy
+	if err := c.BodyParser(&policy); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	err := ah.attendanceService.SetAttendancePolicy(&policy)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Attendance policy updated successfully",
+		"data":    policy,
+	})
+}
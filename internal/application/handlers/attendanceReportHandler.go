@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AttendanceReportHandler struct {
+	reportService models.AttendanceReportService
+}
+
+func NewAttendanceReportHandler(rs models.AttendanceReportService) *AttendanceReportHandler {
+	return &AttendanceReportHandler{
+		reportService: rs,
+	}
+}
+
+// GetAttendanceReportHandler reports attendance rates grouped by the group_by
+// query parameter; teachers only see the lessons they teach
+func (rh *AttendanceReportHandler) GetAttendanceReportHandler(c *fiber.Ctx) error {
+	filter := models.AttendanceReportFilter{
+		GroupBy:   c.Query("group_by"),
+		Period:    c.Query("period"),
+		ClassID:   c.Query("class_id"),
+		LessonID:  c.Query("lesson_id"),
+		TeacherID: c.Query("teacher_id"),
+		StudentID: c.Query("student_id"),
+	}
+	if teacherID := ownTeacherID(c); teacherID != "" {
+		filter.TeacherID = teacherID
+	}
+
+	for param, date := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": "invalid " + param + " format, use YYYY-MM-DD",
+			})
+		}
+		*date = parsed
+	}
+
+	report, err := rh.reportService.GetAttendanceReport(filter)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": report,
+	})
+}
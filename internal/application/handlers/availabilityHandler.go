@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type AvailabilityHandler struct {
+	availabilityService models.AvailabilityService
+}
+
+func NewAvailabilityHandler(as models.AvailabilityService) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		availabilityService: as,
+	}
+}
+
+func (ah *AvailabilityHandler) CreateAvailabilityWindowHandler(c *fiber.Ctx) error {
+	var window models.AvailabilityWindow
+	if err := c.BodyParser(&window); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	// Teachers manage their own availability, admins may pick the teacher
+	if teacherID := ownTeacherID(c); teacherID != "" {
+		window.TeacherID = teacherID
+	}
+
+	err := ah.availabilityService.CreateAvailabilityWindow(&window)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Availability window created successfully",
+		"data":    window,
+	})
+}
+
+func (ah *AvailabilityHandler) GetMyAvailabilityHandler(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(string)
+
+	windows, err := ah.availabilityService.GetAvailabilityWindows(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": windows,
+	})
+}
+
+func (ah *AvailabilityHandler) GetTeacherAvailabilityHandler(c *fiber.Ctx) error {
+	teacherID := c.Params("teacherId")
+	if teacherID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "teacher ID is required",
+		})
+	}
+
+	windows, err := ah.availabilityService.GetAvailabilityWindows(teacherID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": windows,
+	})
+}
+
+func (ah *AvailabilityHandler) UpdateAvailabilityWindowHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "availability window ID is required",
+		})
+	}
+
+	var window models.AvailabilityWindow
+	if err := c.BodyParser(&window); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	window.ID = id
+	window.TeacherID = ownTeacherID(c)
+
+	err := ah.availabilityService.UpdateAvailabilityWindow(&window)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Availability window updated successfully",
+		"data":    window,
+	})
+}
+
+func (ah *AvailabilityHandler) DeleteAvailabilityWindowHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "availability window ID is required",
+		})
+	}
+
+	err := ah.availabilityService.DeleteAvailabilityWindow(ownTeacherID(c), id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Availability window deleted successfully",
+	})
+}
+
+// ownTeacherID returns the caller's ID when their changes are limited to their
+// own availability, and "" for admins
+func ownTeacherID(c *fiber.Ctx) string {
+	roles, _ := c.Locals("userRoles").([]string)
+	if slices.Contains(roles, "admin") {
+		return ""
+	}
+
+	userID, _ := c.Locals("userID").(string)
+	return userID
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type BellScheduleHandler struct {
+	bellScheduleService models.BellScheduleService
+}
+
+func NewBellScheduleHandler(bs models.BellScheduleService) *BellScheduleHandler {
+	return &BellScheduleHandler{
+		bellScheduleService: bs,
+	}
+}
+
+func (bh *BellScheduleHandler) CreateBellScheduleHandler(c *fiber.Ctx) error {
+	var bellSchedule models.BellSchedule
+	if err := c.BodyParser(&bellSchedule); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	err := bh.bellScheduleService.CreateBellSchedule(&bellSchedule)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Bell schedule created successfully",
+		"data":    bellSchedule,
+	})
+}
+
+func (bh *BellScheduleHandler) GetAllBellSchedulesHandler(c *fiber.Ctx) error {
+	bellSchedules, err := bh.bellScheduleService.GetAllBellSchedules()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": bellSchedules,
+	})
+}
+
+// GetActiveBellScheduleHandler returns null data when lessons use free-form
+// times
+func (bh *BellScheduleHandler) GetActiveBellScheduleHandler(c *fiber.Ctx) error {
+	bellSchedule, err := bh.bellScheduleService.GetActiveBellSchedule()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": bellSchedule,
+	})
+}
+
+func (bh *BellScheduleHandler) GetBellScheduleByIDHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "bell schedule ID is required",
+		})
+	}
+
+	bellSchedule, err := bh.bellScheduleService.GetBellScheduleByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": bellSchedule,
+	})
+}
+
+func (bh *BellScheduleHandler) UpdateBellScheduleHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "bell schedule ID is required",
+		})
+	}
+
+	var bellSchedule models.BellSchedule
+	if err := c.BodyParser(&bellSchedule); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+	bellSchedule.ID = id
+
+	err := bh.bellScheduleService.UpdateBellSchedule(&bellSchedule)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Bell schedule updated successfully",
+		"data":    bellSchedule,
+	})
+}
+
+func (bh *BellScheduleHandler) DeleteBellScheduleHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "bell schedule ID is required",
+		})
+	}
+
+	err := bh.bellScheduleService.DeleteBellSchedule(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Bell schedule deleted successfully",
+	})
+}
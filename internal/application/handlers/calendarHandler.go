@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+	"slices"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CalendarHandler struct {
+	calendarService models.CalendarService
+}
+
+func NewCalendarHandler(cs models.CalendarService) *CalendarHandler {
+	return &CalendarHandler{
+		calendarService: cs,
+	}
+}
+
+type CreateFeedRequest struct {
+	OwnerType string `json:"owner_type"`
+	OwnerID   string `json:"owner_id"`
+}
+
+func (ch *CalendarHandler) CreateFeedHandler(c *fiber.Ctx) error {
+	var req CreateFeedRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	userID, _ := c.Locals("userID").(string)
+	roles, _ := c.Locals("userRoles").([]string)
+
+	// Teachers and students default to a feed of their own timetable
+	if req.OwnerType == "" {
+		if slices.Contains(roles, "teacher") {
+			req.OwnerType = models.FeedOwnerTeacher
+		} else {
+			req.OwnerType = models.FeedOwnerStudent
+		}
+	}
+	if req.OwnerID == "" && req.OwnerType != models.FeedOwnerClass {
+		req.OwnerID = userID
+	}
+
+	if !canSubscribe(roles, userID, req.OwnerType, req.OwnerID) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   "Forbidden",
+			"message": "not allowed to subscribe to this calendar",
+		})
+	}
+
+	feed, err := ch.calendarService.CreateFeed(req.OwnerType, req.OwnerID, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Calendar feed created successfully",
+		"data":    feed,
+		"url":     c.BaseURL() + "/v1/api/calendar/feed/" + feed.Token + ".ics",
+	})
+}
+
+func (ch *CalendarHandler) GetMyFeedsHandler(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(string)
+
+	feeds, err := ch.calendarService.GetFeedsByUser(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": feeds,
+	})
+}
+
+func (ch *CalendarHandler) RevokeFeedHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "feed ID is required",
+		})
+	}
+
+	userID, _ := c.Locals("userID").(string)
+
+	if err := ch.calendarService.RevokeFeed(id, userID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Calendar feed revoked successfully",
+	})
+}
+
+// GetFeedHandler serves the iCalendar document. It is public, the token in
+// the URL is the credential, so calendar apps can subscribe without a bearer
+// token.
+func (ch *CalendarHandler) GetFeedHandler(c *fiber.Ctx) error {
+	token := strings.TrimSuffix(c.Params("token"), ".ics")
+	if token == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "feed token is required",
+		})
+	}
+
+	homeworkStyle := c.Query("homework")
+	if homeworkStyle != "" && homeworkStyle != models.HomeworkAsEvent && homeworkStyle != models.HomeworkAsTodo {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "homework must be event or todo",
+		})
+	}
+
+	calendar, err := ch.calendarService.RenderFeed(token, homeworkStyle)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": "calendar feed not found",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, `inline; filename="timetable.ics"`)
+	return c.Status(fiber.StatusOK).SendString(calendar)
+}
+
+// canSubscribe reports whether the user may create a feed for the owner.
+// Admins may subscribe to anything, teachers to their own timetable and to
+// classes, students only to their own timetable.
+func canSubscribe(roles []string, userID, ownerType, ownerID string) bool {
+	if slices.Contains(roles, "admin") {
+		return true
+	}
+
+	switch ownerType {
+	case models.FeedOwnerTeacher:
+		return slices.Contains(roles, "teacher") && ownerID == userID
+	case models.FeedOwnerClass:
+		return slices.Contains(roles, "teacher")
+	case models.FeedOwnerStudent:
+		return slices.Contains(roles, "student") && ownerID == userID
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type CheckInHandler struct {
+	checkInService models.CheckInService
+}
+
+func NewCheckInHandler(cs models.CheckInService) *CheckInHandler {
+	return &CheckInHandler{
+		checkInService: cs,
+	}
+}
+
+// OpenSessionHandler opens a check-in for the lesson; the body may set
+// code_seconds, window_minutes and late_after_minutes
+func (ch *CheckInHandler) OpenSessionHandler(c *fiber.Ctx) error {
+	scheduleID := c.Params("scheduleID")
+	if scheduleID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "schedule ID is required",
+		})
+	}
+
+	var session models.CheckInSession
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&session); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": err.Error(),
+			})
+		}
+	}
+	session.ScheduleID = scheduleID
+	session.OpenedBy, _ = c.Locals("userID").(string)
+
+	err := ch.checkInService.OpenSession(&session, ownTeacherID(c))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Check-in session opened successfully",
+		"data":    session,
+	})
+}
+
+// GetCurrentCodeHandler returns the code and QR payload to show the class
+func (ch *CheckInHandler) GetCurrentCodeHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "session ID is required",
+		})
+	}
+
+	code, err := ch.checkInService.GetCurrentCode(id, ownTeacherID(c))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": code,
+	})
+}
+
+func (ch *CheckInHandler) CloseSessionHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "session ID is required",
+		})
+	}
+
+	session, err := ch.checkInService.CloseSession(id, ownTeacherID(c))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Check-in session closed successfully",
+		"data":    session,
+	})
+}
+
+func (ch *CheckInHandler) GetCheckInsHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "session ID is required",
+		})
+	}
+
+	checkIns, err := ch.checkInService.GetCheckIns(id, ownTeacherID(c))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": checkIns,
+	})
+}
+
+// CheckInHandler checks in the student in the token
+func (ch *CheckInHandler) CheckInHandler(c *fiber.Ctx) error {
+	var submission models.CheckInSubmission
+	if err := c.BodyParser(&submission); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	studentID, _ := c.Locals("userID").(string)
+	checkIn, err := ch.checkInService.CheckIn(studentID, submission)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Checked in successfully",
+		"data":    checkIn,
+	})
+}
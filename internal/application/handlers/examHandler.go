@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ExamHandler struct {
+	examService models.ExamService
+}
+
+func NewExamHandler(es models.ExamService) *ExamHandler {
+	return &ExamHandler{
+		examService: es,
+	}
+}
+
+func (eh *ExamHandler) CreateExamHandler(c *fiber.Ctx) error {
+	var exam models.Exam
+	if err := c.BodyParser(&exam); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	err := eh.examService.CreateExam(&exam)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Exam created successfully",
+		"data":    exam,
+	})
+}
+
+func (eh *ExamHandler) GetExamByIDHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "exam ID is required",
+		})
+	}
+
+	exam, err := eh.examService.GetExamByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": exam,
+	})
+}
+
+func (eh *ExamHandler) UpdateExamHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "exam ID is required",
+		})
+	}
+
+	var exam models.Exam
+	if err := c.BodyParser(&exam); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+	exam.ID = id
+
+	err := eh.examService.UpdateExam(&exam)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Exam updated successfully",
+		"data":    exam,
+	})
+}
+
+func (eh *ExamHandler) DeleteExamHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "exam ID is required",
+		})
+	}
+
+	err := eh.examService.DeleteExam(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Exam deleted successfully",
+	})
+}
+
+func (eh *ExamHandler) GetExamRulesHandler(c *fiber.Ctx) error {
+	rules, err := eh.examService.GetExamRules()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": rules,
+	})
+}
+
+func (eh *ExamHandler) SetExamRulesHandler(c *fiber.Ctx) error {
+	var rules models.ExamRules
+	if err := c.BodyParser(&rules); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	err := eh.examService.SetExamRules(&rules)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Exam rules updated successfully",
+		"data":    rules,
+	})
+}
+
+// GetClassExamCalendarHandler lists the class's exams for the dates from and
+// to, by default the next four weeks
+func (eh *ExamHandler) GetClassExamCalendarHandler(c *fiber.Ctx) error {
+	classID := c.Params("classID")
+	if classID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "class ID is required",
+		})
+	}
+
+	var from, to time.Time
+	for param, date := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": "invalid " + param + " format, use YYYY-MM-DD",
+			})
+		}
+		*date = parsed
+	}
+
+	calendar, err := eh.examService.GetClassExamCalendar(classID, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": calendar,
+	})
+}
+
+// GetMyExamCalendarHandler lists the exams of the classes of the student in
+// the token
+func (eh *ExamHandler) GetMyExamCalendarHandler(c *fiber.Ctx) error {
+	studentID, _ := c.Locals("userID").(string)
+
+	var from, to time.Time
+	for param, date := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": "invalid " + param + " format, use YYYY-MM-DD",
+			})
+		}
+		*date = parsed
+	}
+
+	calendar, err := eh.examService.GetStudentExamCalendar(studentID, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": calendar,
+	})
+}
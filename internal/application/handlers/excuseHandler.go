@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ExcuseHandler struct {
+	excuseService models.ExcuseService
+}
+
+func NewExcuseHandler(es models.ExcuseService) *ExcuseHandler {
+	return &ExcuseHandler{
+		excuseService: es,
+	}
+}
+
+// SubmitExcuseHandler files an excuse. Students excuse themselves; staff give
+// the student_id of the student a guardian excused.
+func (eh *ExcuseHandler) SubmitExcuseHandler(c *fiber.Ctx) error {
+	var excuse models.Excuse
+	if err := c.BodyParser(&excuse); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	userID, _ := c.Locals("userID").(string)
+	roles, _ := c.Locals("userRoles").([]string)
+	if slices.Contains(roles, "student") && !slices.Contains(roles, "teacher") && !slices.Contains(roles, "admin") {
+		excuse.StudentID = userID
+	}
+	excuse.SubmittedBy = userID
+
+	err := eh.excuseService.SubmitExcuse(&excuse)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Excuse submitted successfully",
+		"data":    excuse,
+	})
+}
+
+func (eh *ExcuseHandler) GetExcuseByIDHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "excuse ID is required",
+		})
+	}
+
+	excuse, err := eh.excuseService.GetExcuseByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": excuse,
+	})
+}
+
+func (eh *ExcuseHandler) GetPendingExcusesHandler(c *fiber.Ctx) error {
+	excuses, err := eh.excuseService.GetPendingExcuses()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": excuses,
+	})
+}
+
+func (eh *ExcuseHandler) GetExcusesByStudentIDHandler(c *fiber.Ctx) error {
+	studentID := c.Params("studentID")
+	if studentID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "student ID is required",
+		})
+	}
+
+	excuses, err := eh.excuseService.GetExcusesByStudentID(studentID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": excuses,
+	})
+}
+
+// GetMyExcusesHandler lists the excuses of the student in the token
+func (eh *ExcuseHandler) GetMyExcusesHandler(c *fiber.Ctx) error {
+	studentID, _ := c.Locals("userID").(string)
+
+	excuses, err := eh.excuseService.GetExcusesByStudentID(studentID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": excuses,
+	})
+}
+
+func (eh *ExcuseHandler) ApproveExcuseHandler(c *fiber.Ctx) error {
+	return eh.reviewExcuse(c, eh.excuseService.ApproveExcuse, "Excuse approved successfully")
+}
+
+func (eh *ExcuseHandler) RejectExcuseHandler(c *fiber.Ctx) error {
+	return eh.reviewExcuse(c, eh.excuseService.RejectExcuse, "Excuse rejected successfully")
+}
+
+// reviewExcuse records the decision of the staff member in the token
+func (eh *ExcuseHandler) reviewExcuse(c *fiber.Ctx, review func(id, reviewerID, note string) (*models.ExcuseReview, error), message string) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "excuse ID is required",
+		})
+	}
+
+	var req struct {
+		Note string `json:"note"`
+	}
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": err.Error(),
+			})
+		}
+	}
+
+	reviewerID, _ := c.Locals("userID").(string)
+	result, err := review(id, reviewerID, req.Note)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": message,
+		"data":    result,
+	})
+}
+
+// GetAttendanceAuditsHandler lists the changes excuses made to an attendance
+func (eh *ExcuseHandler) GetAttendanceAuditsHandler(c *fiber.Ctx) error {
+	attendanceID := c.Params("attendanceID")
+	if attendanceID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "attendance ID is required",
+		})
+	}
+
+	audits, err := eh.excuseService.GetAttendanceAudits(attendanceID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": audits,
+	})
+}
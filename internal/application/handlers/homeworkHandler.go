@@ -42,6 +42,8 @@ func (hh *HomeworkHandler) CreateHomeworkHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	homework.DueDate = homework.DueDate.In(displayLocation(c))
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"message": "Homework created successfully",
 		"data":    homework,
@@ -65,6 +67,8 @@ func (hh *HomeworkHandler) GetHomeworkByIDHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	homework.DueDate = homework.DueDate.In(displayLocation(c))
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"data": homework,
 	})
@@ -97,6 +101,8 @@ func (hh *HomeworkHandler) UpdateHomeworkHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	homework.DueDate = homework.DueDate.In(displayLocation(c))
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Homework updated successfully",
 		"data":    homework,
@@ -135,7 +141,7 @@ func (hh *HomeworkHandler) GetAllHomeworksHandler(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": homeworks,
+		"data": localizeHomeworks(c, homeworks),
 	})
 }
 
@@ -157,7 +163,7 @@ func (hh *HomeworkHandler) GetHomeworksByTeacherIDHandler(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": homeworks,
+		"data": localizeHomeworks(c, homeworks),
 	})
 }
 
@@ -179,7 +185,7 @@ func (hh *HomeworkHandler) GetHomeworksByLessonIDHandler(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": homeworks,
+		"data": localizeHomeworks(c, homeworks),
 	})
 }
 
@@ -201,7 +207,7 @@ func (hh *HomeworkHandler) GetHomeworksByClassIDHandler(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": homeworks,
+		"data": localizeHomeworks(c, homeworks),
 	})
 }
 
@@ -215,7 +221,7 @@ func (hh *HomeworkHandler) GetActiveHomeworksHandler(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": homeworks,
+		"data": localizeHomeworks(c, homeworks),
 	})
 }
 
@@ -229,7 +235,7 @@ func (hh *HomeworkHandler) GetOverdueHomeworksHandler(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": homeworks,
+		"data": localizeHomeworks(c, homeworks),
 	})
 }
 
@@ -252,7 +258,7 @@ func (hh *HomeworkHandler) GetHomeworksDueSoonHandler(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": homeworks,
+		"data": localizeHomeworks(c, homeworks),
 		"hours": hours,
 	})
 }
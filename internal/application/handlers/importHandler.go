@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ImportHandler struct {
+	importService models.ImportService
+}
+
+func NewImportHandler(is models.ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: is,
+	}
+}
+
+// ImportSchedulesHandler accepts an .ics or .csv file, either as the "file"
+// field of a multipart form or as the raw request body. It is a dry run
+// unless dry_run=false is given.
+func (ih *ImportHandler) ImportSchedulesHandler(c *fiber.Ctx) error {
+	options := models.ImportOptions{
+		Format:    c.Query("format", c.FormValue("format")),
+		TeacherID: c.Query("teacher_id", c.FormValue("teacher_id")),
+		ClassID:   c.Query("class_id", c.FormValue("class_id")),
+		DryRun:    c.Query("dry_run", c.FormValue("dry_run")) != "false",
+	}
+
+	data := c.Body()
+	if fileHeader, err := c.FormFile("file"); err == nil {
+		file, err := fileHeader.Open()
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": err.Error(),
+			})
+		}
+		defer file.Close()
+
+		if data, err = io.ReadAll(file); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": err.Error(),
+			})
+		}
+
+		if options.Format == "" {
+			options.Format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+		}
+	} else if options.Format == "" && strings.HasPrefix(string(c.Request().Header.ContentType()), "text/calendar") {
+		options.Format = models.ImportFormatICS
+	}
+
+	report, err := ih.importService.ImportSchedules(data, options)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	if report.DryRun {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": "Import checked, nothing was stored",
+			"data":    report,
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Schedules imported successfully",
+		"data":    report,
+	})
+}
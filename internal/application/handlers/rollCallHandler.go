@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RollCallHandler struct {
+	rollCallService models.RollCallService
+}
+
+func NewRollCallHandler(rs models.RollCallService) *RollCallHandler {
+	return &RollCallHandler{
+		rollCallService: rs,
+	}
+}
+
+// CheckEndedLessonsHandler checks the ended lessons now instead of waiting
+// for the scheduled run
+func (rh *RollCallHandler) CheckEndedLessonsHandler(c *fiber.Ctx) error {
+	reminders, err := rh.rollCallService.CheckEndedLessons()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Ended lessons checked successfully",
+		"data":    reminders,
+	})
+}
+
+// GetRemindersHandler lists the open reminders; teachers only see their own
+func (rh *RollCallHandler) GetRemindersHandler(c *fiber.Ctx) error {
+	reminders, err := rh.rollCallService.GetReminders(ownTeacherID(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": reminders,
+	})
+}
+
+func (rh *RollCallHandler) ResolveReminderHandler(c *fiber.Ctx) error {
+	scheduleID := c.Params("scheduleID")
+	if scheduleID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "schedule ID is required",
+		})
+	}
+
+	if err := rh.rollCallService.ResolveReminder(scheduleID, ownTeacherID(c)); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Roll call reminder resolved successfully",
+	})
+}
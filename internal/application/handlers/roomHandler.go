@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type RoomHandler struct {
+	roomService models.RoomService
+}
+
+func NewRoomHandler(rs models.RoomService) *RoomHandler {
+	return &RoomHandler{
+		roomService: rs,
+	}
+}
+
+func (rh *RoomHandler) CreateRoomHandler(c *fiber.Ctx) error {
+	var room models.Room
+	if err := c.BodyParser(&room); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	if room.Name == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "name is required",
+		})
+	}
+
+	err := rh.roomService.CreateRoom(&room)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Room created successfully",
+		"data":    room,
+	})
+}
+
+func (rh *RoomHandler) GetRoomByIDHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "room ID is required",
+		})
+	}
+
+	room, err := rh.roomService.GetRoomByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": room,
+	})
+}
+
+func (rh *RoomHandler) UpdateRoomHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "room ID is required",
+		})
+	}
+
+	var room models.Room
+	if err := c.BodyParser(&room); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	room.ID = id
+
+	err := rh.roomService.UpdateRoom(&room)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Room updated successfully",
+		"data":    room,
+	})
+}
+
+func (rh *RoomHandler) DeleteRoomHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "room ID is required",
+		})
+	}
+
+	err := rh.roomService.DeleteRoom(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Room deleted successfully",
+	})
+}
+
+func (rh *RoomHandler) GetAllRoomsHandler(c *fiber.Ctx) error {
+	rooms, err := rh.roomService.GetAllRooms()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": rooms,
+	})
+}
+
+func (rh *RoomHandler) FindRoomsHandler(c *fiber.Ctx) error {
+	minCapacity, err := strconv.Atoi(c.Query("min_capacity", "0"))
+	if err != nil || minCapacity < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "invalid min_capacity parameter, must be non-negative integer",
+		})
+	}
+
+	var features []string
+	if featuresParam := c.Query("features"); featuresParam != "" {
+		features = strings.Split(featuresParam, ",")
+	}
+
+	rooms, err := rh.roomService.FindRooms(minCapacity, features)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":         rooms,
+		"min_capacity": minCapacity,
+		"features":     features,
+	})
+}
@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"Education_Dashboard/internal/helper"
 	"Education_Dashboard/internal/models"
+	"fmt"
 	"strconv"
 	"time"
 
@@ -42,6 +44,8 @@ func (sh *ScheduleHandler) CreateScheduleHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	localizeSchedule(&schedule, displayLocation(c))
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"message": "Schedule created successfully",
 		"data":    schedule,
@@ -65,6 +69,8 @@ func (sh *ScheduleHandler) GetScheduleByIDHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	localizeSchedule(schedule, displayLocation(c))
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"data": schedule,
 	})
@@ -97,6 +103,8 @@ func (sh *ScheduleHandler) UpdateScheduleHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	localizeSchedule(&schedule, displayLocation(c))
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Schedule updated successfully",
 		"data":    schedule,
@@ -135,7 +143,7 @@ func (sh *ScheduleHandler) GetAllSchedulesHandler(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": schedules,
+		"data": localizeSchedules(c, schedules),
 	})
 }
 
@@ -157,7 +165,7 @@ func (sh *ScheduleHandler) GetSchedulesByTeacherIDHandler(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": schedules,
+		"data": localizeSchedules(c, schedules),
 	})
 }
 
@@ -179,7 +187,29 @@ func (sh *ScheduleHandler) GetSchedulesByClassIDHandler(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": schedules,
+		"data": localizeSchedules(c, schedules),
+	})
+}
+
+func (sh *ScheduleHandler) GetSchedulesByRoomIDHandler(c *fiber.Ctx) error {
+	roomID := c.Params("roomID")
+	if roomID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "room ID is required",
+		})
+	}
+
+	schedules, err := sh.scheduleService.GetSchedulesByRoomID(roomID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": localizeSchedules(c, schedules),
 	})
 }
 
@@ -193,8 +223,8 @@ func (sh *ScheduleHandler) GetTodaySchedulesHandler(c *fiber.Ctx) error {
 	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data": schedules,
-		"date": time.Now().Format("2006-01-02"),
+		"data": localizeSchedules(c, schedules),
+		"date": helper.SchoolToday().Format("2006-01-02"),
 	})
 }
 
@@ -205,7 +235,7 @@ func (sh *ScheduleHandler) GetWeekSchedulesHandler(c *fiber.Ctx) error {
 
 	if dateParam == "" {
 		// Default to current week
-		startDate = time.Now()
+		startDate = helper.SchoolToday()
 	} else {
 		startDate, err = time.Parse("2006-01-02", dateParam)
 		if err != nil {
@@ -224,8 +254,36 @@ func (sh *ScheduleHandler) GetWeekSchedulesHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	closures, err := sh.scheduleService.GetClosureDays(startDate, startDate.AddDate(0, 0, 7))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	// Period-by-day grid of the active bell schedule, null without one
+	grid, err := sh.scheduleService.GetWeekGrid(startDate)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+	if grid != nil {
+		for i := range grid.Rows {
+			for j := range grid.Rows[i].Cells {
+				localizeSchedules(c, grid.Rows[i].Cells[j].Schedules)
+			}
+		}
+		localizeSchedules(c, grid.Unplaced)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data":       schedules,
+		"data":       localizeSchedules(c, schedules),
+		"grid":       grid,
+		"closures":   closures,
+		"cancelled":  countCancelled(schedules),
 		"start_date": startDate.Format("2006-01-02"),
 		"end_date":   startDate.AddDate(0, 0, 7).Format("2006-01-02"),
 	})
@@ -257,8 +315,19 @@ func (sh *ScheduleHandler) GetUpcomingSchedulesHandler(c *fiber.Ctx) error {
 		})
 	}
 
+	today := helper.SchoolToday()
+	closures, err := sh.scheduleService.GetClosureDays(today, today.AddDate(0, 0, days+1))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"data":       schedules,
+		"data":       localizeSchedules(c, schedules),
+		"closures":   closures,
+		"cancelled":  countCancelled(schedules),
 		"teacher_id": teacherID,
 		"days":       days,
 	})
@@ -268,8 +337,10 @@ func (sh *ScheduleHandler) CheckConflictsHandler(c *fiber.Ctx) error {
 	var req struct {
 		TeacherID string    `json:"teacher_id"`
 		ClassID   string    `json:"class_id"`
+		RoomID    string    `json:"room_id"`
 		Date      time.Time `json:"date"`
 		Time      time.Time `json:"time"`
+		EndTime   time.Time `json:"end_time"`
 	}
 
 	if err := c.BodyParser(&req); err != nil {
@@ -279,7 +350,7 @@ func (sh *ScheduleHandler) CheckConflictsHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	conflicts, err := sh.scheduleService.GetScheduleConflicts(req.TeacherID, req.ClassID, req.Date, req.Time)
+	conflicts, err := sh.scheduleService.GetScheduleConflicts(req.TeacherID, req.ClassID, req.RoomID, req.Date, req.Time, req.EndTime)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error":   "Internal Server Error",
@@ -289,10 +360,45 @@ func (sh *ScheduleHandler) CheckConflictsHandler(c *fiber.Ctx) error {
 
 	hasConflicts := len(conflicts) > 0
 
+	// Lessons outside the teacher's availability are rejected on save, warn early
+	var availabilityWarning string
+	if req.TeacherID != "" {
+		slot := models.Schedule{Date: req.Date, Time: req.Time, EndTime: req.EndTime}
+		if err := sh.scheduleService.CheckTeacherAvailability(req.TeacherID, slot); err != nil {
+			availabilityWarning = err.Error()
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"has_conflicts":        hasConflicts,
+		"conflicts":            conflicts,
+		"count":                len(conflicts),
+		"availability_warning": availabilityWarning,
+	})
+}
+
+// FindSlotsHandler returns the next free slots common to a teacher, a class
+// and an optional room
+func (sh *ScheduleHandler) FindSlotsHandler(c *fiber.Ctx) error {
+	var req models.SlotSearchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	slots, err := sh.scheduleService.FindFreeSlots(req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"has_conflicts": hasConflicts,
-		"conflicts":     conflicts,
-		"count":         len(conflicts),
+		"data":  slots,
+		"count": len(slots),
 	})
 }
 
@@ -331,3 +437,317 @@ func (sh *ScheduleHandler) RescheduleHandler(c *fiber.Ctx) error {
 		"new_time": req.NewTime.Format("15:04"),
 	})
 }
+
+// BulkRescheduleHandler moves a day or date range of lessons at once. When a
+// lesson cannot be moved nothing changes and every problem is reported.
+func (sh *ScheduleHandler) BulkRescheduleHandler(c *fiber.Ctx) error {
+	var req models.BulkRescheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	report, err := sh.scheduleService.BulkReschedule(req)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	switch {
+	case report.Applied:
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": "Schedules rescheduled successfully",
+			"data":    report,
+		})
+	case report.Conflicting > 0:
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   "Conflict",
+			"message": fmt.Sprintf("%d of %d schedules cannot be moved, nothing was changed", report.Conflicting, report.Total),
+			"data":    report,
+		})
+	default:
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"message": "Reschedule checked, nothing was changed",
+			"data":    report,
+		})
+	}
+}
+
+func (sh *ScheduleHandler) CancelScheduleHandler(c *fiber.Ctx) error {
+	scheduleID := c.Params("id")
+	if scheduleID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "schedule ID is required",
+		})
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	userID, _ := c.Locals("userID").(string)
+	schedule, err := sh.scheduleService.CancelSchedule(scheduleID, req.Reason, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	localizeSchedule(schedule, displayLocation(c))
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Schedule cancelled successfully",
+		"data":    schedule,
+	})
+}
+
+func (sh *ScheduleHandler) CancelOccurrenceHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "series ID is required",
+		})
+	}
+
+	occurrenceDate, err := time.Parse("2006-01-02", c.Query("occurrence_date"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "invalid occurrence_date format, use YYYY-MM-DD",
+		})
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	userID, _ := c.Locals("userID").(string)
+	schedule, err := sh.scheduleService.CancelOccurrence(id, occurrenceDate, req.Reason, userID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	localizeSchedule(schedule, displayLocation(c))
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Occurrence cancelled successfully",
+		"data":    schedule,
+	})
+}
+
+func (sh *ScheduleHandler) CreateScheduleSeriesHandler(c *fiber.Ctx) error {
+	var series models.ScheduleSeries
+	if err := c.BodyParser(&series); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	if series.TeacherID == "" || series.LessonID == "" || series.ClassID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "teacher_id, lesson_id, and class_id are required",
+		})
+	}
+
+	err := sh.scheduleService.CreateScheduleSeries(&series)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Schedule series created successfully",
+		"data":    series,
+	})
+}
+
+func (sh *ScheduleHandler) GetScheduleSeriesByIDHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "series ID is required",
+		})
+	}
+
+	series, err := sh.scheduleService.GetScheduleSeriesByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": series,
+	})
+}
+
+func (sh *ScheduleHandler) GetAllScheduleSeriesHandler(c *fiber.Ctx) error {
+	series, err := sh.scheduleService.GetAllScheduleSeries()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": series,
+	})
+}
+
+func (sh *ScheduleHandler) UpdateScheduleSeriesHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "series ID is required",
+		})
+	}
+
+	var req struct {
+		Scope          string    `json:"scope"`
+		OccurrenceDate time.Time `json:"occurrence_date"`
+		models.ScheduleSeries
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	if req.Scope == "" {
+		req.Scope = models.SeriesScopeAll
+	}
+
+	if req.Scope != models.SeriesScopeAll && req.OccurrenceDate.IsZero() {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "occurrence_date is required for this scope",
+		})
+	}
+
+	err := sh.scheduleService.UpdateScheduleSeries(id, req.Scope, req.OccurrenceDate, &req.ScheduleSeries)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Schedule series updated successfully",
+		"scope":   req.Scope,
+	})
+}
+
+func (sh *ScheduleHandler) DeleteScheduleSeriesHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "series ID is required",
+		})
+	}
+
+	scope := c.Query("scope", models.SeriesScopeAll)
+
+	var occurrenceDate time.Time
+	if scope != models.SeriesScopeAll {
+		var err error
+		occurrenceDate, err = time.Parse("2006-01-02", c.Query("occurrence_date"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": "invalid occurrence_date format, use YYYY-MM-DD",
+			})
+		}
+	}
+
+	err := sh.scheduleService.DeleteScheduleSeries(id, scope, occurrenceDate)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Schedule series deleted successfully",
+		"scope":   scope,
+	})
+}
+
+func (sh *ScheduleHandler) MaterializeOccurrenceHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "series ID is required",
+		})
+	}
+
+	occurrenceDate, err := time.Parse("2006-01-02", c.Query("occurrence_date"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "invalid occurrence_date format, use YYYY-MM-DD",
+		})
+	}
+
+	schedule, err := sh.scheduleService.MaterializeOccurrence(id, occurrenceDate)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	localizeSchedule(schedule, displayLocation(c))
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Occurrence materialized successfully",
+		"data":    schedule,
+	})
+}
+
+// countCancelled returns how many of the lessons were cancelled, views list
+// them next to the lessons that still take place
+func countCancelled(schedules []models.Schedule) int {
+	count := 0
+	for _, schedule := range schedules {
+		if schedule.Status == models.ScheduleCancelled {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type StudentTimetableHandler struct {
+	studentTimetableService models.StudentTimetableService
+}
+
+func NewStudentTimetableHandler(sts models.StudentTimetableService) *StudentTimetableHandler {
+	return &StudentTimetableHandler{
+		studentTimetableService: sts,
+	}
+}
+
+// GetMyTimetableHandler returns the timetable of the student in the token
+// for the dates from and to, by default the current week
+func (sth *StudentTimetableHandler) GetMyTimetableHandler(c *fiber.Ctx) error {
+	studentID, _ := c.Locals("userID").(string)
+
+	var from, to time.Time
+	for param, date := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": "invalid " + param + " format, use YYYY-MM-DD",
+			})
+		}
+		*date = parsed
+	}
+
+	timetable, err := sth.studentTimetableService.GetStudentTimetable(studentID, from, to)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	loc := displayLocation(c)
+	for i := range timetable.Entries {
+		localizeSchedule(&timetable.Entries[i].Schedule, loc)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": timetable,
+	})
+}
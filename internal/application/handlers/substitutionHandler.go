@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type SubstitutionHandler struct {
+	substitutionService models.SubstitutionService
+}
+
+func NewSubstitutionHandler(ss models.SubstitutionService) *SubstitutionHandler {
+	return &SubstitutionHandler{
+		substitutionService: ss,
+	}
+}
+
+func (sh *SubstitutionHandler) MarkTeacherAbsentHandler(c *fiber.Ctx) error {
+	var absence models.TeacherAbsence
+	if err := c.BodyParser(&absence); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	err := sh.substitutionService.MarkTeacherAbsent(&absence)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Teacher absence created successfully",
+		"data":    absence,
+	})
+}
+
+func (sh *SubstitutionHandler) GetTeacherAbsencesHandler(c *fiber.Ctx) error {
+	teacherID := c.Params("teacherId")
+	if teacherID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "teacher ID is required",
+		})
+	}
+
+	absences, err := sh.substitutionService.GetTeacherAbsences(teacherID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": absences,
+	})
+}
+
+func (sh *SubstitutionHandler) DeleteTeacherAbsenceHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "absence ID is required",
+		})
+	}
+
+	err := sh.substitutionService.DeleteTeacherAbsence(id)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Teacher absence deleted successfully",
+	})
+}
+
+func (sh *SubstitutionHandler) GetAffectedSchedulesHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "absence ID is required",
+		})
+	}
+
+	schedules, err := sh.substitutionService.GetAffectedSchedules(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":  localizeSchedules(c, schedules),
+		"count": len(schedules),
+	})
+}
+
+func (sh *SubstitutionHandler) ProposeSubstitutesHandler(c *fiber.Ctx) error {
+	var request models.SubstitutionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	candidates, err := sh.substitutionService.ProposeSubstitutes(request)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":  candidates,
+		"count": len(candidates),
+	})
+}
+
+func (sh *SubstitutionHandler) AssignSubstituteHandler(c *fiber.Ctx) error {
+	var request models.SubstitutionRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	schedule, err := sh.substitutionService.AssignSubstitute(request)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	localizeSchedule(schedule, displayLocation(c))
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Substitute assigned successfully",
+		"data":    schedule,
+	})
+}
+
+func (sh *SubstitutionHandler) RemoveSubstituteHandler(c *fiber.Ctx) error {
+	scheduleID := c.Params("scheduleId")
+	if scheduleID == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "schedule ID is required",
+		})
+	}
+
+	err := sh.substitutionService.RemoveSubstitute(scheduleID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Substitute removed successfully",
+	})
+}
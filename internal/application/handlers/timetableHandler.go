@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type TimetableHandler struct {
+	timetableService models.TimetableService
+}
+
+func NewTimetableHandler(ts models.TimetableService) *TimetableHandler {
+	return &TimetableHandler{
+		timetableService: ts,
+	}
+}
+
+func (th *TimetableHandler) GenerateTimetableHandler(c *fiber.Ctx) error {
+	var request models.TimetableRequest
+	if err := c.BodyParser(&request); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	preview, err := th.timetableService.GenerateTimetable(&request)
+	if err != nil {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"error":   "Unprocessable Entity",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data":  preview,
+		"count": len(preview.Schedules),
+	})
+}
+
+func (th *TimetableHandler) CommitTimetableHandler(c *fiber.Ctx) error {
+	var req struct {
+		Schedules []models.Schedule `json:"schedules"`
+	}
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	err := th.timetableService.CommitTimetable(req.Schedules)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Timetable committed successfully",
+		"data":    req.Schedules,
+		"count":   len(req.Schedules),
+	})
+}
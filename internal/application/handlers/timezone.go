@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// displayLocation picks the time zone responses are shown in: the
+// X-Timezone header, then the user's zoneinfo claim, then the school zone
+func displayLocation(c *fiber.Ctx) *time.Location {
+	zone := c.Get("X-Timezone")
+	if zone == "" {
+		zone, _ = c.Locals("userTimezone").(string)
+	}
+
+	if zone != "" {
+		if loc, err := time.LoadLocation(zone); err == nil {
+			return loc
+		}
+	}
+
+	return helper.SchoolLocation()
+}
+
+// localizeSchedule sets the lesson's start and end instants in the display zone
+func localizeSchedule(schedule *models.Schedule, loc *time.Location) {
+	startsAt := helper.SchoolDateTime(schedule.Date, schedule.Time).In(loc)
+	schedule.StartsAt = &startsAt
+
+	endsAt := helper.SchoolDateTime(schedule.Date, schedule.EndTime).In(loc)
+	if endsAt.After(startsAt) {
+		schedule.EndsAt = &endsAt
+	}
+}
+
+func localizeSchedules(c *fiber.Ctx, schedules []models.Schedule) []models.Schedule {
+	loc := displayLocation(c)
+	for i := range schedules {
+		localizeSchedule(&schedules[i], loc)
+	}
+	return schedules
+}
+
+func localizeHomeworks(c *fiber.Ctx, homeworks []models.Homework) []models.Homework {
+	loc := displayLocation(c)
+	for i := range homeworks {
+		homeworks[i].DueDate = homeworks[i].DueDate.In(loc)
+	}
+	return homeworks
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"Education_Dashboard/internal/models"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type WorkloadHandler struct {
+	workloadService models.WorkloadService
+}
+
+func NewWorkloadHandler(ws models.WorkloadService) *WorkloadHandler {
+	return &WorkloadHandler{
+		workloadService: ws,
+	}
+}
+
+// SetWorkloadLimitHandler sets a teacher's limits, or the school default when
+// no teacher_id is sent
+func (wh *WorkloadHandler) SetWorkloadLimitHandler(c *fiber.Ctx) error {
+	var limit models.WorkloadLimit
+	if err := c.BodyParser(&limit); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	err := wh.workloadService.SetWorkloadLimit(&limit)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Workload limit saved successfully",
+		"data":    limit,
+	})
+}
+
+func (wh *WorkloadHandler) GetWorkloadLimitsHandler(c *fiber.Ctx) error {
+	limits, err := wh.workloadService.GetWorkloadLimits()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": limits,
+	})
+}
+
+func (wh *WorkloadHandler) DeleteWorkloadLimitHandler(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if id == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": "workload limit ID is required",
+		})
+	}
+
+	err := wh.workloadService.DeleteWorkloadLimit(id)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Workload limit deleted successfully",
+	})
+}
+
+// GetWorkloadReportHandler reports the term given by term_id, the dates
+// from and to, or the current week
+func (wh *WorkloadHandler) GetWorkloadReportHandler(c *fiber.Ctx) error {
+	var from, to time.Time
+	for param, date := range map[string]*time.Time{"from": &from, "to": &to} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": "invalid " + param + " format, use YYYY-MM-DD",
+			})
+		}
+		*date = parsed
+	}
+
+	report, err := wh.workloadService.GetWorkloadReport(c.Query("teacher_id"), c.Query("term_id"), from, to)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"data": report,
+	})
+}
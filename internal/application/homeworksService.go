@@ -1,6 +1,7 @@
 package application
 
 import (
+	"Education_Dashboard/internal/helper"
 	"Education_Dashboard/internal/models"
 	"fmt"
 	"time"
@@ -43,7 +44,7 @@ func (hs *HomeworkService) CreateHomework(homework *models.Homework) error {
 	}
 
 	// Validate due date is in the future
-	if homework.DueDate.Before(time.Now()) {
+	if homework.DueDate.Before(helper.SchoolNow()) {
 		return fmt.Errorf("due date must be in the future")
 	}
 
@@ -91,7 +92,7 @@ func (hs *HomeworkService) UpdateHomework(homework *models.Homework) error {
 	}
 
 	// Check if due date is being changed to past (only if not already past)
-	if !existing.DueDate.Before(time.Now()) && homework.DueDate.Before(time.Now()) {
+	if !existing.DueDate.Before(helper.SchoolNow()) && homework.DueDate.Before(helper.SchoolNow()) {
 		return fmt.Errorf("cannot set due date to past for active homework")
 	}
 
@@ -110,7 +111,7 @@ func (hs *HomeworkService) DeleteHomework(id string) error {
 	}
 
 	// Business rule: Cannot delete homework that is past due date
-	if homework.DueDate.Before(time.Now()) {
+	if homework.DueDate.Before(helper.SchoolNow()) {
 		return fmt.Errorf("cannot delete homework that is past due date")
 	}
 
@@ -160,7 +161,7 @@ func (hs *HomeworkService) GetActiveHomeworks() ([]models.Homework, error) {
 	}
 
 	var activeHomeworks []models.Homework
-	now := time.Now()
+	now := helper.SchoolNow()
 
 	for _, homework := range allHomeworks {
 		if homework.DueDate.After(now) {
@@ -178,7 +179,7 @@ func (hs *HomeworkService) GetOverdueHomeworks() ([]models.Homework, error) {
 	}
 
 	var overdueHomeworks []models.Homework
-	now := time.Now()
+	now := helper.SchoolNow()
 
 	for _, homework := range allHomeworks {
 		if homework.DueDate.Before(now) {
@@ -200,7 +201,7 @@ func (hs *HomeworkService) GetHomeworksDueSoon(hours int) ([]models.Homework, er
 	}
 
 	var dueSoonHomeworks []models.Homework
-	now := time.Now()
+	now := helper.SchoolNow()
 	threshold := now.Add(time.Duration(hours) * time.Hour)
 
 	for _, homework := range allHomeworks {
@@ -217,7 +218,7 @@ func (hs *HomeworkService) ExtendDueDate(homeworkID string, newDueDate time.Time
 		return fmt.Errorf("homework ID is required")
 	}
 
-	if newDueDate.Before(time.Now()) {
+	if newDueDate.Before(helper.SchoolNow()) {
 		return fmt.Errorf("new due date must be in the future")
 	}
 
@@ -0,0 +1,331 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	icalProductID = "-//Education Dashboard//Timetable//EN"
+	icalUIDDomain = "education-dashboard"
+	// RFC 5545 content lines are folded after 75 octets
+	icalLineLimit = 75
+)
+
+// icalWriter builds an RFC 5545 document with CRLF line endings and folded
+// content lines
+type icalWriter struct {
+	b strings.Builder
+}
+
+func (w *icalWriter) prop(name, value string) {
+	line := name + ":" + value
+	limit := icalLineLimit
+	for len(line) > limit {
+		// Never split inside a multi-byte character
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		w.b.WriteString(line[:cut])
+		w.b.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts towards the limit
+		limit = icalLineLimit - 1
+	}
+	w.b.WriteString(line)
+	w.b.WriteString("\r\n")
+}
+
+func (w *icalWriter) text(name, value string) {
+	w.prop(name, icalText(value))
+}
+
+// local writes date-times as wall clock times in the school time zone
+func (w *icalWriter) local(name string, loc *time.Location, times ...time.Time) {
+	values := make([]string, 0, len(times))
+	for _, t := range times {
+		values = append(values, t.Format("20060102T150405"))
+	}
+	w.prop(name+";TZID="+loc.String(), strings.Join(values, ","))
+}
+
+func (w *icalWriter) String() string {
+	return w.b.String()
+}
+
+// icalText escapes a TEXT value
+func icalText(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return replacer.Replace(value)
+}
+
+func icalUTC(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// calendarData is everything a feed document is built from
+type calendarData struct {
+	Name          string
+	Schedules     []models.Schedule
+	Series        []models.ScheduleSeries
+	Homeworks     []models.Homework
+	LessonNames   map[string]string
+	RoomNames     map[string]string
+	HomeworkStyle string
+	// ClosedDates are the closure days and days outside the terms the series
+	// rules run over
+	ClosedDates map[time.Time]bool
+	// Location is the school time zone the times are given in
+	Location *time.Location
+}
+
+// renderCalendar writes schedules as VEVENTs, series as recurring VEVENTs
+// with RRULE and EXDATE, and homework due dates as VEVENTs or VTODOs
+func renderCalendar(data calendarData, now time.Time) string {
+	var w icalWriter
+	stamp := icalUTC(now)
+	loc := data.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	w.prop("BEGIN", "VCALENDAR")
+	w.prop("VERSION", "2.0")
+	w.prop("PRODID", icalProductID)
+	w.prop("CALSCALE", "GREGORIAN")
+	w.prop("METHOD", "PUBLISH")
+	w.text("X-WR-CALNAME", data.Name)
+	firstYear, lastYear := calendarYears(data, now)
+	writeTimezone(&w, loc, firstYear, lastYear)
+
+	for _, schedule := range data.Schedules {
+		w.prop("BEGIN", "VEVENT")
+		w.prop("UID", schedule.ID+"@"+icalUIDDomain)
+		w.prop("DTSTAMP", stamp)
+		w.local("DTSTART", loc, atClock(schedule.Date, clockOffset(schedule.Time)))
+		w.local("DTEND", loc, atClock(schedule.Date, scheduleEnd(schedule)))
+		writeLessonDetails(&w, schedule.LessonID, schedule.RoomID, data)
+		if schedule.Status == models.ScheduleCancelled {
+			w.prop("STATUS", "CANCELLED")
+		}
+		w.prop("END", "VEVENT")
+	}
+
+	for _, series := range data.Series {
+		first, ok := firstRuleDate(series)
+		if !ok {
+			continue
+		}
+		occurrence := seriesOccurrence(series, first)
+
+		w.prop("BEGIN", "VEVENT")
+		w.prop("UID", "series-"+series.ID+"@"+icalUIDDomain)
+		w.prop("DTSTAMP", stamp)
+		w.local("DTSTART", loc, atClock(first, clockOffset(occurrence.Time)))
+		w.local("DTEND", loc, atClock(first, scheduleEnd(occurrence)))
+		w.prop("RRULE", icalRRule(series, loc))
+
+		if exdates := seriesExcludedDates(series, data.ClosedDates); len(exdates) > 0 {
+			starts := make([]time.Time, 0, len(exdates))
+			for _, date := range exdates {
+				starts = append(starts, atClock(date, clockOffset(series.Time)))
+			}
+			w.local("EXDATE", loc, starts...)
+		}
+
+		writeLessonDetails(&w, series.LessonID, series.RoomID, data)
+		w.prop("END", "VEVENT")
+	}
+
+	for _, homework := range data.Homeworks {
+		summary := "Homework due: " + homework.Title
+		if name := data.LessonNames[homework.LessonID]; name != "" {
+			summary = name + " - " + summary
+		}
+
+		if data.HomeworkStyle == models.HomeworkAsTodo {
+			w.prop("BEGIN", "VTODO")
+			w.prop("UID", "homework-"+homework.ID+"@"+icalUIDDomain)
+			w.prop("DTSTAMP", stamp)
+			w.local("DUE", loc, homework.DueDate.In(loc))
+			w.text("SUMMARY", summary)
+			if homework.Content != "" {
+				w.text("DESCRIPTION", homework.Content)
+			}
+			w.prop("STATUS", "NEEDS-ACTION")
+			w.prop("END", "VTODO")
+			continue
+		}
+
+		w.prop("BEGIN", "VEVENT")
+		w.prop("UID", "homework-"+homework.ID+"@"+icalUIDDomain)
+		w.prop("DTSTAMP", stamp)
+		w.local("DTSTART", loc, homework.DueDate.In(loc))
+		w.local("DTEND", loc, homework.DueDate.In(loc))
+		w.text("SUMMARY", summary)
+		if homework.Content != "" {
+			w.text("DESCRIPTION", homework.Content)
+		}
+		w.prop("TRANSP", "TRANSPARENT")
+		w.prop("END", "VEVENT")
+	}
+
+	w.prop("END", "VCALENDAR")
+	return w.String()
+}
+
+func writeLessonDetails(w *icalWriter, lessonID, roomID string, data calendarData) {
+	summary := data.LessonNames[lessonID]
+	if summary == "" {
+		summary = "Lesson"
+	}
+	w.text("SUMMARY", summary)
+
+	if room := data.RoomNames[roomID]; room != "" {
+		w.text("LOCATION", room)
+	}
+}
+
+// icalRRule renders the series rule. With a DTSTART in a time zone, UNTIL
+// has to be a UTC date-time, so it is set to the end of the last day at the
+// school.
+func icalRRule(series models.ScheduleSeries, loc *time.Location) string {
+	until := series.Until
+	series.Until = nil
+	rule := FormatRRule(series)
+	if until != nil {
+		y, m, d := until.Date()
+		rule += ";UNTIL=" + icalUTC(time.Date(y, m, d, 23, 59, 59, 0, loc))
+	}
+	return rule
+}
+
+// seriesExcludedDates returns the exception dates of the series and the
+// closed dates its rule would otherwise generate an occurrence on, in order
+func seriesExcludedDates(series models.ScheduleSeries, closed map[time.Time]bool) []time.Time {
+	dates := make([]time.Time, 0, len(series.ExceptionDates))
+	for _, date := range series.ExceptionDates {
+		dates = append(dates, dateOnly(date))
+	}
+	for date := range closed {
+		if isOccurrenceDate(series, date) {
+			dates = append(dates, date)
+		}
+	}
+
+	slices.SortFunc(dates, func(a, b time.Time) int { return a.Compare(b) })
+	return slices.CompactFunc(dates, isSameDay)
+}
+
+// calendarYears returns the first and last year the document has times in,
+// which the time zone definition has to cover
+func calendarYears(data calendarData, now time.Time) (int, int) {
+	first, last := now.Year(), now.Year()
+	include := func(t time.Time) {
+		first = min(first, t.Year())
+		last = max(last, t.Year())
+	}
+
+	for _, schedule := range data.Schedules {
+		include(schedule.Date)
+	}
+	for _, series := range data.Series {
+		include(series.StartDate)
+		if series.Until != nil || series.Count > 0 {
+			include(lastOccurrenceDate(series))
+		} else {
+			// An open series keeps going, cover the coming year
+			include(now.AddDate(1, 0, 0))
+		}
+	}
+	for _, homework := range data.Homeworks {
+		include(homework.DueDate)
+	}
+	return first, last
+}
+
+// writeTimezone writes the VTIMEZONE of the location with the offset at the
+// start of the first year and every change of offset up to the end of the
+// last year
+func writeTimezone(w *icalWriter, loc *time.Location, firstYear, lastYear int) {
+	start := time.Date(firstYear, time.January, 1, 0, 0, 0, 0, loc)
+	end := time.Date(lastYear+1, time.January, 1, 0, 0, 0, 0, loc)
+
+	w.prop("BEGIN", "VTIMEZONE")
+	w.prop("TZID", loc.String())
+
+	_, offset := start.Zone()
+	writeTimezonePeriod(w, time.Date(1970, time.January, 1, 0, 0, 0, 0, time.UTC), start, offset, offset)
+
+	for day := start; day.Before(end); day = day.Add(24 * time.Hour) {
+		next := day.Add(24 * time.Hour)
+		_, before := day.Zone()
+		_, after := next.Zone()
+		if before == after {
+			continue
+		}
+
+		// Offsets change on whole seconds, find the first one of the new offset
+		lo, hi := day.Unix(), next.Unix()
+		for hi-lo > 1 {
+			mid := lo + (hi-lo)/2
+			if _, o := time.Unix(mid, 0).In(loc).Zone(); o == before {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		change := time.Unix(hi, 0).In(loc)
+		// DTSTART is the onset in the wall clock of the offset before it
+		onset := change.UTC().Add(time.Duration(before) * time.Second)
+		writeTimezonePeriod(w, onset, change, before, after)
+	}
+
+	w.prop("END", "VTIMEZONE")
+}
+
+func writeTimezonePeriod(w *icalWriter, onset, at time.Time, from, to int) {
+	kind := "STANDARD"
+	if at.IsDST() {
+		kind = "DAYLIGHT"
+	}
+	name, _ := at.Zone()
+
+	w.prop("BEGIN", kind)
+	w.prop("DTSTART", onset.Format("20060102T150405"))
+	w.prop("TZOFFSETFROM", icalOffset(from))
+	w.prop("TZOFFSETTO", icalOffset(to))
+	w.text("TZNAME", name)
+	w.prop("END", kind)
+}
+
+// icalOffset formats a UTC offset in seconds as +HHMM
+func icalOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// firstRuleDate returns the first date the rule generates, ignoring exception
+// dates, which is the DTSTART of the recurring event
+func firstRuleDate(series models.ScheduleSeries) (time.Time, bool) {
+	start := dateOnly(series.StartDate)
+	for i := 0; i < 7; i++ {
+		date := start.AddDate(0, 0, i)
+		if series.Until != nil && date.After(dateOnly(*series.Until)) {
+			return time.Time{}, false
+		}
+		if slices.Contains(series.Weekdays, date.Weekday()) {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
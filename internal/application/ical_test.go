@@ -0,0 +1,79 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderCalendarWritesEventsSeriesAndHomework(t *testing.T) {
+	monday := time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	data := calendarData{
+		Name: "Class timetable",
+		Schedules: []models.Schedule{{
+			ID:       "s1",
+			LessonID: "math",
+			RoomID:   "r1",
+			Date:     monday,
+			Time:     clock(9, 0),
+			EndTime:  clock(9, 45),
+		}},
+		Series: []models.ScheduleSeries{{
+			ID:             "series1",
+			LessonID:       "math",
+			StartDate:      monday.AddDate(0, 0, -1),
+			Time:           clock(10, 0),
+			EndTime:        clock(10, 40),
+			Weekdays:       []time.Weekday{time.Monday, time.Wednesday},
+			Until:          &until,
+			ExceptionDates: []time.Time{monday.AddDate(0, 0, 7)},
+		}},
+		Homeworks: []models.Homework{{
+			ID:       "h1",
+			LessonID: "math",
+			Title:    "Fractions; part 1, exercises",
+			Content:  strings.Repeat("Solve every exercise on page 12. ", 4),
+			DueDate:  time.Date(2025, 9, 5, 8, 0, 0, 0, berlin),
+		}},
+		LessonNames:   map[string]string{"math": "Mathematics"},
+		RoomNames:     map[string]string{"r1": "Room 101"},
+		HomeworkStyle: models.HomeworkAsTodo,
+		// A closure on Wednesday the 17th and on a Tuesday the rule skips
+		ClosedDates: map[time.Time]bool{monday.AddDate(0, 0, 16): true, monday.AddDate(0, 0, 15): true},
+		Location:    berlin,
+	}
+
+	ics := renderCalendar(data, time.Date(2025, 8, 1, 12, 0, 0, 0, time.UTC))
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > icalLineLimit {
+			t.Errorf("line longer than %d octets: %q", icalLineLimit, line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Class timetable\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20250330T020000\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST\r\nEND:DAYLIGHT\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20251026T030000\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\nTZNAME:CET\r\nEND:STANDARD\r\n",
+		"UID:s1@education-dashboard\r\nDTSTAMP:20250801T120000Z\r\nDTSTART;TZID=Europe/Berlin:20250901T090000\r\nDTEND;TZID=Europe/Berlin:20250901T094500\r\nSUMMARY:Mathematics\r\nLOCATION:Room 101\r\n",
+		"DTSTART;TZID=Europe/Berlin:20250901T100000\r\nDTEND;TZID=Europe/Berlin:20250901T104000\r\nRRULE:FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20250930T215959Z\r\nEXDATE;TZID=Europe/Berlin:20250908T100000,20250917T100000\r\n",
+		"BEGIN:VTODO\r\n",
+		"DUE;TZID=Europe/Berlin:20250905T080000\r\n",
+		`SUMMARY:Mathematics - Homework due: Fractions\; part 1\, exercises` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("calendar is missing %q:\n%s", want, ics)
+		}
+	}
+}
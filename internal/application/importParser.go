@@ -0,0 +1,479 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// importEntry is a schedule read from an import file before lesson, teacher,
+// class and room names are resolved. A recurring entry carries its RRULE.
+type importEntry struct {
+	line      int
+	uid       string
+	date      time.Time
+	start     time.Time
+	end       time.Time
+	rrule     string
+	exdates   []time.Time
+	lesson    string
+	teacher   string
+	class     string
+	room      string
+	cancelled bool
+	// recurrenceID is set on an ICS event that replaces one occurrence of
+	// the recurring event with the same UID
+	recurrenceID *time.Time
+	err          error
+}
+
+// icsLine is an unfolded content line with the file line it starts on
+type icsLine struct {
+	number int
+	name   string
+	params map[string]string
+	value  string
+}
+
+// parseICS reads the VEVENTs of an iCalendar file. Lessons come from
+// SUMMARY, rooms from LOCATION, teachers from the ORGANIZER common name or
+// e-mail and classes from the first CATEGORIES value.
+func parseICS(data []byte) ([]importEntry, error) {
+	lines, err := unfoldICS(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 0 || lines[0].name != "BEGIN" || !strings.EqualFold(lines[0].value, "VCALENDAR") {
+		return nil, fmt.Errorf("file is not an iCalendar file")
+	}
+
+	var entries []importEntry
+	var entry *importEntry
+	var start icsLine
+	var hasStart bool
+	var duration time.Duration
+	var end icsLine
+	var hasEnd bool
+	nested := 0
+
+	for _, line := range lines {
+		switch {
+		case line.name == "BEGIN" && strings.EqualFold(line.value, "VEVENT"):
+			entry = &importEntry{line: line.number}
+			hasStart, hasEnd, duration = false, false, 0
+			continue
+		case entry == nil:
+			continue
+		case line.name == "BEGIN":
+			// Alarms and other components inside the event are ignored
+			nested++
+			continue
+		case line.name == "END" && nested > 0:
+			nested--
+			continue
+		case nested > 0:
+			continue
+		}
+
+		switch line.name {
+		case "END":
+			if !strings.EqualFold(line.value, "VEVENT") {
+				continue
+			}
+			if entry.err == nil {
+				entry.err = entry.setICSTimes(start, hasStart, end, hasEnd, duration)
+			}
+			entries = append(entries, *entry)
+			entry = nil
+		case "UID":
+			entry.uid = line.value
+		case "SUMMARY":
+			entry.lesson = unescapeICSText(line.value)
+		case "LOCATION":
+			entry.room = unescapeICSText(line.value)
+		case "ORGANIZER":
+			if name := line.params["CN"]; name != "" {
+				entry.teacher = name
+			} else {
+				entry.teacher = strings.TrimPrefix(strings.ToLower(line.value), "mailto:")
+			}
+		case "CATEGORIES":
+			category, _, _ := strings.Cut(line.value, ",")
+			entry.class = unescapeICSText(category)
+		case "DTSTART":
+			start, hasStart = line, true
+		case "DTEND":
+			end, hasEnd = line, true
+		case "DURATION":
+			d, err := parseICSDuration(line.value)
+			if err != nil && entry.err == nil {
+				entry.err = err
+			}
+			duration = d
+		case "RRULE":
+			entry.rrule = line.value
+		case "EXDATE":
+			for _, value := range strings.Split(line.value, ",") {
+				date, err := parseICSTime(icsLine{name: line.name, params: line.params, value: value})
+				if err != nil {
+					// EXDATE may be a plain date even for timed events
+					date, err = time.Parse("20060102", value)
+				}
+				if err != nil {
+					if entry.err == nil {
+						entry.err = fmt.Errorf("invalid EXDATE %q", value)
+					}
+					continue
+				}
+				entry.exdates = append(entry.exdates, dateOnly(date))
+			}
+		case "RECURRENCE-ID":
+			date, err := parseICSTime(line)
+			if err != nil {
+				date, err = time.Parse("20060102", line.value)
+			}
+			if err != nil {
+				if entry.err == nil {
+					entry.err = fmt.Errorf("invalid RECURRENCE-ID %q", line.value)
+				}
+				continue
+			}
+			date = dateOnly(date)
+			entry.recurrenceID = &date
+		case "STATUS":
+			entry.cancelled = strings.EqualFold(line.value, "CANCELLED")
+		}
+	}
+
+	if entry != nil {
+		return nil, fmt.Errorf("line %d: event is not closed", entry.line)
+	}
+
+	return applyICSOverrides(entries), nil
+}
+
+func (e *importEntry) setICSTimes(start icsLine, hasStart bool, end icsLine, hasEnd bool, duration time.Duration) error {
+	if !hasStart {
+		return fmt.Errorf("event has no DTSTART")
+	}
+
+	startAt, err := parseICSTime(start)
+	if err != nil {
+		return err
+	}
+	e.date = dateOnly(startAt)
+	e.start = wallClock(startAt)
+
+	switch {
+	case hasEnd:
+		endAt, err := parseICSTime(end)
+		if err != nil {
+			return err
+		}
+		if !isSameDay(startAt, endAt) {
+			return fmt.Errorf("event must start and end on the same day")
+		}
+		e.end = wallClock(endAt)
+	case duration > 0:
+		endAt := startAt.Add(duration)
+		if !isSameDay(startAt, endAt) {
+			return fmt.Errorf("event must start and end on the same day")
+		}
+		e.end = wallClock(endAt)
+	}
+	return nil
+}
+
+// applyICSOverrides turns events with a RECURRENCE-ID into exception dates
+// of their recurring event. The override itself is imported on its own
+// unless it was cancelled; other cancelled events are dropped.
+func applyICSOverrides(entries []importEntry) []importEntry {
+	masters := make(map[string]int)
+	for i, entry := range entries {
+		if entry.recurrenceID == nil && entry.rrule != "" && entry.uid != "" {
+			masters[entry.uid] = i
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.recurrenceID != nil {
+			if i, ok := masters[entry.uid]; ok {
+				entries[i].exdates = append(entries[i].exdates, *entry.recurrenceID)
+			}
+		}
+	}
+
+	result := make([]importEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.cancelled {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// unfoldICS joins folded lines and splits each content line into name,
+// parameters and value
+func unfoldICS(data []byte) ([]icsLine, error) {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var lines []icsLine
+	var current strings.Builder
+	currentLine := 0
+
+	flush := func() error {
+		if current.Len() == 0 {
+			return nil
+		}
+		line, err := splitICSLine(current.String())
+		if err != nil {
+			return fmt.Errorf("line %d: %w", currentLine, err)
+		}
+		line.number = currentLine
+		lines = append(lines, line)
+		current.Reset()
+		return nil
+	}
+
+	for i, raw := range strings.Split(text, "\n") {
+		if strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t") {
+			current.WriteString(raw[1:])
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		current.WriteString(strings.TrimRight(raw, "\r"))
+		currentLine = i + 1
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+func splitICSLine(raw string) (icsLine, error) {
+	// The value starts at the first colon outside a quoted parameter
+	quoted := false
+	colon := -1
+	for i, r := range raw {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return icsLine{}, fmt.Errorf("invalid content line %q", raw)
+	}
+
+	parts := strings.Split(raw[:colon], ";")
+	line := icsLine{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string),
+		value:  raw[colon+1:],
+	}
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		line.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return line, nil
+}
+
+// parseICSTime reads a DATE-TIME value. UTC and TZID times are converted to
+// the server's local time; floating times are taken as they are.
+func parseICSTime(line icsLine) (time.Time, error) {
+	value := line.value
+	if strings.EqualFold(line.params["VALUE"], "DATE") || len(value) == len("20060102") {
+		return time.Time{}, fmt.Errorf("all-day events cannot be imported")
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s value %q", line.name, value)
+		}
+		return t.In(time.Local), nil
+	}
+
+	if tzid := line.params["TZID"]; tzid != "" {
+		location, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("unknown time zone %q", tzid)
+		}
+		t, err := time.ParseInLocation("20060102T150405", value, location)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid %s value %q", line.name, value)
+		}
+		return t.In(time.Local), nil
+	}
+
+	t, err := time.Parse("20060102T150405", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s value %q", line.name, value)
+	}
+	return t, nil
+}
+
+// parseICSDuration reads a DURATION such as PT45M or PT1H30M
+func parseICSDuration(value string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(strings.ToUpper(value), "PT")
+	if !ok {
+		return 0, fmt.Errorf("unsupported DURATION %q, lessons must be shorter than a day", value)
+	}
+
+	d, err := time.ParseDuration(strings.ToLower(rest))
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid DURATION %q", value)
+	}
+	return d, nil
+}
+
+func unescapeICSText(value string) string {
+	replacer := strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+	return strings.TrimSpace(replacer.Replace(value))
+}
+
+// CSV import columns and the header names accepted for them
+var csvColumns = map[string][]string{
+	"date":    {"date", "day"},
+	"start":   {"start", "start_time", "time", "from"},
+	"end":     {"end", "end_time", "to"},
+	"lesson":  {"lesson", "lesson_name", "subject"},
+	"teacher": {"teacher", "teacher_name"},
+	"class":   {"class", "class_name"},
+	"room":    {"room", "room_name", "location"},
+	"rrule":   {"rrule", "recurrence"},
+	"exdates": {"exdates", "exception_dates"},
+	"uid":     {"uid", "id"},
+}
+
+// parseCSV reads a spreadsheet export with a header row. The date, start and
+// lesson columns are required; dates are YYYY-MM-DD or DD.MM.YYYY and times
+// HH:MM. An optional rrule column makes a row recurring.
+func parseCSV(data []byte) ([]importEntry, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for column, aliases := range csvColumns {
+			for _, alias := range aliases {
+				if name == alias {
+					columns[column] = i
+				}
+			}
+		}
+	}
+
+	for _, required := range []string{"date", "start", "lesson"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("CSV header has no %s column", required)
+		}
+	}
+
+	var entries []importEntry
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		field := func(column string) string {
+			i, ok := columns[column]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+
+		entry := importEntry{
+			line:    line,
+			uid:     field("uid"),
+			lesson:  field("lesson"),
+			teacher: field("teacher"),
+			class:   field("class"),
+			room:    field("room"),
+			rrule:   field("rrule"),
+		}
+		entry.err = entry.setCSVFields(field("date"), field("start"), field("end"), field("exdates"))
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (e *importEntry) setCSVFields(date, start, end, exdates string) error {
+	var err error
+	if e.date, err = parseImportDate(date); err != nil {
+		return err
+	}
+
+	if e.start, err = parseImportClock(start); err != nil {
+		return err
+	}
+
+	if end != "" {
+		if e.end, err = parseImportClock(end); err != nil {
+			return err
+		}
+	}
+
+	for _, value := range strings.FieldsFunc(exdates, func(r rune) bool { return r == ',' || r == ';' || r == ' ' }) {
+		exdate, err := parseImportDate(value)
+		if err != nil {
+			return err
+		}
+		e.exdates = append(e.exdates, exdate)
+	}
+	return nil
+}
+
+func parseImportDate(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", "02.01.2006", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, use YYYY-MM-DD", value)
+}
+
+func parseImportClock(value string) (time.Time, error) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return wallClock(t), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, use HH:MM", value)
+}
+
+// wallClock keeps only the clock of t, anchored like TIME columns
+func wallClock(t time.Time) time.Time {
+	h, m, s := t.Clock()
+	return time.Date(0, 1, 1, h, m, s, 0, time.UTC)
+}
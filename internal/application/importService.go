@@ -0,0 +1,346 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxImportWeeks bounds how far ahead a recurring import row is expanded
+const maxImportWeeks = 53
+
+type ImportService struct {
+	scheduleService models.ScheduleService
+	scheduleRepo    models.ScheduleRepository
+	lessonRepo      models.LessonRepository
+	roomRepo        models.RoomRepository
+	userService     models.KeycloakService
+	classService    models.ClassService
+}
+
+func NewImportService(scheduleService models.ScheduleService, scheduleRepo models.ScheduleRepository, lessonRepo models.LessonRepository, roomRepo models.RoomRepository, userService models.KeycloakService, classService models.ClassService) models.ImportService {
+	return &ImportService{
+		scheduleService: scheduleService,
+		scheduleRepo:    scheduleRepo,
+		lessonRepo:      lessonRepo,
+		roomRepo:        roomRepo,
+		userService:     userService,
+		classService:    classService,
+	}
+}
+
+// ImportSchedules parses an ICS or CSV file into schedules, maps lesson,
+// teacher, class and room names to IDs and runs the schedule validations on
+// every row, including conflicts with stored schedules and with the earlier
+// rows of the file. Recurring rows are expanded from today on. Unless it is
+// a dry run the accepted rows are stored in one transaction; rejected and
+// conflicting rows are skipped.
+func (is *ImportService) ImportSchedules(data []byte, options models.ImportOptions) (*models.ImportReport, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("import file is empty")
+	}
+
+	format := strings.ToLower(options.Format)
+	if format == "" {
+		format = models.ImportFormatCSV
+		if bytes.Contains(data[:min(len(data), 64)], []byte("BEGIN:VCALENDAR")) {
+			format = models.ImportFormatICS
+		}
+	}
+
+	var entries []importEntry
+	var err error
+	switch format {
+	case models.ImportFormatICS:
+		entries, err = parseICS(data)
+	case models.ImportFormatCSV:
+		entries, err = parseCSV(data)
+	default:
+		return nil, fmt.Errorf("unsupported import format %q", options.Format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	directory, err := is.loadImportDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ImportReport{DryRun: options.DryRun}
+	var accepted []models.Schedule
+	today := helper.SchoolToday()
+
+	for _, entry := range entries {
+		rows := directory.rows(entry, options, today)
+
+		for _, row := range rows {
+			if row.Status == "" {
+				if err := is.checkImportRow(&row, accepted, today); err != nil {
+					return nil, err
+				}
+			}
+
+			switch row.Status {
+			case models.ImportRowAccepted:
+				accepted = append(accepted, row.Schedule)
+				report.Accepted++
+			case models.ImportRowConflicting:
+				report.Conflicting++
+			default:
+				report.Rejected++
+			}
+			report.Rows = append(report.Rows, row)
+		}
+	}
+
+	if options.DryRun || len(accepted) == 0 {
+		return report, nil
+	}
+
+	if err := is.scheduleRepo.CreateSchedules(accepted); err != nil {
+		return nil, fmt.Errorf("failed to store imported schedules: %w", err)
+	}
+	report.Committed = len(accepted)
+
+	return report, nil
+}
+
+// checkImportRow applies the CreateSchedule validations to a resolved row
+func (is *ImportService) checkImportRow(row *models.ImportRow, accepted []models.Schedule, today time.Time) error {
+	schedule := &row.Schedule
+
+	if err := is.scheduleService.PlaceInPeriod(schedule); err != nil {
+		rejectRow(row, err.Error())
+		return nil
+	}
+
+	if err := normalizeScheduleTimes(schedule, defaultLessonDuration); err != nil {
+		rejectRow(row, err.Error())
+		return nil
+	}
+
+	if dateOnly(schedule.Date).Before(today) {
+		rejectRow(row, "cannot create schedule for past dates")
+		return nil
+	}
+
+	if err := is.scheduleService.CheckScheduleDate(schedule.Date); err != nil {
+		rejectRow(row, err.Error())
+		return nil
+	}
+
+	if err := is.scheduleService.CheckTeacherAvailability(schedule.TeacherID, *schedule); err != nil {
+		rejectRow(row, err.Error())
+		return nil
+	}
+
+	conflicts, err := is.scheduleService.GetScheduleConflicts(schedule.TeacherID, schedule.ClassID, schedule.RoomID, schedule.Date, schedule.Time, schedule.EndTime)
+	if err != nil {
+		return fmt.Errorf("line %d: failed to check conflicts: %w", row.Line, err)
+	}
+
+	// Earlier rows of the file are not stored yet, so check them here
+	for _, other := range accepted {
+		reason := batchConflict(other, *schedule)
+		if reason == "" {
+			continue
+		}
+		start, end, _ := overlapWindow(other, *schedule)
+		conflicts = append(conflicts, models.ScheduleConflict{
+			Schedule:     other,
+			Reasons:      []string{reason},
+			OverlapStart: atClock(schedule.Date, start),
+			OverlapEnd:   atClock(schedule.Date, end),
+		})
+	}
+
+	if len(conflicts) > 0 {
+		row.Status = models.ImportRowConflicting
+		row.Conflicts = conflicts
+		row.Errors = append(row.Errors, conflictError(conflicts, *schedule).Error())
+		return nil
+	}
+
+	row.Status = models.ImportRowAccepted
+	return nil
+}
+
+// importDirectory maps the names used in import files to IDs
+type importDirectory struct {
+	lessons  nameIndex
+	teachers nameIndex
+	classes  nameIndex
+	rooms    nameIndex
+}
+
+func (is *ImportService) loadImportDirectory() (*importDirectory, error) {
+	directory := &importDirectory{
+		lessons:  nameIndex{},
+		teachers: nameIndex{},
+		classes:  nameIndex{},
+		rooms:    nameIndex{},
+	}
+
+	lessons, err := is.lessonRepo.GetAllLessons()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lessons: %w", err)
+	}
+	for _, lesson := range lessons {
+		directory.lessons.add(lesson.ID, lesson.ID, lesson.LessonName)
+	}
+
+	users, err := is.userService.GetAllUsers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+	for _, user := range users {
+		if user.Role != "teacher" {
+			continue
+		}
+		directory.teachers.add(user.ID, user.ID, user.Username, user.Email, strings.TrimSpace(user.FirstName+" "+user.LastName))
+	}
+
+	classes, err := is.classService.GetAllClasses()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get classes: %w", err)
+	}
+	for _, class := range classes {
+		directory.classes.add(class.ID, class.ID, class.ClassName)
+	}
+
+	rooms, err := is.roomRepo.GetAllRooms()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rooms: %w", err)
+	}
+	for _, room := range rooms {
+		directory.rooms.add(room.ID, room.ID, room.Name)
+	}
+
+	return directory, nil
+}
+
+// rows resolves the entry into one row, or one row per occurrence for a
+// recurring entry. Rows that cannot be resolved are already rejected.
+func (d *importDirectory) rows(entry importEntry, options models.ImportOptions, today time.Time) []models.ImportRow {
+	row := models.ImportRow{
+		Line: entry.line,
+		UID:  entry.uid,
+		Schedule: models.Schedule{
+			Date:    entry.date,
+			Time:    entry.start,
+			EndTime: entry.end,
+		},
+	}
+
+	if entry.err != nil {
+		rejectRow(&row, entry.err.Error())
+		return []models.ImportRow{row}
+	}
+
+	teacher := entry.teacher
+	if teacher == "" {
+		teacher = options.TeacherID
+	}
+	class := entry.class
+	if class == "" {
+		class = options.ClassID
+	}
+
+	var err error
+	if row.Schedule.LessonID, err = d.lessons.lookup("lesson", entry.lesson); err != nil {
+		rejectRow(&row, err.Error())
+	}
+	if row.Schedule.TeacherID, err = d.teachers.lookup("teacher", teacher); err != nil {
+		rejectRow(&row, err.Error())
+	}
+	if row.Schedule.ClassID, err = d.classes.lookup("class", class); err != nil {
+		rejectRow(&row, err.Error())
+	}
+	if entry.room != "" {
+		if row.Schedule.RoomID, err = d.rooms.lookup("room", entry.room); err != nil {
+			rejectRow(&row, err.Error())
+		}
+	}
+
+	if row.Status != "" || entry.rrule == "" {
+		return []models.ImportRow{row}
+	}
+
+	series := models.ScheduleSeries{
+		TeacherID:      row.Schedule.TeacherID,
+		LessonID:       row.Schedule.LessonID,
+		ClassID:        row.Schedule.ClassID,
+		RoomID:         row.Schedule.RoomID,
+		StartDate:      entry.date,
+		Time:           entry.start,
+		EndTime:        entry.end,
+		Weekdays:       []time.Weekday{entry.date.Weekday()},
+		ExceptionDates: entry.exdates,
+	}
+	if err := ParseRRule(entry.rrule, &series); err != nil {
+		rejectRow(&row, err.Error())
+		return []models.ImportRow{row}
+	}
+
+	from := dateOnly(today)
+	if entry.date.After(from) {
+		from = entry.date
+	}
+	occurrences := expandSeries(series, from, from.AddDate(0, 0, 7*maxImportWeeks))
+	if len(occurrences) == 0 {
+		rejectRow(&row, "recurring event has no occurrences from today on")
+		return []models.ImportRow{row}
+	}
+
+	rows := make([]models.ImportRow, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		occurrence.SeriesID = ""
+		occurrence.OccurrenceDate = nil
+		rows = append(rows, models.ImportRow{
+			Line:     entry.line,
+			UID:      entry.uid,
+			Schedule: occurrence,
+		})
+	}
+	return rows
+}
+
+// nameIndex looks up IDs by ID or by case-insensitive name. A name shared by
+// several records is ambiguous and cannot be used.
+type nameIndex map[string][]string
+
+func (n nameIndex) add(id string, names ...string) {
+	for _, name := range names {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" || slices.Contains(n[key], id) {
+			continue
+		}
+		n[key] = append(n[key], id)
+	}
+}
+
+func (n nameIndex) lookup(kind, name string) (string, error) {
+	if strings.TrimSpace(name) == "" {
+		return "", fmt.Errorf("%s is required", kind)
+	}
+
+	ids := n[strings.ToLower(strings.TrimSpace(name))]
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("%s %q not found", kind, name)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%s %q is ambiguous, use the ID", kind, name)
+	}
+}
+
+func rejectRow(row *models.ImportRow, message string) {
+	row.Status = models.ImportRowRejected
+	row.Errors = append(row.Errors, message)
+}
@@ -0,0 +1,138 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+type importLessonRepo struct{ fakeLessonRepo }
+
+func (importLessonRepo) GetAllLessons() ([]models.Lesson, error) {
+	return []models.Lesson{{ID: "l1", LessonName: "Mathematics"}, {ID: "l2", LessonName: "Art"}}, nil
+}
+
+type importUserService struct{ models.KeycloakService }
+
+func (importUserService) GetAllUsers() ([]models.User, error) {
+	return []models.User{
+		{ID: "t1", Username: "jdoe", FirstName: "Jane", LastName: "Doe", Role: "teacher"},
+		{ID: "s1", Username: "student", FirstName: "Jane", LastName: "Doe", Role: "student"},
+	}, nil
+}
+
+type importClassService struct{ models.ClassService }
+
+func (importClassService) GetAllClasses() ([]models.Class, error) {
+	return []models.Class{{ID: "c1", ClassName: "5A"}}, nil
+}
+
+func newTestImportService(repo *fakeScheduleRepo) models.ImportService {
+	scheduleService := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+	return NewImportService(scheduleService, repo, importLessonRepo{}, fakeRoomRepo{}, importUserService{}, importClassService{})
+}
+
+// nextWeekday returns the first date on or after date falling on weekday
+func nextWeekday(date time.Time, weekday time.Weekday) time.Time {
+	return date.AddDate(0, 0, (int(weekday)-int(date.Weekday())+7)%7)
+}
+
+func TestImportSchedulesICSDryRunReportsRows(t *testing.T) {
+	monday := nextWeekday(futureDate(), time.Monday)
+	day := func(offset int) string { return monday.AddDate(0, 0, offset).Format("20060102") }
+
+	ics := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Mathematics",
+		"ORGANIZER;CN=Jane Doe:mailto:jane@example.com",
+		"CATEGORIES:5A",
+		"DTSTART:" + day(0) + "T090000",
+		"DTEND:" + day(0) + "T094500",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4",
+		"EXDATE:" + day(2) + "T090000",
+		"BEGIN:VALARM",
+		"TRIGGER:-PT10M",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:clash",
+		"SUMMARY:Art",
+		"ORGANIZER;CN=Jane Doe:mailto:jane@example.com",
+		"CATEGORIES:5A",
+		"DTSTART:" + day(7) + "T093000",
+		"DURATION:PT40M",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:unknown",
+		"SUMMARY:Chemistry",
+		"DTSTART:" + day(1) + "T080000",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	repo := newFakeScheduleRepo()
+	service := newTestImportService(repo)
+
+	report, err := service.ImportSchedules([]byte(ics), models.ImportOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// COUNT=4 gives Mon, Wed, Mon, Wed; the first Wednesday is excluded
+	if report.Accepted != 3 || report.Conflicting != 1 || report.Rejected != 1 {
+		t.Fatalf("expected 3 accepted, 1 conflicting and 1 rejected rows, got %+v", report)
+	}
+
+	for _, row := range report.Rows {
+		switch row.UID {
+		case "clash":
+			if row.Status != models.ImportRowConflicting || len(row.Conflicts) != 1 {
+				t.Errorf("expected the overlapping event to conflict with the series, got %+v", row)
+			}
+		case "unknown":
+			if row.Status != models.ImportRowRejected || !strings.Contains(strings.Join(row.Errors, ";"), `lesson "Chemistry" not found`) {
+				t.Errorf("expected the unknown lesson to be rejected, got %+v", row)
+			}
+		case "weekly":
+			if row.Schedule.TeacherID != "t1" || row.Schedule.ClassID != "c1" || row.Schedule.LessonID != "l1" {
+				t.Errorf("expected names to be mapped to IDs, got %+v", row.Schedule)
+			}
+		}
+	}
+
+	if len(repo.schedules) != 0 {
+		t.Fatalf("dry run must not store schedules, got %d", len(repo.schedules))
+	}
+}
+
+func TestImportSchedulesCSVCommitsAcceptedRows(t *testing.T) {
+	date := futureDate().Format("2006-01-02")
+	csv := "Date,Start,End,Lesson,Teacher,Class\n" +
+		date + ",09:00,09:45,Mathematics,jdoe,5A\n" +
+		date + ",09:30,10:15,Art,jdoe,5A\n" +
+		date + ",25:00,26:00,Art,jdoe,5A\n"
+
+	repo := newFakeScheduleRepo()
+	service := newTestImportService(repo)
+
+	report, err := service.ImportSchedules([]byte(csv), models.ImportOptions{Format: models.ImportFormatCSV})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Accepted != 1 || report.Conflicting != 1 || report.Rejected != 1 {
+		t.Fatalf("expected 1 accepted, 1 conflicting and 1 rejected row, got %+v", report)
+	}
+
+	if report.Rows[2].Line != 4 {
+		t.Errorf("expected the invalid row to be reported on line 4, got %d", report.Rows[2].Line)
+	}
+
+	if report.Committed != 1 || len(repo.schedules) != 1 {
+		t.Fatalf("expected only the accepted row to be stored, got %d", len(repo.schedules))
+	}
+}
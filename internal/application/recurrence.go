@@ -0,0 +1,244 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSeriesOccurrences bounds COUNT so a single series cannot flood the timetable
+const maxSeriesOccurrences = 400
+
+// RFC 5545 BYDAY codes
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// ParseRRule reads a weekly RFC 5545 recurrence rule such as
+// "FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20250613" into the series.
+func ParseRRule(rule string, series *models.ScheduleSeries) error {
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	if rule == "" {
+		return fmt.Errorf("recurrence rule is empty")
+	}
+
+	var weekdays []time.Weekday
+	var until *time.Time
+	count := 0
+	frequency := ""
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return fmt.Errorf("invalid recurrence rule part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			frequency = strings.ToUpper(value)
+		case "BYDAY":
+			for _, code := range strings.Split(strings.ToUpper(value), ",") {
+				weekday, ok := weekdayCodes[code]
+				if !ok {
+					return fmt.Errorf("unsupported BYDAY value %q", code)
+				}
+				weekdays = append(weekdays, weekday)
+			}
+		case "UNTIL":
+			date, err := parseRRuleDate(value)
+			if err != nil {
+				return err
+			}
+			until = &date
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid COUNT value %q", value)
+			}
+			count = n
+		case "INTERVAL":
+			if value != "1" {
+				return fmt.Errorf("only weekly series with INTERVAL=1 are supported")
+			}
+		case "WKST":
+			// Week start does not change weekly expansion with INTERVAL=1
+		default:
+			return fmt.Errorf("unsupported recurrence rule part %q", key)
+		}
+	}
+
+	if frequency != "WEEKLY" {
+		return fmt.Errorf("only FREQ=WEEKLY series are supported")
+	}
+
+	if until != nil && count > 0 {
+		return fmt.Errorf("recurrence rule cannot have both UNTIL and COUNT")
+	}
+
+	if len(weekdays) > 0 {
+		series.Weekdays = weekdays
+	}
+	series.Until = until
+	series.Count = count
+	return nil
+}
+
+// FormatRRule renders the series recurrence as an RFC 5545 RRULE value
+func FormatRRule(series models.ScheduleSeries) string {
+	codes := make([]string, 0, len(series.Weekdays))
+	for _, weekday := range sortedWeekdays(series.Weekdays) {
+		for code, day := range weekdayCodes {
+			if day == weekday {
+				codes = append(codes, code)
+				break
+			}
+		}
+	}
+
+	rule := "FREQ=WEEKLY;BYDAY=" + strings.Join(codes, ",")
+	if series.Until != nil {
+		rule += ";UNTIL=" + series.Until.Format("20060102")
+	}
+	if series.Count > 0 {
+		rule += ";COUNT=" + strconv.Itoa(series.Count)
+	}
+	return rule
+}
+
+func parseRRuleDate(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return dateOnly(t), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid UNTIL value %q", value)
+}
+
+// expandSeries returns the occurrences of the series dated within [from, to).
+// COUNT is applied before exception dates are removed, as in RFC 5545.
+func expandSeries(series models.ScheduleSeries, from, to time.Time) []models.Schedule {
+	var occurrences []models.Schedule
+
+	from = dateOnly(from)
+	to = dateOnly(to)
+	generated := 0
+
+	for date := dateOnly(series.StartDate); date.Before(to); date = date.AddDate(0, 0, 1) {
+		if series.Until != nil && date.After(dateOnly(*series.Until)) {
+			break
+		}
+		if series.Count > 0 && generated >= series.Count {
+			break
+		}
+		if !slices.Contains(series.Weekdays, date.Weekday()) {
+			continue
+		}
+
+		generated++
+		if date.Before(from) || isExceptionDate(series, date) {
+			continue
+		}
+		occurrences = append(occurrences, seriesOccurrence(series, date))
+	}
+
+	return occurrences
+}
+
+// lastOccurrenceDate returns the date the series ends on
+func lastOccurrenceDate(series models.ScheduleSeries) time.Time {
+	if series.Until != nil {
+		return dateOnly(*series.Until)
+	}
+
+	last := dateOnly(series.StartDate)
+	generated := 0
+	for date := last; generated < series.Count; date = date.AddDate(0, 0, 1) {
+		if slices.Contains(series.Weekdays, date.Weekday()) {
+			generated++
+			last = date
+		}
+	}
+	return last
+}
+
+// occurrencesBefore counts the occurrences the rule generates before date
+func occurrencesBefore(series models.ScheduleSeries, date time.Time) int {
+	generated := 0
+	for d := dateOnly(series.StartDate); d.Before(dateOnly(date)); d = d.AddDate(0, 0, 1) {
+		if slices.Contains(series.Weekdays, d.Weekday()) {
+			generated++
+		}
+	}
+	return generated
+}
+
+// isOccurrenceDate reports whether the rule generates an occurrence on date
+func isOccurrenceDate(series models.ScheduleSeries, date time.Time) bool {
+	date = dateOnly(date)
+	if date.Before(dateOnly(series.StartDate)) || !slices.Contains(series.Weekdays, date.Weekday()) {
+		return false
+	}
+	if series.Until != nil && date.After(dateOnly(*series.Until)) {
+		return false
+	}
+	if series.Count > 0 && occurrencesBefore(series, date) >= series.Count {
+		return false
+	}
+	return !isExceptionDate(series, date)
+}
+
+func isExceptionDate(series models.ScheduleSeries, date time.Time) bool {
+	for _, exception := range series.ExceptionDates {
+		if isSameDay(exception, date) {
+			return true
+		}
+	}
+	return false
+}
+
+func seriesOccurrence(series models.ScheduleSeries, date time.Time) models.Schedule {
+	occurrenceDate := date
+	return models.Schedule{
+		Date:           date,
+		TeacherID:      series.TeacherID,
+		LessonID:       series.LessonID,
+		ClassID:        series.ClassID,
+		Time:           series.Time,
+		EndTime:        series.EndTime,
+		RoomID:         series.RoomID,
+		SeriesID:       series.ID,
+		OccurrenceDate: &occurrenceDate,
+		Status:         models.ScheduleScheduled,
+	}
+}
+
+func sortedWeekdays(weekdays []time.Weekday) []time.Weekday {
+	sorted := slices.Clone(weekdays)
+	slices.Sort(sorted)
+	return slices.Compact(sorted)
+}
+
+// sortSchedules orders schedules by date and start time
+func sortSchedules(schedules []models.Schedule) {
+	sort.SliceStable(schedules, func(i, j int) bool {
+		if !isSameDay(schedules[i].Date, schedules[j].Date) {
+			return schedules[i].Date.Before(schedules[j].Date)
+		}
+		return clockOffset(schedules[i].Time) < clockOffset(schedules[j].Time)
+	})
+}
+
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
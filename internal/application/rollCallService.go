@@ -0,0 +1,172 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	// rollCallGraceMinutes gives teachers time to take the roll call after
+	// the lesson before students are marked absent
+	rollCallGraceMinutes = 15
+
+	// rollCallLookbackDays limits the check to recent lessons, so older
+	// lessons without attendance are not marked absent when the job starts
+	rollCallLookbackDays = 7
+
+	autoAbsentNote = "marked absent automatically, no attendance was taken"
+)
+
+type RollCallService struct {
+	rollCallRepo    models.RollCallRepository
+	excuseRepo      models.ExcuseRepository
+	scheduleService models.ScheduleService
+	classService    models.ClassService
+	alertService    models.AttendanceAlertService
+}
+
+func NewRollCallService(rollCallRepo models.RollCallRepository, excuseRepo models.ExcuseRepository, scheduleService models.ScheduleService, classService models.ClassService, alertService models.AttendanceAlertService) models.RollCallService {
+	return &RollCallService{
+		rollCallRepo:    rollCallRepo,
+		excuseRepo:      excuseRepo,
+		scheduleService: scheduleService,
+		classService:    classService,
+		alertService:    alertService,
+	}
+}
+
+// CheckEndedLessons checks every lesson of the last week that ended at least
+// the grace period ago, once. Ended occurrences of recurring series are
+// stored as schedules first, so their absences have a lesson to refer to. A
+// lesson that fails is logged and checked again on the next run, without
+// holding up the others.
+func (rs *RollCallService) CheckEndedLessons() ([]models.RollCallCheck, error) {
+	now := helper.SchoolNow()
+	today := helper.SchoolToday()
+	from := today.AddDate(0, 0, -rollCallLookbackDays)
+
+	ended := func(schedule models.Schedule) bool {
+		end := helper.SchoolDateTime(schedule.Date, schedule.EndTime)
+		return !now.Before(end.Add(rollCallGraceMinutes * time.Minute))
+	}
+
+	occurrences, err := rs.scheduleService.GetSeriesOccurrencesBetween(from, today.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series occurrences: %w", err)
+	}
+	for _, occurrence := range occurrences {
+		if !ended(occurrence) {
+			continue
+		}
+		if _, err := rs.scheduleService.MaterializeOccurrence(occurrence.SeriesID, *occurrence.OccurrenceDate); err != nil {
+			log.Printf("roll call check of series %s failed to store the occurrence on %s: %v", occurrence.SeriesID, occurrence.Date.Format("2006-01-02"), err)
+		}
+	}
+
+	schedules, err := rs.rollCallRepo.GetUncheckedSchedules(from, today)
+	if err != nil {
+		return nil, err
+	}
+
+	reminders := []models.RollCallCheck{}
+	var marked []string
+	for _, schedule := range schedules {
+		if !ended(schedule) {
+			continue
+		}
+
+		check, err := rs.checkLesson(schedule, now)
+		if err != nil {
+			log.Printf("roll call check of schedule %s failed: %v", schedule.ID, err)
+			continue
+		}
+
+		if len(check.MissingStudentIDs) > 0 {
+			reminders = append(reminders, *check)
+			marked = append(marked, check.MissingStudentIDs...)
+		}
+	}
+
+	evaluateAttendanceAlerts(rs.alertService, marked...)
+
+	return reminders, nil
+}
+
+// checkLesson records the students without attendance for the lesson as
+// absent, or excused when they have an approved excuse, and stores the check
+func (rs *RollCallService) checkLesson(schedule models.Schedule, now time.Time) (*models.RollCallCheck, error) {
+	students, err := rs.classService.GetStudentsByClassID(schedule.ClassID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class students: %w", err)
+	}
+
+	absences := make([]models.Attendance, 0, len(students))
+	for _, student := range students {
+		absences = append(absences, models.Attendance{
+			StudentID:  student.ID,
+			ScheduleID: schedule.ID,
+			Status:     models.AttendanceAbsent,
+			Note:       autoAbsentNote,
+		})
+	}
+
+	audits, err := applyApprovedExcuses(rs.excuseRepo, schedule, absences)
+	if err != nil {
+		return nil, err
+	}
+
+	// The substitute took the lesson, so the roll call was theirs
+	teacherID := schedule.TeacherID
+	if schedule.SubstituteTeacherID != "" {
+		teacherID = schedule.SubstituteTeacherID
+	}
+
+	check := &models.RollCallCheck{
+		ScheduleID: schedule.ID,
+		TeacherID:  teacherID,
+		CheckedAt:  now,
+	}
+	if err := rs.rollCallRepo.SaveRollCallCheck(check, absences, audits); err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+// GetReminders returns the teacher's open reminders, or every open reminder
+// when teacherID is empty
+func (rs *RollCallService) GetReminders(teacherID string) ([]models.RollCallCheck, error) {
+	reminders, err := rs.rollCallRepo.GetOpenReminders(teacherID)
+	if err != nil {
+		return nil, err
+	}
+	if reminders == nil {
+		reminders = []models.RollCallCheck{}
+	}
+	return reminders, nil
+}
+
+// ResolveReminder dismisses the reminder once the teacher reviewed the
+// roll call; an empty teacherID skips the ownership check
+func (rs *RollCallService) ResolveReminder(scheduleID, teacherID string) error {
+	if scheduleID == "" {
+		return fmt.Errorf("schedule ID is required")
+	}
+
+	check, err := rs.rollCallRepo.GetRollCallCheck(scheduleID)
+	if err != nil {
+		return fmt.Errorf("roll call reminder not found: %w", err)
+	}
+
+	if teacherID != "" && check.TeacherID != teacherID {
+		return fmt.Errorf("only the lesson's teacher can resolve its reminder")
+	}
+
+	if check.ResolvedAt != nil {
+		return fmt.Errorf("roll call reminder is already resolved")
+	}
+
+	return rs.rollCallRepo.ResolveRollCallCheck(scheduleID)
+}
@@ -0,0 +1,222 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type memoryRollCallRepo struct {
+	schedules   *fakeScheduleRepo
+	attendances *memoryAttendanceRepo
+	checks      []models.RollCallCheck
+}
+
+func (r *memoryRollCallRepo) GetUncheckedSchedules(from, to time.Time) ([]models.Schedule, error) {
+	var schedules []models.Schedule
+	for _, schedule := range r.schedules.schedules {
+		checked := slices.ContainsFunc(r.checks, func(check models.RollCallCheck) bool { return check.ScheduleID == schedule.ID })
+		if !checked && !schedule.Date.Before(from) && !schedule.Date.After(to) {
+			schedules = append(schedules, schedule)
+		}
+	}
+	sortSchedules(schedules)
+	return schedules, nil
+}
+
+func newTestRollCallService(repo *memoryRollCallRepo, seriesRepo *fakeSeriesRepo) models.RollCallService {
+	scheduleService := NewScheduleService(repo.schedules, seriesRepo, fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+	return NewRollCallService(repo, &fakeExcuseRepo{}, scheduleService, rosterClassService{}, fakeAlertService{})
+}
+
+func (r *memoryRollCallRepo) SaveRollCallCheck(check *models.RollCallCheck, absences []models.Attendance, audits map[string]models.AttendanceAudit) error {
+	for _, absence := range absences {
+		existing, _ := r.attendances.GetAttendanceByStudentID(absence.StudentID)
+		if slices.ContainsFunc(existing, func(a models.Attendance) bool { return a.ScheduleID == absence.ScheduleID }) {
+			continue
+		}
+		if err := r.attendances.CreateAttendance(&absence, excuseAudit(audits, absence.StudentID)); err != nil {
+			return err
+		}
+		check.MissingStudentIDs = append(check.MissingStudentIDs, absence.StudentID)
+	}
+	r.checks = append(r.checks, *check)
+	return nil
+}
+
+func (r *memoryRollCallRepo) GetRollCallCheck(scheduleID string) (*models.RollCallCheck, error) {
+	for _, check := range r.checks {
+		if check.ScheduleID == scheduleID {
+			return &check, nil
+		}
+	}
+	return nil, fmt.Errorf("roll call check %s not found", scheduleID)
+}
+
+func (r *memoryRollCallRepo) GetOpenReminders(teacherID string) ([]models.RollCallCheck, error) {
+	var reminders []models.RollCallCheck
+	for _, check := range r.checks {
+		if check.ResolvedAt == nil && len(check.MissingStudentIDs) > 0 && (teacherID == "" || check.TeacherID == teacherID) {
+			reminders = append(reminders, check)
+		}
+	}
+	return reminders, nil
+}
+
+func (r *memoryRollCallRepo) ResolveRollCallCheck(scheduleID string) error {
+	for i := range r.checks {
+		if r.checks[i].ScheduleID == scheduleID {
+			now := helper.SchoolNow()
+			r.checks[i].ResolvedAt = &now
+		}
+	}
+	return nil
+}
+
+func TestCheckEndedLessonsMarksMissingStudentsAbsent(t *testing.T) {
+	date := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	restore := helper.SetNow(func() time.Time { return helper.SchoolDateTime(date, clock(10, 0)) })
+	defer restore()
+
+	attendanceRepo := &memoryAttendanceRepo{attendances: []models.Attendance{
+		{ID: "taken", StudentID: "s1", ScheduleID: "first", Status: models.AttendancePresent},
+	}}
+	schedules := newFakeScheduleRepo(
+		models.Schedule{ID: "first", Date: date, ClassID: "c1", TeacherID: "t1", SubstituteTeacherID: "t2", Time: clock(8, 30), EndTime: clock(9, 10)},
+		models.Schedule{ID: "second", Date: date, ClassID: "c1", TeacherID: "t1", Time: clock(9, 10), EndTime: clock(9, 50)},
+	)
+	repo := &memoryRollCallRepo{attendances: attendanceRepo, schedules: schedules}
+	service := newTestRollCallService(repo, newFakeSeriesRepo(schedules))
+
+	reminders, err := service.CheckEndedLessons()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reminders) != 1 || reminders[0].ScheduleID != "first" || reminders[0].TeacherID != "t2" {
+		t.Fatalf("expected a reminder for the substitute of the first lesson only, got %+v", reminders)
+	}
+	if !slices.Equal(reminders[0].MissingStudentIDs, []string{"s2", "s3"}) {
+		t.Fatalf("expected the students without attendance to be missing, got %v", reminders[0].MissingStudentIDs)
+	}
+
+	absences, _ := attendanceRepo.GetAttendanceByScheduleID("first")
+	for _, attendance := range absences {
+		if attendance.StudentID != "s1" && attendance.Status != models.AttendanceAbsent {
+			t.Fatalf("expected %s to be marked absent, got %+v", attendance.StudentID, attendance)
+		}
+		if attendance.StudentID == "s1" && attendance.Status != models.AttendancePresent {
+			t.Fatalf("expected the attendance taken to be kept, got %+v", attendance)
+		}
+	}
+
+	// The second lesson is checked once its grace period passed, and the
+	// first one is not checked again
+	restore = helper.SetNow(func() time.Time { return helper.SchoolDateTime(date, clock(10, 10)) })
+	defer restore()
+	reminders, err = service.CheckEndedLessons()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reminders) != 1 || reminders[0].ScheduleID != "second" || len(reminders[0].MissingStudentIDs) != 3 {
+		t.Fatalf("expected a reminder for the second lesson, got %+v", reminders)
+	}
+
+	if err := service.ResolveReminder("first", "t1"); err == nil || !strings.Contains(err.Error(), "lesson's teacher") {
+		t.Fatalf("expected another teacher to be refused, got %v", err)
+	}
+	if err := service.ResolveReminder("first", "t2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	open, _ := service.GetReminders("")
+	if len(open) != 1 || open[0].ScheduleID != "second" {
+		t.Fatalf("expected only the second reminder to stay open, got %+v", open)
+	}
+}
+
+func TestCheckEndedLessonsStoresSeriesOccurrences(t *testing.T) {
+	date := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	restore := helper.SetNow(func() time.Time { return helper.SchoolDateTime(date, clock(10, 0)) })
+	defer restore()
+
+	schedules := newFakeScheduleRepo()
+	seriesRepo := newFakeSeriesRepo(schedules, models.ScheduleSeries{
+		ID: "series-1", TeacherID: "t1", LessonID: "l1", ClassID: "c1", StartDate: date.AddDate(0, 0, -7),
+		Time: clock(8, 30), EndTime: clock(9, 10), Weekdays: []time.Weekday{date.Weekday()}, Count: 3,
+	})
+	attendanceRepo := &memoryAttendanceRepo{}
+	repo := &memoryRollCallRepo{attendances: attendanceRepo, schedules: schedules}
+	service := newTestRollCallService(repo, seriesRepo)
+
+	reminders, err := service.CheckEndedLessons()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reminders) != 2 || len(reminders[0].MissingStudentIDs) != 3 || len(reminders[1].MissingStudentIDs) != 3 {
+		t.Fatalf("expected both ended occurrences to be checked, got %+v", reminders)
+	}
+
+	stored, _ := schedules.GetAllSchedules()
+	if len(stored) != 2 || len(seriesRepo.series["series-1"].ExceptionDates) != 2 {
+		t.Fatalf("expected the ended occurrences to be stored and the next one to stay in the series, got %+v", stored)
+	}
+	for _, schedule := range stored {
+		if schedule.SeriesID != "series-1" {
+			t.Fatalf("expected the stored lesson to keep its series, got %+v", schedule)
+		}
+		absences, _ := attendanceRepo.GetAttendanceByScheduleID(schedule.ID)
+		if len(absences) != 3 {
+			t.Fatalf("expected every student of %s to be marked absent, got %+v", schedule.Date, absences)
+		}
+	}
+
+	// Checking again neither stores nor checks the occurrences twice
+	reminders, err = service.CheckEndedLessons()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored, _ = schedules.GetAllSchedules(); len(reminders) != 0 || len(stored) != 2 {
+		t.Fatalf("expected nothing new, got %+v and %d schedules", reminders, len(stored))
+	}
+}
+
+// failingExcuseRepo fails the excuse lookup of one lesson
+type failingExcuseRepo struct {
+	fakeExcuseRepo
+	scheduleID string
+}
+
+func (r *failingExcuseRepo) GetApprovedExcusesForLesson(scheduleID string, date time.Time) ([]models.Excuse, error) {
+	if scheduleID == r.scheduleID {
+		return nil, fmt.Errorf("connection reset")
+	}
+	return r.fakeExcuseRepo.GetApprovedExcusesForLesson(scheduleID, date)
+}
+
+func TestCheckEndedLessonsContinuesAfterAFailingLesson(t *testing.T) {
+	date := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	restore := helper.SetNow(func() time.Time { return helper.SchoolDateTime(date, clock(10, 30)) })
+	defer restore()
+
+	schedules := newFakeScheduleRepo(
+		models.Schedule{ID: "first", Date: date, ClassID: "c1", TeacherID: "t1", Time: clock(8, 30), EndTime: clock(9, 10)},
+		models.Schedule{ID: "second", Date: date, ClassID: "c1", TeacherID: "t1", Time: clock(9, 10), EndTime: clock(9, 50)},
+	)
+	repo := &memoryRollCallRepo{attendances: &memoryAttendanceRepo{}, schedules: schedules}
+	scheduleService := NewScheduleService(schedules, newFakeSeriesRepo(schedules), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+	service := NewRollCallService(repo, &failingExcuseRepo{scheduleID: "first"}, scheduleService, rosterClassService{}, fakeAlertService{})
+
+	reminders, err := service.CheckEndedLessons()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(reminders) != 1 || reminders[0].ScheduleID != "second" {
+		t.Fatalf("expected the second lesson to be checked despite the first failing, got %+v", reminders)
+	}
+	if len(repo.checks) != 1 {
+		t.Fatalf("expected the failed lesson to stay unchecked for the next run, got %+v", repo.checks)
+	}
+}
@@ -0,0 +1,197 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type RoomService struct {
+	roomRepo     models.RoomRepository
+	scheduleRepo models.ScheduleRepository
+	seriesRepo   models.ScheduleSeriesRepository
+}
+
+func NewRoomService(roomRepo models.RoomRepository, scheduleRepo models.ScheduleRepository, seriesRepo models.ScheduleSeriesRepository) models.RoomService {
+	return &RoomService{
+		roomRepo:     roomRepo,
+		scheduleRepo: scheduleRepo,
+		seriesRepo:   seriesRepo,
+	}
+}
+
+func (rs *RoomService) CreateRoom(room *models.Room) error {
+	if err := validateRoom(room); err != nil {
+		return err
+	}
+
+	// Check for duplicate room names
+	existingRooms, err := rs.roomRepo.GetAllRooms()
+	if err != nil {
+		return fmt.Errorf("failed to check existing rooms: %w", err)
+	}
+
+	for _, existing := range existingRooms {
+		if strings.EqualFold(existing.Name, room.Name) {
+			return fmt.Errorf("room with name '%s' already exists", room.Name)
+		}
+	}
+
+	return rs.roomRepo.CreateRoom(room)
+}
+
+func (rs *RoomService) GetRoomByID(id string) (*models.Room, error) {
+	if id == "" {
+		return nil, fmt.Errorf("room ID is required")
+	}
+
+	return rs.roomRepo.GetRoomByID(id)
+}
+
+func (rs *RoomService) UpdateRoom(room *models.Room) error {
+	// Validate room exists
+	existing, err := rs.roomRepo.GetRoomByID(room.ID)
+	if err != nil {
+		return fmt.Errorf("room not found: %w", err)
+	}
+
+	if err := validateRoom(room); err != nil {
+		return err
+	}
+
+	// Check for duplicate room names (excluding current room)
+	if !strings.EqualFold(existing.Name, room.Name) {
+		allRooms, err := rs.roomRepo.GetAllRooms()
+		if err != nil {
+			return fmt.Errorf("failed to check existing rooms: %w", err)
+		}
+
+		for _, otherRoom := range allRooms {
+			if otherRoom.ID != room.ID && strings.EqualFold(otherRoom.Name, room.Name) {
+				return fmt.Errorf("room with name '%s' already exists", room.Name)
+			}
+		}
+	}
+
+	return rs.roomRepo.UpdateRoom(room)
+}
+
+func (rs *RoomService) DeleteRoom(id string) error {
+	if id == "" {
+		return fmt.Errorf("room ID is required")
+	}
+
+	// Validate room exists
+	_, err := rs.roomRepo.GetRoomByID(id)
+	if err != nil {
+		return fmt.Errorf("room not found: %w", err)
+	}
+
+	today := helper.SchoolToday()
+
+	// Check if room has upcoming schedules
+	schedules, err := rs.scheduleRepo.GetSchedulesByRoomID(id)
+	if err != nil {
+		return fmt.Errorf("failed to check associated schedules: %w", err)
+	}
+
+	upcoming := 0
+	for _, schedule := range schedules {
+		if !schedule.Date.Before(today) {
+			upcoming++
+		}
+	}
+
+	if upcoming > 0 {
+		return fmt.Errorf("cannot delete room with upcoming schedules (%d found). Please move the schedules to another room first", upcoming)
+	}
+
+	// Check if room has series that are still running
+	series, err := rs.seriesRepo.GetScheduleSeriesByRoomID(id)
+	if err != nil {
+		return fmt.Errorf("failed to check associated schedule series: %w", err)
+	}
+
+	for _, s := range series {
+		if !lastOccurrenceDate(s).Before(today) {
+			return fmt.Errorf("cannot delete room used by a running schedule series. Please move the series to another room first")
+		}
+	}
+
+	return rs.roomRepo.DeleteRoom(id)
+}
+
+func (rs *RoomService) GetAllRooms() ([]models.Room, error) {
+	return rs.roomRepo.GetAllRooms()
+}
+
+// FindRooms returns the rooms seating at least minCapacity students and
+// offering every requested feature
+func (rs *RoomService) FindRooms(minCapacity int, features []string) ([]models.Room, error) {
+	if minCapacity < 0 {
+		return nil, fmt.Errorf("minimum capacity cannot be negative")
+	}
+
+	allRooms, err := rs.roomRepo.GetAllRooms()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all rooms: %w", err)
+	}
+
+	required := normalizeRoomFeatures(features)
+
+	var matchingRooms []models.Room
+	for _, room := range allRooms {
+		if room.Capacity < minCapacity {
+			continue
+		}
+
+		hasAll := true
+		for _, feature := range required {
+			if !slices.Contains(room.Features, feature) {
+				hasAll = false
+				break
+			}
+		}
+
+		if hasAll {
+			matchingRooms = append(matchingRooms, room)
+		}
+	}
+
+	return matchingRooms, nil
+}
+
+func validateRoom(room *models.Room) error {
+	// Normalize room name
+	room.Name = strings.TrimSpace(room.Name)
+
+	if room.Name == "" {
+		return fmt.Errorf("room name is required")
+	}
+
+	if len(room.Name) > 255 {
+		return fmt.Errorf("room name cannot exceed 255 characters")
+	}
+
+	if room.Capacity <= 0 {
+		return fmt.Errorf("room capacity must be positive")
+	}
+
+	room.Features = normalizeRoomFeatures(room.Features)
+	return nil
+}
+
+// normalizeRoomFeatures lowercases and deduplicates feature names, so
+// "Projector" and "projector " match
+func normalizeRoomFeatures(features []string) []string {
+	normalized := []string{}
+	for _, feature := range features {
+		feature = strings.ToLower(strings.TrimSpace(feature))
+		if feature != "" && !slices.Contains(normalized, feature) {
+			normalized = append(normalized, feature)
+		}
+	}
+	return normalized
+}
@@ -1,22 +1,36 @@
 package application
 
 import (
+	"Education_Dashboard/internal/helper"
 	"Education_Dashboard/internal/models"
 	"fmt"
+	"strings"
 	"time"
 )
 
 type ScheduleService struct {
-	scheduleRepo    models.ScheduleRepository
-	lessonRepo      models.LessonRepository
-	attendanceRepo  models.AttendanceRepository
+	scheduleRepo     models.ScheduleRepository
+	seriesRepo       models.ScheduleSeriesRepository
+	roomRepo         models.RoomRepository
+	lessonRepo       models.LessonRepository
+	attendanceRepo   models.AttendanceRepository
+	calendarRepo     models.AcademicCalendarRepository
+	availabilityRepo models.AvailabilityRepository
+	workloadRepo     models.WorkloadLimitRepository
+	bellRepo         models.BellScheduleRepository
 }
 
-func NewScheduleService(scheduleRepo models.ScheduleRepository, lessonRepo models.LessonRepository, attendanceRepo models.AttendanceRepository) models.ScheduleService {
+func NewScheduleService(scheduleRepo models.ScheduleRepository, seriesRepo models.ScheduleSeriesRepository, roomRepo models.RoomRepository, lessonRepo models.LessonRepository, attendanceRepo models.AttendanceRepository, calendarRepo models.AcademicCalendarRepository, availabilityRepo models.AvailabilityRepository, workloadRepo models.WorkloadLimitRepository, bellRepo models.BellScheduleRepository) models.ScheduleService {
 	return &ScheduleService{
-		scheduleRepo:   scheduleRepo,
-		lessonRepo:     lessonRepo,
-		attendanceRepo: attendanceRepo,
+		scheduleRepo:     scheduleRepo,
+		seriesRepo:       seriesRepo,
+		roomRepo:         roomRepo,
+		lessonRepo:       lessonRepo,
+		attendanceRepo:   attendanceRepo,
+		calendarRepo:     calendarRepo,
+		availabilityRepo: availabilityRepo,
+		workloadRepo:     workloadRepo,
+		bellRepo:         bellRepo,
 	}
 }
 
@@ -40,38 +54,53 @@ func (ss *ScheduleService) CreateSchedule(schedule *models.Schedule) error {
 		return fmt.Errorf("lesson not found: %w", err)
 	}
 
+	// Validate room exists if one is booked
+	if schedule.RoomID != "" {
+		if _, err := ss.roomRepo.GetRoomByID(schedule.RoomID); err != nil {
+			return fmt.Errorf("room not found: %w", err)
+		}
+	}
+
+	if err := ss.placeInPeriod(schedule); err != nil {
+		return err
+	}
+
+	if err := normalizeScheduleTimes(schedule, defaultLessonDuration); err != nil {
+		return err
+	}
+
 	// Validate date is not in the past
-	today := time.Now().Truncate(24 * time.Hour)
-	scheduleDate := schedule.Date.Truncate(24 * time.Hour)
-	
+	today := helper.SchoolToday()
+	scheduleDate := dateOnly(schedule.Date)
+
 	if scheduleDate.Before(today) {
 		return fmt.Errorf("cannot create schedule for past dates")
 	}
 
-	// Check for conflicts - same teacher, same time, same day
-	existingSchedules, err := ss.scheduleRepo.GetSchedulesByTeacherID(schedule.TeacherID)
-	if err != nil {
-		return fmt.Errorf("failed to check teacher schedules: %w", err)
+	// Validate date is inside a term and not a holiday or closure day
+	if err := checkAcademicDate(ss.calendarRepo, schedule.Date); err != nil {
+		return err
 	}
 
-	for _, existing := range existingSchedules {
-		if isSameDay(existing.Date, schedule.Date) && isSameTime(existing.Time, schedule.Time) {
-			return fmt.Errorf("teacher already has a schedule at this time on this date")
-		}
+	if err := checkTeacherAvailability(ss.availabilityRepo, schedule.TeacherID, *schedule); err != nil {
+		return err
 	}
 
-	// Check for class conflicts - same class, same time, same day
-	classSchedules, err := ss.scheduleRepo.GetSchedulesByClassID(schedule.ClassID)
-	if err != nil {
-		return fmt.Errorf("failed to check class schedules: %w", err)
+	if err := ss.checkWorkload(schedule); err != nil {
+		return err
 	}
 
-	for _, existing := range classSchedules {
-		if isSameDay(existing.Date, schedule.Date) && isSameTime(existing.Time, schedule.Time) {
-			return fmt.Errorf("class already has a schedule at this time on this date")
-		}
+	// Check for teacher, class and room conflicts - overlapping intervals on the same day
+	if err := ss.checkScheduleConflicts(schedule); err != nil {
+		return err
 	}
 
+	// New lessons always start out scheduled, cancelling has its own endpoint
+	schedule.Status = models.ScheduleScheduled
+	schedule.CancellationReason = ""
+	schedule.CancelledBy = ""
+	schedule.CancelledAt = nil
+
 	return ss.scheduleRepo.CreateSchedule(schedule)
 }
 
@@ -90,6 +119,10 @@ func (ss *ScheduleService) UpdateSchedule(schedule *models.Schedule) error {
 		return fmt.Errorf("schedule not found: %w", err)
 	}
 
+	if existing.Status == models.ScheduleCancelled {
+		return fmt.Errorf("cannot modify cancelled schedules")
+	}
+
 	// Validate required fields
 	if schedule.TeacherID == "" {
 		return fmt.Errorf("teacher ID is required")
@@ -111,59 +144,84 @@ func (ss *ScheduleService) UpdateSchedule(schedule *models.Schedule) error {
 		}
 	}
 
+	// Validate room exists if changed
+	if schedule.RoomID != "" && existing.RoomID != schedule.RoomID {
+		if _, err := ss.roomRepo.GetRoomByID(schedule.RoomID); err != nil {
+			return fmt.Errorf("room not found: %w", err)
+		}
+	}
+
+	// Series link is managed through the series endpoints, substitutes
+	// through the substitution endpoints and cancellation through its own
+	schedule.SeriesID = existing.SeriesID
+	schedule.OccurrenceDate = existing.OccurrenceDate
+	schedule.SubstituteTeacherID = existing.SubstituteTeacherID
+	schedule.Status = existing.Status
+	schedule.CancellationReason = existing.CancellationReason
+	schedule.CancelledBy = existing.CancelledBy
+	schedule.CancelledAt = existing.CancelledAt
+
+	// Lessons created before the bell schedule keep their times until moved
+	if !isSameDay(existing.Date, schedule.Date) ||
+		!isSameTime(existing.Time, schedule.Time) ||
+		(!schedule.EndTime.IsZero() && !isSameTime(existing.EndTime, schedule.EndTime)) ||
+		existing.PeriodID != schedule.PeriodID {
+
+		if err := ss.placeInPeriod(schedule); err != nil {
+			return err
+		}
+	}
+
+	// Keep the existing lesson length when no end time is sent
+	if err := normalizeScheduleTimes(schedule, scheduleDuration(*existing)); err != nil {
+		return err
+	}
+
 	// Business rule: Cannot change date/time if schedule is in the past
-	if existing.Date.Before(time.Now().Truncate(24 * time.Hour)) {
-		if !isSameDay(existing.Date, schedule.Date) || !isSameTime(existing.Time, schedule.Time) {
+	if dateOnly(existing.Date).Before(helper.SchoolToday()) {
+		if !isSameDay(existing.Date, schedule.Date) || !isSameTime(existing.Time, schedule.Time) || !isSameTime(existing.EndTime, schedule.EndTime) {
 			return fmt.Errorf("cannot modify date/time for past schedules")
 		}
 	}
 
 	// Validate new date is not in the past
-	today := time.Now().Truncate(24 * time.Hour)
-	scheduleDate := schedule.Date.Truncate(24 * time.Hour)
-	
+	today := helper.SchoolToday()
+	scheduleDate := dateOnly(schedule.Date)
+
 	if scheduleDate.Before(today) {
 		return fmt.Errorf("cannot schedule for past dates")
 	}
 
-	// Check for conflicts only if date/time/teacher/class changed
-	if !isSameDay(existing.Date, schedule.Date) || 
-	   !isSameTime(existing.Time, schedule.Time) || 
-	   existing.TeacherID != schedule.TeacherID ||
-	   existing.ClassID != schedule.ClassID {
-		
-		// Check teacher conflicts
-		if existing.TeacherID != schedule.TeacherID || 
-		   !isSameDay(existing.Date, schedule.Date) || 
-		   !isSameTime(existing.Time, schedule.Time) {
-			
-			teacherSchedules, err := ss.scheduleRepo.GetSchedulesByTeacherID(schedule.TeacherID)
-			if err != nil {
-				return fmt.Errorf("failed to check teacher schedules: %w", err)
-			}
+	if !isSameDay(existing.Date, schedule.Date) {
+		if err := checkAcademicDate(ss.calendarRepo, schedule.Date); err != nil {
+			return err
+		}
+	}
 
-			for _, other := range teacherSchedules {
-				if other.ID != schedule.ID && isSameDay(other.Date, schedule.Date) && isSameTime(other.Time, schedule.Time) {
-					return fmt.Errorf("teacher already has a schedule at this time on this date")
-				}
-			}
+	if !isSameDay(existing.Date, schedule.Date) ||
+		!isSameTime(existing.Time, schedule.Time) ||
+		!isSameTime(existing.EndTime, schedule.EndTime) ||
+		existing.TeacherID != schedule.TeacherID {
+
+		if err := checkTeacherAvailability(ss.availabilityRepo, effectiveTeacherID(*schedule), *schedule); err != nil {
+			return err
 		}
 
-		// Check class conflicts
-		if existing.ClassID != schedule.ClassID || 
-		   !isSameDay(existing.Date, schedule.Date) || 
-		   !isSameTime(existing.Time, schedule.Time) {
-			
-			classSchedules, err := ss.scheduleRepo.GetSchedulesByClassID(schedule.ClassID)
-			if err != nil {
-				return fmt.Errorf("failed to check class schedules: %w", err)
-			}
+		if err := ss.checkWorkload(schedule); err != nil {
+			return err
+		}
+	}
 
-			for _, other := range classSchedules {
-				if other.ID != schedule.ID && isSameDay(other.Date, schedule.Date) && isSameTime(other.Time, schedule.Time) {
-					return fmt.Errorf("class already has a schedule at this time on this date")
-				}
-			}
+	// Check for conflicts only if date/time/teacher/class/room changed
+	if !isSameDay(existing.Date, schedule.Date) ||
+		!isSameTime(existing.Time, schedule.Time) ||
+		!isSameTime(existing.EndTime, schedule.EndTime) ||
+		existing.TeacherID != schedule.TeacherID ||
+		existing.ClassID != schedule.ClassID ||
+		existing.RoomID != schedule.RoomID {
+
+		if err := ss.checkScheduleConflicts(schedule); err != nil {
+			return err
 		}
 	}
 
@@ -182,7 +240,7 @@ func (ss *ScheduleService) DeleteSchedule(id string) error {
 	}
 
 	// Business rule: Cannot delete schedules from the past
-	if schedule.Date.Before(time.Now().Truncate(24 * time.Hour)) {
+	if dateOnly(schedule.Date).Before(helper.SchoolToday()) {
 		return fmt.Errorf("cannot delete past schedules")
 	}
 
@@ -193,7 +251,7 @@ func (ss *ScheduleService) DeleteSchedule(id string) error {
 	}
 
 	if len(attendances) > 0 {
-		return fmt.Errorf("cannot delete schedule with existing attendance records (%d found)", len(attendances))
+		return fmt.Errorf("cannot delete schedule with existing attendance records (%d found), cancel it instead", len(attendances))
 	}
 
 	return ss.scheduleRepo.DeleteSchedule(id)
@@ -208,7 +266,8 @@ func (ss *ScheduleService) GetSchedulesByTeacherID(teacherID string) ([]models.S
 		return nil, fmt.Errorf("teacher ID is required")
 	}
 
-	return ss.scheduleRepo.GetSchedulesByTeacherID(teacherID)
+	// Lessons handed to a substitute move to the substitute's schedule
+	return teachingSchedules(ss.scheduleRepo, teacherID)
 }
 
 func (ss *ScheduleService) GetSchedulesByClassID(classID string) ([]models.Schedule, error) {
@@ -219,44 +278,27 @@ func (ss *ScheduleService) GetSchedulesByClassID(classID string) ([]models.Sched
 	return ss.scheduleRepo.GetSchedulesByClassID(classID)
 }
 
-// Additional business methods
-
-func (ss *ScheduleService) GetTodaySchedules() ([]models.Schedule, error) {
-	allSchedules, err := ss.scheduleRepo.GetAllSchedules()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get all schedules: %w", err)
+func (ss *ScheduleService) GetSchedulesByRoomID(roomID string) ([]models.Schedule, error) {
+	if roomID == "" {
+		return nil, fmt.Errorf("room ID is required")
 	}
 
-	today := time.Now().Truncate(24 * time.Hour)
-	var todaySchedules []models.Schedule
+	return ss.scheduleRepo.GetSchedulesByRoomID(roomID)
+}
 
-	for _, schedule := range allSchedules {
-		if isSameDay(schedule.Date, today) {
-			todaySchedules = append(todaySchedules, schedule)
-		}
-	}
+// Additional business methods
 
-	return todaySchedules, nil
+func (ss *ScheduleService) GetTodaySchedules() ([]models.Schedule, error) {
+	today := helper.SchoolToday()
+
+	return ss.schedulesBetween(today, today.AddDate(0, 0, 1))
 }
 
 func (ss *ScheduleService) GetWeekSchedules(startDate time.Time) ([]models.Schedule, error) {
-	allSchedules, err := ss.scheduleRepo.GetAllSchedules()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get all schedules: %w", err)
-	}
-
-	startOfWeek := startDate.Truncate(24 * time.Hour)
+	startOfWeek := dateOnly(startDate)
 	endOfWeek := startOfWeek.AddDate(0, 0, 7)
 
-	var weekSchedules []models.Schedule
-	for _, schedule := range allSchedules {
-		scheduleDate := schedule.Date.Truncate(24 * time.Hour)
-		if (scheduleDate.Equal(startOfWeek) || scheduleDate.After(startOfWeek)) && scheduleDate.Before(endOfWeek) {
-			weekSchedules = append(weekSchedules, schedule)
-		}
-	}
-
-	return weekSchedules, nil
+	return ss.schedulesBetween(startOfWeek, endOfWeek)
 }
 
 func (ss *ScheduleService) GetUpcomingSchedules(teacherID string, days int) ([]models.Schedule, error) {
@@ -268,63 +310,86 @@ func (ss *ScheduleService) GetUpcomingSchedules(teacherID string, days int) ([]m
 		return nil, fmt.Errorf("days must be positive")
 	}
 
-	teacherSchedules, err := ss.scheduleRepo.GetSchedulesByTeacherID(teacherID)
+	now := helper.SchoolNow()
+	today := helper.SchoolToday()
+	endDate := today.AddDate(0, 0, days+1)
+
+	teacherSchedules, err := ss.teacherSchedules(teacherID, today, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get teacher schedules: %w", err)
 	}
 
-	now := time.Now()
-	endDate := now.AddDate(0, 0, days)
-
+	// Lessons later today count, the window ends after the last requested day
 	var upcomingSchedules []models.Schedule
 	for _, schedule := range teacherSchedules {
-		if schedule.Date.After(now) && schedule.Date.Before(endDate) {
+		if helper.SchoolDateTime(schedule.Date, schedule.Time).After(now) && dateOnly(schedule.Date).Before(endDate) {
 			upcomingSchedules = append(upcomingSchedules, schedule)
 		}
 	}
 
+	sortSchedules(upcomingSchedules)
 	return upcomingSchedules, nil
 }
 
-func (ss *ScheduleService) GetScheduleConflicts(teacherID, classID string, date time.Time, startTime time.Time) ([]models.Schedule, error) {
-	var conflicts []models.Schedule
+// GetSchedulesBetween returns every schedule and series occurrence dated
+// within [from, to)
+func (ss *ScheduleService) GetSchedulesBetween(from, to time.Time) ([]models.Schedule, error) {
+	return ss.schedulesBetween(dateOnly(from), dateOnly(to))
+}
+
+// CheckScheduleDate returns an error when no lesson may be held on the date
+func (ss *ScheduleService) CheckScheduleDate(date time.Time) error {
+	return checkAcademicDate(ss.calendarRepo, date)
+}
+
+// CheckTeacherAvailability returns an error when the teacher's availability
+// windows do not allow the lesson
+func (ss *ScheduleService) CheckTeacherAvailability(teacherID string, schedule models.Schedule) error {
+	return checkTeacherAvailability(ss.availabilityRepo, teacherID, schedule)
+}
+
+// GetClosureDays lists the holidays and closure days in [from, to) so
+// schedule views can mark them
+func (ss *ScheduleService) GetClosureDays(from, to time.Time) ([]models.ClosureDay, error) {
+	return closureDays(ss.calendarRepo, from, to)
+}
+
+func (ss *ScheduleService) GetScheduleConflicts(teacherID, classID, roomID string, date time.Time, startTime time.Time, endTime time.Time) ([]models.ScheduleConflict, error) {
+	slot := models.Schedule{Date: date, Time: startTime, EndTime: endTime}
+	if err := normalizeScheduleTimes(&slot, defaultLessonDuration); err != nil {
+		return nil, err
+	}
+
+	var conflicts []models.ScheduleConflict
 
 	// Check teacher conflicts
 	if teacherID != "" {
-		teacherSchedules, err := ss.scheduleRepo.GetSchedulesByTeacherID(teacherID)
+		teacherSchedules, err := ss.teacherSchedules(teacherID, slot.Date, slot.Date.AddDate(0, 0, 1))
 		if err != nil {
 			return nil, fmt.Errorf("failed to get teacher schedules: %w", err)
 		}
 
-		for _, schedule := range teacherSchedules {
-			if isSameDay(schedule.Date, date) && isSameTime(schedule.Time, startTime) {
-				conflicts = append(conflicts, schedule)
-			}
-		}
+		conflicts = appendOverlaps(conflicts, teacherSchedules, slot, models.ConflictTeacher)
 	}
 
 	// Check class conflicts
 	if classID != "" {
-		classSchedules, err := ss.scheduleRepo.GetSchedulesByClassID(classID)
+		classSchedules, err := ss.classSchedules(classID, slot.Date, slot.Date.AddDate(0, 0, 1))
 		if err != nil {
 			return nil, fmt.Errorf("failed to get class schedules: %w", err)
 		}
 
-		for _, schedule := range classSchedules {
-			if isSameDay(schedule.Date, date) && isSameTime(schedule.Time, startTime) {
-				// Avoid duplicates
-				found := false
-				for _, conflict := range conflicts {
-					if conflict.ID == schedule.ID {
-						found = true
-						break
-					}
-				}
-				if !found {
-					conflicts = append(conflicts, schedule)
-				}
-			}
+		conflicts = appendOverlaps(conflicts, classSchedules, slot, models.ConflictClass)
+	}
+
+	// Check room conflicts
+	if roomID != "" {
+		roomSchedules, err := ss.roomSchedules(roomID, slot.Date, slot.Date.AddDate(0, 0, 1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get room schedules: %w", err)
 		}
+
+		conflicts = appendOverlaps(conflicts, roomSchedules, slot, models.ConflictRoom)
 	}
 
 	return conflicts, nil
@@ -341,27 +406,783 @@ func (ss *ScheduleService) RescheduleSchedule(scheduleID string, newDate time.Ti
 	}
 
 	// Check if the schedule is in the past
-	if schedule.Date.Before(time.Now().Truncate(24 * time.Hour)) {
+	if dateOnly(schedule.Date).Before(helper.SchoolToday()) {
 		return fmt.Errorf("cannot reschedule past schedules")
 	}
 
+	if schedule.Status == models.ScheduleCancelled {
+		return fmt.Errorf("cannot reschedule cancelled schedules")
+	}
+
+	// Move the whole interval, keeping the lesson length
+	duration := scheduleDuration(*schedule)
+	schedule.Date = newDate
+	schedule.Time = newTime
+	schedule.EndTime = newTime.Add(duration)
+	schedule.PeriodID = ""
+
+	if err := ss.placeInPeriod(schedule); err != nil {
+		return err
+	}
+
+	if err := normalizeScheduleTimes(schedule, duration); err != nil {
+		return err
+	}
+
+	if err := checkAcademicDate(ss.calendarRepo, schedule.Date); err != nil {
+		return err
+	}
+
+	if err := checkTeacherAvailability(ss.availabilityRepo, effectiveTeacherID(*schedule), *schedule); err != nil {
+		return err
+	}
+
+	if err := ss.checkWorkload(schedule); err != nil {
+		return err
+	}
+
 	// Check for conflicts
-	conflicts, err := ss.GetScheduleConflicts(schedule.TeacherID, schedule.ClassID, newDate, newTime)
+	if err := ss.checkScheduleConflicts(schedule); err != nil {
+		return err
+	}
+
+	return ss.scheduleRepo.UpdateSchedule(schedule)
+}
+
+// CancelSchedule marks the lesson as cancelled and records why, who and when.
+// The lesson stays visible in schedule views but no longer blocks the slot.
+// Occurrences of a series are cancelled with CancelOccurrence.
+func (ss *ScheduleService) CancelSchedule(scheduleID, reason, cancelledBy string) (*models.Schedule, error) {
+	if scheduleID == "" {
+		return nil, fmt.Errorf("schedule ID is required")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("cancellation reason is required")
+	}
+
+	schedule, err := ss.scheduleRepo.GetScheduleByID(scheduleID)
 	if err != nil {
-		return fmt.Errorf("failed to check conflicts: %w", err)
+		return nil, fmt.Errorf("schedule not found: %w", err)
 	}
 
-	for _, conflict := range conflicts {
-		if conflict.ID != scheduleID {
-			return fmt.Errorf("schedule conflict found at new time slot")
+	switch schedule.Status {
+	case models.ScheduleCancelled:
+		return nil, fmt.Errorf("schedule is already cancelled")
+	case models.ScheduleCompleted:
+		return nil, fmt.Errorf("cannot cancel completed schedules")
+	}
+
+	if dateOnly(schedule.Date).Before(helper.SchoolToday()) {
+		return nil, fmt.Errorf("cannot cancel past schedules")
+	}
+
+	return ss.cancelSchedule(schedule, reason, cancelledBy)
+}
+
+// CancelOccurrence materializes the occurrence of the series and cancels it,
+// so the cancellation is kept like that of any other lesson
+func (ss *ScheduleService) CancelOccurrence(seriesID string, occurrenceDate time.Time, reason, cancelledBy string) (*models.Schedule, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("cancellation reason is required")
+	}
+
+	series, err := ss.seriesRepo.GetScheduleSeriesByID(seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("schedule series not found: %w", err)
+	}
+
+	if err := checkEditableOccurrence(*series, occurrenceDate); err != nil {
+		return nil, err
+	}
+
+	schedule, err := ss.MaterializeOccurrence(seriesID, occurrenceDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return ss.cancelSchedule(schedule, reason, cancelledBy)
+}
+
+func (ss *ScheduleService) cancelSchedule(schedule *models.Schedule, reason, cancelledBy string) (*models.Schedule, error) {
+	cancelledAt := helper.SchoolNow()
+	schedule.Status = models.ScheduleCancelled
+	schedule.CancellationReason = reason
+	schedule.CancelledBy = cancelledBy
+	schedule.CancelledAt = &cancelledAt
+
+	if err := ss.scheduleRepo.UpdateSchedule(schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// Recurring series
+
+func (ss *ScheduleService) CreateScheduleSeries(series *models.ScheduleSeries) error {
+	if series.RRule != "" {
+		if err := ParseRRule(series.RRule, series); err != nil {
+			return fmt.Errorf("invalid recurrence rule: %w", err)
 		}
 	}
 
-	// Update the schedule
-	schedule.Date = newDate
-	schedule.Time = newTime
+	if err := ss.validateScheduleSeries(series); err != nil {
+		return err
+	}
 
-	return ss.scheduleRepo.UpdateSchedule(schedule)
+	// Validate series does not start in the past
+	if dateOnly(series.StartDate).Before(helper.SchoolToday()) {
+		return fmt.Errorf("cannot create schedule series starting in the past")
+	}
+
+	if err := ss.checkSeriesConflicts(series, series.ID); err != nil {
+		return err
+	}
+
+	if err := ss.seriesRepo.CreateScheduleSeries(series); err != nil {
+		return err
+	}
+
+	series.RRule = FormatRRule(*series)
+	return nil
+}
+
+func (ss *ScheduleService) GetScheduleSeriesByID(id string) (*models.ScheduleSeries, error) {
+	if id == "" {
+		return nil, fmt.Errorf("series ID is required")
+	}
+
+	series, err := ss.seriesRepo.GetScheduleSeriesByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	series.RRule = FormatRRule(*series)
+	return series, nil
+}
+
+func (ss *ScheduleService) GetAllScheduleSeries() ([]models.ScheduleSeries, error) {
+	allSeries, err := ss.seriesRepo.GetAllScheduleSeries()
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range allSeries {
+		allSeries[i].RRule = FormatRRule(allSeries[i])
+	}
+	return allSeries, nil
+}
+
+func (ss *ScheduleService) GetSeriesOccurrencesBetween(from, to time.Time) ([]models.Schedule, error) {
+	allSeries, err := ss.seriesRepo.GetAllScheduleSeries()
+	if err != nil {
+		return nil, err
+	}
+
+	return ss.expandOpenSeries(allSeries, dateOnly(from), dateOnly(to))
+}
+
+// UpdateScheduleSeries edits one occurrence, the occurrence and all following
+// ones, or the whole series. For the "this" scope a non-zero StartDate in
+// changes moves the occurrence to that date.
+func (ss *ScheduleService) UpdateScheduleSeries(seriesID, scope string, occurrenceDate time.Time, changes *models.ScheduleSeries) error {
+	series, err := ss.seriesRepo.GetScheduleSeriesByID(seriesID)
+	if err != nil {
+		return fmt.Errorf("schedule series not found: %w", err)
+	}
+
+	today := helper.SchoolToday()
+
+	switch scope {
+	case models.SeriesScopeThis:
+		if err := checkEditableOccurrence(*series, occurrenceDate); err != nil {
+			return err
+		}
+
+		occurrence := seriesOccurrence(*series, dateOnly(occurrenceDate))
+		applyOccurrenceChanges(&occurrence, changes)
+
+		if occurrence.Date.Before(today) {
+			return fmt.Errorf("cannot move an occurrence to a past date")
+		}
+
+		return ss.detachOccurrence(series, &occurrence, true)
+
+	case models.SeriesScopeFollowing:
+		if err := checkEditableOccurrence(*series, occurrenceDate); err != nil {
+			return err
+		}
+
+		// Editing from the first occurrence on is an edit of the whole series
+		if occurrencesBefore(*series, occurrenceDate) == 0 {
+			return ss.replaceScheduleSeries(series, changes)
+		}
+
+		following := *series
+		following.ID = ""
+		following.StartDate = dateOnly(occurrenceDate)
+		if series.Count > 0 {
+			following.Count = series.Count - occurrencesBefore(*series, occurrenceDate)
+		}
+		if err := applySeriesChanges(&following, changes); err != nil {
+			return err
+		}
+
+		truncateSeries(series, &following, occurrenceDate)
+
+		if err := ss.validateScheduleSeries(&following); err != nil {
+			return err
+		}
+
+		// Occurrences of the original series from this date on are replaced
+		if err := ss.checkSeriesConflicts(&following, series.ID); err != nil {
+			return err
+		}
+
+		return ss.seriesRepo.SplitScheduleSeries(series, &following)
+
+	case models.SeriesScopeAll:
+		if dateOnly(series.StartDate).Before(today) {
+			return fmt.Errorf("series has already started, edit the following occurrences instead")
+		}
+
+		return ss.replaceScheduleSeries(series, changes)
+
+	default:
+		return fmt.Errorf("invalid scope %q, expected this, following or all", scope)
+	}
+}
+
+func (ss *ScheduleService) DeleteScheduleSeries(seriesID, scope string, occurrenceDate time.Time) error {
+	series, err := ss.seriesRepo.GetScheduleSeriesByID(seriesID)
+	if err != nil {
+		return fmt.Errorf("schedule series not found: %w", err)
+	}
+
+	switch scope {
+	case models.SeriesScopeThis:
+		if err := checkEditableOccurrence(*series, occurrenceDate); err != nil {
+			return err
+		}
+
+		series.ExceptionDates = append(series.ExceptionDates, dateOnly(occurrenceDate))
+		return ss.seriesRepo.UpdateScheduleSeries(series)
+
+	case models.SeriesScopeFollowing:
+		if err := checkEditableOccurrence(*series, occurrenceDate); err != nil {
+			return err
+		}
+
+		if occurrencesBefore(*series, occurrenceDate) == 0 {
+			return ss.seriesRepo.DeleteScheduleSeries(series.ID)
+		}
+
+		truncateSeries(series, nil, occurrenceDate)
+		return ss.seriesRepo.UpdateScheduleSeries(series)
+
+	case models.SeriesScopeAll:
+		// Business rule: Cannot delete series that already have past occurrences
+		if dateOnly(series.StartDate).Before(helper.SchoolToday()) {
+			return fmt.Errorf("series has already started, delete the following occurrences instead")
+		}
+
+		return ss.seriesRepo.DeleteScheduleSeries(series.ID)
+
+	default:
+		return fmt.Errorf("invalid scope %q, expected this, following or all", scope)
+	}
+}
+
+// MaterializeOccurrence stores an occurrence as a regular schedule without
+// changing it, so attendance can be recorded against it
+func (ss *ScheduleService) MaterializeOccurrence(seriesID string, occurrenceDate time.Time) (*models.Schedule, error) {
+	series, err := ss.seriesRepo.GetScheduleSeriesByID(seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("schedule series not found: %w", err)
+	}
+
+	if !isOccurrenceDate(*series, occurrenceDate) {
+		return nil, fmt.Errorf("series has no occurrence on %s", occurrenceDate.Format("2006-01-02"))
+	}
+
+	occurrence := seriesOccurrence(*series, dateOnly(occurrenceDate))
+	if err := ss.detachOccurrence(series, &occurrence, false); err != nil {
+		return nil, err
+	}
+
+	return &occurrence, nil
+}
+
+func (ss *ScheduleService) validateScheduleSeries(series *models.ScheduleSeries) error {
+	// Validate required fields
+	if series.TeacherID == "" {
+		return fmt.Errorf("teacher ID is required")
+	}
+
+	if series.LessonID == "" {
+		return fmt.Errorf("lesson ID is required")
+	}
+
+	if series.ClassID == "" {
+		return fmt.Errorf("class ID is required")
+	}
+
+	if series.StartDate.IsZero() {
+		return fmt.Errorf("start date is required")
+	}
+
+	if len(series.Weekdays) == 0 {
+		return fmt.Errorf("at least one weekday is required")
+	}
+
+	for _, weekday := range series.Weekdays {
+		if weekday < time.Sunday || weekday > time.Saturday {
+			return fmt.Errorf("invalid weekday %d", weekday)
+		}
+	}
+
+	// Series must be bounded by an end date or a number of occurrences
+	if series.Until == nil && series.Count <= 0 {
+		return fmt.Errorf("either until or count is required")
+	}
+
+	if series.Until != nil && series.Count > 0 {
+		return fmt.Errorf("until and count cannot be used together")
+	}
+
+	if series.Until != nil && dateOnly(*series.Until).Before(dateOnly(series.StartDate)) {
+		return fmt.Errorf("until date cannot be before start date")
+	}
+
+	if series.Count > maxSeriesOccurrences {
+		return fmt.Errorf("count cannot exceed %d occurrences", maxSeriesOccurrences)
+	}
+
+	// Validate lesson exists
+	_, err := ss.lessonRepo.GetLessonByID(series.LessonID)
+	if err != nil {
+		return fmt.Errorf("lesson not found: %w", err)
+	}
+
+	// Validate room exists if one is booked
+	if series.RoomID != "" {
+		if _, err := ss.roomRepo.GetRoomByID(series.RoomID); err != nil {
+			return fmt.Errorf("room not found: %w", err)
+		}
+	}
+
+	series.StartDate = dateOnly(series.StartDate)
+	if err := ss.placeSeriesInPeriods(series); err != nil {
+		return err
+	}
+
+	template := models.Schedule{Time: series.Time, EndTime: series.EndTime}
+	if err := normalizeScheduleTimes(&template, defaultLessonDuration); err != nil {
+		return err
+	}
+
+	series.EndTime = template.EndTime
+	series.Weekdays = sortedWeekdays(series.Weekdays)
+	for i := range series.ExceptionDates {
+		series.ExceptionDates[i] = dateOnly(series.ExceptionDates[i])
+	}
+
+	if err := ss.excludeClosedDates(series); err != nil {
+		return err
+	}
+
+	if len(expandSeries(*series, series.StartDate, lastOccurrenceDate(*series).AddDate(0, 0, 1))) == 0 {
+		return fmt.Errorf("series does not produce any occurrence")
+	}
+
+	return nil
+}
+
+// excludeClosedDates adds the occurrences falling on closure days or outside
+// the terms to the series' exception dates, so a weekly lesson skips holidays
+func (ss *ScheduleService) excludeClosedDates(series *models.ScheduleSeries) error {
+	from := dateOnly(series.StartDate)
+	to := lastOccurrenceDate(*series).AddDate(0, 0, 1)
+
+	closed, err := academicClosedDates(ss.calendarRepo, from, to)
+	if err != nil {
+		return err
+	}
+
+	for _, occurrence := range expandSeries(*series, from, to) {
+		if closed[dateOnly(occurrence.Date)] {
+			series.ExceptionDates = append(series.ExceptionDates, dateOnly(occurrence.Date))
+		}
+	}
+	return nil
+}
+
+// checkSeriesConflicts checks every occurrence of the series against the
+// teacher's and class's schedules. Occurrences of the series identified by
+// replacedSeriesID are ignored, as they are being replaced.
+func (ss *ScheduleService) checkSeriesConflicts(series *models.ScheduleSeries, replacedSeriesID string) error {
+	from := dateOnly(series.StartDate)
+	to := lastOccurrenceDate(*series).AddDate(0, 0, 1)
+
+	teacherSchedules, err := ss.teacherSchedules(series.TeacherID, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to check teacher schedules: %w", err)
+	}
+
+	classSchedules, err := ss.classSchedules(series.ClassID, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to check class schedules: %w", err)
+	}
+
+	var roomSchedules []models.Schedule
+	if series.RoomID != "" {
+		roomSchedules, err = ss.roomSchedules(series.RoomID, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to check room schedules: %w", err)
+		}
+	}
+
+	windows, err := ss.availabilityRepo.GetAvailabilityWindowsByTeacherID(series.TeacherID)
+	if err != nil {
+		return fmt.Errorf("failed to check teacher availability: %w", err)
+	}
+
+	candidate := *series
+	candidate.ID = replacedSeriesID
+
+	for _, occurrence := range expandSeries(candidate, from, to) {
+		if err := availabilityError(windows, occurrence); err != nil {
+			return fmt.Errorf("occurrence on %s: %w", occurrence.Date.Format("2006-01-02"), err)
+		}
+
+		var conflicts []models.ScheduleConflict
+		conflicts = appendOverlaps(conflicts, teacherSchedules, occurrence, models.ConflictTeacher)
+		conflicts = appendOverlaps(conflicts, classSchedules, occurrence, models.ConflictClass)
+		conflicts = appendOverlaps(conflicts, roomSchedules, occurrence, models.ConflictRoom)
+
+		if err := conflictError(conflicts, occurrence); err != nil {
+			return fmt.Errorf("occurrence on %s: %w", occurrence.Date.Format("2006-01-02"), err)
+		}
+	}
+
+	return nil
+}
+
+// replaceScheduleSeries applies the changes to the whole series
+func (ss *ScheduleService) replaceScheduleSeries(series *models.ScheduleSeries, changes *models.ScheduleSeries) error {
+	updated := *series
+	if !changes.StartDate.IsZero() {
+		updated.StartDate = changes.StartDate
+	}
+	if err := applySeriesChanges(&updated, changes); err != nil {
+		return err
+	}
+
+	if err := ss.validateScheduleSeries(&updated); err != nil {
+		return err
+	}
+
+	if err := ss.checkSeriesConflicts(&updated, series.ID); err != nil {
+		return err
+	}
+
+	return ss.seriesRepo.UpdateScheduleSeries(&updated)
+}
+
+// detachOccurrence stores the occurrence as its own schedule and excludes its
+// original date from the series
+func (ss *ScheduleService) detachOccurrence(series *models.ScheduleSeries, occurrence *models.Schedule, checkConflicts bool) error {
+	if err := checkAcademicDate(ss.calendarRepo, occurrence.Date); err != nil {
+		return err
+	}
+
+	if occurrence.LessonID != series.LessonID {
+		_, err := ss.lessonRepo.GetLessonByID(occurrence.LessonID)
+		if err != nil {
+			return fmt.Errorf("lesson not found: %w", err)
+		}
+	}
+
+	if occurrence.RoomID != "" && occurrence.RoomID != series.RoomID {
+		if _, err := ss.roomRepo.GetRoomByID(occurrence.RoomID); err != nil {
+			return fmt.Errorf("room not found: %w", err)
+		}
+	}
+
+	if checkConflicts {
+		if err := ss.placeInPeriod(occurrence); err != nil {
+			return err
+		}
+	}
+
+	if err := normalizeScheduleTimes(occurrence, scheduleDuration(seriesOccurrence(*series, occurrence.Date))); err != nil {
+		return err
+	}
+
+	if checkConflicts {
+		if err := ss.checkScheduleConflicts(occurrence); err != nil {
+			return err
+		}
+	}
+
+	series.ExceptionDates = append(series.ExceptionDates, dateOnly(*occurrence.OccurrenceDate))
+	return ss.seriesRepo.DetachOccurrence(series, occurrence)
+}
+
+// schedulesBetween returns every schedule and series occurrence dated within [from, to)
+func (ss *ScheduleService) schedulesBetween(from, to time.Time) ([]models.Schedule, error) {
+	allSchedules, err := ss.scheduleRepo.GetAllSchedules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all schedules: %w", err)
+	}
+
+	allSeries, err := ss.seriesRepo.GetAllScheduleSeries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all schedule series: %w", err)
+	}
+
+	occurrences, err := ss.expandOpenSeries(allSeries, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var schedules []models.Schedule
+	for _, schedule := range append(allSchedules, occurrences...) {
+		scheduleDate := dateOnly(schedule.Date)
+		if !scheduleDate.Before(from) && scheduleDate.Before(to) {
+			schedules = append(schedules, schedule)
+		}
+	}
+
+	sortSchedules(schedules)
+	return schedules, nil
+}
+
+// roomSchedules returns the room's schedules together with the occurrences
+// of the series booked into it dated within [from, to)
+func (ss *ScheduleService) roomSchedules(roomID string, from, to time.Time) ([]models.Schedule, error) {
+	schedules, err := ss.scheduleRepo.GetSchedulesByRoomID(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := ss.seriesRepo.GetScheduleSeriesByRoomID(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := ss.expandOpenSeries(series, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(schedules, occurrences...), nil
+}
+
+// teachingSchedules returns the stored schedules the teacher actually takes:
+// their own lessons not covered by a substitute and the lessons they cover
+func teachingSchedules(scheduleRepo models.ScheduleRepository, teacherID string) ([]models.Schedule, error) {
+	schedules, err := scheduleRepo.GetSchedulesByTeacherID(teacherID)
+	if err != nil {
+		return nil, err
+	}
+
+	substituting, err := scheduleRepo.GetSchedulesBySubstituteTeacherID(teacherID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Lessons covered by a substitute no longer occupy the original teacher
+	var teaching []models.Schedule
+	for _, schedule := range schedules {
+		if schedule.SubstituteTeacherID == "" {
+			teaching = append(teaching, schedule)
+		}
+	}
+	return append(teaching, substituting...), nil
+}
+
+// teacherSchedules returns the teacher's schedules together with the
+// occurrences of their series dated within [from, to)
+func (ss *ScheduleService) teacherSchedules(teacherID string, from, to time.Time) ([]models.Schedule, error) {
+	teaching, err := teachingSchedules(ss.scheduleRepo, teacherID)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := ss.seriesRepo.GetScheduleSeriesByTeacherID(teacherID)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := ss.expandOpenSeries(series, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(teaching, occurrences...), nil
+}
+
+// classSchedules returns the class's schedules together with the
+// occurrences of its series dated within [from, to)
+func (ss *ScheduleService) classSchedules(classID string, from, to time.Time) ([]models.Schedule, error) {
+	schedules, err := ss.scheduleRepo.GetSchedulesByClassID(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	series, err := ss.seriesRepo.GetScheduleSeriesByClassID(classID)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := ss.expandOpenSeries(series, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(schedules, occurrences...), nil
+}
+
+// expandOpenSeries expands the series within [from, to), leaving out the
+// occurrences on days no lesson is held, including closures added after the
+// series was created
+func (ss *ScheduleService) expandOpenSeries(allSeries []models.ScheduleSeries, from, to time.Time) ([]models.Schedule, error) {
+	occurrences := expandAllSeries(allSeries, from, to)
+	if len(occurrences) == 0 {
+		return nil, nil
+	}
+
+	closed, err := academicClosedDates(ss.calendarRepo, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	open := occurrences[:0]
+	for _, occurrence := range occurrences {
+		if !closed[dateOnly(occurrence.Date)] {
+			open = append(open, occurrence)
+		}
+	}
+	return open, nil
+}
+
+func expandAllSeries(allSeries []models.ScheduleSeries, from, to time.Time) []models.Schedule {
+	var occurrences []models.Schedule
+	for _, series := range allSeries {
+		occurrences = append(occurrences, expandSeries(series, from, to)...)
+	}
+	return occurrences
+}
+
+// checkEditableOccurrence makes sure the series has a future occurrence on date
+func checkEditableOccurrence(series models.ScheduleSeries, date time.Time) error {
+	if !isOccurrenceDate(series, date) {
+		return fmt.Errorf("series has no occurrence on %s", date.Format("2006-01-02"))
+	}
+
+	if dateOnly(date).Before(helper.SchoolToday()) {
+		return fmt.Errorf("cannot modify past occurrences")
+	}
+
+	return nil
+}
+
+// truncateSeries ends the series the day before date, handing the exception
+// dates from date on over to the following series
+func truncateSeries(series *models.ScheduleSeries, following *models.ScheduleSeries, date time.Time) {
+	var kept, moved []time.Time
+	for _, exception := range series.ExceptionDates {
+		if exception.Before(dateOnly(date)) {
+			kept = append(kept, exception)
+		} else {
+			moved = append(moved, exception)
+		}
+	}
+
+	until := dateOnly(date).AddDate(0, 0, -1)
+	series.Until = &until
+	series.Count = 0
+	series.ExceptionDates = kept
+
+	if following != nil {
+		following.ExceptionDates = moved
+	}
+}
+
+// applySeriesChanges copies the fields set in changes onto the series. A new
+// start time without an end time keeps the lesson length.
+func applySeriesChanges(series *models.ScheduleSeries, changes *models.ScheduleSeries) error {
+	if changes.TeacherID != "" {
+		series.TeacherID = changes.TeacherID
+	}
+	if changes.LessonID != "" {
+		series.LessonID = changes.LessonID
+	}
+	if changes.ClassID != "" {
+		series.ClassID = changes.ClassID
+	}
+	if changes.RoomID != "" {
+		series.RoomID = changes.RoomID
+	}
+
+	if !changes.Time.IsZero() {
+		duration := scheduleDuration(models.Schedule{Time: series.Time, EndTime: series.EndTime})
+		series.Time = changes.Time
+		series.EndTime = changes.Time.Add(duration)
+	}
+	if !changes.EndTime.IsZero() {
+		series.EndTime = changes.EndTime
+	}
+
+	if len(changes.Weekdays) > 0 {
+		series.Weekdays = changes.Weekdays
+	}
+
+	switch {
+	case changes.RRule != "":
+		if err := ParseRRule(changes.RRule, series); err != nil {
+			return fmt.Errorf("invalid recurrence rule: %w", err)
+		}
+	case changes.Until != nil:
+		series.Until = changes.Until
+		series.Count = 0
+	case changes.Count > 0:
+		series.Count = changes.Count
+		series.Until = nil
+	}
+
+	return nil
+}
+
+// applyOccurrenceChanges copies the fields set in changes onto a single occurrence
+func applyOccurrenceChanges(occurrence *models.Schedule, changes *models.ScheduleSeries) {
+	if !changes.StartDate.IsZero() {
+		occurrence.Date = dateOnly(changes.StartDate)
+	}
+	if changes.TeacherID != "" {
+		occurrence.TeacherID = changes.TeacherID
+	}
+	if changes.LessonID != "" {
+		occurrence.LessonID = changes.LessonID
+	}
+	if changes.ClassID != "" {
+		occurrence.ClassID = changes.ClassID
+	}
+	if changes.RoomID != "" {
+		occurrence.RoomID = changes.RoomID
+	}
+
+	if !changes.Time.IsZero() {
+		duration := scheduleDuration(*occurrence)
+		occurrence.Time = changes.Time
+		occurrence.EndTime = changes.Time.Add(duration)
+	}
+	if !changes.EndTime.IsZero() {
+		occurrence.EndTime = changes.EndTime
+	}
 }
 
 // Helper functions
@@ -375,4 +1196,143 @@ func isSameTime(time1, time2 time.Time) bool {
 	h1, m1, _ := time1.Clock()
 	h2, m2, _ := time2.Clock()
 	return h1 == h2 && m1 == m2
-}
\ No newline at end of file
+}
+
+// defaultLessonDuration is used when a schedule is submitted without an end time
+const defaultLessonDuration = 40 * time.Minute
+
+// checkScheduleConflicts rejects the schedule if its interval overlaps
+// another schedule of the same teacher, class or room
+func (ss *ScheduleService) checkScheduleConflicts(schedule *models.Schedule) error {
+	conflicts, err := ss.GetScheduleConflicts(effectiveTeacherID(*schedule), schedule.ClassID, schedule.RoomID, schedule.Date, schedule.Time, schedule.EndTime)
+	if err != nil {
+		return fmt.Errorf("failed to check conflicts: %w", err)
+	}
+
+	return conflictError(conflicts, *schedule)
+}
+
+// conflictError reports the first conflict that is not the schedule itself
+func conflictError(conflicts []models.ScheduleConflict, schedule models.Schedule) error {
+	for _, conflict := range conflicts {
+		if isSameSchedule(conflict.Schedule, schedule) {
+			continue
+		}
+		return fmt.Errorf("%s already has a schedule overlapping %s-%s on this date",
+			conflict.Reasons[0], conflict.OverlapStart.Format("15:04"), conflict.OverlapEnd.Format("15:04"))
+	}
+
+	return nil
+}
+
+// isSameSchedule reports whether both entries describe the same lesson, either
+// the same stored schedule or the same occurrence of a series
+func isSameSchedule(a, b models.Schedule) bool {
+	if a.ID != "" && a.ID == b.ID {
+		return true
+	}
+
+	if a.SeriesID == "" || a.SeriesID != b.SeriesID || a.OccurrenceDate == nil || b.OccurrenceDate == nil {
+		return false
+	}
+
+	return isSameDay(*a.OccurrenceDate, *b.OccurrenceDate)
+}
+
+// normalizeScheduleTimes fills a missing end time from the given duration and
+// makes sure the interval does not end before it starts
+func normalizeScheduleTimes(schedule *models.Schedule, duration time.Duration) error {
+	if schedule.EndTime.IsZero() {
+		schedule.EndTime = schedule.Time.Add(duration)
+	}
+
+	if clockOffset(schedule.EndTime) <= clockOffset(schedule.Time) {
+		return fmt.Errorf("end time must be after start time on the same day")
+	}
+
+	return nil
+}
+
+// appendOverlaps adds every schedule overlapping the slot to conflicts, merging
+// the reason into an existing entry when the schedule is already reported
+func appendOverlaps(conflicts []models.ScheduleConflict, schedules []models.Schedule, slot models.Schedule, reason string) []models.ScheduleConflict {
+	for _, schedule := range schedules {
+		// Cancelled lessons free their slot
+		if !isSameDay(schedule.Date, slot.Date) || schedule.Status == models.ScheduleCancelled {
+			continue
+		}
+
+		start, end, ok := overlapWindow(schedule, slot)
+		if !ok {
+			continue
+		}
+
+		// Avoid duplicates
+		found := false
+		for i := range conflicts {
+			if scheduleKey(conflicts[i].Schedule) == scheduleKey(schedule) {
+				conflicts[i].Reasons = append(conflicts[i].Reasons, reason)
+				found = true
+				break
+			}
+		}
+		if !found {
+			conflicts = append(conflicts, models.ScheduleConflict{
+				Schedule:     schedule,
+				Reasons:      []string{reason},
+				OverlapStart: atClock(slot.Date, start),
+				OverlapEnd:   atClock(slot.Date, end),
+			})
+		}
+	}
+
+	return conflicts
+}
+
+// overlapWindow returns the part of the day shared by both schedules, if any.
+// Intervals are half-open, so back-to-back lessons do not overlap.
+func overlapWindow(a, b models.Schedule) (time.Duration, time.Duration, bool) {
+	start := max(clockOffset(a.Time), clockOffset(b.Time))
+	end := min(scheduleEnd(a), scheduleEnd(b))
+	return start, end, start < end
+}
+
+// scheduleEnd returns the end of the schedule as an offset from midnight
+func scheduleEnd(schedule models.Schedule) time.Duration {
+	end := clockOffset(schedule.EndTime)
+	if end <= clockOffset(schedule.Time) {
+		return clockOffset(schedule.Time) + defaultLessonDuration
+	}
+	return end
+}
+
+func scheduleDuration(schedule models.Schedule) time.Duration {
+	return scheduleEnd(schedule) - clockOffset(schedule.Time)
+}
+
+func clockOffset(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+}
+
+func atClock(date time.Time, offset time.Duration) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, date.Location()).Add(offset)
+}
+
+// effectiveTeacherID returns the teacher actually giving the lesson
+func effectiveTeacherID(schedule models.Schedule) string {
+	if schedule.SubstituteTeacherID != "" {
+		return schedule.SubstituteTeacherID
+	}
+	return schedule.TeacherID
+}
+
+// scheduleKey identifies stored schedules by ID and expanded series
+// occurrences by their series and date
+func scheduleKey(schedule models.Schedule) string {
+	if schedule.ID != "" {
+		return schedule.ID
+	}
+	return schedule.SeriesID + "@" + schedule.Date.Format("2006-01-02")
+}
@@ -0,0 +1,686 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeScheduleRepo struct {
+	schedules map[string]models.Schedule
+	nextID    int
+}
+
+func newFakeScheduleRepo(schedules ...models.Schedule) *fakeScheduleRepo {
+	repo := &fakeScheduleRepo{schedules: make(map[string]models.Schedule)}
+	for _, schedule := range schedules {
+		repo.schedules[schedule.ID] = schedule
+	}
+	return repo
+}
+
+func (r *fakeScheduleRepo) CreateSchedule(schedule *models.Schedule) error {
+	r.nextID++
+	schedule.ID = fmt.Sprintf("new-%d", r.nextID)
+	r.schedules[schedule.ID] = *schedule
+	return nil
+}
+
+func (r *fakeScheduleRepo) CreateSchedules(schedules []models.Schedule) error {
+	for i := range schedules {
+		if err := r.CreateSchedule(&schedules[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fakeScheduleRepo) GetScheduleByID(id string) (*models.Schedule, error) {
+	schedule, ok := r.schedules[id]
+	if !ok {
+		return nil, fmt.Errorf("schedule %s not found", id)
+	}
+	return &schedule, nil
+}
+
+func (r *fakeScheduleRepo) UpdateSchedule(schedule *models.Schedule) error {
+	r.schedules[schedule.ID] = *schedule
+	return nil
+}
+
+func (r *fakeScheduleRepo) DeleteSchedule(id string) error {
+	delete(r.schedules, id)
+	return nil
+}
+
+func (r *fakeScheduleRepo) GetAllSchedules() ([]models.Schedule, error) {
+	var schedules []models.Schedule
+	for _, schedule := range r.schedules {
+		schedules = append(schedules, schedule)
+	}
+	return schedules, nil
+}
+
+func (r *fakeScheduleRepo) GetSchedulesByTeacherID(teacherID string) ([]models.Schedule, error) {
+	var schedules []models.Schedule
+	for _, schedule := range r.schedules {
+		if schedule.TeacherID == teacherID {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules, nil
+}
+
+func (r *fakeScheduleRepo) GetSchedulesBySubstituteTeacherID(teacherID string) ([]models.Schedule, error) {
+	var schedules []models.Schedule
+	for _, schedule := range r.schedules {
+		if schedule.SubstituteTeacherID == teacherID {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules, nil
+}
+
+func (r *fakeScheduleRepo) GetSchedulesByIDs(ids []string) ([]models.Schedule, error) {
+	var schedules []models.Schedule
+	for _, id := range ids {
+		if schedule, ok := r.schedules[id]; ok {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules, nil
+}
+
+func (r *fakeScheduleRepo) GetSchedulesByClassID(classID string) ([]models.Schedule, error) {
+	var schedules []models.Schedule
+	for _, schedule := range r.schedules {
+		if schedule.ClassID == classID {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules, nil
+}
+
+type fakeSeriesRepo struct {
+	series    map[string]models.ScheduleSeries
+	schedules *fakeScheduleRepo
+	nextID    int
+}
+
+func newFakeSeriesRepo(schedules *fakeScheduleRepo, series ...models.ScheduleSeries) *fakeSeriesRepo {
+	repo := &fakeSeriesRepo{series: make(map[string]models.ScheduleSeries), schedules: schedules}
+	for _, s := range series {
+		repo.series[s.ID] = s
+	}
+	return repo
+}
+
+func (r *fakeSeriesRepo) CreateScheduleSeries(series *models.ScheduleSeries) error {
+	r.nextID++
+	series.ID = fmt.Sprintf("series-%d", r.nextID)
+	r.series[series.ID] = *series
+	return nil
+}
+
+func (r *fakeSeriesRepo) GetScheduleSeriesByID(id string) (*models.ScheduleSeries, error) {
+	series, ok := r.series[id]
+	if !ok {
+		return nil, fmt.Errorf("series %s not found", id)
+	}
+	return &series, nil
+}
+
+func (r *fakeSeriesRepo) UpdateScheduleSeries(series *models.ScheduleSeries) error {
+	r.series[series.ID] = *series
+	return nil
+}
+
+func (r *fakeSeriesRepo) DeleteScheduleSeries(id string) error {
+	delete(r.series, id)
+	return nil
+}
+
+func (r *fakeSeriesRepo) GetAllScheduleSeries() ([]models.ScheduleSeries, error) {
+	var all []models.ScheduleSeries
+	for _, series := range r.series {
+		all = append(all, series)
+	}
+	return all, nil
+}
+
+func (r *fakeSeriesRepo) GetScheduleSeriesByTeacherID(teacherID string) ([]models.ScheduleSeries, error) {
+	var all []models.ScheduleSeries
+	for _, series := range r.series {
+		if series.TeacherID == teacherID {
+			all = append(all, series)
+		}
+	}
+	return all, nil
+}
+
+func (r *fakeSeriesRepo) GetScheduleSeriesByClassID(classID string) ([]models.ScheduleSeries, error) {
+	var all []models.ScheduleSeries
+	for _, series := range r.series {
+		if series.ClassID == classID {
+			all = append(all, series)
+		}
+	}
+	return all, nil
+}
+
+func (r *fakeSeriesRepo) GetScheduleSeriesByRoomID(roomID string) ([]models.ScheduleSeries, error) {
+	var all []models.ScheduleSeries
+	for _, series := range r.series {
+		if series.RoomID == roomID {
+			all = append(all, series)
+		}
+	}
+	return all, nil
+}
+
+func (r *fakeSeriesRepo) DetachOccurrence(series *models.ScheduleSeries, occurrence *models.Schedule) error {
+	r.series[series.ID] = *series
+	return r.schedules.CreateSchedule(occurrence)
+}
+
+func (r *fakeSeriesRepo) MoveSchedules(schedules []models.Schedule, series []models.ScheduleSeries, occurrences []models.Schedule) error {
+	for _, schedule := range schedules {
+		r.schedules.schedules[schedule.ID] = schedule
+	}
+	for _, s := range series {
+		r.series[s.ID] = s
+	}
+	for i := range occurrences {
+		if err := r.schedules.CreateSchedule(&occurrences[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fakeSeriesRepo) SplitScheduleSeries(series *models.ScheduleSeries, following *models.ScheduleSeries) error {
+	r.series[series.ID] = *series
+	return r.CreateScheduleSeries(following)
+}
+
+func (r *fakeScheduleRepo) GetSchedulesByRoomID(roomID string) ([]models.Schedule, error) {
+	var schedules []models.Schedule
+	for _, schedule := range r.schedules {
+		if schedule.RoomID == roomID {
+			schedules = append(schedules, schedule)
+		}
+	}
+	return schedules, nil
+}
+
+type fakeRoomRepo struct{}
+
+func (fakeRoomRepo) CreateRoom(room *models.Room) error { return nil }
+func (fakeRoomRepo) GetRoomByID(id string) (*models.Room, error) {
+	return &models.Room{ID: id, Name: "Room " + id, Capacity: 30}, nil
+}
+func (fakeRoomRepo) UpdateRoom(room *models.Room) error  { return nil }
+func (fakeRoomRepo) DeleteRoom(id string) error          { return nil }
+func (fakeRoomRepo) GetAllRooms() ([]models.Room, error) { return nil, nil }
+
+type fakeLessonRepo struct{}
+
+func (fakeLessonRepo) CreateLesson(lesson *models.Lesson) error { return nil }
+func (fakeLessonRepo) GetLessonByID(id string) (*models.Lesson, error) {
+	return &models.Lesson{ID: id, LessonName: "Lesson " + id}, nil
+}
+func (fakeLessonRepo) UpdateLesson(lesson *models.Lesson) error { return nil }
+func (fakeLessonRepo) DeleteLesson(id string) error             { return nil }
+func (fakeLessonRepo) GetAllLessons() ([]models.Lesson, error)  { return nil, nil }
+
+type fakeAttendanceRepo struct{}
+
+func (fakeAttendanceRepo) CreateAttendance(attendance *models.Attendance, audit *models.AttendanceAudit) error {
+	return nil
+}
+func (fakeAttendanceRepo) GetAttendanceByID(id string) (*models.Attendance, error) {
+	return nil, fmt.Errorf("attendance %s not found", id)
+}
+func (fakeAttendanceRepo) UpdateAttendance(attendance *models.Attendance, audit *models.AttendanceAudit) error {
+	return nil
+}
+func (fakeAttendanceRepo) DeleteAttendance(id string) error { return nil }
+func (fakeAttendanceRepo) GetAttendanceByStudentID(studentID string) ([]models.Attendance, error) {
+	return nil, nil
+}
+func (fakeAttendanceRepo) GetAttendanceByScheduleID(scheduleID string) ([]models.Attendance, error) {
+	return nil, nil
+}
+func (fakeAttendanceRepo) GetAttendanceByClassID(classID string, from, to time.Time) ([]models.Attendance, error) {
+	return nil, nil
+}
+func (fakeAttendanceRepo) SaveAttendances(attendances []models.Attendance, audits map[string]models.AttendanceAudit) error {
+	return nil
+}
+func (fakeAttendanceRepo) GetAttendancePolicy() (*models.AttendancePolicy, error) {
+	return &models.AttendancePolicy{PresentWeight: 1, LateWeight: 1, RemoteWeight: 1, ExcusedWeight: 1}, nil
+}
+func (fakeAttendanceRepo) UpdateAttendancePolicy(policy *models.AttendancePolicy) error { return nil }
+
+type fakeCalendarRepo struct {
+	terms    []models.Term
+	closures []models.Closure
+}
+
+func (r *fakeCalendarRepo) CreateTerm(term *models.Term) error {
+	term.ID = fmt.Sprintf("term-%d", len(r.terms)+1)
+	r.terms = append(r.terms, *term)
+	return nil
+}
+func (r *fakeCalendarRepo) GetTermByID(id string) (*models.Term, error) {
+	for _, term := range r.terms {
+		if term.ID == id {
+			return &term, nil
+		}
+	}
+	return nil, fmt.Errorf("term %s not found", id)
+}
+func (r *fakeCalendarRepo) UpdateTerm(term *models.Term) error { return nil }
+func (r *fakeCalendarRepo) DeleteTerm(id string) error         { return nil }
+func (r *fakeCalendarRepo) GetAllTerms() ([]models.Term, error) {
+	return r.terms, nil
+}
+func (r *fakeCalendarRepo) CreateClosure(closure *models.Closure) error {
+	closure.ID = fmt.Sprintf("closure-%d", len(r.closures)+1)
+	r.closures = append(r.closures, *closure)
+	return nil
+}
+func (r *fakeCalendarRepo) GetClosureByID(id string) (*models.Closure, error) {
+	return nil, fmt.Errorf("closure %s not found", id)
+}
+func (r *fakeCalendarRepo) UpdateClosure(closure *models.Closure) error { return nil }
+func (r *fakeCalendarRepo) DeleteClosure(id string) error               { return nil }
+func (r *fakeCalendarRepo) GetAllClosures() ([]models.Closure, error) {
+	return r.closures, nil
+}
+func (r *fakeCalendarRepo) GetClosuresBetween(from, to time.Time) ([]models.Closure, error) {
+	var closures []models.Closure
+	for _, closure := range r.closures {
+		if !closure.EndDate.Before(from) && !closure.StartDate.After(to) {
+			closures = append(closures, closure)
+		}
+	}
+	return closures, nil
+}
+
+func clock(hour, minute int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func futureDate() time.Time {
+	return time.Now().AddDate(0, 0, 7).Truncate(24 * time.Hour)
+}
+
+func TestGetScheduleConflictsUsesIntervalOverlap(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(models.Schedule{
+		ID: "s1", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", RoomID: "r1",
+		Time: clock(9, 0), EndTime: clock(10, 30),
+	})
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	tests := []struct {
+		name      string
+		teacherID string
+		classID   string
+		roomID    string
+		start     time.Time
+		end       time.Time
+		wantStart time.Time
+		wantEnd   time.Time
+		reasons   int
+	}{
+		{"starts inside", "t1", "", "", clock(9, 45), clock(10, 45), clock(9, 45), clock(10, 30), 1},
+		{"contains existing", "", "c1", "", clock(8, 0), clock(11, 0), clock(9, 0), clock(10, 30), 1},
+		{"teacher and class", "t1", "c1", "", clock(10, 0), time.Time{}, clock(10, 0), clock(10, 30), 2},
+		{"room only", "t2", "c2", "r1", clock(10, 0), clock(10, 40), clock(10, 0), clock(10, 30), 1},
+		{"other room", "t2", "c2", "r2", clock(10, 0), clock(10, 40), time.Time{}, time.Time{}, 0},
+		{"back to back", "t1", "c1", "r1", clock(10, 30), clock(11, 10), time.Time{}, time.Time{}, 0},
+		{"ends at start", "t1", "c1", "r1", clock(8, 20), clock(9, 0), time.Time{}, time.Time{}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conflicts, err := service.GetScheduleConflicts(tt.teacherID, tt.classID, tt.roomID, date, tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.reasons == 0 {
+				if len(conflicts) != 0 {
+					t.Fatalf("expected no conflicts, got %d", len(conflicts))
+				}
+				return
+			}
+
+			if len(conflicts) != 1 {
+				t.Fatalf("expected 1 conflict, got %d", len(conflicts))
+			}
+			conflict := conflicts[0]
+			if len(conflict.Reasons) != tt.reasons {
+				t.Errorf("expected %d reasons, got %v", tt.reasons, conflict.Reasons)
+			}
+			if !isSameTime(conflict.OverlapStart, tt.wantStart) || !isSameTime(conflict.OverlapEnd, tt.wantEnd) {
+				t.Errorf("expected overlap %s-%s, got %s-%s",
+					tt.wantStart.Format("15:04"), tt.wantEnd.Format("15:04"),
+					conflict.OverlapStart.Format("15:04"), conflict.OverlapEnd.Format("15:04"))
+			}
+		})
+	}
+}
+
+func TestCreateScheduleRejectsOverlappingLesson(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(models.Schedule{
+		ID: "s1", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1",
+		Time: clock(9, 0), EndTime: clock(10, 30),
+	})
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	overlapping := &models.Schedule{Date: date, TeacherID: "t1", LessonID: "l2", ClassID: "c2", Time: clock(9, 45)}
+	if err := service.CreateSchedule(overlapping); err == nil {
+		t.Fatal("expected overlapping schedule to be rejected")
+	}
+
+	following := &models.Schedule{Date: date, TeacherID: "t1", LessonID: "l2", ClassID: "c2", Time: clock(10, 30)}
+	if err := service.CreateSchedule(following); err != nil {
+		t.Fatalf("expected back to back schedule to be accepted: %v", err)
+	}
+	if !isSameTime(following.EndTime, clock(11, 10)) {
+		t.Errorf("expected default end time 11:10, got %s", following.EndTime.Format("15:04"))
+	}
+}
+
+func TestRescheduleScheduleKeepsDuration(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "s1", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(10, 30)},
+		models.Schedule{ID: "s2", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c2", Time: clock(12, 0), EndTime: clock(12, 40)},
+	)
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	if err := service.RescheduleSchedule("s1", date, clock(11, 0)); err == nil {
+		t.Fatal("expected reschedule into 11:00-12:30 to conflict with 12:00 lesson")
+	}
+
+	if err := service.RescheduleSchedule("s1", date, clock(13, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	moved := repo.schedules["s1"]
+	if !isSameTime(moved.EndTime, clock(14, 30)) {
+		t.Errorf("expected end time 14:30, got %s", moved.EndTime.Format("15:04"))
+	}
+}
+
+func TestExpandSeriesAppliesCountBeforeExceptions(t *testing.T) {
+	// 2030-01-07 is a Monday
+	start := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	series := models.ScheduleSeries{ID: "series-1", StartDate: start, Time: clock(9, 0), EndTime: clock(9, 40)}
+	if err := ParseRRule("RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4", &series); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	series.ExceptionDates = []time.Time{start.AddDate(0, 0, 2)}
+
+	occurrences := expandSeries(series, start, start.AddDate(0, 1, 0))
+
+	want := []string{"2030-01-07", "2030-01-14", "2030-01-16"}
+	if len(occurrences) != len(want) {
+		t.Fatalf("expected %d occurrences, got %d", len(want), len(occurrences))
+	}
+	for i, occurrence := range occurrences {
+		if got := occurrence.Date.Format("2006-01-02"); got != want[i] {
+			t.Errorf("occurrence %d: expected %s, got %s", i, want[i], got)
+		}
+		if occurrence.SeriesID != "series-1" {
+			t.Errorf("occurrence %d: expected series ID series-1, got %q", i, occurrence.SeriesID)
+		}
+	}
+
+	if rule := FormatRRule(series); rule != "FREQ=WEEKLY;BYDAY=MO,WE;COUNT=4" {
+		t.Errorf("unexpected rule %q", rule)
+	}
+}
+
+func TestUpdateScheduleSeriesFollowingSplitsSeries(t *testing.T) {
+	start := futureDate()
+	series := models.ScheduleSeries{
+		ID: "series-0", TeacherID: "t1", LessonID: "l1", ClassID: "c1",
+		StartDate: start, Time: clock(9, 0), EndTime: clock(9, 40),
+		Weekdays: []time.Weekday{start.Weekday()}, Count: 4,
+	}
+	repo := newFakeScheduleRepo()
+	seriesRepo := newFakeSeriesRepo(repo, series)
+	service := NewScheduleService(repo, seriesRepo, fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	splitDate := start.AddDate(0, 0, 14)
+	changes := &models.ScheduleSeries{Time: clock(11, 0)}
+	if err := service.UpdateScheduleSeries("series-0", models.SeriesScopeFollowing, splitDate, changes); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	original := seriesRepo.series["series-0"]
+	if original.Until == nil || !isSameDay(*original.Until, splitDate.AddDate(0, 0, -1)) || original.Count != 0 {
+		t.Errorf("expected original series to end the day before the split, got until=%v count=%d", original.Until, original.Count)
+	}
+
+	following, ok := seriesRepo.series["series-1"]
+	if !ok {
+		t.Fatal("expected following series to be created")
+	}
+	if following.Count != 2 || !isSameDay(following.StartDate, splitDate) {
+		t.Errorf("expected 2 occurrences from %s, got %d from %s", splitDate.Format("2006-01-02"), following.Count, following.StartDate.Format("2006-01-02"))
+	}
+	if !isSameTime(following.EndTime, clock(11, 40)) {
+		t.Errorf("expected end time 11:40, got %s", following.EndTime.Format("15:04"))
+	}
+}
+
+func TestCreateScheduleSeriesRejectsRoomDoubleBooking(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(models.Schedule{
+		ID: "s1", Date: date.AddDate(0, 0, 7), TeacherID: "t1", LessonID: "l1", ClassID: "c1", RoomID: "lab",
+		Time: clock(9, 0), EndTime: clock(9, 40),
+	})
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	series := &models.ScheduleSeries{
+		TeacherID: "t2", LessonID: "l2", ClassID: "c2", RoomID: "lab",
+		StartDate: date, Time: clock(9, 20), Weekdays: []time.Weekday{date.Weekday()}, Count: 3,
+	}
+	err := service.CreateScheduleSeries(series)
+	if err == nil {
+		t.Fatal("expected series booked into the same room to be rejected")
+	}
+	if !strings.Contains(err.Error(), models.ConflictRoom) {
+		t.Errorf("expected room conflict, got %v", err)
+	}
+
+	series.RoomID = "gym"
+	if err := service.CreateScheduleSeries(series); err != nil {
+		t.Fatalf("expected series in another room to be accepted: %v", err)
+	}
+}
+
+func TestScheduleDatesFollowSchoolTimeZone(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Fatalf("load time zone: %v", err)
+	}
+	helper.SetSchoolLocation(istanbul)
+	defer helper.SetSchoolLocation(nil)
+
+	// 22:30 UTC on the 9th is already 01:30 on the 10th at the school
+	restore := helper.SetNow(func() time.Time { return time.Date(2026, 3, 9, 22, 30, 0, 0, time.UTC) })
+	defer restore()
+
+	yesterday := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "early", Date: today, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(1, 0), EndTime: clock(1, 40)},
+		models.Schedule{ID: "later", Date: today, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+	)
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	err = service.CreateSchedule(&models.Schedule{Date: yesterday, TeacherID: "t1", LessonID: "l1", ClassID: "c2", Time: clock(10, 0), EndTime: clock(10, 40)})
+	if err == nil || !strings.Contains(err.Error(), "past dates") {
+		t.Fatalf("expected the previous school day to count as past, got %v", err)
+	}
+
+	if err := service.CreateSchedule(&models.Schedule{Date: today, TeacherID: "t1", LessonID: "l1", ClassID: "c2", Time: clock(10, 0), EndTime: clock(10, 40)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	upcoming, err := service.GetUpcomingSchedules("t1", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(upcoming) != 2 || upcoming[0].ID != "later" {
+		t.Fatalf("expected the lesson that already started at the school to be skipped, got %+v", upcoming)
+	}
+}
+
+func TestCancelScheduleKeepsHistoryAndFreesSlot(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "math", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40), Status: models.ScheduleScheduled},
+	)
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	if _, err := service.CancelSchedule("math", " ", "admin-1"); err == nil {
+		t.Fatal("expected a cancellation without reason to be rejected")
+	}
+
+	schedule, err := service.CancelSchedule("math", "Teacher training", "admin-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule.Status != models.ScheduleCancelled || schedule.CancelledBy != "admin-1" || schedule.CancelledAt == nil {
+		t.Fatalf("expected the cancellation to be recorded, got %+v", schedule)
+	}
+
+	if _, err := service.CancelSchedule("math", "Again", "admin-1"); err == nil {
+		t.Fatal("expected cancelling twice to be rejected")
+	}
+	if err := service.RescheduleSchedule("math", date, clock(11, 0)); err == nil {
+		t.Fatal("expected rescheduling a cancelled lesson to be rejected")
+	}
+
+	// The slot is free again but the lesson stays in the week view
+	if err := service.CreateSchedule(&models.Schedule{Date: date, TeacherID: "t1", LessonID: "l2", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)}); err != nil {
+		t.Fatalf("expected the cancelled lesson to free its slot, got %v", err)
+	}
+
+	week, err := service.GetWeekSchedules(date)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(week) != 2 {
+		t.Fatalf("expected the cancelled lesson to stay visible, got %+v", week)
+	}
+}
+
+func TestCancelOccurrenceMaterializesIt(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo()
+	seriesRepo := newFakeSeriesRepo(repo, models.ScheduleSeries{
+		ID: "series-1", TeacherID: "t1", LessonID: "l1", ClassID: "c1", StartDate: date,
+		Time: clock(9, 0), EndTime: clock(9, 40), Weekdays: []time.Weekday{date.Weekday()}, Count: 3,
+	})
+	service := NewScheduleService(repo, seriesRepo, fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	if _, err := service.CancelOccurrence("series-1", date.AddDate(0, 0, 1), "Trip", "admin-1"); err == nil {
+		t.Fatal("expected a date without occurrence to be rejected")
+	}
+
+	schedule, err := service.CancelOccurrence("series-1", date.AddDate(0, 0, 7), "Trip", "admin-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule.ID == "" || schedule.SeriesID != "series-1" || schedule.Status != models.ScheduleCancelled {
+		t.Fatalf("expected the occurrence to be stored cancelled, got %+v", schedule)
+	}
+
+	week, err := service.GetWeekSchedules(date.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(week) != 1 || week[0].ID != schedule.ID {
+		t.Fatalf("expected only the cancelled lesson in its week, got %+v", week)
+	}
+}
+
+func TestBulkRescheduleIsAllOrNothing(t *testing.T) {
+	day := futureDate()
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "math", Date: day, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "next", Date: day.AddDate(0, 0, 1), TeacherID: "t1", LessonID: "l1", ClassID: "c3", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "blocker", Date: day.AddDate(0, 0, 2), TeacherID: "t1", LessonID: "l1", ClassID: "c4", Time: clock(9, 20), EndTime: clock(10, 0)},
+	)
+	seriesRepo := newFakeSeriesRepo(repo, models.ScheduleSeries{
+		ID: "series-1", TeacherID: "t2", LessonID: "l2", ClassID: "c2", StartDate: day,
+		Time: clock(10, 0), EndTime: clock(10, 40), Weekdays: []time.Weekday{day.Weekday()}, Count: 3,
+	})
+	service := NewScheduleService(repo, seriesRepo, fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	// Moving the snow day onto the next day: "next" stays there and blocks
+	report, err := service.BulkReschedule(models.BulkRescheduleRequest{FromDate: day, TargetDate: day.AddDate(0, 0, 1)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Applied || report.Total != 2 || report.Conflicting != 1 {
+		t.Fatalf("expected one blocked move and nothing applied, got %+v", report)
+	}
+	if !isSameDay(repo.schedules["math"].Date, day) {
+		t.Fatal("expected no lesson to move when one move is blocked")
+	}
+
+	// Shifting both days: lessons moving away do not block each other, the
+	// lesson staying on the third day does
+	to := day.AddDate(0, 0, 1)
+	report, err = service.BulkReschedule(models.BulkRescheduleRequest{FromDate: day, ToDate: &to, TargetDate: day.AddDate(0, 0, 1), TeacherID: "t1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Applied || report.Conflicting != 1 || report.Moves[1].Schedule.ID != "next" {
+		t.Fatalf("expected only the move onto the blocker to be reported, got %+v", report)
+	}
+
+	target := day.AddDate(0, 0, 3)
+	report, err = service.BulkReschedule(models.BulkRescheduleRequest{FromDate: day, TargetDate: target})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.Applied || report.Conflicting != 0 {
+		t.Fatalf("expected the moves to be applied, got %+v", report)
+	}
+	if !isSameDay(repo.schedules["math"].Date, target) {
+		t.Fatalf("expected the lesson on %s, got %s", target, repo.schedules["math"].Date)
+	}
+
+	series := seriesRepo.series["series-1"]
+	if len(series.ExceptionDates) != 1 || !isSameDay(series.ExceptionDates[0], day) {
+		t.Fatalf("expected the series occurrence to be excluded from the series, got %+v", series.ExceptionDates)
+	}
+	moved, err := service.GetWeekSchedules(target)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	found := false
+	for _, schedule := range moved {
+		if schedule.SeriesID == "series-1" && isSameDay(schedule.Date, target) && isSameDay(*schedule.OccurrenceDate, day) {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected the detached occurrence on the target date, got %+v", moved)
+	}
+}
@@ -0,0 +1,205 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// schoolDayStart and schoolDayEnd bound slot searches without periods
+	schoolDayStart = 8 * time.Hour
+	schoolDayEnd   = 17 * time.Hour
+	// slotStep is how far a slot search moves past an unavailable window
+	slotStep = 5 * time.Minute
+
+	defaultSlotLimit      = 5
+	maxSlotLimit          = 50
+	defaultSlotSearchDays = 14
+	maxSlotSearchDays     = 62
+)
+
+// FindFreeSlots walks the requested date window day by day and returns the
+// earliest slots in which the teacher, the class and the room are all free.
+// Closure days and days outside the terms are skipped and the teacher's
+// availability windows are respected. Slots found on the same day do not
+// overlap each other.
+func (ss *ScheduleService) FindFreeSlots(request models.SlotSearchRequest) ([]models.FreeSlot, error) {
+	if request.TeacherID == "" || request.ClassID == "" {
+		return nil, fmt.Errorf("teacher ID and class ID are required")
+	}
+
+	duration := time.Duration(request.Duration) * time.Minute
+	if request.Duration == 0 {
+		duration = defaultLessonDuration
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("duration must be positive")
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultSlotLimit
+	}
+	if limit < 0 || limit > maxSlotLimit {
+		return nil, fmt.Errorf("limit must be between 1 and %d", maxSlotLimit)
+	}
+
+	today := helper.SchoolToday()
+	from := dateOnly(request.FromDate)
+	if request.FromDate.IsZero() || from.Before(today) {
+		from = today
+	}
+
+	to := from.AddDate(0, 0, defaultSlotSearchDays-1)
+	if !request.ToDate.IsZero() {
+		to = dateOnly(request.ToDate)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("to date must not be before from date")
+	}
+	if to.After(from.AddDate(0, 0, maxSlotSearchDays-1)) {
+		return nil, fmt.Errorf("date window must not exceed %d days", maxSlotSearchDays)
+	}
+
+	// Without periods of its own the search uses the bell schedule's
+	requested := request.Periods
+	if len(requested) == 0 {
+		bellPeriods, err := ss.GetBellPeriods()
+		if err != nil {
+			return nil, err
+		}
+		requested = bellPeriods
+	}
+
+	periods, err := slotPeriods(requested, duration)
+	if err != nil {
+		return nil, err
+	}
+
+	end := to.AddDate(0, 0, 1)
+	teacherSchedules, err := ss.teacherSchedules(request.TeacherID, from, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher schedules: %w", err)
+	}
+
+	classSchedules, err := ss.classSchedules(request.ClassID, from, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get class schedules: %w", err)
+	}
+
+	var roomSchedules []models.Schedule
+	if request.RoomID != "" {
+		roomSchedules, err = ss.roomSchedules(request.RoomID, from, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get room schedules: %w", err)
+		}
+	}
+
+	windows, err := ss.availabilityRepo.GetAvailabilityWindowsByTeacherID(request.TeacherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check teacher availability: %w", err)
+	}
+
+	// blockedUntil returns the end of the latest lesson overlapping the slot
+	blockedUntil := func(slot models.Schedule) (time.Duration, bool) {
+		conflicts := appendOverlaps(nil, teacherSchedules, slot, models.ConflictTeacher)
+		conflicts = appendOverlaps(conflicts, classSchedules, slot, models.ConflictClass)
+		conflicts = appendOverlaps(conflicts, roomSchedules, slot, models.ConflictRoom)
+
+		var until time.Duration
+		for _, conflict := range conflicts {
+			until = max(until, scheduleEnd(conflict.Schedule))
+		}
+		return until, len(conflicts) > 0
+	}
+
+	now := helper.SchoolNow()
+	slots := []models.FreeSlot{}
+	for date := from; !date.After(to) && len(slots) < limit; date = date.AddDate(0, 0, 1) {
+		if checkAcademicDate(ss.calendarRepo, date) != nil {
+			continue
+		}
+
+		// Slots already begun today are not offered
+		earliest := time.Duration(0)
+		if date.Equal(today) {
+			earliest = clockOffset(now)
+		}
+
+		free := func(start time.Duration) (models.Schedule, time.Duration, bool) {
+			slot := models.Schedule{Date: date, Time: atClock(date, start), EndTime: atClock(date, start+duration)}
+			if until, blocked := blockedUntil(slot); blocked {
+				return slot, until, false
+			}
+			if availabilityError(windows, slot) != nil {
+				return slot, start + slotStep, false
+			}
+			return slot, start + duration, true
+		}
+
+		if periods != nil {
+			for _, period := range periods {
+				start := clockOffset(period.Time)
+				if period.Weekday != date.Weekday() || start < earliest {
+					continue
+				}
+				if slot, _, ok := free(start); ok && len(slots) < limit {
+					slots = append(slots, toFreeSlot(slot))
+				}
+			}
+			continue
+		}
+
+		if date.Weekday() == time.Saturday || date.Weekday() == time.Sunday {
+			continue
+		}
+
+		start := max(schoolDayStart, earliest.Truncate(slotStep))
+		if start < earliest {
+			start += slotStep
+		}
+		for start+duration <= schoolDayEnd && len(slots) < limit {
+			slot, next, ok := free(start)
+			if ok {
+				slots = append(slots, toFreeSlot(slot))
+			}
+			start = next
+		}
+	}
+
+	return slots, nil
+}
+
+// slotPeriods keeps the periods long enough for the duration, in weekly order.
+// It returns nil when no periods were given.
+func slotPeriods(periods []models.TimetablePeriod, duration time.Duration) ([]models.TimetablePeriod, error) {
+	if len(periods) == 0 {
+		return nil, nil
+	}
+
+	fitting := []models.TimetablePeriod{}
+	for _, period := range periods {
+		length := clockOffset(period.EndTime) - clockOffset(period.Time)
+		if length <= 0 {
+			return nil, fmt.Errorf("period end time must be after its start time")
+		}
+		if length >= duration {
+			fitting = append(fitting, period)
+		}
+	}
+
+	sort.Slice(fitting, func(i, j int) bool {
+		if fitting[i].Weekday != fitting[j].Weekday {
+			return fitting[i].Weekday < fitting[j].Weekday
+		}
+		return clockOffset(fitting[i].Time) < clockOffset(fitting[j].Time)
+	})
+	return fitting, nil
+}
+
+func toFreeSlot(slot models.Schedule) models.FreeSlot {
+	return models.FreeSlot{Date: slot.Date, Time: slot.Time, EndTime: slot.EndTime}
+}
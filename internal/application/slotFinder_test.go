@@ -0,0 +1,51 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"testing"
+	"time"
+)
+
+func TestFindFreeSlotsSkipsBusyAndUnavailableTimes(t *testing.T) {
+	monday := nextWeekday(futureDate(), time.Monday)
+	weekday := time.Monday
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "teacher-busy", Date: monday, TeacherID: "t1", LessonID: "l1", ClassID: "c2", Time: clock(8, 0), EndTime: clock(8, 40)},
+		models.Schedule{ID: "class-busy", Date: monday, TeacherID: "t2", LessonID: "l2", ClassID: "c1", Time: clock(9, 0), EndTime: clock(10, 0)},
+	)
+	availabilityRepo := &fakeAvailabilityRepo{windows: []models.AvailabilityWindow{
+		{ID: "w1", TeacherID: "t1", Kind: models.AvailabilityUnavailable, Weekday: &weekday, Time: clock(10, 40), EndTime: clock(11, 30)},
+	}}
+	calendarRepo := &fakeCalendarRepo{closures: []models.Closure{
+		{ID: "snow", Name: "Snow day", Kind: models.ClosureSchool, StartDate: monday.AddDate(0, 0, 1), EndDate: monday.AddDate(0, 0, 1)},
+	}}
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, calendarRepo, availabilityRepo, &fakeWorkloadRepo{}, &fakeBellRepo{})
+
+	slots, err := service.FindFreeSlots(models.SlotSearchRequest{TeacherID: "t1", ClassID: "c1", FromDate: monday, ToDate: monday, Limit: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"10:00-10:40", "11:30-12:10", "12:10-12:50"}
+	if len(slots) != len(want) {
+		t.Fatalf("expected %d slots, got %+v", len(want), slots)
+	}
+	for i, slot := range slots {
+		if got := slot.Time.Format("15:04") + "-" + slot.EndTime.Format("15:04"); got != want[i] {
+			t.Fatalf("slot %d: expected %s, got %s", i, want[i], got)
+		}
+	}
+
+	// With periods only the period starts are offered and the closure is skipped
+	periods := []models.TimetablePeriod{
+		{Weekday: time.Monday, Time: clock(9, 0), EndTime: clock(9, 40)},
+		{Weekday: time.Tuesday, Time: clock(9, 0), EndTime: clock(9, 40)},
+		{Weekday: time.Wednesday, Time: clock(9, 0), EndTime: clock(9, 40)},
+	}
+	slots, err = service.FindFreeSlots(models.SlotSearchRequest{TeacherID: "t1", ClassID: "c1", FromDate: monday, Periods: periods, Limit: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(slots) != 1 || !isSameDay(slots[0].Date, monday.AddDate(0, 0, 2)) {
+		t.Fatalf("expected the Wednesday period, got %+v", slots)
+	}
+}
@@ -0,0 +1,142 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxStudentTimetableDays bounds the date range of a personal timetable
+const maxStudentTimetableDays = 62
+
+type StudentTimetableService struct {
+	scheduleService models.ScheduleService
+	classService    models.ClassService
+	lessonRepo      models.LessonRepository
+	attendanceRepo  models.AttendanceRepository
+	userService     models.KeycloakService
+}
+
+func NewStudentTimetableService(scheduleService models.ScheduleService, classService models.ClassService, lessonRepo models.LessonRepository, attendanceRepo models.AttendanceRepository, userService models.KeycloakService) models.StudentTimetableService {
+	return &StudentTimetableService{
+		scheduleService: scheduleService,
+		classService:    classService,
+		lessonRepo:      lessonRepo,
+		attendanceRepo:  attendanceRepo,
+		userService:     userService,
+	}
+}
+
+// GetStudentTimetable resolves the student's classes from their group
+// membership and lists the lessons of those classes, cancelled ones included,
+// with lesson and teacher names and the student's attendance.
+func (sts *StudentTimetableService) GetStudentTimetable(studentID string, from, to time.Time) (*models.StudentTimetable, error) {
+	if studentID == "" {
+		return nil, fmt.Errorf("student ID is required")
+	}
+
+	if from.IsZero() {
+		from = weekStart(helper.SchoolToday())
+	}
+	if to.IsZero() {
+		to = from.AddDate(0, 0, 6)
+	}
+
+	from, to = dateOnly(from), dateOnly(to)
+	if to.Before(from) {
+		return nil, fmt.Errorf("to date must not be before from date")
+	}
+	if to.After(from.AddDate(0, 0, maxStudentTimetableDays-1)) {
+		return nil, fmt.Errorf("date range must not exceed %d days", maxStudentTimetableDays)
+	}
+
+	classes, err := sts.classService.GetClassesByStudentID(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get student classes: %w", err)
+	}
+
+	timetable := &models.StudentTimetable{
+		StudentID: studentID,
+		From:      from,
+		To:        to,
+		Classes:   classes,
+		Entries:   []models.TimetableEntry{},
+	}
+	if timetable.Classes == nil {
+		timetable.Classes = []models.Class{}
+	}
+	if len(classes) == 0 {
+		return timetable, nil
+	}
+
+	classNames := make(map[string]string, len(classes))
+	for _, class := range classes {
+		classNames[class.ID] = class.ClassName
+	}
+
+	schedules, err := sts.scheduleService.GetSchedulesBetween(from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedules: %w", err)
+	}
+
+	lessons, err := sts.lessonRepo.GetAllLessons()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lessons: %w", err)
+	}
+	lessonNames := make(map[string]string, len(lessons))
+	for _, lesson := range lessons {
+		lessonNames[lesson.ID] = lesson.LessonName
+	}
+
+	attendances, err := sts.attendanceRepo.GetAttendanceByStudentID(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance: %w", err)
+	}
+	attendance := make(map[string]string, len(attendances))
+	for _, record := range attendances {
+		attendance[record.ScheduleID] = record.Status
+	}
+
+	sortSchedules(schedules)
+	teacherNames := map[string]string{}
+	for _, schedule := range schedules {
+		className, ok := classNames[schedule.ClassID]
+		if !ok {
+			continue
+		}
+
+		entry := models.TimetableEntry{
+			Schedule:    schedule,
+			LessonName:  lessonNames[schedule.LessonID],
+			ClassName:   className,
+			TeacherName: sts.teacherName(teacherNames, schedule.TeacherID),
+		}
+		if schedule.SubstituteTeacherID != "" {
+			entry.SubstituteTeacherName = sts.teacherName(teacherNames, schedule.SubstituteTeacherID)
+		}
+		if schedule.ID != "" {
+			entry.Attendance = attendance[schedule.ID]
+		}
+		timetable.Entries = append(timetable.Entries, entry)
+	}
+
+	return timetable, nil
+}
+
+// teacherName looks each teacher up once; teachers no longer in Keycloak are
+// left without a name
+func (sts *StudentTimetableService) teacherName(names map[string]string, teacherID string) string {
+	if name, ok := names[teacherID]; ok {
+		return name
+	}
+
+	user, err := sts.userService.GetUserByID(teacherID)
+	if err == nil {
+		names[teacherID] = strings.TrimSpace(user.FirstName + " " + user.LastName)
+	} else {
+		names[teacherID] = ""
+	}
+	return names[teacherID]
+}
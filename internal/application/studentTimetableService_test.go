@@ -0,0 +1,49 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"testing"
+	"time"
+)
+
+type studentClassService struct{ models.ClassService }
+
+func (studentClassService) GetClassesByStudentID(studentID string) ([]models.Class, error) {
+	return []models.Class{{ID: "c1", ClassName: "5A"}}, nil
+}
+
+func TestStudentTimetableListsOwnClassLessons(t *testing.T) {
+	monday := nextWeekday(futureDate(), time.Monday)
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "maths", Date: monday, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(8, 0), EndTime: clock(8, 40)},
+		models.Schedule{ID: "art", Date: monday, TeacherID: "t1", SubstituteTeacherID: "t2", LessonID: "l2", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "other-class", Date: monday, TeacherID: "t3", LessonID: "l1", ClassID: "c2", Time: clock(8, 0), EndTime: clock(8, 40)},
+		models.Schedule{ID: "next-week", Date: monday.AddDate(0, 0, 7), TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(8, 0), EndTime: clock(8, 40)},
+	)
+	attendanceRepo := &memoryAttendanceRepo{attendances: []models.Attendance{
+		{ID: "a1", StudentID: "s1", ScheduleID: "maths", Status: models.AttendancePresent},
+		{ID: "a2", StudentID: "s1", ScheduleID: "art", Status: models.AttendanceAbsent},
+	}}
+	scheduleService := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+	service := NewStudentTimetableService(scheduleService, studentClassService{}, importLessonRepo{}, attendanceRepo, substitutionUserService{})
+
+	timetable, err := service.GetStudentTimetable("s1", monday, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !timetable.To.Equal(monday.AddDate(0, 0, 6)) {
+		t.Fatalf("expected the range to default to one week, got %s", timetable.To)
+	}
+	if len(timetable.Entries) != 2 {
+		t.Fatalf("expected the two lessons of class 5A that week, got %+v", timetable.Entries)
+	}
+
+	maths, art := timetable.Entries[0], timetable.Entries[1]
+	if maths.ID != "maths" || maths.LessonName != "Mathematics" || maths.ClassName != "5A" || maths.TeacherName != "Ada Absent" || maths.Attendance != models.AttendancePresent {
+		t.Fatalf("unexpected maths entry %+v", maths)
+	}
+	if art.SubstituteTeacherName == "" || art.Attendance != models.AttendanceAbsent {
+		t.Fatalf("unexpected art entry %+v", art)
+	}
+}
@@ -0,0 +1,334 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+type SubstitutionService struct {
+	scheduleService models.ScheduleService
+	scheduleRepo    models.ScheduleRepository
+	seriesRepo      models.ScheduleSeriesRepository
+	absenceRepo     models.TeacherAbsenceRepository
+	userService     models.KeycloakService
+}
+
+func NewSubstitutionService(scheduleService models.ScheduleService, scheduleRepo models.ScheduleRepository, seriesRepo models.ScheduleSeriesRepository, absenceRepo models.TeacherAbsenceRepository, userService models.KeycloakService) models.SubstitutionService {
+	return &SubstitutionService{
+		scheduleService: scheduleService,
+		scheduleRepo:    scheduleRepo,
+		seriesRepo:      seriesRepo,
+		absenceRepo:     absenceRepo,
+		userService:     userService,
+	}
+}
+
+func (ss *SubstitutionService) MarkTeacherAbsent(absence *models.TeacherAbsence) error {
+	if absence.TeacherID == "" {
+		return fmt.Errorf("teacher ID is required")
+	}
+
+	if err := ss.checkTeacher(absence.TeacherID); err != nil {
+		return err
+	}
+
+	if absence.StartDate.IsZero() {
+		return fmt.Errorf("absence start date is required")
+	}
+
+	// A single day absence only needs the start date
+	if absence.EndDate.IsZero() {
+		absence.EndDate = absence.StartDate
+	}
+
+	absence.StartDate = dateOnly(absence.StartDate)
+	absence.EndDate = dateOnly(absence.EndDate)
+	if absence.EndDate.Before(absence.StartDate) {
+		return fmt.Errorf("absence end date must not be before its start date")
+	}
+
+	absence.Reason = strings.TrimSpace(absence.Reason)
+	return ss.absenceRepo.CreateTeacherAbsence(absence)
+}
+
+func (ss *SubstitutionService) GetTeacherAbsences(teacherID string) ([]models.TeacherAbsence, error) {
+	if teacherID == "" {
+		return nil, fmt.Errorf("teacher ID is required")
+	}
+
+	return ss.absenceRepo.GetTeacherAbsencesByTeacherID(teacherID)
+}
+
+func (ss *SubstitutionService) DeleteTeacherAbsence(id string) error {
+	if id == "" {
+		return fmt.Errorf("absence ID is required")
+	}
+
+	// Validate absence exists
+	if _, err := ss.absenceRepo.GetTeacherAbsenceByID(id); err != nil {
+		return fmt.Errorf("absence not found: %w", err)
+	}
+
+	return ss.absenceRepo.DeleteTeacherAbsence(id)
+}
+
+func (ss *SubstitutionService) GetAffectedSchedules(absenceID string) ([]models.Schedule, error) {
+	absence, err := ss.absenceRepo.GetTeacherAbsenceByID(absenceID)
+	if err != nil {
+		return nil, fmt.Errorf("absence not found: %w", err)
+	}
+
+	from := dateOnly(absence.StartDate)
+	to := dateOnly(absence.EndDate).AddDate(0, 0, 1)
+
+	schedules, err := ss.scheduleRepo.GetSchedulesByTeacherID(absence.TeacherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher schedules: %w", err)
+	}
+
+	series, err := ss.seriesRepo.GetScheduleSeriesByTeacherID(absence.TeacherID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher schedule series: %w", err)
+	}
+
+	affected := []models.Schedule{}
+	for _, schedule := range append(schedules, expandAllSeries(series, from, to)...) {
+		date := dateOnly(schedule.Date)
+		if !date.Before(from) && date.Before(to) && schedule.Status != models.ScheduleCancelled {
+			affected = append(affected, schedule)
+		}
+	}
+
+	sortSchedules(affected)
+	return affected, nil
+}
+
+func (ss *SubstitutionService) ProposeSubstitutes(request models.SubstitutionRequest) ([]models.SubstituteCandidate, error) {
+	lesson, err := ss.resolveLesson(request)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err := ss.userService.GetAllUsers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teachers: %w", err)
+	}
+
+	absent, err := ss.absentTeachers(lesson.Date)
+	if err != nil {
+		return nil, err
+	}
+
+	allSchedules, err := ss.scheduleRepo.GetAllSchedules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all schedules: %w", err)
+	}
+
+	allSeries, err := ss.seriesRepo.GetAllScheduleSeries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all schedule series: %w", err)
+	}
+
+	// Teachers who already teach the lesson are preferred
+	qualified := map[string]bool{}
+	for _, schedule := range allSchedules {
+		if schedule.LessonID == lesson.LessonID {
+			qualified[schedule.TeacherID] = true
+		}
+	}
+	for _, series := range allSeries {
+		if series.LessonID == lesson.LessonID {
+			qualified[series.TeacherID] = true
+		}
+	}
+
+	day := dateOnly(lesson.Date)
+	busy := map[string][]models.Schedule{}
+	for _, schedule := range append(allSchedules, expandAllSeries(allSeries, day, day.AddDate(0, 0, 1))...) {
+		if isSameDay(schedule.Date, day) {
+			teacherID := effectiveTeacherID(schedule)
+			busy[teacherID] = append(busy[teacherID], schedule)
+		}
+	}
+
+	candidates := []models.SubstituteCandidate{}
+	for _, user := range users {
+		if user.Role != "teacher" || user.ID == lesson.TeacherID || absent[user.ID] {
+			continue
+		}
+
+		if len(appendOverlaps(nil, busy[user.ID], *lesson, models.ConflictTeacher)) > 0 {
+			continue
+		}
+
+		if ss.scheduleService.CheckTeacherAvailability(user.ID, *lesson) != nil {
+			continue
+		}
+
+		candidates = append(candidates, models.SubstituteCandidate{
+			TeacherID:      user.ID,
+			Name:           strings.TrimSpace(user.FirstName + " " + user.LastName),
+			Qualified:      qualified[user.ID],
+			LessonsThatDay: len(busy[user.ID]),
+		})
+	}
+
+	// Qualified teachers first, then the least loaded
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Qualified != candidates[j].Qualified {
+			return candidates[i].Qualified
+		}
+		if candidates[i].LessonsThatDay != candidates[j].LessonsThatDay {
+			return candidates[i].LessonsThatDay < candidates[j].LessonsThatDay
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	return candidates, nil
+}
+
+func (ss *SubstitutionService) AssignSubstitute(request models.SubstitutionRequest) (*models.Schedule, error) {
+	if request.SubstituteTeacherID == "" {
+		return nil, fmt.Errorf("substitute teacher ID is required")
+	}
+
+	lesson, err := ss.resolveLesson(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if dateOnly(lesson.Date).Before(helper.SchoolToday()) {
+		return nil, fmt.Errorf("cannot assign a substitute to a past lesson")
+	}
+
+	if request.SubstituteTeacherID == lesson.TeacherID {
+		return nil, fmt.Errorf("substitute must differ from the original teacher")
+	}
+
+	if err := ss.checkTeacher(request.SubstituteTeacherID); err != nil {
+		return nil, err
+	}
+
+	absent, err := ss.absentTeachers(lesson.Date)
+	if err != nil {
+		return nil, err
+	}
+	if absent[request.SubstituteTeacherID] {
+		return nil, fmt.Errorf("substitute is absent on %s", lesson.Date.Format("2006-01-02"))
+	}
+
+	if err := ss.scheduleService.CheckTeacherAvailability(request.SubstituteTeacherID, *lesson); err != nil {
+		return nil, err
+	}
+
+	conflicts, err := ss.scheduleService.GetScheduleConflicts(request.SubstituteTeacherID, "", "", lesson.Date, lesson.Time, lesson.EndTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check conflicts: %w", err)
+	}
+	if err := conflictError(conflicts, *lesson); err != nil {
+		return nil, err
+	}
+
+	// A series occurrence is stored on its own so the substitute can be recorded
+	if lesson.ID == "" {
+		lesson, err = ss.scheduleService.MaterializeOccurrence(lesson.SeriesID, *lesson.OccurrenceDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	lesson.SubstituteTeacherID = request.SubstituteTeacherID
+	if err := ss.scheduleRepo.UpdateSchedule(lesson); err != nil {
+		return nil, err
+	}
+
+	return lesson, nil
+}
+
+func (ss *SubstitutionService) RemoveSubstitute(scheduleID string) error {
+	if scheduleID == "" {
+		return fmt.Errorf("schedule ID is required")
+	}
+
+	schedule, err := ss.scheduleRepo.GetScheduleByID(scheduleID)
+	if err != nil {
+		return fmt.Errorf("schedule not found: %w", err)
+	}
+
+	if schedule.SubstituteTeacherID == "" {
+		return fmt.Errorf("schedule has no substitute")
+	}
+
+	// The original teacher must be free again to take the lesson back
+	schedule.SubstituteTeacherID = ""
+	conflicts, err := ss.scheduleService.GetScheduleConflicts(schedule.TeacherID, "", "", schedule.Date, schedule.Time, schedule.EndTime)
+	if err != nil {
+		return fmt.Errorf("failed to check conflicts: %w", err)
+	}
+	if err := conflictError(conflicts, *schedule); err != nil {
+		return err
+	}
+
+	return ss.scheduleRepo.UpdateSchedule(schedule)
+}
+
+// resolveLesson finds the schedule or series occurrence the request refers to
+func (ss *SubstitutionService) resolveLesson(request models.SubstitutionRequest) (*models.Schedule, error) {
+	if request.ScheduleID != "" {
+		schedule, err := ss.scheduleRepo.GetScheduleByID(request.ScheduleID)
+		if err != nil {
+			return nil, fmt.Errorf("schedule not found: %w", err)
+		}
+		if schedule.Status == models.ScheduleCancelled {
+			return nil, fmt.Errorf("schedule is cancelled")
+		}
+		return schedule, nil
+	}
+
+	if request.SeriesID == "" || request.OccurrenceDate.IsZero() {
+		return nil, fmt.Errorf("schedule ID or series ID with occurrence date is required")
+	}
+
+	series, err := ss.seriesRepo.GetScheduleSeriesByID(request.SeriesID)
+	if err != nil {
+		return nil, fmt.Errorf("schedule series not found: %w", err)
+	}
+
+	if !isOccurrenceDate(*series, request.OccurrenceDate) {
+		return nil, fmt.Errorf("series has no occurrence on %s", request.OccurrenceDate.Format("2006-01-02"))
+	}
+
+	occurrence := seriesOccurrence(*series, dateOnly(request.OccurrenceDate))
+	return &occurrence, nil
+}
+
+// absentTeachers returns the teachers marked absent on date
+func (ss *SubstitutionService) absentTeachers(date time.Time) (map[string]bool, error) {
+	absences, err := ss.absenceRepo.GetTeacherAbsencesBetween(dateOnly(date), dateOnly(date))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher absences: %w", err)
+	}
+
+	absent := map[string]bool{}
+	for _, absence := range absences {
+		absent[absence.TeacherID] = true
+	}
+	return absent, nil
+}
+
+func (ss *SubstitutionService) checkTeacher(teacherID string) error {
+	user, err := ss.userService.GetUserByID(teacherID)
+	if err != nil {
+		return fmt.Errorf("teacher not found: %w", err)
+	}
+
+	if user.Role != "teacher" {
+		return fmt.Errorf("user %s is not a teacher", teacherID)
+	}
+
+	return nil
+}
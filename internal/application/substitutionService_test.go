@@ -0,0 +1,154 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type fakeAbsenceRepo struct {
+	absences []models.TeacherAbsence
+}
+
+func (r *fakeAbsenceRepo) CreateTeacherAbsence(absence *models.TeacherAbsence) error {
+	absence.ID = fmt.Sprintf("absence-%d", len(r.absences)+1)
+	r.absences = append(r.absences, *absence)
+	return nil
+}
+
+func (r *fakeAbsenceRepo) GetTeacherAbsenceByID(id string) (*models.TeacherAbsence, error) {
+	for _, absence := range r.absences {
+		if absence.ID == id {
+			return &absence, nil
+		}
+	}
+	return nil, fmt.Errorf("absence %s not found", id)
+}
+
+func (r *fakeAbsenceRepo) DeleteTeacherAbsence(id string) error {
+	return nil
+}
+
+func (r *fakeAbsenceRepo) GetTeacherAbsencesByTeacherID(teacherID string) ([]models.TeacherAbsence, error) {
+	return nil, nil
+}
+
+func (r *fakeAbsenceRepo) GetTeacherAbsencesBetween(from, to time.Time) ([]models.TeacherAbsence, error) {
+	var absences []models.TeacherAbsence
+	for _, absence := range r.absences {
+		if !absence.StartDate.After(to) && !absence.EndDate.Before(from) {
+			absences = append(absences, absence)
+		}
+	}
+	return absences, nil
+}
+
+type substitutionUserService struct{ models.KeycloakService }
+
+var substitutionUsers = []models.User{
+	{ID: "t1", FirstName: "Ada", LastName: "Absent", Role: "teacher"},
+	{ID: "t2", FirstName: "Bora", LastName: "Busy", Role: "teacher"},
+	{ID: "t3", FirstName: "Cem", LastName: "Free", Role: "teacher"},
+	{ID: "t4", FirstName: "Deniz", LastName: "Math", Role: "teacher"},
+	{ID: "s1", FirstName: "Ela", LastName: "Student", Role: "student"},
+}
+
+func (substitutionUserService) GetAllUsers() ([]models.User, error) {
+	return substitutionUsers, nil
+}
+
+func (substitutionUserService) GetUserByID(id string) (models.User, error) {
+	for _, user := range substitutionUsers {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return models.User{}, fmt.Errorf("user %s not found", id)
+}
+
+func TestSubstitutionKeepsOriginalTeacher(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "math", Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		models.Schedule{ID: "busy", Date: date, TeacherID: "t2", LessonID: "l2", ClassID: "c2", Time: clock(9, 20), EndTime: clock(10, 0)},
+		models.Schedule{ID: "other", Date: date.AddDate(0, 0, 1), TeacherID: "t4", LessonID: "l1", ClassID: "c3", Time: clock(9, 0), EndTime: clock(9, 40)},
+	)
+	seriesRepo := newFakeSeriesRepo(repo)
+	scheduleService := NewScheduleService(repo, seriesRepo, fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+	service := NewSubstitutionService(scheduleService, repo, seriesRepo, &fakeAbsenceRepo{}, substitutionUserService{})
+
+	absence := &models.TeacherAbsence{TeacherID: "t1", StartDate: date}
+	if err := service.MarkTeacherAbsent(absence); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	affected, err := service.GetAffectedSchedules(absence.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(affected) != 1 || affected[0].ID != "math" {
+		t.Fatalf("expected only the absent teacher's lesson to be affected, got %+v", affected)
+	}
+
+	candidates, err := service.ProposeSubstitutes(models.SubstitutionRequest{ScheduleID: "math"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(candidates) != 2 || candidates[0].TeacherID != "t4" || !candidates[0].Qualified || candidates[1].TeacherID != "t3" {
+		t.Fatalf("expected the qualified free teacher before the other free teacher, got %+v", candidates)
+	}
+
+	if _, err := service.AssignSubstitute(models.SubstitutionRequest{ScheduleID: "math", SubstituteTeacherID: "t2"}); err == nil {
+		t.Fatal("expected a busy substitute to be rejected")
+	}
+
+	schedule, err := service.AssignSubstitute(models.SubstitutionRequest{ScheduleID: "math", SubstituteTeacherID: "t4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if schedule.TeacherID != "t1" || repo.schedules["math"].SubstituteTeacherID != "t4" {
+		t.Fatalf("expected the substitute to be stored next to the original teacher, got %+v", repo.schedules["math"])
+	}
+
+	// The substitute is now busy, the original teacher is free again
+	conflicts, err := scheduleService.GetScheduleConflicts("t4", "", "", date, clock(9, 10), clock(9, 30))
+	if err != nil || len(conflicts) != 1 {
+		t.Fatalf("expected the substitute to be busy during the lesson, got %v %+v", err, conflicts)
+	}
+	conflicts, err = scheduleService.GetScheduleConflicts("t1", "", "", date, clock(9, 10), clock(9, 30))
+	if err != nil || len(conflicts) != 0 {
+		t.Fatalf("expected the original teacher to be free, got %v %+v", err, conflicts)
+	}
+
+	// The lesson moves from the original teacher's schedule to the substitute's
+	if mine, _ := scheduleService.GetSchedulesByTeacherID("t1"); len(mine) != 0 {
+		t.Fatalf("expected the covered lesson to leave the original teacher's schedule, got %+v", mine)
+	}
+	if covering, _ := scheduleService.GetSchedulesByTeacherID("t4"); len(covering) != 2 {
+		t.Fatalf("expected the substitute to see their own and the covered lesson, got %+v", covering)
+	}
+}
+
+func TestAssignSubstituteMaterializesSeriesOccurrence(t *testing.T) {
+	date := futureDate()
+	repo := newFakeScheduleRepo()
+	seriesRepo := newFakeSeriesRepo(repo, models.ScheduleSeries{
+		ID: "series-1", TeacherID: "t1", LessonID: "l1", ClassID: "c1", StartDate: date,
+		Time: clock(10, 0), EndTime: clock(10, 40), Weekdays: []time.Weekday{date.Weekday()},
+	})
+	scheduleService := NewScheduleService(repo, seriesRepo, fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+	service := NewSubstitutionService(scheduleService, repo, seriesRepo, &fakeAbsenceRepo{}, substitutionUserService{})
+
+	schedule, err := service.AssignSubstitute(models.SubstitutionRequest{
+		SeriesID: "series-1", OccurrenceDate: date.AddDate(0, 0, 7), SubstituteTeacherID: "t3",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	stored := repo.schedules[schedule.ID]
+	if stored.SeriesID != "series-1" || stored.TeacherID != "t1" || stored.SubstituteTeacherID != "t3" {
+		t.Fatalf("expected the occurrence to be stored with its substitute, got %+v", stored)
+	}
+}
@@ -0,0 +1,640 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// maxTimetableWeeks bounds how many weeks a generated pattern is repeated for
+	maxTimetableWeeks = 20
+	// maxSolverSteps stops the search on requirement sets with no solution
+	// that cannot be ruled out quickly
+	maxSolverSteps = 200000
+)
+
+type TimetableService struct {
+	scheduleService  models.ScheduleService
+	scheduleRepo     models.ScheduleRepository
+	lessonRepo       models.LessonRepository
+	roomRepo         models.RoomRepository
+	availabilityRepo models.AvailabilityRepository
+}
+
+func NewTimetableService(scheduleService models.ScheduleService, scheduleRepo models.ScheduleRepository, lessonRepo models.LessonRepository, roomRepo models.RoomRepository, availabilityRepo models.AvailabilityRepository) models.TimetableService {
+	return &TimetableService{
+		scheduleService:  scheduleService,
+		scheduleRepo:     scheduleRepo,
+		lessonRepo:       lessonRepo,
+		roomRepo:         roomRepo,
+		availabilityRepo: availabilityRepo,
+	}
+}
+
+// GenerateTimetable places every required lesson hour into one of the
+// available periods so that no teacher, class or room is booked twice, the
+// teachers' unavailable windows are respected and existing schedules in the
+// covered weeks are left untouched. The weekly pattern is repeated for
+// request.Weeks weeks. Nothing is stored; see CommitTimetable.
+func (ts *TimetableService) GenerateTimetable(request *models.TimetableRequest) (*models.TimetablePreview, error) {
+	if err := ts.validateTimetableRequest(request); err != nil {
+		return nil, err
+	}
+
+	existing, err := ts.existingSchedules(request.WeekStart, request.Weeks)
+	if err != nil {
+		return nil, err
+	}
+
+	availability, err := ts.teacherAvailability(request)
+	if err != nil {
+		return nil, err
+	}
+
+	solver := newTimetableSolver(request, existing, availability)
+	if !solver.solve() {
+		if solver.steps >= maxSolverSteps {
+			return nil, fmt.Errorf("no timetable found within the search limit, try adding periods or relaxing requirements")
+		}
+		return nil, fmt.Errorf("no conflict-free timetable exists for the given requirements and periods")
+	}
+
+	preview := &models.TimetablePreview{
+		WeekStart: request.WeekStart,
+		Weeks:     request.Weeks,
+	}
+
+	// The weekly pattern skips holidays, closure days, days outside terms and
+	// one-off unavailability of the teachers; the skipped lessons are reported
+	// so the hours can be made up
+	for _, schedule := range solver.schedules() {
+		err := ts.scheduleService.CheckScheduleDate(schedule.Date)
+		if err == nil {
+			err = availabilityError(availability[schedule.TeacherID], schedule)
+		}
+		if err != nil {
+			preview.Dropped = append(preview.Dropped, models.DroppedLesson{Schedule: schedule, Reason: err.Error()})
+			continue
+		}
+		preview.Schedules = append(preview.Schedules, schedule)
+	}
+
+	preview.UnmetHours = unmetRequirements(request, preview.Dropped)
+	return preview, nil
+}
+
+// unmetRequirements totals the dropped lessons per class, lesson and teacher
+func unmetRequirements(request *models.TimetableRequest, dropped []models.DroppedLesson) []models.UnmetRequirement {
+	if len(dropped) == 0 {
+		return nil
+	}
+
+	key := func(classID, lessonID, teacherID string) string {
+		return classID + "|" + lessonID + "|" + teacherID
+	}
+
+	missing := make(map[string]int)
+	for _, lesson := range dropped {
+		missing[key(lesson.ClassID, lesson.LessonID, lesson.TeacherID)]++
+	}
+
+	var unmet []models.UnmetRequirement
+	index := make(map[string]int)
+	for _, requirement := range request.Requirements {
+		k := key(requirement.ClassID, requirement.LessonID, requirement.TeacherID)
+		if missing[k] == 0 {
+			continue
+		}
+
+		hours := requirement.HoursPerWeek * request.Weeks
+		if i, ok := index[k]; ok {
+			unmet[i].RequiredHours += hours
+			unmet[i].ScheduledHours += hours
+			continue
+		}
+
+		index[k] = len(unmet)
+		unmet = append(unmet, models.UnmetRequirement{
+			ClassID:        requirement.ClassID,
+			LessonID:       requirement.LessonID,
+			TeacherID:      requirement.TeacherID,
+			RequiredHours:  hours,
+			ScheduledHours: hours,
+		})
+	}
+
+	for i := range unmet {
+		unmet[i].ScheduledHours -= missing[key(unmet[i].ClassID, unmet[i].LessonID, unmet[i].TeacherID)]
+	}
+	return unmet
+}
+
+// CommitTimetable validates a (possibly edited) preview once more and stores
+// all of its schedules in one transaction
+func (ts *TimetableService) CommitTimetable(schedules []models.Schedule) error {
+	if len(schedules) == 0 {
+		return fmt.Errorf("timetable has no schedules")
+	}
+
+	today := helper.SchoolToday()
+	checkedLessons := make(map[string]bool)
+	checkedRooms := make(map[string]bool)
+
+	for i := range schedules {
+		schedule := &schedules[i]
+		schedule.ID = ""
+		schedule.SeriesID = ""
+		schedule.OccurrenceDate = nil
+
+		if schedule.TeacherID == "" || schedule.LessonID == "" || schedule.ClassID == "" {
+			return fmt.Errorf("schedule %d: teacher ID, lesson ID and class ID are required", i+1)
+		}
+
+		if !checkedLessons[schedule.LessonID] {
+			if _, err := ts.lessonRepo.GetLessonByID(schedule.LessonID); err != nil {
+				return fmt.Errorf("schedule %d: lesson not found: %w", i+1, err)
+			}
+			checkedLessons[schedule.LessonID] = true
+		}
+
+		if schedule.RoomID != "" && !checkedRooms[schedule.RoomID] {
+			if _, err := ts.roomRepo.GetRoomByID(schedule.RoomID); err != nil {
+				return fmt.Errorf("schedule %d: room not found: %w", i+1, err)
+			}
+			checkedRooms[schedule.RoomID] = true
+		}
+
+		if err := ts.scheduleService.PlaceInPeriod(schedule); err != nil {
+			return fmt.Errorf("schedule %d: %w", i+1, err)
+		}
+
+		if err := normalizeScheduleTimes(schedule, defaultLessonDuration); err != nil {
+			return fmt.Errorf("schedule %d: %w", i+1, err)
+		}
+
+		if dateOnly(schedule.Date).Before(today) {
+			return fmt.Errorf("schedule %d: cannot create schedule for past dates", i+1)
+		}
+
+		if err := ts.scheduleService.CheckScheduleDate(schedule.Date); err != nil {
+			return fmt.Errorf("schedule %d: %w", i+1, err)
+		}
+
+		if err := ts.scheduleService.CheckTeacherAvailability(schedule.TeacherID, *schedule); err != nil {
+			return fmt.Errorf("schedule %d: %w", i+1, err)
+		}
+
+		// Check against the schedules already stored
+		conflicts, err := ts.scheduleService.GetScheduleConflicts(schedule.TeacherID, schedule.ClassID, schedule.RoomID, schedule.Date, schedule.Time, schedule.EndTime)
+		if err != nil {
+			return fmt.Errorf("schedule %d: failed to check conflicts: %w", i+1, err)
+		}
+		if err := conflictError(conflicts, *schedule); err != nil {
+			return fmt.Errorf("schedule %d: %w", i+1, err)
+		}
+
+		// Check against the earlier schedules of the timetable itself
+		for j := 0; j < i; j++ {
+			if reason := batchConflict(schedules[j], *schedule); reason != "" {
+				return fmt.Errorf("schedules %d and %d: %s is booked twice", j+1, i+1, reason)
+			}
+		}
+	}
+
+	return ts.scheduleRepo.CreateSchedules(schedules)
+}
+
+// teacherAvailability loads the stored availability windows of every teacher
+// in the request
+func (ts *TimetableService) teacherAvailability(request *models.TimetableRequest) (map[string][]models.AvailabilityWindow, error) {
+	availability := make(map[string][]models.AvailabilityWindow)
+	for _, requirement := range request.Requirements {
+		if _, ok := availability[requirement.TeacherID]; ok {
+			continue
+		}
+
+		windows, err := ts.availabilityRepo.GetAvailabilityWindowsByTeacherID(requirement.TeacherID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get teacher availability: %w", err)
+		}
+		availability[requirement.TeacherID] = windows
+	}
+
+	return availability, nil
+}
+
+func (ts *TimetableService) validateTimetableRequest(request *models.TimetableRequest) error {
+	if request.WeekStart.IsZero() {
+		return fmt.Errorf("week start is required")
+	}
+
+	request.WeekStart = dateOnly(request.WeekStart)
+	if request.WeekStart.Before(helper.SchoolToday()) {
+		return fmt.Errorf("cannot generate a timetable for past dates")
+	}
+
+	if request.Weeks == 0 {
+		request.Weeks = 1
+	}
+	if request.Weeks < 0 || request.Weeks > maxTimetableWeeks {
+		return fmt.Errorf("weeks must be between 1 and %d", maxTimetableWeeks)
+	}
+
+	if len(request.Requirements) == 0 {
+		return fmt.Errorf("at least one lesson requirement is required")
+	}
+
+	// Without periods the active bell schedule's periods are filled in
+	if len(request.Periods) == 0 {
+		periods, err := ts.scheduleService.GetBellPeriods()
+		if err != nil {
+			return err
+		}
+		request.Periods = periods
+	}
+
+	if len(request.Periods) == 0 {
+		return fmt.Errorf("at least one period is required")
+	}
+
+	for i, period := range request.Periods {
+		if period.Weekday < time.Sunday || period.Weekday > time.Saturday {
+			return fmt.Errorf("period %d: invalid weekday %d", i+1, period.Weekday)
+		}
+		slot := models.Schedule{Time: period.Time, EndTime: period.EndTime}
+		if err := normalizeScheduleTimes(&slot, defaultLessonDuration); err != nil {
+			return fmt.Errorf("period %d: %w", i+1, err)
+		}
+		request.Periods[i].EndTime = slot.EndTime
+	}
+
+	for i, window := range request.Unavailability {
+		if window.TeacherID == "" {
+			return fmt.Errorf("unavailability %d: teacher ID is required", i+1)
+		}
+		if clockOffset(window.EndTime) <= clockOffset(window.Time) {
+			return fmt.Errorf("unavailability %d: end time must be after start time", i+1)
+		}
+	}
+
+	classHours := make(map[string]int)
+	teacherHours := make(map[string]int)
+	checkedLessons := make(map[string]bool)
+	checkedRooms := make(map[string]bool)
+
+	for i, requirement := range request.Requirements {
+		if requirement.ClassID == "" || requirement.LessonID == "" || requirement.TeacherID == "" {
+			return fmt.Errorf("requirement %d: class ID, lesson ID and teacher ID are required", i+1)
+		}
+
+		if requirement.HoursPerWeek <= 0 {
+			return fmt.Errorf("requirement %d: hours per week must be positive", i+1)
+		}
+
+		if !checkedLessons[requirement.LessonID] {
+			if _, err := ts.lessonRepo.GetLessonByID(requirement.LessonID); err != nil {
+				return fmt.Errorf("requirement %d: lesson not found: %w", i+1, err)
+			}
+			checkedLessons[requirement.LessonID] = true
+		}
+
+		if requirement.RoomID != "" && !checkedRooms[requirement.RoomID] {
+			if _, err := ts.roomRepo.GetRoomByID(requirement.RoomID); err != nil {
+				return fmt.Errorf("requirement %d: room not found: %w", i+1, err)
+			}
+			checkedRooms[requirement.RoomID] = true
+		}
+
+		classHours[requirement.ClassID] += requirement.HoursPerWeek
+		teacherHours[requirement.TeacherID] += requirement.HoursPerWeek
+	}
+
+	for classID, hours := range classHours {
+		if hours > len(request.Periods) {
+			return fmt.Errorf("class %s needs %d hours but only %d periods are available", classID, hours, len(request.Periods))
+		}
+	}
+
+	for teacherID, hours := range teacherHours {
+		if hours > len(request.Periods) {
+			return fmt.Errorf("teacher %s needs %d hours but only %d periods are available", teacherID, hours, len(request.Periods))
+		}
+	}
+
+	return nil
+}
+
+// existingSchedules loads every schedule and series occurrence in the weeks
+// the timetable covers, leaving out cancelled lessons
+func (ts *TimetableService) existingSchedules(weekStart time.Time, weeks int) ([]models.Schedule, error) {
+	var existing []models.Schedule
+	for week := 0; week < weeks; week++ {
+		schedules, err := ts.scheduleService.GetWeekSchedules(weekStart.AddDate(0, 0, 7*week))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get existing schedules: %w", err)
+		}
+		for _, schedule := range schedules {
+			if schedule.Status != models.ScheduleCancelled {
+				existing = append(existing, schedule)
+			}
+		}
+	}
+	return existing, nil
+}
+
+// batchConflict returns which resource two overlapping schedules share, if any
+func batchConflict(a, b models.Schedule) string {
+	if !isSameDay(a.Date, b.Date) {
+		return ""
+	}
+	if _, _, ok := overlapWindow(a, b); !ok {
+		return ""
+	}
+
+	switch {
+	case effectiveTeacherID(a) == effectiveTeacherID(b):
+		return models.ConflictTeacher
+	case a.ClassID == b.ClassID:
+		return models.ConflictClass
+	case a.RoomID != "" && a.RoomID == b.RoomID:
+		return models.ConflictRoom
+	}
+	return ""
+}
+
+// timetableSolver is a backtracking constraint solver. Each requirement needs
+// HoursPerWeek distinct periods; a period may be used by a requirement only if
+// no requirement sharing its teacher, class or room holds an overlapping
+// period. The requirement with the least slack (free periods minus hours
+// still needed) is placed first, and periods on days the requirement does not
+// use yet are tried first so lessons spread over the week.
+type timetableSolver struct {
+	request     *models.TimetableRequest
+	periods     []models.TimetablePeriod
+	overlaps    [][]int  // period -> periods overlapping it, itself included
+	candidates  [][]bool // requirement -> periods allowed before placement
+	remaining   []int    // requirement -> hours still to place
+	assigned    [][]int  // requirement -> placed periods, ascending
+	teacherBusy map[string][]int
+	classBusy   map[string][]int
+	roomBusy    map[string][]int
+	weekly      map[string][]models.AvailabilityWindow // teacher -> weekly availability windows
+	steps       int
+}
+
+func newTimetableSolver(request *models.TimetableRequest, existing []models.Schedule, availability map[string][]models.AvailabilityWindow) *timetableSolver {
+	periods := append([]models.TimetablePeriod(nil), request.Periods...)
+	sort.SliceStable(periods, func(i, j int) bool {
+		if periods[i].Weekday != periods[j].Weekday {
+			return periods[i].Weekday < periods[j].Weekday
+		}
+		return clockOffset(periods[i].Time) < clockOffset(periods[j].Time)
+	})
+
+	s := &timetableSolver{
+		request:     request,
+		periods:     periods,
+		overlaps:    make([][]int, len(periods)),
+		candidates:  make([][]bool, len(request.Requirements)),
+		remaining:   make([]int, len(request.Requirements)),
+		assigned:    make([][]int, len(request.Requirements)),
+		teacherBusy: make(map[string][]int),
+		classBusy:   make(map[string][]int),
+		roomBusy:    make(map[string][]int),
+		weekly:      make(map[string][]models.AvailabilityWindow),
+	}
+
+	// One-off windows only affect single dates and are applied to the result
+	for teacherID, windows := range availability {
+		for _, window := range windows {
+			if window.Weekday != nil {
+				s.weekly[teacherID] = append(s.weekly[teacherID], window)
+			}
+		}
+	}
+
+	for i, a := range periods {
+		for j, b := range periods {
+			if a.Weekday == b.Weekday && periodsOverlap(a, b) {
+				s.overlaps[i] = append(s.overlaps[i], j)
+			}
+		}
+	}
+
+	for r, requirement := range request.Requirements {
+		s.remaining[r] = requirement.HoursPerWeek
+		s.candidates[r] = make([]bool, len(periods))
+		for p, period := range periods {
+			s.candidates[r][p] = !s.blocked(requirement, period, existing)
+		}
+		s.busy(s.teacherBusy, requirement.TeacherID)
+		s.busy(s.classBusy, requirement.ClassID)
+		if requirement.RoomID != "" {
+			s.busy(s.roomBusy, requirement.RoomID)
+		}
+	}
+
+	return s
+}
+
+// blocked reports whether the period clashes with the teacher's unavailability,
+// falls outside their weekly availability or clashes with an existing schedule
+// of the teacher, class or room in any week
+func (s *timetableSolver) blocked(requirement models.LessonRequirement, period models.TimetablePeriod, existing []models.Schedule) bool {
+	for _, window := range s.request.Unavailability {
+		if window.TeacherID == requirement.TeacherID && window.Weekday == period.Weekday &&
+			periodsOverlap(period, models.TimetablePeriod{Time: window.Time, EndTime: window.EndTime}) {
+			return true
+		}
+	}
+
+	if windows := s.weekly[requirement.TeacherID]; len(windows) > 0 {
+		slot := models.Schedule{Date: periodDate(s.request.WeekStart, period.Weekday, 0), Time: period.Time, EndTime: period.EndTime}
+		if availabilityError(windows, slot) != nil {
+			return true
+		}
+	}
+
+	for _, schedule := range existing {
+		if schedule.Date.Weekday() != period.Weekday {
+			continue
+		}
+		if effectiveTeacherID(schedule) != requirement.TeacherID && schedule.ClassID != requirement.ClassID &&
+			(requirement.RoomID == "" || schedule.RoomID != requirement.RoomID) {
+			continue
+		}
+		if periodsOverlap(period, models.TimetablePeriod{Time: schedule.Time, EndTime: schedule.EndTime}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *timetableSolver) busy(busy map[string][]int, key string) {
+	if _, ok := busy[key]; !ok {
+		busy[key] = make([]int, len(s.periods))
+	}
+}
+
+func (s *timetableSolver) solve() bool {
+	s.steps++
+	if s.steps >= maxSolverSteps {
+		return false
+	}
+
+	// Pick the requirement with the least slack
+	next, nextSlack := -1, 0
+	for r := range s.request.Requirements {
+		if s.remaining[r] == 0 {
+			continue
+		}
+		slack := s.freePeriods(r) - s.remaining[r]
+		if slack < 0 {
+			return false
+		}
+		if next == -1 || slack < nextSlack {
+			next, nextSlack = r, slack
+		}
+	}
+	if next == -1 {
+		return true
+	}
+
+	for _, p := range s.orderedPeriods(next) {
+		s.place(next, p, 1)
+		if s.solve() {
+			return true
+		}
+		s.place(next, p, -1)
+
+		if s.steps >= maxSolverSteps {
+			return false
+		}
+	}
+
+	return false
+}
+
+// freePeriods counts the periods the requirement can still take. Periods are
+// taken in ascending order per requirement, which avoids trying the same set
+// of periods in every permutation.
+func (s *timetableSolver) freePeriods(r int) int {
+	free := 0
+	for p := s.firstPeriod(r); p < len(s.periods); p++ {
+		if s.available(r, p) {
+			free++
+		}
+	}
+	return free
+}
+
+func (s *timetableSolver) firstPeriod(r int) int {
+	if n := len(s.assigned[r]); n > 0 {
+		return s.assigned[r][n-1] + 1
+	}
+	return 0
+}
+
+func (s *timetableSolver) available(r, p int) bool {
+	if !s.candidates[r][p] {
+		return false
+	}
+
+	requirement := s.request.Requirements[r]
+	if s.teacherBusy[requirement.TeacherID][p] > 0 || s.classBusy[requirement.ClassID][p] > 0 {
+		return false
+	}
+	if requirement.RoomID != "" && s.roomBusy[requirement.RoomID][p] > 0 {
+		return false
+	}
+	return true
+}
+
+// orderedPeriods lists the available periods of the requirement, preferring
+// days it is not taught on yet
+func (s *timetableSolver) orderedPeriods(r int) []int {
+	var dayCounts [7]int
+	for _, p := range s.assigned[r] {
+		dayCounts[s.periods[p].Weekday]++
+	}
+
+	var ordered []int
+	for p := s.firstPeriod(r); p < len(s.periods); p++ {
+		if s.available(r, p) {
+			ordered = append(ordered, p)
+		}
+	}
+
+	load := make(map[int]int, len(ordered))
+	for _, p := range ordered {
+		load[p] = dayCounts[s.periods[p].Weekday]
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return load[ordered[i]] < load[ordered[j]]
+	})
+	return ordered
+}
+
+// place assigns (delta 1) or releases (delta -1) period p for requirement r
+func (s *timetableSolver) place(r, p, delta int) {
+	requirement := s.request.Requirements[r]
+	for _, q := range s.overlaps[p] {
+		s.teacherBusy[requirement.TeacherID][q] += delta
+		s.classBusy[requirement.ClassID][q] += delta
+		if requirement.RoomID != "" {
+			s.roomBusy[requirement.RoomID][q] += delta
+		}
+	}
+
+	if delta > 0 {
+		s.assigned[r] = append(s.assigned[r], p)
+		s.remaining[r]--
+	} else {
+		s.assigned[r] = s.assigned[r][:len(s.assigned[r])-1]
+		s.remaining[r]++
+	}
+}
+
+// schedules turns the solution into dated schedules for every covered week
+func (s *timetableSolver) schedules() []models.Schedule {
+	var schedules []models.Schedule
+	weekStart := s.request.WeekStart
+
+	for week := 0; week < s.request.Weeks; week++ {
+		for r, requirement := range s.request.Requirements {
+			for _, p := range s.assigned[r] {
+				period := s.periods[p]
+				schedules = append(schedules, models.Schedule{
+					Date:      periodDate(weekStart, period.Weekday, week),
+					TeacherID: requirement.TeacherID,
+					LessonID:  requirement.LessonID,
+					ClassID:   requirement.ClassID,
+					RoomID:    requirement.RoomID,
+					Time:      period.Time,
+					EndTime:   period.EndTime,
+				})
+			}
+		}
+	}
+
+	sortSchedules(schedules)
+	return schedules
+}
+
+// periodDate returns the date of the weekday in the given week of the timetable
+func periodDate(weekStart time.Time, weekday time.Weekday, week int) time.Time {
+	offset := (int(weekday) - int(weekStart.Weekday()) + 7) % 7
+	return weekStart.AddDate(0, 0, offset+7*week)
+}
+
+func periodsOverlap(a, b models.TimetablePeriod) bool {
+	_, _, ok := overlapWindow(
+		models.Schedule{Time: a.Time, EndTime: a.EndTime},
+		models.Schedule{Time: b.Time, EndTime: b.EndTime},
+	)
+	return ok
+}
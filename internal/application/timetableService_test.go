@@ -0,0 +1,157 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestTimetableService(repo *fakeScheduleRepo) models.TimetableService {
+	seriesRepo := newFakeSeriesRepo(repo)
+	scheduleService := NewScheduleService(repo, seriesRepo, fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+	return NewTimetableService(scheduleService, repo, fakeLessonRepo{}, fakeRoomRepo{}, &fakeAvailabilityRepo{})
+}
+
+func weekPeriods(weekdays []time.Weekday, starts ...time.Time) []models.TimetablePeriod {
+	var periods []models.TimetablePeriod
+	for _, weekday := range weekdays {
+		for _, start := range starts {
+			periods = append(periods, models.TimetablePeriod{Weekday: weekday, Time: start, EndTime: start.Add(40 * time.Minute)})
+		}
+	}
+	return periods
+}
+
+func TestGenerateTimetableIsConflictFree(t *testing.T) {
+	weekStart := futureDate()
+	monday := weekStart.AddDate(0, 0, (int(time.Monday)-int(weekStart.Weekday())+7)%7)
+
+	// An existing lesson of teacher t1 on Monday at 09:00 must be avoided
+	repo := newFakeScheduleRepo(models.Schedule{
+		ID: "s1", Date: monday, TeacherID: "t1", LessonID: "l9", ClassID: "c9",
+		Time: clock(9, 0), EndTime: clock(9, 40),
+	})
+	service := newTestTimetableService(repo)
+
+	request := &models.TimetableRequest{
+		WeekStart: weekStart,
+		Requirements: []models.LessonRequirement{
+			{ClassID: "c1", LessonID: "math", TeacherID: "t1", HoursPerWeek: 3},
+			{ClassID: "c2", LessonID: "math", TeacherID: "t1", HoursPerWeek: 2},
+			{ClassID: "c1", LessonID: "art", TeacherID: "t2", HoursPerWeek: 2, RoomID: "studio"},
+			{ClassID: "c2", LessonID: "art", TeacherID: "t2", HoursPerWeek: 1, RoomID: "studio"},
+		},
+		Periods: weekPeriods([]time.Weekday{time.Monday, time.Tuesday, time.Wednesday}, clock(9, 0), clock(10, 0)),
+		Unavailability: []models.TeacherUnavailability{
+			{TeacherID: "t2", Weekday: time.Tuesday, Time: clock(8, 0), EndTime: clock(12, 0)},
+		},
+	}
+
+	preview, err := service.GenerateTimetable(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(preview.Schedules) != 8 {
+		t.Fatalf("expected 8 schedules, got %d", len(preview.Schedules))
+	}
+
+	for i, a := range preview.Schedules {
+		if a.TeacherID == "t1" && isSameDay(a.Date, monday) && isSameTime(a.Time, clock(9, 0)) {
+			t.Errorf("schedule %d overlaps the existing lesson of t1", i)
+		}
+		if a.TeacherID == "t2" && a.Date.Weekday() == time.Tuesday {
+			t.Errorf("schedule %d placed during unavailability of t2", i)
+		}
+		for j := i + 1; j < len(preview.Schedules); j++ {
+			if reason := batchConflict(a, preview.Schedules[j]); reason != "" {
+				t.Errorf("schedules %d and %d share a %s", i, j, reason)
+			}
+		}
+	}
+}
+
+func TestGenerateTimetableReportsInfeasibleRequirements(t *testing.T) {
+	service := newTestTimetableService(newFakeScheduleRepo())
+
+	// Both classes need the only teacher for both periods
+	request := &models.TimetableRequest{
+		WeekStart: futureDate(),
+		Requirements: []models.LessonRequirement{
+			{ClassID: "c1", LessonID: "math", TeacherID: "t1", HoursPerWeek: 2},
+			{ClassID: "c2", LessonID: "math", TeacherID: "t1", HoursPerWeek: 1},
+		},
+		Periods: weekPeriods([]time.Weekday{time.Monday}, clock(9, 0), clock(10, 0)),
+	}
+
+	if _, err := service.GenerateTimetable(request); err == nil {
+		t.Fatal("expected infeasible requirements to be rejected")
+	}
+}
+
+func TestGenerateTimetableReportsDroppedLessons(t *testing.T) {
+	weekStart := futureDate()
+	monday := weekStart.AddDate(0, 0, (int(time.Monday)-int(weekStart.Weekday())+7)%7)
+
+	// The Monday of the second week is a holiday
+	calendarRepo := &fakeCalendarRepo{closures: []models.Closure{{
+		ID: "closure-1", Name: "Republic Day", Kind: models.ClosureHoliday,
+		StartDate: monday.AddDate(0, 0, 7), EndDate: monday.AddDate(0, 0, 7),
+	}}}
+	repo := newFakeScheduleRepo()
+	scheduleService := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, calendarRepo, &fakeAvailabilityRepo{}, &fakeWorkloadRepo{}, &fakeBellRepo{})
+	service := NewTimetableService(scheduleService, repo, fakeLessonRepo{}, fakeRoomRepo{}, &fakeAvailabilityRepo{})
+
+	request := &models.TimetableRequest{
+		WeekStart: weekStart,
+		Weeks:     2,
+		Requirements: []models.LessonRequirement{
+			{ClassID: "c1", LessonID: "math", TeacherID: "t1", HoursPerWeek: 1},
+		},
+		Periods: weekPeriods([]time.Weekday{time.Monday}, clock(9, 0)),
+	}
+
+	preview, err := service.GenerateTimetable(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(preview.Schedules) != 1 || len(preview.Dropped) != 1 || !isSameDay(preview.Dropped[0].Date, monday.AddDate(0, 0, 7)) {
+		t.Fatalf("expected the holiday lesson to be dropped, got %+v", preview)
+	}
+	if !strings.Contains(preview.Dropped[0].Reason, "Republic Day") {
+		t.Errorf("expected the holiday to be named, got %q", preview.Dropped[0].Reason)
+	}
+	if len(preview.UnmetHours) != 1 || preview.UnmetHours[0].RequiredHours != 2 || preview.UnmetHours[0].ScheduledHours != 1 {
+		t.Errorf("expected 1 of 2 hours to be scheduled, got %+v", preview.UnmetHours)
+	}
+}
+
+func TestCommitTimetableStoresAllOrNothing(t *testing.T) {
+	repo := newFakeScheduleRepo()
+	service := newTestTimetableService(repo)
+	date := futureDate()
+
+	clashing := []models.Schedule{
+		{Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		{Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c2", Time: clock(9, 20), EndTime: clock(10, 0)},
+	}
+	if err := service.CommitTimetable(clashing); err == nil {
+		t.Fatal("expected clashing timetable to be rejected")
+	}
+	if len(repo.schedules) != 0 {
+		t.Fatalf("expected nothing stored, got %d schedules", len(repo.schedules))
+	}
+
+	valid := []models.Schedule{
+		{Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(9, 0), EndTime: clock(9, 40)},
+		{Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c2", Time: clock(9, 40), EndTime: clock(10, 20)},
+	}
+	if err := service.CommitTimetable(valid); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.schedules) != 2 || valid[0].ID == "" {
+		t.Fatalf("expected 2 stored schedules with IDs, got %d", len(repo.schedules))
+	}
+}
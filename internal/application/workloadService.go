@@ -0,0 +1,387 @@
+package application
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// consecutiveBreak is the longest gap between two lessons that still
+	// counts them as consecutive
+	consecutiveBreak = 15 * time.Minute
+	// maxWorkloadReportDays bounds reports not tied to a term
+	maxWorkloadReportDays = 366
+)
+
+type WorkloadService struct {
+	scheduleService models.ScheduleService
+	limitRepo       models.WorkloadLimitRepository
+	calendarRepo    models.AcademicCalendarRepository
+	userService     models.KeycloakService
+}
+
+func NewWorkloadService(scheduleService models.ScheduleService, limitRepo models.WorkloadLimitRepository, calendarRepo models.AcademicCalendarRepository, userService models.KeycloakService) models.WorkloadService {
+	return &WorkloadService{
+		scheduleService: scheduleService,
+		limitRepo:       limitRepo,
+		calendarRepo:    calendarRepo,
+		userService:     userService,
+	}
+}
+
+func (ws *WorkloadService) SetWorkloadLimit(limit *models.WorkloadLimit) error {
+	if limit.MaxWeeklyHours < 0 || limit.MaxConsecutiveLessons < 0 {
+		return fmt.Errorf("workload limits must not be negative")
+	}
+
+	if limit.MaxWeeklyHours > 24*7 {
+		return fmt.Errorf("max weekly hours must not exceed %d", 24*7)
+	}
+
+	if limit.TeacherID != "" {
+		user, err := ws.userService.GetUserByID(limit.TeacherID)
+		if err != nil {
+			return fmt.Errorf("teacher not found: %w", err)
+		}
+		if user.Role != "teacher" {
+			return fmt.Errorf("user %s is not a teacher", limit.TeacherID)
+		}
+	}
+
+	limits, err := ws.limitRepo.GetAllWorkloadLimits()
+	if err != nil {
+		return fmt.Errorf("failed to get workload limits: %w", err)
+	}
+
+	// One limit per teacher and one school default, replace it if present
+	for _, existing := range limits {
+		if existing.TeacherID == limit.TeacherID {
+			limit.ID = existing.ID
+			return ws.limitRepo.UpdateWorkloadLimit(limit)
+		}
+	}
+
+	return ws.limitRepo.CreateWorkloadLimit(limit)
+}
+
+func (ws *WorkloadService) GetWorkloadLimits() ([]models.WorkloadLimit, error) {
+	return ws.limitRepo.GetAllWorkloadLimits()
+}
+
+func (ws *WorkloadService) DeleteWorkloadLimit(id string) error {
+	if id == "" {
+		return fmt.Errorf("workload limit ID is required")
+	}
+
+	return ws.limitRepo.DeleteWorkloadLimit(id)
+}
+
+// GetWorkloadReport sums every teacher's lessons per day and week. Lessons
+// count for the teacher giving them, so substitutes carry the hours they
+// cover, and cancelled lessons are left out. Without a term or dates the
+// current week is reported.
+func (ws *WorkloadService) GetWorkloadReport(teacherID, termID string, from, to time.Time) (*models.WorkloadReport, error) {
+	report := &models.WorkloadReport{TermID: termID, Teachers: []models.TeacherWorkload{}}
+
+	switch {
+	case termID != "":
+		term, err := ws.calendarRepo.GetTermByID(termID)
+		if err != nil {
+			return nil, fmt.Errorf("term not found: %w", err)
+		}
+		from, to = term.StartDate, term.EndDate
+	case from.IsZero():
+		from = weekStart(helper.SchoolToday())
+		to = from.AddDate(0, 0, 6)
+	case to.IsZero():
+		to = from.AddDate(0, 0, 6)
+	}
+
+	report.From = dateOnly(from)
+	report.To = dateOnly(to)
+	if report.To.Before(report.From) {
+		return nil, fmt.Errorf("to date must not be before from date")
+	}
+	if termID == "" && report.To.After(report.From.AddDate(0, 0, maxWorkloadReportDays-1)) {
+		return nil, fmt.Errorf("report period must not exceed %d days", maxWorkloadReportDays)
+	}
+
+	schedules, err := ws.scheduleService.GetSchedulesBetween(report.From, report.To.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedules: %w", err)
+	}
+
+	limits, err := ws.limitRepo.GetAllWorkloadLimits()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workload limits: %w", err)
+	}
+
+	users, err := ws.userService.GetAllUsers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teachers: %w", err)
+	}
+
+	lessons := map[string][]models.Schedule{}
+	for _, schedule := range schedules {
+		if schedule.Status == models.ScheduleCancelled {
+			continue
+		}
+		id := effectiveTeacherID(schedule)
+		lessons[id] = append(lessons[id], schedule)
+	}
+
+	// Every teacher is listed, including those without lessons
+	names := map[string]string{}
+	for _, user := range users {
+		if user.Role == "teacher" {
+			names[user.ID] = strings.TrimSpace(user.FirstName + " " + user.LastName)
+		}
+	}
+	for id := range lessons {
+		if _, ok := names[id]; !ok {
+			names[id] = ""
+		}
+	}
+
+	for id, name := range names {
+		if teacherID != "" && id != teacherID {
+			continue
+		}
+		workload := teacherWorkload(lessons[id], workloadLimitFor(limits, id))
+		workload.TeacherID = id
+		workload.Name = name
+		report.Teachers = append(report.Teachers, workload)
+	}
+
+	sort.Slice(report.Teachers, func(i, j int) bool {
+		a, b := report.Teachers[i], report.Teachers[j]
+		if a.TotalHours != b.TotalHours {
+			return a.TotalHours > b.TotalHours
+		}
+		return a.Name < b.Name
+	})
+
+	return report, nil
+}
+
+// teacherWorkload sums the lessons of one teacher and flags the limits
+// they exceed
+func teacherWorkload(lessons []models.Schedule, limit *models.WorkloadLimit) models.TeacherWorkload {
+	workload := models.TeacherWorkload{
+		Lessons:     len(lessons),
+		DailyHours:  map[string]float64{},
+		WeeklyHours: map[string]float64{},
+		Limit:       limit,
+	}
+
+	daily := map[string]time.Duration{}
+	weekly := map[string]time.Duration{}
+	var total time.Duration
+	days := map[string][]models.Schedule{}
+	for _, lesson := range lessons {
+		duration := scheduleDuration(lesson)
+		day := dateOnly(lesson.Date).Format("2006-01-02")
+		week := weekStart(lesson.Date).Format("2006-01-02")
+
+		daily[day] += duration
+		weekly[week] += duration
+		total += duration
+		days[day] = append(days[day], lesson)
+	}
+
+	for day, duration := range daily {
+		workload.DailyHours[day] = toHours(duration)
+	}
+	for week, duration := range weekly {
+		workload.WeeklyHours[week] = toHours(duration)
+	}
+	workload.TotalHours = toHours(total)
+
+	for _, lessons := range days {
+		workload.MaxConsecutiveLessons = max(workload.MaxConsecutiveLessons, longestRun(lessons))
+	}
+
+	if limit == nil {
+		return workload
+	}
+
+	if limit.MaxWeeklyHours > 0 {
+		var weeks []string
+		for week := range weekly {
+			weeks = append(weeks, week)
+		}
+		sort.Strings(weeks)
+
+		for _, week := range weeks {
+			if hours := toHours(weekly[week]); hours > limit.MaxWeeklyHours {
+				workload.Exceeded = append(workload.Exceeded, fmt.Sprintf("week of %s: %s hours, limit %s", week, formatHours(hours), formatHours(limit.MaxWeeklyHours)))
+			}
+		}
+	}
+
+	if limit.MaxConsecutiveLessons > 0 && workload.MaxConsecutiveLessons > limit.MaxConsecutiveLessons {
+		workload.Exceeded = append(workload.Exceeded, fmt.Sprintf("%d consecutive lessons, limit %d", workload.MaxConsecutiveLessons, limit.MaxConsecutiveLessons))
+	}
+
+	return workload
+}
+
+// checkWorkload rejects a lesson that takes its teacher over the weekly hours
+// or the consecutive lessons limit. The lessons of batch are moved together
+// with it and count at their new slots instead of their stored ones.
+func (ss *ScheduleService) checkWorkload(schedule *models.Schedule, batch ...models.Schedule) error {
+	teacherID := effectiveTeacherID(*schedule)
+
+	limits, err := ss.workloadRepo.GetAllWorkloadLimits()
+	if err != nil {
+		return fmt.Errorf("failed to check workload limits: %w", err)
+	}
+
+	limit := workloadLimitFor(limits, teacherID)
+	if limit == nil {
+		return nil
+	}
+
+	week := weekStart(schedule.Date)
+	teacherSchedules, err := ss.teacherSchedules(teacherID, week, week.AddDate(0, 0, 7))
+	if err != nil {
+		return fmt.Errorf("failed to get teacher schedules: %w", err)
+	}
+
+	inWeek := func(lesson models.Schedule) bool {
+		date := dateOnly(lesson.Date)
+		return !date.Before(week) && date.Before(week.AddDate(0, 0, 7))
+	}
+	inBatch := func(lesson models.Schedule) bool {
+		return slices.ContainsFunc(batch, func(moved models.Schedule) bool { return isSameSchedule(lesson, moved) })
+	}
+
+	// The lesson itself is counted at its new date and time
+	var lessons []models.Schedule
+	for _, lesson := range teacherSchedules {
+		if !inWeek(lesson) || lesson.Status == models.ScheduleCancelled {
+			continue
+		}
+		if isSameSchedule(lesson, *schedule) || inBatch(lesson) {
+			continue
+		}
+		lessons = append(lessons, lesson)
+	}
+	for _, lesson := range batch {
+		if effectiveTeacherID(lesson) == teacherID && inWeek(lesson) && !isSameSchedule(lesson, *schedule) {
+			lessons = append(lessons, lesson)
+		}
+	}
+
+	return workloadError(limit, lessons, *schedule)
+}
+
+// workloadError checks the lesson against the limit, given the teacher's
+// other lessons in the same week
+func workloadError(limit *models.WorkloadLimit, lessons []models.Schedule, schedule models.Schedule) error {
+	if limit.MaxWeeklyHours > 0 {
+		total := scheduleDuration(schedule)
+		for _, lesson := range lessons {
+			total += scheduleDuration(lesson)
+		}
+
+		if hours := toHours(total); hours > limit.MaxWeeklyHours {
+			return fmt.Errorf("lesson exceeds the teacher's weekly limit of %s hours (%s hours that week)", formatHours(limit.MaxWeeklyHours), formatHours(hours))
+		}
+	}
+
+	if limit.MaxConsecutiveLessons > 0 {
+		var day []models.Schedule
+		for _, lesson := range lessons {
+			if isSameDay(lesson.Date, schedule.Date) {
+				day = append(day, lesson)
+			}
+		}
+
+		if run := consecutiveLessons(day, schedule); run > limit.MaxConsecutiveLessons {
+			return fmt.Errorf("lesson makes %d consecutive lessons for the teacher, the limit is %d", run, limit.MaxConsecutiveLessons)
+		}
+	}
+
+	return nil
+}
+
+// workloadLimitFor returns the teacher's own limit, falling back to the
+// school default
+func workloadLimitFor(limits []models.WorkloadLimit, teacherID string) *models.WorkloadLimit {
+	var fallback *models.WorkloadLimit
+	for i := range limits {
+		switch limits[i].TeacherID {
+		case teacherID:
+			return &limits[i]
+		case "":
+			fallback = &limits[i]
+		}
+	}
+	return fallback
+}
+
+// consecutiveLessons returns the length of the run of back-to-back lessons
+// the schedule is part of, given the other lessons of its day
+func consecutiveLessons(day []models.Schedule, schedule models.Schedule) int {
+	count := 1
+	start, end := clockOffset(schedule.Time), scheduleEnd(schedule)
+
+	for extended := true; extended; {
+		extended = false
+		for _, lesson := range day {
+			lessonStart, lessonEnd := clockOffset(lesson.Time), scheduleEnd(lesson)
+			switch {
+			case lessonEnd <= start && start-lessonEnd <= consecutiveBreak:
+				start = lessonStart
+			case lessonStart >= end && lessonStart-end <= consecutiveBreak:
+				end = lessonEnd
+			default:
+				continue
+			}
+			count++
+			extended = true
+		}
+	}
+
+	return count
+}
+
+// longestRun returns the most back-to-back lessons of a day
+func longestRun(day []models.Schedule) int {
+	sorted := append([]models.Schedule(nil), day...)
+	sortSchedules(sorted)
+
+	longest, run := 0, 0
+	var previousEnd time.Duration
+	for i, lesson := range sorted {
+		if i > 0 && clockOffset(lesson.Time)-previousEnd <= consecutiveBreak {
+			run++
+		} else {
+			run = 1
+		}
+		longest = max(longest, run)
+		previousEnd = max(previousEnd, scheduleEnd(lesson))
+	}
+	return longest
+}
+
+// weekStart returns the Monday of the date's week
+func weekStart(date time.Time) time.Time {
+	date = dateOnly(date)
+	return date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+}
+
+func toHours(duration time.Duration) float64 {
+	return math.Round(duration.Hours()*100) / 100
+}
+
+func formatHours(hours float64) string {
+	return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.2f", hours), "0"), ".")
+}
@@ -0,0 +1,165 @@
+package application
+
+import (
+	"Education_Dashboard/internal/models"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeWorkloadRepo struct {
+	limits []models.WorkloadLimit
+}
+
+func (r *fakeWorkloadRepo) CreateWorkloadLimit(limit *models.WorkloadLimit) error {
+	limit.ID = fmt.Sprintf("limit-%d", len(r.limits)+1)
+	r.limits = append(r.limits, *limit)
+	return nil
+}
+
+func (r *fakeWorkloadRepo) UpdateWorkloadLimit(limit *models.WorkloadLimit) error {
+	for i := range r.limits {
+		if r.limits[i].ID == limit.ID {
+			r.limits[i] = *limit
+		}
+	}
+	return nil
+}
+
+func (r *fakeWorkloadRepo) DeleteWorkloadLimit(id string) error {
+	return nil
+}
+
+func (r *fakeWorkloadRepo) GetAllWorkloadLimits() ([]models.WorkloadLimit, error) {
+	return r.limits, nil
+}
+
+func TestScheduleEnforcesWorkloadLimits(t *testing.T) {
+	monday := nextWeekday(futureDate(), time.Monday)
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "first", Date: monday, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(8, 0), EndTime: clock(8, 40)},
+		models.Schedule{ID: "second", Date: monday, TeacherID: "t1", LessonID: "l1", ClassID: "c2", Time: clock(8, 50), EndTime: clock(9, 30)},
+		models.Schedule{ID: "tuesday", Date: monday.AddDate(0, 0, 1), TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(8, 0), EndTime: clock(9, 20)},
+	)
+	workloadRepo := &fakeWorkloadRepo{}
+	scheduleService := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, workloadRepo, &fakeBellRepo{})
+	service := NewWorkloadService(scheduleService, workloadRepo, &fakeCalendarRepo{}, substitutionUserService{})
+
+	if err := service.SetWorkloadLimit(&models.WorkloadLimit{MaxWeeklyHours: 3.5, MaxConsecutiveLessons: 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	newSchedule := func(date time.Time, hour, minute int) *models.Schedule {
+		return &models.Schedule{Date: date, TeacherID: "t1", LessonID: "l1", ClassID: "c3", Time: clock(hour, minute), EndTime: clock(hour, minute+40)}
+	}
+
+	// 09:40 follows the two Monday lessons after a ten minute break
+	err := scheduleService.CreateSchedule(newSchedule(monday, 9, 40))
+	if err == nil || !strings.Contains(err.Error(), "3 consecutive lessons") {
+		t.Fatalf("expected the consecutive limit to be enforced, got %v", err)
+	}
+
+	if err := scheduleService.CreateSchedule(newSchedule(monday, 11, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 3h20m are booked, another 40 minutes go over 3.5 hours
+	err = scheduleService.CreateSchedule(newSchedule(monday.AddDate(0, 0, 2), 10, 0))
+	if err == nil || !strings.Contains(err.Error(), "weekly limit of 3.5 hours") {
+		t.Fatalf("expected the weekly limit to be enforced, got %v", err)
+	}
+
+	// The next week is not affected, and a teacher's own limit wins
+	if err := service.SetWorkloadLimit(&models.WorkloadLimit{TeacherID: "t1", MaxWeeklyHours: 4}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := scheduleService.CreateSchedule(newSchedule(monday.AddDate(0, 0, 2), 10, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := scheduleService.RescheduleSchedule("tuesday", monday.AddDate(0, 0, 7), clock(8, 0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report, err := service.GetWorkloadReport("", "", monday, monday.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var workload *models.TeacherWorkload
+	for i := range report.Teachers {
+		if report.Teachers[i].TeacherID == "t1" {
+			workload = &report.Teachers[i]
+		}
+	}
+	if workload == nil || len(report.Teachers) != 4 {
+		t.Fatalf("expected every teacher in the report, got %+v", report.Teachers)
+	}
+	if workload.Lessons != 4 || workload.TotalHours != 2.67 || workload.MaxConsecutiveLessons != 2 || workload.Limit.MaxWeeklyHours != 4 {
+		t.Fatalf("unexpected workload %+v", workload)
+	}
+	if workload.DailyHours[monday.Format("2006-01-02")] != 2 || workload.WeeklyHours[monday.Format("2006-01-02")] != 2.67 {
+		t.Fatalf("unexpected daily or weekly hours %+v %+v", workload.DailyHours, workload.WeeklyHours)
+	}
+}
+
+func TestBulkRescheduleEnforcesWorkloadLimits(t *testing.T) {
+	monday := nextWeekday(futureDate(), time.Monday)
+	friday := monday.AddDate(0, 0, -3)
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "first", Date: monday, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(8, 0), EndTime: clock(8, 40)},
+		models.Schedule{ID: "second", Date: monday, TeacherID: "t1", LessonID: "l1", ClassID: "c2", Time: clock(8, 50), EndTime: clock(9, 30)},
+		models.Schedule{ID: "friday", Date: friday, TeacherID: "t1", LessonID: "l1", ClassID: "c3", Time: clock(9, 40), EndTime: clock(10, 20)},
+	)
+	workloadRepo := &fakeWorkloadRepo{limits: []models.WorkloadLimit{{ID: "limit-1", MaxConsecutiveLessons: 2}}}
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, workloadRepo, &fakeBellRepo{})
+
+	// Friday's lesson would follow the two Monday lessons
+	report, err := service.BulkReschedule(models.BulkRescheduleRequest{FromDate: friday, TargetDate: monday})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Applied || report.Conflicting != 1 || len(report.Moves[0].Errors) != 1 || !strings.Contains(report.Moves[0].Errors[0], "3 consecutive lessons") {
+		t.Fatalf("expected the consecutive limit to block the move, got %+v", report)
+	}
+}
+
+func TestBulkRescheduleCountsTheWholeBatch(t *testing.T) {
+	monday := nextWeekday(futureDate(), time.Monday)
+	tuesday := monday.AddDate(0, 0, 1)
+	repo := newFakeScheduleRepo(
+		models.Schedule{ID: "early", Date: monday, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(8, 0), EndTime: clock(8, 40)},
+		models.Schedule{ID: "late", Date: tuesday, TeacherID: "t1", LessonID: "l1", ClassID: "c2", Time: clock(8, 50), EndTime: clock(9, 30)},
+	)
+	workloadRepo := &fakeWorkloadRepo{limits: []models.WorkloadLimit{{ID: "limit-1", MaxConsecutiveLessons: 1}}}
+	service := NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, workloadRepo, &fakeBellRepo{})
+
+	// "early" lands right before the old slot of "late", which moves away to
+	// Wednesday at the same time, so neither breaks the limit
+	toDate := tuesday
+	report, err := service.BulkReschedule(models.BulkRescheduleRequest{FromDate: monday, ToDate: &toDate, TargetDate: tuesday})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.Applied || report.Conflicting != 0 {
+		t.Fatalf("expected the lessons to move together, got %+v", report)
+	}
+
+	// Lessons moving in together with an existing one make three in a row
+	wednesday := tuesday.AddDate(0, 0, 1)
+	repo = newFakeScheduleRepo(
+		models.Schedule{ID: "first", Date: tuesday, TeacherID: "t1", LessonID: "l1", ClassID: "c1", Time: clock(8, 0), EndTime: clock(8, 40)},
+		models.Schedule{ID: "second", Date: tuesday, TeacherID: "t1", LessonID: "l1", ClassID: "c2", Time: clock(8, 50), EndTime: clock(9, 30)},
+		models.Schedule{ID: "third", Date: wednesday, TeacherID: "t1", LessonID: "l1", ClassID: "c3", Time: clock(9, 40), EndTime: clock(10, 20)},
+	)
+	workloadRepo.limits[0].MaxConsecutiveLessons = 2
+	service = NewScheduleService(repo, newFakeSeriesRepo(repo), fakeRoomRepo{}, fakeLessonRepo{}, fakeAttendanceRepo{}, &fakeCalendarRepo{}, &fakeAvailabilityRepo{}, workloadRepo, &fakeBellRepo{})
+
+	report, err = service.BulkReschedule(models.BulkRescheduleRequest{FromDate: tuesday, TargetDate: wednesday})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.Applied || report.Conflicting != 2 {
+		t.Fatalf("expected the moved lessons to be counted together, got %+v", report)
+	}
+}
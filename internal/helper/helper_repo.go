@@ -3,6 +3,8 @@ package helper
 
 import (
 	"fmt"
+	"time"
+
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/google/uuid"
 )
@@ -29,6 +31,14 @@ func ConvertStringToUUID(s string) (pgtype.UUID, error) {
 	return pgUUID, nil
 }
 
+// ConvertNullableStringToUUID maps an empty string to a NULL UUID
+func ConvertNullableStringToUUID(s string) (pgtype.UUID, error) {
+	if s == "" {
+		return pgtype.UUID{}, nil
+	}
+	return ConvertStringToUUID(s)
+}
+
 func ConvertUUIDToString(pgUUID pgtype.UUID) string {
 	if !pgUUID.Valid {
 		return ""
@@ -37,4 +47,35 @@ func ConvertUUIDToString(pgUUID pgtype.UUID) string {
 	// Convert pgtype.UUID to uuid.UUID and then to string
 	u := uuid.UUID(pgUUID.Bytes)
 	return u.String()
-}
\ No newline at end of file
+}
+
+// Helper functions for TIME conversion
+
+func ConvertTimeToPgTime(t time.Time) pgtype.Time {
+	return pgtype.Time{
+		Microseconds: int64(t.Hour())*3600000000 + int64(t.Minute())*60000000,
+		Valid:        true,
+	}
+}
+
+func ConvertPgTimeToTime(pgTime pgtype.Time) time.Time {
+	// TIME columns carry no date, so the clock is anchored to the zero date
+	return time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(pgTime.Microseconds) * time.Microsecond)
+}
+
+// Helper functions for nullable DATE conversion
+
+func ConvertNullableTimeToPgDate(t *time.Time) pgtype.Date {
+	if t == nil {
+		return pgtype.Date{}
+	}
+	return pgtype.Date{Time: *t, Valid: true}
+}
+
+func ConvertPgDateToNullableTime(pgDate pgtype.Date) *time.Time {
+	if !pgDate.Valid {
+		return nil
+	}
+	t := pgDate.Time
+	return &t
+}
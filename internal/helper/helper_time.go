@@ -0,0 +1,91 @@
+package helper
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	// schoolLocation is the time zone the school runs on. DATE, TIME and
+	// TIMESTAMP columns hold wall-clock values in this zone.
+	schoolLocation = time.UTC
+
+	// now is replaced in tests to pin the current instant
+	now = time.Now
+)
+
+// SetSchoolLocation configures the school time zone, nil resets it to UTC
+func SetSchoolLocation(loc *time.Location) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	schoolLocation = loc
+}
+
+func SchoolLocation() *time.Location {
+	return schoolLocation
+}
+
+// SetNow replaces the clock and returns a function restoring the previous one
+func SetNow(fn func() time.Time) func() {
+	previous := now
+	now = fn
+	return func() { now = previous }
+}
+
+// SchoolNow returns the current instant in the school time zone
+func SchoolNow() time.Time {
+	return now().In(schoolLocation)
+}
+
+// SchoolToday returns the school's current calendar date. Dates are kept at
+// UTC midnight, the way DATE columns are read.
+func SchoolToday() time.Time {
+	return SchoolDate(now())
+}
+
+// SchoolDate returns the school calendar date the instant falls on
+func SchoolDate(t time.Time) time.Time {
+	y, m, d := t.In(schoolLocation).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// SchoolDateTime combines a calendar date and a wall clock into an instant
+// in the school time zone. Clocks skipped by a DST change move forward.
+func SchoolDateTime(date, clock time.Time) time.Time {
+	y, m, d := date.Date()
+	h, min, s := clock.Clock()
+	return time.Date(y, m, d, h, min, s, 0, schoolLocation)
+}
+
+// Helper functions for TIMESTAMP conversion
+
+func ConvertTimeToPgTimestamp(t time.Time) pgtype.Timestamp {
+	// TIMESTAMP has no zone, store the school wall clock
+	wall := t.In(schoolLocation)
+	return pgtype.Timestamp{
+		Time:  time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), wall.Nanosecond(), time.UTC),
+		Valid: true,
+	}
+}
+
+func ConvertPgTimestampToTime(ts pgtype.Timestamp) time.Time {
+	t := ts.Time
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), schoolLocation)
+}
+
+func ConvertNullableTimeToPgTimestamp(t *time.Time) pgtype.Timestamp {
+	if t == nil {
+		return pgtype.Timestamp{}
+	}
+	return ConvertTimeToPgTimestamp(*t)
+}
+
+func ConvertPgTimestampToNullableTime(ts pgtype.Timestamp) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ConvertPgTimestampToTime(ts)
+	return &t
+}
@@ -0,0 +1,70 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+func useSchool(t *testing.T, zone string, instant time.Time) {
+	t.Helper()
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		t.Fatalf("load %s: %v", zone, err)
+	}
+	SetSchoolLocation(loc)
+	restore := SetNow(func() time.Time { return instant })
+	t.Cleanup(func() {
+		restore()
+		SetSchoolLocation(nil)
+	})
+}
+
+func TestSchoolTodayAroundMidnight(t *testing.T) {
+	// 22:30 UTC is already 01:30 the next day in Istanbul
+	useSchool(t, "Europe/Istanbul", time.Date(2026, 3, 9, 22, 30, 0, 0, time.UTC))
+
+	today := SchoolToday()
+	if want := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC); !today.Equal(want) {
+		t.Fatalf("expected school date %s, got %s", want, today)
+	}
+
+	// 20:59 UTC is still the same day
+	restore := SetNow(func() time.Time { return time.Date(2026, 3, 9, 20, 59, 0, 0, time.UTC) })
+	defer restore()
+	if want := time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC); !SchoolToday().Equal(want) {
+		t.Fatalf("expected school date %s, got %s", want, SchoolToday())
+	}
+}
+
+func TestSchoolDateTimeAcrossDST(t *testing.T) {
+	useSchool(t, "Europe/Berlin", time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	clock := time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	// Berlin moves from UTC+1 to UTC+2 on 29 March 2026
+	before := SchoolDateTime(time.Date(2026, 3, 28, 0, 0, 0, 0, time.UTC), clock)
+	after := SchoolDateTime(time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC), clock)
+
+	if got := before.UTC().Hour(); got != 8 {
+		t.Fatalf("expected 09:00 before the change to be 08:00 UTC, got %02d:00", got)
+	}
+	if got := after.UTC().Hour(); got != 7 {
+		t.Fatalf("expected 09:00 after the change to be 07:00 UTC, got %02d:00", got)
+	}
+	if h, m, _ := after.Clock(); h != 9 || m != 0 {
+		t.Fatalf("expected the wall clock to stay at 09:00, got %02d:%02d", h, m)
+	}
+}
+
+func TestPgTimestampKeepsSchoolWallClock(t *testing.T) {
+	useSchool(t, "Europe/Istanbul", time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC))
+
+	due := time.Date(2026, 3, 9, 21, 30, 0, 0, time.UTC)
+	ts := ConvertTimeToPgTimestamp(due)
+	if ts.Time.Day() != 10 || ts.Time.Hour() != 0 || ts.Time.Minute() != 30 {
+		t.Fatalf("expected the school wall clock 2026-03-10 00:30 to be stored, got %s", ts.Time)
+	}
+
+	if back := ConvertPgTimestampToTime(ts); !back.Equal(due) {
+		t.Fatalf("expected %s after the round trip, got %s", due, back)
+	}
+}
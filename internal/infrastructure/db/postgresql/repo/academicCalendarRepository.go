@@ -0,0 +1,243 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AcademicCalendarRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewAcademicCalendarRepository(db *pgxpool.Pool) models.AcademicCalendarRepository {
+	return &AcademicCalendarRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (acr *AcademicCalendarRepository) CreateTerm(term *models.Term) error {
+	ctx := context.Background()
+
+	params := tutorial.CreateTermParams{
+		Name:      term.Name,
+		StartDate: pgtype.Date{Time: term.StartDate, Valid: true},
+		EndDate:   pgtype.Date{Time: term.EndDate, Valid: true},
+	}
+
+	res, err := acr.queries.CreateTerm(ctx, params)
+	if err != nil {
+		return fmt.Errorf("create term fail:%w", err)
+	}
+
+	term.ID = helper.ConvertUUIDToString(res.ID)
+	return nil
+}
+
+func (acr *AcademicCalendarRepository) GetTermByID(id string) (*models.Term, error) {
+	ctx := context.Background()
+
+	termID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid term ID: %w", err)
+	}
+
+	result, err := acr.queries.GetTermByID(ctx, termID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get term: %w", err)
+	}
+
+	term := toTermModel(result)
+	return &term, nil
+}
+
+func (acr *AcademicCalendarRepository) UpdateTerm(term *models.Term) error {
+	ctx := context.Background()
+
+	termID, err := helper.ConvertStringToUUID(term.ID)
+	if err != nil {
+		return fmt.Errorf("invalid term id:%w", err)
+	}
+
+	params := tutorial.UpdateTermParams{
+		ID:        termID,
+		Name:      term.Name,
+		StartDate: pgtype.Date{Time: term.StartDate, Valid: true},
+		EndDate:   pgtype.Date{Time: term.EndDate, Valid: true},
+	}
+
+	_, err = acr.queries.UpdateTerm(ctx, params)
+	if err != nil {
+		return fmt.Errorf("update term fail:%w", err)
+	}
+
+	return nil
+}
+
+func (acr *AcademicCalendarRepository) DeleteTerm(id string) error {
+	ctx := context.Background()
+
+	termID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid term id:%w", err)
+	}
+
+	err = acr.queries.DeleteTerm(ctx, termID)
+	if err != nil {
+		return fmt.Errorf("delete term fail:%w", err)
+	}
+	return nil
+}
+
+func (acr *AcademicCalendarRepository) GetAllTerms() ([]models.Term, error) {
+	ctx := context.Background()
+
+	results, err := acr.queries.GetAllTerms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all terms: %w", err)
+	}
+
+	var terms []models.Term
+	for _, result := range results {
+		terms = append(terms, toTermModel(result))
+	}
+
+	return terms, nil
+}
+
+func (acr *AcademicCalendarRepository) CreateClosure(closure *models.Closure) error {
+	ctx := context.Background()
+
+	params := tutorial.CreateClosureParams{
+		Name:      closure.Name,
+		Kind:      closure.Kind,
+		StartDate: pgtype.Date{Time: closure.StartDate, Valid: true},
+		EndDate:   pgtype.Date{Time: closure.EndDate, Valid: true},
+	}
+
+	res, err := acr.queries.CreateClosure(ctx, params)
+	if err != nil {
+		return fmt.Errorf("create closure fail:%w", err)
+	}
+
+	closure.ID = helper.ConvertUUIDToString(res.ID)
+	return nil
+}
+
+func (acr *AcademicCalendarRepository) GetClosureByID(id string) (*models.Closure, error) {
+	ctx := context.Background()
+
+	closureID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid closure ID: %w", err)
+	}
+
+	result, err := acr.queries.GetClosureByID(ctx, closureID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get closure: %w", err)
+	}
+
+	closure := toClosureModel(result)
+	return &closure, nil
+}
+
+func (acr *AcademicCalendarRepository) UpdateClosure(closure *models.Closure) error {
+	ctx := context.Background()
+
+	closureID, err := helper.ConvertStringToUUID(closure.ID)
+	if err != nil {
+		return fmt.Errorf("invalid closure id:%w", err)
+	}
+
+	params := tutorial.UpdateClosureParams{
+		ID:        closureID,
+		Name:      closure.Name,
+		Kind:      closure.Kind,
+		StartDate: pgtype.Date{Time: closure.StartDate, Valid: true},
+		EndDate:   pgtype.Date{Time: closure.EndDate, Valid: true},
+	}
+
+	_, err = acr.queries.UpdateClosure(ctx, params)
+	if err != nil {
+		return fmt.Errorf("update closure fail:%w", err)
+	}
+
+	return nil
+}
+
+func (acr *AcademicCalendarRepository) DeleteClosure(id string) error {
+	ctx := context.Background()
+
+	closureID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid closure id:%w", err)
+	}
+
+	err = acr.queries.DeleteClosure(ctx, closureID)
+	if err != nil {
+		return fmt.Errorf("delete closure fail:%w", err)
+	}
+	return nil
+}
+
+func (acr *AcademicCalendarRepository) GetAllClosures() ([]models.Closure, error) {
+	ctx := context.Background()
+
+	results, err := acr.queries.GetAllClosures(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all closures: %w", err)
+	}
+
+	return toClosureModels(results), nil
+}
+
+func (acr *AcademicCalendarRepository) GetClosuresBetween(from, to time.Time) ([]models.Closure, error) {
+	ctx := context.Background()
+
+	params := tutorial.GetClosuresBetweenParams{
+		FromDate: pgtype.Date{Time: from, Valid: true},
+		ToDate:   pgtype.Date{Time: to, Valid: true},
+	}
+
+	results, err := acr.queries.GetClosuresBetween(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get closures: %w", err)
+	}
+
+	return toClosureModels(results), nil
+}
+
+func toTermModel(result tutorial.Term) models.Term {
+	return models.Term{
+		ID:        helper.ConvertUUIDToString(result.ID),
+		Name:      result.Name,
+		StartDate: result.StartDate.Time,
+		EndDate:   result.EndDate.Time,
+	}
+}
+
+func toClosureModels(results []tutorial.Closure) []models.Closure {
+	var closures []models.Closure
+	for _, result := range results {
+		closures = append(closures, toClosureModel(result))
+	}
+	return closures
+}
+
+func toClosureModel(result tutorial.Closure) models.Closure {
+	return models.Closure{
+		ID:        helper.ConvertUUIDToString(result.ID),
+		Name:      result.Name,
+		Kind:      result.Kind,
+		StartDate: result.StartDate.Time,
+		EndDate:   result.EndDate.Time,
+	}
+}
@@ -0,0 +1,245 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AttendanceAlertRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewAttendanceAlertRepository(db *pgxpool.Pool) models.AttendanceAlertRepository {
+	return &AttendanceAlertRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (ar *AttendanceAlertRepository) CreateThreshold(threshold *models.AttendanceThreshold) error {
+	ctx := context.Background()
+	lessonID, err := helper.ConvertNullableStringToUUID(threshold.LessonID)
+	if err != nil {
+		return fmt.Errorf("invalid lesson id:%w", err)
+	}
+
+	res, err := ar.queries.CreateAttendanceThreshold(ctx, tutorial.CreateAttendanceThresholdParams{
+		LessonID:   lessonID,
+		MinRate:    threshold.MinRate,
+		MinLessons: int32(threshold.MinLessons),
+	})
+	if err != nil {
+		return fmt.Errorf("create attendance threshold fail:%w", err)
+	}
+
+	*threshold = toAttendanceThresholdModel(res)
+	return nil
+}
+
+func (ar *AttendanceAlertRepository) GetThresholdByID(id string) (*models.AttendanceThreshold, error) {
+	ctx := context.Background()
+	thresholdID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid threshold id: %w", err)
+	}
+
+	res, err := ar.queries.GetAttendanceThresholdByID(ctx, thresholdID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance threshold: %w", err)
+	}
+
+	threshold := toAttendanceThresholdModel(res)
+	return &threshold, nil
+}
+
+func (ar *AttendanceAlertRepository) GetThresholds() ([]models.AttendanceThreshold, error) {
+	ctx := context.Background()
+
+	results, err := ar.queries.GetAttendanceThresholds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance thresholds: %w", err)
+	}
+
+	var thresholds []models.AttendanceThreshold
+	for _, result := range results {
+		thresholds = append(thresholds, toAttendanceThresholdModel(result))
+	}
+	return thresholds, nil
+}
+
+func (ar *AttendanceAlertRepository) UpdateThreshold(threshold *models.AttendanceThreshold) error {
+	ctx := context.Background()
+	thresholdID, err := helper.ConvertStringToUUID(threshold.ID)
+	if err != nil {
+		return fmt.Errorf("invalid threshold id:%w", err)
+	}
+
+	res, err := ar.queries.UpdateAttendanceThreshold(ctx, tutorial.UpdateAttendanceThresholdParams{
+		ID:         thresholdID,
+		MinRate:    threshold.MinRate,
+		MinLessons: int32(threshold.MinLessons),
+	})
+	if err != nil {
+		return fmt.Errorf("update attendance threshold fail:%w", err)
+	}
+
+	*threshold = toAttendanceThresholdModel(res)
+	return nil
+}
+
+func (ar *AttendanceAlertRepository) DeleteThreshold(id string) error {
+	ctx := context.Background()
+	thresholdID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid threshold id:%w", err)
+	}
+
+	if err := ar.queries.DeleteAttendanceThreshold(ctx, thresholdID); err != nil {
+		return fmt.Errorf("delete attendance threshold fail:%w", err)
+	}
+	return nil
+}
+
+func (ar *AttendanceAlertRepository) CreateAlert(alert *models.AttendanceAlert) error {
+	ctx := context.Background()
+	studentID, err := helper.ConvertStringToUUID(alert.StudentID)
+	if err != nil {
+		return fmt.Errorf("invalid student id:%w", err)
+	}
+
+	lessonID, err := helper.ConvertNullableStringToUUID(alert.LessonID)
+	if err != nil {
+		return fmt.Errorf("invalid lesson id:%w", err)
+	}
+
+	thresholdID, err := helper.ConvertNullableStringToUUID(alert.ThresholdID)
+	if err != nil {
+		return fmt.Errorf("invalid threshold id:%w", err)
+	}
+
+	recipientIDs := make([]pgtype.UUID, 0, len(alert.RecipientIDs))
+	for _, id := range alert.RecipientIDs {
+		recipientID, err := helper.ConvertStringToUUID(id)
+		if err != nil {
+			return fmt.Errorf("invalid recipient id:%w", err)
+		}
+		recipientIDs = append(recipientIDs, recipientID)
+	}
+
+	res, err := ar.queries.CreateAttendanceAlert(ctx, tutorial.CreateAttendanceAlertParams{
+		StudentID:     studentID,
+		LessonID:      lessonID,
+		ThresholdID:   thresholdID,
+		Rate:          alert.Rate,
+		MinRate:       alert.MinRate,
+		RecipientIds:  recipientIDs,
+		GuardianPhone: alert.GuardianPhone,
+	})
+	if err != nil {
+		return fmt.Errorf("create attendance alert fail:%w", err)
+	}
+
+	*alert = toAttendanceAlertModel(res)
+	return nil
+}
+
+func (ar *AttendanceAlertRepository) GetOpenAlerts() ([]models.AttendanceAlert, error) {
+	ctx := context.Background()
+
+	results, err := ar.queries.GetOpenAttendanceAlerts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance alerts: %w", err)
+	}
+
+	return toAttendanceAlertModels(results), nil
+}
+
+func (ar *AttendanceAlertRepository) GetOpenAlertsByStudentID(studentID string) ([]models.AttendanceAlert, error) {
+	ctx := context.Background()
+	studentUUID, err := helper.ConvertStringToUUID(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid student id: %w", err)
+	}
+
+	results, err := ar.queries.GetOpenAttendanceAlertsByStudentID(ctx, studentUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance alerts: %w", err)
+	}
+
+	return toAttendanceAlertModels(results), nil
+}
+
+func (ar *AttendanceAlertRepository) GetOpenAlertsByRecipientID(recipientID string) ([]models.AttendanceAlert, error) {
+	ctx := context.Background()
+	recipientUUID, err := helper.ConvertStringToUUID(recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient id: %w", err)
+	}
+
+	results, err := ar.queries.GetOpenAttendanceAlertsByRecipientID(ctx, recipientUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance alerts: %w", err)
+	}
+
+	return toAttendanceAlertModels(results), nil
+}
+
+func (ar *AttendanceAlertRepository) ResolveAlert(id string) error {
+	ctx := context.Background()
+	alertID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid alert id:%w", err)
+	}
+
+	if err := ar.queries.ResolveAttendanceAlert(ctx, alertID); err != nil {
+		return fmt.Errorf("resolve attendance alert fail:%w", err)
+	}
+	return nil
+}
+
+func toAttendanceThresholdModel(result tutorial.AttendanceThreshold) models.AttendanceThreshold {
+	return models.AttendanceThreshold{
+		ID:         helper.ConvertUUIDToString(result.ID),
+		LessonID:   helper.ConvertUUIDToString(result.LessonID),
+		MinRate:    result.MinRate,
+		MinLessons: int(result.MinLessons),
+		CreatedAt:  helper.ConvertPgTimestampToTime(result.CreatedAt),
+		UpdatedAt:  helper.ConvertPgTimestampToTime(result.UpdatedAt),
+	}
+}
+
+func toAttendanceAlertModel(result tutorial.AttendanceAlert) models.AttendanceAlert {
+	recipientIDs := make([]string, 0, len(result.RecipientIds))
+	for _, id := range result.RecipientIds {
+		recipientIDs = append(recipientIDs, helper.ConvertUUIDToString(id))
+	}
+
+	return models.AttendanceAlert{
+		ID:            helper.ConvertUUIDToString(result.ID),
+		StudentID:     helper.ConvertUUIDToString(result.StudentID),
+		LessonID:      helper.ConvertUUIDToString(result.LessonID),
+		ThresholdID:   helper.ConvertUUIDToString(result.ThresholdID),
+		Rate:          result.Rate,
+		MinRate:       result.MinRate,
+		RecipientIDs:  recipientIDs,
+		GuardianPhone: result.GuardianPhone,
+		Status:        result.Status,
+		CreatedAt:     helper.ConvertPgTimestampToTime(result.CreatedAt),
+		ResolvedAt:    helper.ConvertPgTimestampToNullableTime(result.ResolvedAt),
+	}
+}
+
+func toAttendanceAlertModels(results []tutorial.AttendanceAlert) []models.AttendanceAlert {
+	var alerts []models.AttendanceAlert
+	for _, result := range results {
+		alerts = append(alerts, toAttendanceAlertModel(result))
+	}
+	return alerts
+}
@@ -0,0 +1,79 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AttendanceReportRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewAttendanceReportRepository(db *pgxpool.Pool) models.AttendanceReportRepository {
+	return &AttendanceReportRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (ar *AttendanceReportRepository) GetAttendanceReport(filter models.AttendanceReportFilter) ([]models.AttendanceReportRow, error) {
+	ctx := context.Background()
+	classID, err := helper.ConvertNullableStringToUUID(filter.ClassID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid class id: %w", err)
+	}
+
+	lessonID, err := helper.ConvertNullableStringToUUID(filter.LessonID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lesson id: %w", err)
+	}
+
+	teacherID, err := helper.ConvertNullableStringToUUID(filter.TeacherID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid teacher id: %w", err)
+	}
+
+	studentID, err := helper.ConvertNullableStringToUUID(filter.StudentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid student id: %w", err)
+	}
+
+	results, err := ar.queries.GetAttendanceReport(ctx, tutorial.GetAttendanceReportParams{
+		GroupBy:   filter.GroupBy,
+		Period:    filter.Period,
+		FromDate:  helper.ConvertNullableTimeToPgDate(&filter.From),
+		ToDate:    helper.ConvertNullableTimeToPgDate(&filter.To),
+		ClassID:   classID,
+		LessonID:  lessonID,
+		TeacherID: teacherID,
+		StudentID: studentID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance report: %w", err)
+	}
+
+	var rows []models.AttendanceReportRow
+	for _, result := range results {
+		rows = append(rows, models.AttendanceReportRow{
+			GroupID:     helper.ConvertUUIDToString(result.GroupID),
+			PeriodStart: result.PeriodStart.Time,
+			Rate:        result.Rate,
+			Lessons:     int(result.Lessons),
+			Breakdown: map[string]int{
+				models.AttendancePresent: int(result.Present),
+				models.AttendanceLate:    int(result.Late),
+				models.AttendanceRemote:  int(result.Remote),
+				models.AttendanceExcused: int(result.Excused),
+				models.AttendanceAbsent:  int(result.Absent),
+			},
+			MinutesLate: int(result.MinutesLate),
+		})
+	}
+	return rows, nil
+}
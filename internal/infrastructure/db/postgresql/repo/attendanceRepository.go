@@ -6,6 +6,7 @@ import (
 	"Education_Dashboard/internal/models"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -22,7 +23,7 @@ func NewAttendanceRepository(db *pgxpool.Pool) models.AttendanceRepository {
 	}
 }
 
-func (ar AttendanceRepository) CreateAttendance(attendance *models.Attendance) error {
+func (ar AttendanceRepository) CreateAttendance(attendance *models.Attendance, audit *models.AttendanceAudit) error {
 	ctx := context.Background()
 	studentID, err := helper.ConvertStringToUUID(attendance.StudentID)
 	if err != nil {
@@ -35,18 +36,37 @@ func (ar AttendanceRepository) CreateAttendance(attendance *models.Attendance) e
 	}
 
 	params := tutorial.CreateAttendanceParams{
-		StudentID:  studentID,
-		ScheduleID: scheduleID,
-		Here:       attendance.Here,
-		Counter:    int32(attendance.Counter),
+		StudentID:   studentID,
+		ScheduleID:  scheduleID,
+		Counter:     int32(attendance.Counter),
+		Status:      attendance.Status,
+		MinutesLate: int32(attendance.MinutesLate),
+		Note:        attendance.Note,
 	}
 
-	result, err := ar.queries.CreateAttendance(ctx, params)
+	tx, err := ar.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction fail:%w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := ar.queries.WithTx(tx)
+	result, err := qtx.CreateAttendance(ctx, params)
 	if err != nil {
 		return fmt.Errorf("attendance create failed :%w", err)
 
 	}
 
+	if audit != nil {
+		if err := createExcuseAudit(ctx, qtx, result.ID, audit); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction fail:%w", err)
+	}
+
 	attendance.ID = helper.ConvertUUIDToString(result.ID)
 	return nil
 }
@@ -63,20 +83,13 @@ func (ar AttendanceRepository) GetAttendanceByID(id string) (*models.Attendance,
 		return nil, fmt.Errorf("get attendance by id fail:%w", err)
 	}
 
-	attendance := &models.Attendance{
-		ID:        helper.ConvertUUIDToString(res.ID),
-		StudentID: helper.ConvertUUIDToString(res.StudentID),
-		Here:      res.Here,
-		Counter:   int(res.Counter),
-	}
-
-	return attendance, nil
+	attendance := toAttendanceModel(res)
+	return &attendance, nil
 }
 
-func (ar AttendanceRepository) UpdateAttendance(attendance *models.Attendance) error {
+func (ar AttendanceRepository) UpdateAttendance(attendance *models.Attendance, audit *models.AttendanceAudit) error {
 	ctx := context.Background()
 
-	
 	attendanceID, err := helper.ConvertStringToUUID(attendance.ID)
 	if err != nil {
 		return fmt.Errorf("invalid attendance ID: %w", err)
@@ -93,17 +106,36 @@ func (ar AttendanceRepository) UpdateAttendance(attendance *models.Attendance) e
 	}
 
 	params := tutorial.UpdateAttendanceParams{
-		ID:         attendanceID,
-		StudentID:  studentID,
-		ScheduleID: scheduleID,
-		Here:       attendance.Here,
-		Counter:    int32(attendance.Counter),
+		ID:          attendanceID,
+		StudentID:   studentID,
+		ScheduleID:  scheduleID,
+		Counter:     int32(attendance.Counter),
+		Status:      attendance.Status,
+		MinutesLate: int32(attendance.MinutesLate),
+		Note:        attendance.Note,
 	}
 
-	_,err = ar.queries.UpdateAttendance(ctx,params)
+	tx, err := ar.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction fail:%w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := ar.queries.WithTx(tx)
+	_, err = qtx.UpdateAttendance(ctx, params)
 	if err != nil {
 		return fmt.Errorf("failed to update attendance: %w", err)
 	}
+
+	if audit != nil {
+		if err := createExcuseAudit(ctx, qtx, attendanceID, audit); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction fail:%w", err)
+	}
 	return nil
 }
 
@@ -135,14 +167,7 @@ func (ar AttendanceRepository) GetAttendanceByStudentID(studentID string) ([]mod
 
 	var attendances []models.Attendance
 	for _, result := range res {
-		attendance := models.Attendance{
-			ID:         helper.ConvertUUIDToString(result.ID),
-			StudentID:  helper.ConvertUUIDToString(result.StudentID),
-			ScheduleID: helper.ConvertUUIDToString(result.ScheduleID),
-			Here:       result.Here,
-			Counter:    int(result.Counter),
-		}
-		attendances = append(attendances, attendance)
+		attendances = append(attendances, toAttendanceModel(result))
 	}
 	return attendances, nil
 }
@@ -153,22 +178,138 @@ func (ar AttendanceRepository) GetAttendanceByScheduleID(scheduleID string) ([]m
 		return nil, fmt.Errorf("invalid schedule ID: %w", err)
 	}
 
-	res,err := ar.queries.GetAttendanceByScheduleID(ctx,scheduleUUID)
-	if err != nil{
-		return nil,fmt.Errorf("getAttendanceByScheduleID failed : %w",err)
+	res, err := ar.queries.GetAttendanceByScheduleID(ctx, scheduleUUID)
+	if err != nil {
+		return nil, fmt.Errorf("getAttendanceByScheduleID failed : %w", err)
 	}
 
 	var attendances []models.Attendance
 	for _, result := range res {
-		attendance := models.Attendance{
-			ID:         helper.ConvertUUIDToString(result.ID),
-			StudentID:  helper.ConvertUUIDToString(result.StudentID),
-			ScheduleID: helper.ConvertUUIDToString(result.ScheduleID),
-			Here:       result.Here,
-			Counter:    int(result.Counter),
-		}
-		attendances = append(attendances, attendance)
+		attendances = append(attendances, toAttendanceModel(result))
+	}
+
+	return attendances, nil
+}
+
+func (ar AttendanceRepository) GetAttendanceByClassID(classID string, from, to time.Time) ([]models.Attendance, error) {
+	ctx := context.Background()
+	classUUID, err := helper.ConvertStringToUUID(classID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid class ID: %w", err)
+	}
+
+	res, err := ar.queries.GetAttendanceByClassID(ctx, tutorial.GetAttendanceByClassIDParams{
+		ClassID:  classUUID,
+		FromDate: helper.ConvertNullableTimeToPgDate(&from),
+		ToDate:   helper.ConvertNullableTimeToPgDate(&to),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getAttendanceByClassID failed : %w", err)
+	}
+
+	var attendances []models.Attendance
+	for _, result := range res {
+		attendances = append(attendances, toAttendanceModel(result))
 	}
 
 	return attendances, nil
 }
+
+func (ar AttendanceRepository) SaveAttendances(attendances []models.Attendance, audits map[string]models.AttendanceAudit) error {
+	ctx := context.Background()
+
+	tx, err := ar.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction fail:%w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := ar.queries.WithTx(tx)
+	saved := make([]models.Attendance, 0, len(attendances))
+	for _, attendance := range attendances {
+		studentID, err := helper.ConvertStringToUUID(attendance.StudentID)
+		if err != nil {
+			return fmt.Errorf("invalid student id :%w", err)
+		}
+
+		scheduleID, err := helper.ConvertStringToUUID(attendance.ScheduleID)
+		if err != nil {
+			return fmt.Errorf("invalid schedule id :%w", err)
+		}
+
+		res, err := qtx.UpsertAttendance(ctx, tutorial.UpsertAttendanceParams{
+			StudentID:   studentID,
+			ScheduleID:  scheduleID,
+			Counter:     int32(attendance.Counter),
+			Status:      attendance.Status,
+			MinutesLate: int32(attendance.MinutesLate),
+			Note:        attendance.Note,
+		})
+		if err != nil {
+			return fmt.Errorf("save attendance fail:%w", err)
+		}
+
+		if audit, ok := audits[attendance.StudentID]; ok {
+			if err := createExcuseAudit(ctx, qtx, res.ID, &audit); err != nil {
+				return err
+			}
+			audits[attendance.StudentID] = audit
+		}
+		saved = append(saved, toAttendanceModel(res))
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction fail:%w", err)
+	}
+
+	copy(attendances, saved)
+	return nil
+}
+
+func (ar AttendanceRepository) GetAttendancePolicy() (*models.AttendancePolicy, error) {
+	ctx := context.Background()
+
+	res, err := ar.queries.GetAttendancePolicy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance policy: %w", err)
+	}
+
+	return &models.AttendancePolicy{
+		PresentWeight: res.PresentWeight,
+		LateWeight:    res.LateWeight,
+		RemoteWeight:  res.RemoteWeight,
+		ExcusedWeight: res.ExcusedWeight,
+		CountExcused:  res.CountExcused,
+		UpdatedAt:     helper.ConvertPgTimestampToTime(res.UpdatedAt),
+	}, nil
+}
+
+func (ar AttendanceRepository) UpdateAttendancePolicy(policy *models.AttendancePolicy) error {
+	ctx := context.Background()
+
+	res, err := ar.queries.UpdateAttendancePolicy(ctx, tutorial.UpdateAttendancePolicyParams{
+		PresentWeight: policy.PresentWeight,
+		LateWeight:    policy.LateWeight,
+		RemoteWeight:  policy.RemoteWeight,
+		ExcusedWeight: policy.ExcusedWeight,
+		CountExcused:  policy.CountExcused,
+	})
+	if err != nil {
+		return fmt.Errorf("update attendance policy fail:%w", err)
+	}
+
+	policy.UpdatedAt = helper.ConvertPgTimestampToTime(res.UpdatedAt)
+	return nil
+}
+
+func toAttendanceModel(result tutorial.Attendance) models.Attendance {
+	return models.Attendance{
+		ID:          helper.ConvertUUIDToString(result.ID),
+		StudentID:   helper.ConvertUUIDToString(result.StudentID),
+		ScheduleID:  helper.ConvertUUIDToString(result.ScheduleID),
+		Status:      result.Status,
+		MinutesLate: int(result.MinutesLate),
+		Note:        result.Note,
+		Counter:     int(result.Counter),
+	}
+}
@@ -0,0 +1,157 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AvailabilityRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewAvailabilityRepository(db *pgxpool.Pool) models.AvailabilityRepository {
+	return &AvailabilityRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (ar *AvailabilityRepository) CreateAvailabilityWindow(window *models.AvailabilityWindow) error {
+	ctx := context.Background()
+
+	teacherID, err := helper.ConvertStringToUUID(window.TeacherID)
+	if err != nil {
+		return fmt.Errorf("invalid teacher id:%w", err)
+	}
+
+	params := tutorial.CreateTeacherAvailabilityParams{
+		TeacherID: teacherID,
+		Kind:      window.Kind,
+		Weekday:   toPgWeekday(window.Weekday),
+		Date:      helper.ConvertNullableTimeToPgDate(window.Date),
+		StartTime: helper.ConvertTimeToPgTime(window.Time),
+		EndTime:   helper.ConvertTimeToPgTime(window.EndTime),
+		Note:      window.Note,
+	}
+
+	res, err := ar.queries.CreateTeacherAvailability(ctx, params)
+	if err != nil {
+		return fmt.Errorf("create availability window fail:%w", err)
+	}
+
+	window.ID = helper.ConvertUUIDToString(res.ID)
+	window.CreatedAt = helper.ConvertPgTimestampToTime(res.CreatedAt)
+	return nil
+}
+
+func (ar *AvailabilityRepository) GetAvailabilityWindowByID(id string) (*models.AvailabilityWindow, error) {
+	ctx := context.Background()
+
+	windowID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid availability window ID: %w", err)
+	}
+
+	result, err := ar.queries.GetTeacherAvailabilityByID(ctx, windowID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability window: %w", err)
+	}
+
+	window := toAvailabilityWindowModel(result)
+	return &window, nil
+}
+
+func (ar *AvailabilityRepository) UpdateAvailabilityWindow(window *models.AvailabilityWindow) error {
+	ctx := context.Background()
+
+	windowID, err := helper.ConvertStringToUUID(window.ID)
+	if err != nil {
+		return fmt.Errorf("invalid availability window id:%w", err)
+	}
+
+	params := tutorial.UpdateTeacherAvailabilityParams{
+		ID:        windowID,
+		Kind:      window.Kind,
+		Weekday:   toPgWeekday(window.Weekday),
+		Date:      helper.ConvertNullableTimeToPgDate(window.Date),
+		StartTime: helper.ConvertTimeToPgTime(window.Time),
+		EndTime:   helper.ConvertTimeToPgTime(window.EndTime),
+		Note:      window.Note,
+	}
+
+	_, err = ar.queries.UpdateTeacherAvailability(ctx, params)
+	if err != nil {
+		return fmt.Errorf("update availability window fail:%w", err)
+	}
+	return nil
+}
+
+func (ar *AvailabilityRepository) DeleteAvailabilityWindow(id string) error {
+	ctx := context.Background()
+
+	windowID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid availability window id:%w", err)
+	}
+
+	err = ar.queries.DeleteTeacherAvailability(ctx, windowID)
+	if err != nil {
+		return fmt.Errorf("delete availability window fail:%w", err)
+	}
+	return nil
+}
+
+func (ar *AvailabilityRepository) GetAvailabilityWindowsByTeacherID(teacherID string) ([]models.AvailabilityWindow, error) {
+	ctx := context.Background()
+
+	teacherUUID, err := helper.ConvertStringToUUID(teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid teacher ID: %w", err)
+	}
+
+	results, err := ar.queries.GetTeacherAvailabilityByTeacherID(ctx, teacherUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get availability windows: %w", err)
+	}
+
+	var windows []models.AvailabilityWindow
+	for _, result := range results {
+		windows = append(windows, toAvailabilityWindowModel(result))
+	}
+	return windows, nil
+}
+
+func toPgWeekday(weekday *time.Weekday) pgtype.Int4 {
+	if weekday == nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: int32(*weekday), Valid: true}
+}
+
+func toAvailabilityWindowModel(result tutorial.TeacherAvailability) models.AvailabilityWindow {
+	window := models.AvailabilityWindow{
+		ID:        helper.ConvertUUIDToString(result.ID),
+		TeacherID: helper.ConvertUUIDToString(result.TeacherID),
+		Kind:      result.Kind,
+		Date:      helper.ConvertPgDateToNullableTime(result.Date),
+		Time:      helper.ConvertPgTimeToTime(result.StartTime),
+		EndTime:   helper.ConvertPgTimeToTime(result.EndTime),
+		Note:      result.Note,
+		CreatedAt: helper.ConvertPgTimestampToTime(result.CreatedAt),
+	}
+
+	if result.Weekday.Valid {
+		weekday := time.Weekday(result.Weekday.Int32)
+		window.Weekday = &weekday
+	}
+
+	return window
+}
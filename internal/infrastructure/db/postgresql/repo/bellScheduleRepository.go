@@ -0,0 +1,248 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type BellScheduleRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewBellScheduleRepository(db *pgxpool.Pool) models.BellScheduleRepository {
+	return &BellScheduleRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (br *BellScheduleRepository) CreateBellSchedule(bellSchedule *models.BellSchedule) error {
+	ctx := context.Background()
+
+	tx, err := br.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction fail:%w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Stored inactive first, the active flag is set once the others are cleared
+	qtx := br.queries.WithTx(tx)
+	res, err := qtx.CreateBellSchedule(ctx, tutorial.CreateBellScheduleParams{Name: bellSchedule.Name})
+	if err != nil {
+		return fmt.Errorf("create bell schedule fail:%w", err)
+	}
+
+	if bellSchedule.Active {
+		if err := qtx.DeactivateBellSchedules(ctx, res.ID); err != nil {
+			return fmt.Errorf("deactivate bell schedules fail:%w", err)
+		}
+
+		params := tutorial.UpdateBellScheduleParams{ID: res.ID, Name: bellSchedule.Name, Active: true}
+		if _, err := qtx.UpdateBellSchedule(ctx, params); err != nil {
+			return fmt.Errorf("update bell schedule fail:%w", err)
+		}
+	}
+
+	for i := range bellSchedule.Periods {
+		period, err := qtx.CreateBellPeriod(ctx, createBellPeriodParams(res.ID, bellSchedule.Periods[i]))
+		if err != nil {
+			return fmt.Errorf("create bell period fail:%w", err)
+		}
+		bellSchedule.Periods[i].ID = helper.ConvertUUIDToString(period.ID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction fail:%w", err)
+	}
+
+	bellSchedule.ID = helper.ConvertUUIDToString(res.ID)
+	bellSchedule.CreatedAt = helper.ConvertPgTimestampToTime(res.CreatedAt)
+	return nil
+}
+
+func (br *BellScheduleRepository) GetBellScheduleByID(id string) (*models.BellSchedule, error) {
+	ctx := context.Background()
+	bellScheduleID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bell schedule id: %w", err)
+	}
+
+	res, err := br.queries.GetBellScheduleByID(ctx, bellScheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bell schedule: %w", err)
+	}
+
+	bellSchedule, err := br.withPeriods(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+	return &bellSchedule, nil
+}
+
+func (br *BellScheduleRepository) UpdateBellSchedule(bellSchedule *models.BellSchedule) error {
+	ctx := context.Background()
+	bellScheduleID, err := helper.ConvertStringToUUID(bellSchedule.ID)
+	if err != nil {
+		return fmt.Errorf("invalid bell schedule id:%w", err)
+	}
+
+	existing, err := br.queries.GetBellPeriodsByScheduleID(ctx, bellScheduleID)
+	if err != nil {
+		return fmt.Errorf("failed to get bell periods: %w", err)
+	}
+
+	tx, err := br.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction fail:%w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := br.queries.WithTx(tx)
+	if bellSchedule.Active {
+		if err := qtx.DeactivateBellSchedules(ctx, bellScheduleID); err != nil {
+			return fmt.Errorf("deactivate bell schedules fail:%w", err)
+		}
+	}
+
+	params := tutorial.UpdateBellScheduleParams{ID: bellScheduleID, Name: bellSchedule.Name, Active: bellSchedule.Active}
+	if _, err := qtx.UpdateBellSchedule(ctx, params); err != nil {
+		return fmt.Errorf("update bell schedule fail:%w", err)
+	}
+
+	kept := map[string]bool{}
+	for i := range bellSchedule.Periods {
+		period := bellSchedule.Periods[i]
+		if period.ID == "" {
+			res, err := qtx.CreateBellPeriod(ctx, createBellPeriodParams(bellScheduleID, period))
+			if err != nil {
+				return fmt.Errorf("create bell period fail:%w", err)
+			}
+			bellSchedule.Periods[i].ID = helper.ConvertUUIDToString(res.ID)
+			continue
+		}
+
+		periodID, err := helper.ConvertStringToUUID(period.ID)
+		if err != nil {
+			return fmt.Errorf("invalid bell period id:%w", err)
+		}
+
+		create := createBellPeriodParams(bellScheduleID, period)
+		_, err = qtx.UpdateBellPeriod(ctx, tutorial.UpdateBellPeriodParams{
+			ID:        periodID,
+			Name:      create.Name,
+			Position:  create.Position,
+			Weekdays:  create.Weekdays,
+			StartTime: create.StartTime,
+			EndTime:   create.EndTime,
+		})
+		if err != nil {
+			return fmt.Errorf("update bell period fail:%w", err)
+		}
+		kept[period.ID] = true
+	}
+
+	// Periods left out are removed, lessons held in them lose the link
+	for _, period := range existing {
+		if !kept[helper.ConvertUUIDToString(period.ID)] {
+			if err := qtx.DeleteBellPeriod(ctx, period.ID); err != nil {
+				return fmt.Errorf("delete bell period fail:%w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction fail:%w", err)
+	}
+	return nil
+}
+
+func (br *BellScheduleRepository) DeleteBellSchedule(id string) error {
+	ctx := context.Background()
+	bellScheduleID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid bell schedule id:%w", err)
+	}
+
+	err = br.queries.DeleteBellSchedule(ctx, bellScheduleID)
+	if err != nil {
+		return fmt.Errorf("delete bell schedule fail:%w", err)
+	}
+	return nil
+}
+
+func (br *BellScheduleRepository) GetAllBellSchedules() ([]models.BellSchedule, error) {
+	ctx := context.Background()
+
+	results, err := br.queries.GetAllBellSchedules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bell schedules: %w", err)
+	}
+
+	var bellSchedules []models.BellSchedule
+	for _, result := range results {
+		bellSchedule, err := br.withPeriods(ctx, result)
+		if err != nil {
+			return nil, err
+		}
+		bellSchedules = append(bellSchedules, bellSchedule)
+	}
+	return bellSchedules, nil
+}
+
+// withPeriods converts the bell schedule and loads its periods
+func (br *BellScheduleRepository) withPeriods(ctx context.Context, result tutorial.BellSchedule) (models.BellSchedule, error) {
+	periods, err := br.queries.GetBellPeriodsByScheduleID(ctx, result.ID)
+	if err != nil {
+		return models.BellSchedule{}, fmt.Errorf("failed to get bell periods: %w", err)
+	}
+
+	bellSchedule := models.BellSchedule{
+		ID:        helper.ConvertUUIDToString(result.ID),
+		Name:      result.Name,
+		Active:    result.Active,
+		Periods:   make([]models.BellPeriod, 0, len(periods)),
+		CreatedAt: helper.ConvertPgTimestampToTime(result.CreatedAt),
+	}
+
+	for _, period := range periods {
+		weekdays := make([]time.Weekday, 0, len(period.Weekdays))
+		for _, weekday := range period.Weekdays {
+			weekdays = append(weekdays, time.Weekday(weekday))
+		}
+
+		bellSchedule.Periods = append(bellSchedule.Periods, models.BellPeriod{
+			ID:       helper.ConvertUUIDToString(period.ID),
+			Name:     period.Name,
+			Position: int(period.Position),
+			Weekdays: weekdays,
+			Time:     helper.ConvertPgTimeToTime(period.StartTime),
+			EndTime:  helper.ConvertPgTimeToTime(period.EndTime),
+		})
+	}
+
+	return bellSchedule, nil
+}
+
+func createBellPeriodParams(bellScheduleID pgtype.UUID, period models.BellPeriod) tutorial.CreateBellPeriodParams {
+	weekdays := make([]int32, 0, len(period.Weekdays))
+	for _, weekday := range period.Weekdays {
+		weekdays = append(weekdays, int32(weekday))
+	}
+
+	return tutorial.CreateBellPeriodParams{
+		BellScheduleID: bellScheduleID,
+		Name:           period.Name,
+		Position:       int32(period.Position),
+		Weekdays:       weekdays,
+		StartTime:      helper.ConvertTimeToPgTime(period.Time),
+		EndTime:        helper.ConvertTimeToPgTime(period.EndTime),
+	}
+}
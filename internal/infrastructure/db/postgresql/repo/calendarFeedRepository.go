@@ -0,0 +1,129 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CalendarFeedRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewCalendarFeedRepository(db *pgxpool.Pool) models.CalendarFeedRepository {
+	return &CalendarFeedRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (cfr *CalendarFeedRepository) CreateCalendarFeed(feed *models.CalendarFeed) error {
+	ctx := context.Background()
+
+	ownerID, err := helper.ConvertStringToUUID(feed.OwnerID)
+	if err != nil {
+		return fmt.Errorf("invalid owner id:%w", err)
+	}
+
+	createdBy, err := helper.ConvertStringToUUID(feed.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("invalid user id:%w", err)
+	}
+
+	params := tutorial.CreateCalendarFeedParams{
+		Token:     feed.Token,
+		OwnerType: feed.OwnerType,
+		OwnerID:   ownerID,
+		CreatedBy: createdBy,
+	}
+
+	res, err := cfr.queries.CreateCalendarFeed(ctx, params)
+	if err != nil {
+		return fmt.Errorf("create calendar feed fail:%w", err)
+	}
+
+	feed.ID = helper.ConvertUUIDToString(res.ID)
+	feed.CreatedAt = helper.ConvertPgTimestampToTime(res.CreatedAt)
+	return nil
+}
+
+func (cfr *CalendarFeedRepository) GetCalendarFeedByID(id string) (*models.CalendarFeed, error) {
+	ctx := context.Background()
+
+	feedID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feed id: %w", err)
+	}
+
+	res, err := cfr.queries.GetCalendarFeedByID(ctx, feedID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	feed := toCalendarFeedModel(res)
+	return &feed, nil
+}
+
+func (cfr *CalendarFeedRepository) GetCalendarFeedByToken(token string) (*models.CalendarFeed, error) {
+	ctx := context.Background()
+
+	res, err := cfr.queries.GetCalendarFeedByToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar feed: %w", err)
+	}
+
+	feed := toCalendarFeedModel(res)
+	return &feed, nil
+}
+
+func (cfr *CalendarFeedRepository) GetCalendarFeedsByCreator(userID string) ([]models.CalendarFeed, error) {
+	ctx := context.Background()
+
+	createdBy, err := helper.ConvertStringToUUID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	results, err := cfr.queries.GetCalendarFeedsByCreator(ctx, createdBy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get calendar feeds: %w", err)
+	}
+
+	var feeds []models.CalendarFeed
+	for _, result := range results {
+		feeds = append(feeds, toCalendarFeedModel(result))
+	}
+
+	return feeds, nil
+}
+
+func (cfr *CalendarFeedRepository) DeleteCalendarFeed(id string) error {
+	ctx := context.Background()
+
+	feedID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid feed id:%w", err)
+	}
+
+	err = cfr.queries.DeleteCalendarFeed(ctx, feedID)
+	if err != nil {
+		return fmt.Errorf("delete calendar feed fail:%w", err)
+	}
+	return nil
+}
+
+func toCalendarFeedModel(result tutorial.CalendarFeed) models.CalendarFeed {
+	return models.CalendarFeed{
+		ID:        helper.ConvertUUIDToString(result.ID),
+		Token:     result.Token,
+		OwnerType: result.OwnerType,
+		OwnerID:   helper.ConvertUUIDToString(result.OwnerID),
+		CreatedBy: helper.ConvertUUIDToString(result.CreatedBy),
+		CreatedAt: helper.ConvertPgTimestampToTime(result.CreatedAt),
+	}
+}
@@ -0,0 +1,197 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CheckInRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewCheckInRepository(db *pgxpool.Pool) models.CheckInRepository {
+	return &CheckInRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (cr *CheckInRepository) CreateSession(session *models.CheckInSession) error {
+	ctx := context.Background()
+	scheduleID, err := helper.ConvertStringToUUID(session.ScheduleID)
+	if err != nil {
+		return fmt.Errorf("invalid schedule id:%w", err)
+	}
+
+	openedBy, err := helper.ConvertStringToUUID(session.OpenedBy)
+	if err != nil {
+		return fmt.Errorf("invalid teacher id:%w", err)
+	}
+
+	res, err := cr.queries.CreateCheckInSession(ctx, tutorial.CreateCheckInSessionParams{
+		ScheduleID:       scheduleID,
+		OpenedBy:         openedBy,
+		Secret:           session.Secret,
+		CodeSeconds:      int32(session.CodeSeconds),
+		LateAfterMinutes: int32(*session.LateAfterMinutes),
+		OpenedAt:         helper.ConvertTimeToPgTimestamp(session.OpenedAt),
+		ClosesAt:         helper.ConvertTimeToPgTimestamp(session.ClosesAt),
+	})
+	if err != nil {
+		return fmt.Errorf("create check-in session fail:%w", err)
+	}
+
+	*session = toCheckInSessionModel(res)
+	return nil
+}
+
+func (cr *CheckInRepository) GetSessionByID(id string) (*models.CheckInSession, error) {
+	ctx := context.Background()
+	sessionID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session id: %w", err)
+	}
+
+	res, err := cr.queries.GetCheckInSessionByID(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check-in session: %w", err)
+	}
+
+	session := toCheckInSessionModel(res)
+	return &session, nil
+}
+
+func (cr *CheckInRepository) GetSessionsByScheduleID(scheduleID string) ([]models.CheckInSession, error) {
+	ctx := context.Background()
+	scheduleUUID, err := helper.ConvertStringToUUID(scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule id: %w", err)
+	}
+
+	results, err := cr.queries.GetCheckInSessionsByScheduleID(ctx, scheduleUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check-in sessions: %w", err)
+	}
+
+	var sessions []models.CheckInSession
+	for _, result := range results {
+		sessions = append(sessions, toCheckInSessionModel(result))
+	}
+	return sessions, nil
+}
+
+func (cr *CheckInRepository) CloseSession(session *models.CheckInSession) error {
+	ctx := context.Background()
+	sessionID, err := helper.ConvertStringToUUID(session.ID)
+	if err != nil {
+		return fmt.Errorf("invalid session id:%w", err)
+	}
+
+	res, err := cr.queries.CloseCheckInSession(ctx, tutorial.CloseCheckInSessionParams{
+		ID:       sessionID,
+		ClosedAt: helper.ConvertNullableTimeToPgTimestamp(session.ClosedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("close check-in session fail, it may already be closed:%w", err)
+	}
+
+	*session = toCheckInSessionModel(res)
+	return nil
+}
+
+func (cr *CheckInRepository) CreateCheckIn(checkIn *models.CheckIn) error {
+	ctx := context.Background()
+	sessionID, err := helper.ConvertStringToUUID(checkIn.SessionID)
+	if err != nil {
+		return fmt.Errorf("invalid session id:%w", err)
+	}
+
+	studentID, err := helper.ConvertStringToUUID(checkIn.StudentID)
+	if err != nil {
+		return fmt.Errorf("invalid student id:%w", err)
+	}
+
+	res, err := cr.queries.CreateCheckIn(ctx, tutorial.CreateCheckInParams{
+		SessionID:   sessionID,
+		StudentID:   studentID,
+		Code:        checkIn.Code,
+		Status:      checkIn.Status,
+		MinutesLate: int32(checkIn.MinutesLate),
+		CheckedInAt: helper.ConvertTimeToPgTimestamp(checkIn.CheckedInAt),
+	})
+	if err != nil {
+		return fmt.Errorf("create check-in fail, the student may already be checked in:%w", err)
+	}
+
+	*checkIn = toCheckInModel(res)
+	return nil
+}
+
+func (cr *CheckInRepository) DeleteCheckIn(id string) error {
+	ctx := context.Background()
+	checkInID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid check-in id:%w", err)
+	}
+
+	if err := cr.queries.DeleteCheckIn(ctx, checkInID); err != nil {
+		return fmt.Errorf("delete check-in fail:%w", err)
+	}
+	return nil
+}
+
+func (cr *CheckInRepository) GetCheckInsBySessionID(sessionID string) ([]models.CheckIn, error) {
+	ctx := context.Background()
+	sessionUUID, err := helper.ConvertStringToUUID(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session id: %w", err)
+	}
+
+	results, err := cr.queries.GetCheckInsBySessionID(ctx, sessionUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check-ins: %w", err)
+	}
+
+	var checkIns []models.CheckIn
+	for _, result := range results {
+		checkIns = append(checkIns, toCheckInModel(result))
+	}
+	return checkIns, nil
+}
+
+func toCheckInSessionModel(result tutorial.CheckInSession) models.CheckInSession {
+	openedAt := helper.ConvertPgTimestampToTime(result.OpenedAt)
+	closesAt := helper.ConvertPgTimestampToTime(result.ClosesAt)
+	lateAfterMinutes := int(result.LateAfterMinutes)
+
+	return models.CheckInSession{
+		ID:               helper.ConvertUUIDToString(result.ID),
+		ScheduleID:       helper.ConvertUUIDToString(result.ScheduleID),
+		OpenedBy:         helper.ConvertUUIDToString(result.OpenedBy),
+		Secret:           result.Secret,
+		CodeSeconds:      int(result.CodeSeconds),
+		WindowMinutes:    int(closesAt.Sub(openedAt).Minutes()),
+		LateAfterMinutes: &lateAfterMinutes,
+		OpenedAt:         openedAt,
+		ClosesAt:         closesAt,
+		ClosedAt:         helper.ConvertPgTimestampToNullableTime(result.ClosedAt),
+	}
+}
+
+func toCheckInModel(result tutorial.CheckIn) models.CheckIn {
+	return models.CheckIn{
+		ID:          helper.ConvertUUIDToString(result.ID),
+		SessionID:   helper.ConvertUUIDToString(result.SessionID),
+		StudentID:   helper.ConvertUUIDToString(result.StudentID),
+		Code:        result.Code,
+		Status:      result.Status,
+		MinutesLate: int(result.MinutesLate),
+		CheckedInAt: helper.ConvertPgTimestampToTime(result.CheckedInAt),
+	}
+}
@@ -0,0 +1,219 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExamRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewExamRepository(db *pgxpool.Pool) models.ExamRepository {
+	return &ExamRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (er *ExamRepository) CreateExam(exam *models.Exam) error {
+	ctx := context.Background()
+	params, err := updateExamParams(exam, false)
+	if err != nil {
+		return err
+	}
+
+	res, err := er.queries.CreateExam(ctx, tutorial.CreateExamParams{
+		LessonID:       params.LessonID,
+		ClassID:        params.ClassID,
+		Title:          params.Title,
+		Date:           params.Date,
+		StartTime:      params.StartTime,
+		EndTime:        params.EndTime,
+		RoomID:         params.RoomID,
+		InvigilatorIds: params.InvigilatorIds,
+	})
+	if err != nil {
+		return fmt.Errorf("create exam fail:%w", err)
+	}
+
+	exam.ID = helper.ConvertUUIDToString(res.ID)
+	exam.CreatedAt = helper.ConvertPgTimestampToTime(res.CreatedAt)
+	return nil
+}
+
+func (er *ExamRepository) GetExamByID(id string) (*models.Exam, error) {
+	ctx := context.Background()
+	examID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exam id: %w", err)
+	}
+
+	res, err := er.queries.GetExamByID(ctx, examID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exam: %w", err)
+	}
+
+	exam := toExamModel(res)
+	return &exam, nil
+}
+
+func (er *ExamRepository) UpdateExam(exam *models.Exam) error {
+	ctx := context.Background()
+	params, err := updateExamParams(exam, true)
+	if err != nil {
+		return err
+	}
+
+	_, err = er.queries.UpdateExam(ctx, params)
+	if err != nil {
+		return fmt.Errorf("update exam fail:%w", err)
+	}
+	return nil
+}
+
+func (er *ExamRepository) DeleteExam(id string) error {
+	ctx := context.Background()
+	examID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid exam id:%w", err)
+	}
+
+	err = er.queries.DeleteExam(ctx, examID)
+	if err != nil {
+		return fmt.Errorf("delete exam fail:%w", err)
+	}
+	return nil
+}
+
+func (er *ExamRepository) GetExamsBetween(from, to time.Time) ([]models.Exam, error) {
+	ctx := context.Background()
+
+	results, err := er.queries.GetExamsBetween(ctx, tutorial.GetExamsBetweenParams{
+		FromDate: pgtype.Date{Time: from, Valid: true},
+		ToDate:   pgtype.Date{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exams: %w", err)
+	}
+
+	var exams []models.Exam
+	for _, result := range results {
+		exams = append(exams, toExamModel(result))
+	}
+	return exams, nil
+}
+
+func (er *ExamRepository) GetExamRules() (*models.ExamRules, error) {
+	ctx := context.Background()
+
+	res, err := er.queries.GetExamRules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exam rules: %w", err)
+	}
+
+	return toExamRulesModel(res), nil
+}
+
+func (er *ExamRepository) UpdateExamRules(rules *models.ExamRules) error {
+	ctx := context.Background()
+
+	res, err := er.queries.UpdateExamRules(ctx, tutorial.UpdateExamRulesParams{
+		MaxExamsPerDay: int32(rules.MaxExamsPerDay),
+		MinGapMinutes:  int32(rules.MinGapMinutes),
+	})
+	if err != nil {
+		return fmt.Errorf("update exam rules fail:%w", err)
+	}
+
+	rules.UpdatedAt = helper.ConvertPgTimestampToTime(res.UpdatedAt)
+	return nil
+}
+
+// updateExamParams converts the exam; the ID is only needed for updates
+func updateExamParams(exam *models.Exam, withID bool) (tutorial.UpdateExamParams, error) {
+	var examID pgtype.UUID
+	if withID {
+		var err error
+		examID, err = helper.ConvertStringToUUID(exam.ID)
+		if err != nil {
+			return tutorial.UpdateExamParams{}, fmt.Errorf("invalid exam id:%w", err)
+		}
+	}
+
+	lessonID, err := helper.ConvertStringToUUID(exam.LessonID)
+	if err != nil {
+		return tutorial.UpdateExamParams{}, fmt.Errorf("invalid lesson id:%w", err)
+	}
+
+	classID, err := helper.ConvertStringToUUID(exam.ClassID)
+	if err != nil {
+		return tutorial.UpdateExamParams{}, fmt.Errorf("invalid class id:%w", err)
+	}
+
+	roomID, err := helper.ConvertNullableStringToUUID(exam.RoomID)
+	if err != nil {
+		return tutorial.UpdateExamParams{}, fmt.Errorf("invalid room id:%w", err)
+	}
+
+	invigilatorIDs := make([]pgtype.UUID, 0, len(exam.InvigilatorIDs))
+	for _, id := range exam.InvigilatorIDs {
+		invigilatorID, err := helper.ConvertStringToUUID(id)
+		if err != nil {
+			return tutorial.UpdateExamParams{}, fmt.Errorf("invalid invigilator id:%w", err)
+		}
+		invigilatorIDs = append(invigilatorIDs, invigilatorID)
+	}
+
+	return tutorial.UpdateExamParams{
+		ID:             examID,
+		LessonID:       lessonID,
+		ClassID:        classID,
+		Title:          exam.Title,
+		Date:           pgtype.Date{Time: exam.Date, Valid: true},
+		StartTime:      helper.ConvertTimeToPgTime(exam.Time),
+		EndTime:        helper.ConvertTimeToPgTime(exam.EndTime),
+		RoomID:         roomID,
+		InvigilatorIds: invigilatorIDs,
+	}, nil
+}
+
+func toExamModel(result tutorial.Exam) models.Exam {
+	invigilatorIDs := make([]string, 0, len(result.InvigilatorIds))
+	for _, id := range result.InvigilatorIds {
+		invigilatorIDs = append(invigilatorIDs, helper.ConvertUUIDToString(id))
+	}
+
+	startTime := helper.ConvertPgTimeToTime(result.StartTime)
+	endTime := helper.ConvertPgTimeToTime(result.EndTime)
+
+	return models.Exam{
+		ID:             helper.ConvertUUIDToString(result.ID),
+		LessonID:       helper.ConvertUUIDToString(result.LessonID),
+		ClassID:        helper.ConvertUUIDToString(result.ClassID),
+		Title:          result.Title,
+		Date:           result.Date.Time,
+		Time:           startTime,
+		Duration:       int(endTime.Sub(startTime).Minutes()),
+		EndTime:        endTime,
+		RoomID:         helper.ConvertUUIDToString(result.RoomID),
+		InvigilatorIDs: invigilatorIDs,
+		CreatedAt:      helper.ConvertPgTimestampToTime(result.CreatedAt),
+	}
+}
+
+func toExamRulesModel(result tutorial.ExamRule) *models.ExamRules {
+	return &models.ExamRules{
+		MaxExamsPerDay: int(result.MaxExamsPerDay),
+		MinGapMinutes:  int(result.MinGapMinutes),
+		UpdatedAt:      helper.ConvertPgTimestampToTime(result.UpdatedAt),
+	}
+}
@@ -0,0 +1,291 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ExcuseRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewExcuseRepository(db *pgxpool.Pool) models.ExcuseRepository {
+	return &ExcuseRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (er *ExcuseRepository) CreateExcuse(excuse *models.Excuse) error {
+	ctx := context.Background()
+	studentID, err := helper.ConvertStringToUUID(excuse.StudentID)
+	if err != nil {
+		return fmt.Errorf("invalid student id:%w", err)
+	}
+
+	submittedBy, err := helper.ConvertStringToUUID(excuse.SubmittedBy)
+	if err != nil {
+		return fmt.Errorf("invalid submitter id:%w", err)
+	}
+
+	scheduleIDs := make([]pgtype.UUID, 0, len(excuse.ScheduleIDs))
+	for _, id := range excuse.ScheduleIDs {
+		scheduleID, err := helper.ConvertStringToUUID(id)
+		if err != nil {
+			return fmt.Errorf("invalid schedule id:%w", err)
+		}
+		scheduleIDs = append(scheduleIDs, scheduleID)
+	}
+
+	res, err := er.queries.CreateExcuse(ctx, tutorial.CreateExcuseParams{
+		StudentID:   studentID,
+		SubmittedBy: submittedBy,
+		Reason:      excuse.Reason,
+		ScheduleIds: scheduleIDs,
+		FromDate:    helper.ConvertNullableTimeToPgDate(excuse.FromDate),
+		ToDate:      helper.ConvertNullableTimeToPgDate(excuse.ToDate),
+		DocumentUrl: excuse.DocumentURL,
+	})
+	if err != nil {
+		return fmt.Errorf("create excuse fail:%w", err)
+	}
+
+	*excuse = toExcuseModel(res)
+	return nil
+}
+
+func (er *ExcuseRepository) GetExcuseByID(id string) (*models.Excuse, error) {
+	ctx := context.Background()
+	excuseID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid excuse id: %w", err)
+	}
+
+	res, err := er.queries.GetExcuseByID(ctx, excuseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excuse: %w", err)
+	}
+
+	excuse := toExcuseModel(res)
+	return &excuse, nil
+}
+
+func (er *ExcuseRepository) GetExcusesByStudentID(studentID string) ([]models.Excuse, error) {
+	ctx := context.Background()
+	studentUUID, err := helper.ConvertStringToUUID(studentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid student id: %w", err)
+	}
+
+	results, err := er.queries.GetExcusesByStudentID(ctx, studentUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excuses: %w", err)
+	}
+
+	var excuses []models.Excuse
+	for _, result := range results {
+		excuses = append(excuses, toExcuseModel(result))
+	}
+	return excuses, nil
+}
+
+func (er *ExcuseRepository) GetExcusesByStatus(status string) ([]models.Excuse, error) {
+	ctx := context.Background()
+
+	results, err := er.queries.GetExcusesByStatus(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get excuses: %w", err)
+	}
+
+	var excuses []models.Excuse
+	for _, result := range results {
+		excuses = append(excuses, toExcuseModel(result))
+	}
+	return excuses, nil
+}
+
+func (er *ExcuseRepository) GetApprovedExcusesForLesson(scheduleID string, date time.Time) ([]models.Excuse, error) {
+	ctx := context.Background()
+	scheduleUUID, err := helper.ConvertStringToUUID(scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule id: %w", err)
+	}
+
+	results, err := er.queries.GetApprovedExcusesForLesson(ctx, tutorial.GetApprovedExcusesForLessonParams{
+		ScheduleID: scheduleUUID,
+		LessonDate: helper.ConvertNullableTimeToPgDate(&date),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get approved excuses: %w", err)
+	}
+
+	var excuses []models.Excuse
+	for _, result := range results {
+		excuses = append(excuses, toExcuseModel(result))
+	}
+	return excuses, nil
+}
+
+func (er *ExcuseRepository) ReviewExcuse(excuse *models.Excuse, attendances []models.Attendance, audits []models.AttendanceAudit) error {
+	ctx := context.Background()
+	excuseID, err := helper.ConvertStringToUUID(excuse.ID)
+	if err != nil {
+		return fmt.Errorf("invalid excuse id:%w", err)
+	}
+
+	reviewedBy, err := helper.ConvertStringToUUID(excuse.ReviewedBy)
+	if err != nil {
+		return fmt.Errorf("invalid reviewer id:%w", err)
+	}
+
+	tx, err := er.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction fail:%w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Only a pending excuse is updated, so two reviewers cannot both decide
+	qtx := er.queries.WithTx(tx)
+	res, err := qtx.UpdateExcuseReview(ctx, tutorial.UpdateExcuseReviewParams{
+		ID:         excuseID,
+		Status:     excuse.Status,
+		ReviewedBy: reviewedBy,
+		ReviewNote: excuse.ReviewNote,
+	})
+	if err != nil {
+		return fmt.Errorf("review excuse fail, it may already be reviewed:%w", err)
+	}
+
+	for _, attendance := range attendances {
+		attendanceID, err := helper.ConvertStringToUUID(attendance.ID)
+		if err != nil {
+			return fmt.Errorf("invalid attendance id:%w", err)
+		}
+
+		err = qtx.UpdateAttendanceStatus(ctx, tutorial.UpdateAttendanceStatusParams{
+			ID:          attendanceID,
+			Status:      attendance.Status,
+			MinutesLate: int32(attendance.MinutesLate),
+			Counter:     int32(attendance.Counter),
+		})
+		if err != nil {
+			return fmt.Errorf("update attendance fail:%w", err)
+		}
+	}
+
+	for i, audit := range audits {
+		attendanceID, err := helper.ConvertStringToUUID(audit.AttendanceID)
+		if err != nil {
+			return fmt.Errorf("invalid attendance id:%w", err)
+		}
+
+		result, err := qtx.CreateAttendanceAudit(ctx, tutorial.CreateAttendanceAuditParams{
+			AttendanceID: attendanceID,
+			ExcuseID:     excuseID,
+			ChangedBy:    reviewedBy,
+			OldStatus:    audit.OldStatus,
+			NewStatus:    audit.NewStatus,
+		})
+		if err != nil {
+			return fmt.Errorf("create attendance audit fail:%w", err)
+		}
+		audits[i] = toAttendanceAuditModel(result)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction fail:%w", err)
+	}
+
+	*excuse = toExcuseModel(res)
+	return nil
+}
+
+func (er *ExcuseRepository) GetAttendanceAudits(attendanceID string) ([]models.AttendanceAudit, error) {
+	ctx := context.Background()
+	attendanceUUID, err := helper.ConvertStringToUUID(attendanceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attendance id: %w", err)
+	}
+
+	results, err := er.queries.GetAttendanceAuditsByAttendanceID(ctx, attendanceUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attendance audits: %w", err)
+	}
+
+	var audits []models.AttendanceAudit
+	for _, result := range results {
+		audits = append(audits, toAttendanceAuditModel(result))
+	}
+	return audits, nil
+}
+
+// createExcuseAudit records that an approved excuse changed the attendance
+// saved in the same transaction
+func createExcuseAudit(ctx context.Context, qtx *tutorial.Queries, attendanceID pgtype.UUID, audit *models.AttendanceAudit) error {
+	excuseID, err := helper.ConvertStringToUUID(audit.ExcuseID)
+	if err != nil {
+		return fmt.Errorf("invalid excuse id:%w", err)
+	}
+
+	changedBy, err := helper.ConvertStringToUUID(audit.ChangedBy)
+	if err != nil {
+		return fmt.Errorf("invalid changed by id:%w", err)
+	}
+
+	result, err := qtx.CreateAttendanceAudit(ctx, tutorial.CreateAttendanceAuditParams{
+		AttendanceID: attendanceID,
+		ExcuseID:     excuseID,
+		ChangedBy:    changedBy,
+		OldStatus:    audit.OldStatus,
+		NewStatus:    audit.NewStatus,
+	})
+	if err != nil {
+		return fmt.Errorf("create attendance audit fail:%w", err)
+	}
+
+	*audit = toAttendanceAuditModel(result)
+	return nil
+}
+
+func toExcuseModel(result tutorial.Excuse) models.Excuse {
+	scheduleIDs := make([]string, 0, len(result.ScheduleIds))
+	for _, id := range result.ScheduleIds {
+		scheduleIDs = append(scheduleIDs, helper.ConvertUUIDToString(id))
+	}
+
+	return models.Excuse{
+		ID:          helper.ConvertUUIDToString(result.ID),
+		StudentID:   helper.ConvertUUIDToString(result.StudentID),
+		SubmittedBy: helper.ConvertUUIDToString(result.SubmittedBy),
+		Reason:      result.Reason,
+		ScheduleIDs: scheduleIDs,
+		FromDate:    helper.ConvertPgDateToNullableTime(result.FromDate),
+		ToDate:      helper.ConvertPgDateToNullableTime(result.ToDate),
+		DocumentURL: result.DocumentUrl,
+		Status:      result.Status,
+		ReviewedBy:  helper.ConvertUUIDToString(result.ReviewedBy),
+		ReviewNote:  result.ReviewNote,
+		ReviewedAt:  helper.ConvertPgTimestampToNullableTime(result.ReviewedAt),
+		CreatedAt:   helper.ConvertPgTimestampToTime(result.CreatedAt),
+	}
+}
+
+func toAttendanceAuditModel(result tutorial.AttendanceAudit) models.AttendanceAudit {
+	return models.AttendanceAudit{
+		ID:           helper.ConvertUUIDToString(result.ID),
+		AttendanceID: helper.ConvertUUIDToString(result.AttendanceID),
+		ExcuseID:     helper.ConvertUUIDToString(result.ExcuseID),
+		ChangedBy:    helper.ConvertUUIDToString(result.ChangedBy),
+		OldStatus:    result.OldStatus,
+		NewStatus:    result.NewStatus,
+		ChangedAt:    helper.ConvertPgTimestampToTime(result.ChangedAt),
+	}
+}
@@ -46,7 +46,7 @@ func (hr HomeworkRepository) CreateHomework(homework *models.Homework) error {
 		ClassID:   classID,
 		Title:     homework.Title,
 		Content:   pgtype.Text{String: homework.Content, Valid: homework.Content != ""},
-		DueDate:   pgtype.Timestamp{Time: homework.DueDate, Valid: true},
+		DueDate:   helper.ConvertTimeToPgTimestamp(homework.DueDate),
 	}
 
 	result, err := hr.queries.CreateHomework(ctx, hwparams)
@@ -79,7 +79,7 @@ func (hr HomeworkRepository) GetHomeworkByID(id string) (*models.Homework, error
 		ClassID:   helper.ConvertUUIDToString(result.ClassID),
 		Title:     result.Title,
 		Content:   result.Content.String,
-		DueDate:   result.DueDate.Time,
+		DueDate:   helper.ConvertPgTimestampToTime(result.DueDate),
 	}
 
 	return homework, nil
@@ -115,7 +115,7 @@ func (hr HomeworkRepository) UpdateHomework(homework *models.Homework) error {
 		ClassID:   classID,
 		Title:     homework.Title,
 		Content:   pgtype.Text{String: homework.Content, Valid: homework.Content != ""},
-		DueDate:   pgtype.Timestamp{Time: homework.DueDate, Valid: true},
+		DueDate:   helper.ConvertTimeToPgTimestamp(homework.DueDate),
 	}
 
 	_, err = hr.queries.UpdateHomework(ctx, params)
@@ -156,7 +156,7 @@ func (hr HomeworkRepository) GetAllHomeworks() ([]models.Homework, error) {
 			ClassID:   helper.ConvertUUIDToString(result.ClassID),
 			Title:     result.Title,
 			Content:   result.Content.String,
-			DueDate:   result.DueDate.Time,
+			DueDate:   helper.ConvertPgTimestampToTime(result.DueDate),
 		}
 		homeworks = append(homeworks, homework)
 	}
@@ -185,7 +185,7 @@ func (hr HomeworkRepository) GetHomeworksByTeacherID(teacherID string) ([]models
 			ClassID:   helper.ConvertUUIDToString(result.ClassID),
 			Title:     result.Title,
 			Content:   result.Content.String,
-			DueDate:   result.DueDate.Time,
+			DueDate:   helper.ConvertPgTimestampToTime(result.DueDate),
 		}
 		homeworks = append(homeworks, homework)
 	}
@@ -215,7 +215,7 @@ func (hr HomeworkRepository) GetHomeworksByLessonID(lessonID string) ([]models.H
 			ClassID:   helper.ConvertUUIDToString(result.ClassID),
 			Title:     result.Title,
 			Content:   result.Content.String,
-			DueDate:   result.DueDate.Time,
+			DueDate:   helper.ConvertPgTimestampToTime(result.DueDate),
 		}
 		homeworks = append(homeworks, homework)
 	}
@@ -245,7 +245,7 @@ func (hr HomeworkRepository) GetHomeworksByClassID(classID string) ([]models.Hom
 			ClassID:   helper.ConvertUUIDToString(result.ClassID),
 			Title:     result.Title,
 			Content:   result.Content.String,
-			DueDate:   result.DueDate.Time,
+			DueDate:   helper.ConvertPgTimestampToTime(result.DueDate),
 		}
 		homeworks = append(homeworks, homework)
 	}
@@ -0,0 +1,185 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RollCallRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewRollCallRepository(db *pgxpool.Pool) models.RollCallRepository {
+	return &RollCallRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (rr *RollCallRepository) GetUncheckedSchedules(from, to time.Time) ([]models.Schedule, error) {
+	ctx := context.Background()
+
+	results, err := rr.queries.GetUncheckedSchedules(ctx, tutorial.GetUncheckedSchedulesParams{
+		FromDate: helper.ConvertNullableTimeToPgDate(&from),
+		ToDate:   helper.ConvertNullableTimeToPgDate(&to),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unchecked schedules: %w", err)
+	}
+
+	var schedules []models.Schedule
+	for _, result := range results {
+		schedules = append(schedules, toScheduleModel(result))
+	}
+
+	return schedules, nil
+}
+
+func (rr *RollCallRepository) SaveRollCallCheck(check *models.RollCallCheck, absences []models.Attendance, audits map[string]models.AttendanceAudit) error {
+	ctx := context.Background()
+	scheduleID, err := helper.ConvertStringToUUID(check.ScheduleID)
+	if err != nil {
+		return fmt.Errorf("invalid schedule id:%w", err)
+	}
+
+	teacherID, err := helper.ConvertStringToUUID(check.TeacherID)
+	if err != nil {
+		return fmt.Errorf("invalid teacher id:%w", err)
+	}
+
+	tx, err := rr.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction fail:%w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := rr.queries.WithTx(tx)
+	missing := make([]pgtype.UUID, 0, len(absences))
+	for _, absence := range absences {
+		studentID, err := helper.ConvertStringToUUID(absence.StudentID)
+		if err != nil {
+			return fmt.Errorf("invalid student id:%w", err)
+		}
+
+		created, err := qtx.CreateMissingAttendance(ctx, tutorial.CreateMissingAttendanceParams{
+			StudentID:  studentID,
+			ScheduleID: scheduleID,
+			Status:     absence.Status,
+			Note:       absence.Note,
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("create missing attendance fail:%w", err)
+		}
+		missing = append(missing, studentID)
+
+		if audit, ok := audits[absence.StudentID]; ok {
+			if err := createExcuseAudit(ctx, qtx, created.ID, &audit); err != nil {
+				return err
+			}
+			audits[absence.StudentID] = audit
+		}
+	}
+
+	res, err := qtx.CreateRollCallCheck(ctx, tutorial.CreateRollCallCheckParams{
+		ScheduleID:        scheduleID,
+		TeacherID:         teacherID,
+		MissingStudentIds: missing,
+		CheckedAt:         helper.ConvertTimeToPgTimestamp(check.CheckedAt),
+	})
+	if err != nil {
+		return fmt.Errorf("create roll call check fail:%w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction fail:%w", err)
+	}
+
+	*check = toRollCallCheckModel(res)
+	return nil
+}
+
+func (rr *RollCallRepository) GetRollCallCheck(scheduleID string) (*models.RollCallCheck, error) {
+	ctx := context.Background()
+	scheduleUUID, err := helper.ConvertStringToUUID(scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule id: %w", err)
+	}
+
+	res, err := rr.queries.GetRollCallCheck(ctx, scheduleUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roll call check: %w", err)
+	}
+
+	check := toRollCallCheckModel(res)
+	return &check, nil
+}
+
+func (rr *RollCallRepository) GetOpenReminders(teacherID string) ([]models.RollCallCheck, error) {
+	ctx := context.Background()
+
+	var results []tutorial.RollCallCheck
+	if teacherID == "" {
+		var err error
+		results, err = rr.queries.GetOpenRollCallReminders(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get roll call reminders: %w", err)
+		}
+	} else {
+		teacherUUID, err := helper.ConvertStringToUUID(teacherID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid teacher id: %w", err)
+		}
+
+		results, err = rr.queries.GetOpenRollCallRemindersByTeacherID(ctx, teacherUUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get roll call reminders: %w", err)
+		}
+	}
+
+	var checks []models.RollCallCheck
+	for _, result := range results {
+		checks = append(checks, toRollCallCheckModel(result))
+	}
+	return checks, nil
+}
+
+func (rr *RollCallRepository) ResolveRollCallCheck(scheduleID string) error {
+	ctx := context.Background()
+	scheduleUUID, err := helper.ConvertStringToUUID(scheduleID)
+	if err != nil {
+		return fmt.Errorf("invalid schedule id:%w", err)
+	}
+
+	if err := rr.queries.ResolveRollCallCheck(ctx, scheduleUUID); err != nil {
+		return fmt.Errorf("resolve roll call check fail:%w", err)
+	}
+	return nil
+}
+
+func toRollCallCheckModel(result tutorial.RollCallCheck) models.RollCallCheck {
+	missing := make([]string, 0, len(result.MissingStudentIds))
+	for _, id := range result.MissingStudentIds {
+		missing = append(missing, helper.ConvertUUIDToString(id))
+	}
+
+	return models.RollCallCheck{
+		ScheduleID:        helper.ConvertUUIDToString(result.ScheduleID),
+		TeacherID:         helper.ConvertUUIDToString(result.TeacherID),
+		MissingStudentIDs: missing,
+		CheckedAt:         helper.ConvertPgTimestampToTime(result.CheckedAt),
+		ResolvedAt:        helper.ConvertPgTimestampToNullableTime(result.ResolvedAt),
+	}
+}
@@ -0,0 +1,129 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type RoomRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewRoomRepository(db *pgxpool.Pool) models.RoomRepository {
+	return &RoomRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (rr *RoomRepository) CreateRoom(room *models.Room) error {
+	ctx := context.Background()
+
+	params := tutorial.CreateRoomParams{
+		Name:     room.Name,
+		Capacity: int32(room.Capacity),
+		Features: roomFeatures(room.Features),
+	}
+
+	res, err := rr.queries.CreateRoom(ctx, params)
+	if err != nil {
+		return fmt.Errorf("create room fail:%w", err)
+	}
+
+	room.ID = helper.ConvertUUIDToString(res.ID)
+	return nil
+}
+
+func (rr *RoomRepository) GetRoomByID(id string) (*models.Room, error) {
+	ctx := context.Background()
+
+	roomID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid room ID: %w", err)
+	}
+
+	result, err := rr.queries.GetRoomByID(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+
+	room := toRoomModel(result)
+	return &room, nil
+}
+
+func (rr *RoomRepository) UpdateRoom(room *models.Room) error {
+	ctx := context.Background()
+
+	roomID, err := helper.ConvertStringToUUID(room.ID)
+	if err != nil {
+		return fmt.Errorf("invalid room id:%w", err)
+	}
+
+	params := tutorial.UpdateRoomParams{
+		ID:       roomID,
+		Name:     room.Name,
+		Capacity: int32(room.Capacity),
+		Features: roomFeatures(room.Features),
+	}
+
+	_, err = rr.queries.UpdateRoom(ctx, params)
+	if err != nil {
+		return fmt.Errorf("update room fail:%w", err)
+	}
+
+	return nil
+}
+
+func (rr *RoomRepository) DeleteRoom(id string) error {
+	ctx := context.Background()
+
+	roomID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid room id:%w", err)
+	}
+
+	err = rr.queries.DeleteRoom(ctx, roomID)
+	if err != nil {
+		return fmt.Errorf("delete room fail:%w", err)
+	}
+	return nil
+}
+
+func (rr *RoomRepository) GetAllRooms() ([]models.Room, error) {
+	ctx := context.Background()
+
+	results, err := rr.queries.GetAllRooms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all rooms: %w", err)
+	}
+
+	var rooms []models.Room
+	for _, result := range results {
+		rooms = append(rooms, toRoomModel(result))
+	}
+
+	return rooms, nil
+}
+
+func toRoomModel(result tutorial.Room) models.Room {
+	return models.Room{
+		ID:       helper.ConvertUUIDToString(result.ID),
+		Name:     result.Name,
+		Capacity: int(result.Capacity),
+		Features: result.Features,
+	}
+}
+
+// roomFeatures keeps the NOT NULL features column from receiving a nil array
+func roomFeatures(features []string) []string {
+	if features == nil {
+		return []string{}
+	}
+	return features
+}
@@ -0,0 +1,378 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ScheduleSeriesRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewScheduleSeriesRepository(db *pgxpool.Pool) models.ScheduleSeriesRepository {
+	return &ScheduleSeriesRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (ssr *ScheduleSeriesRepository) CreateScheduleSeries(series *models.ScheduleSeries) error {
+	ctx := context.Background()
+	params, err := updateScheduleSeriesParams(series)
+	if err != nil {
+		return err
+	}
+
+	res, err := ssr.queries.CreateScheduleSeries(ctx, createScheduleSeriesParams(params))
+	if err != nil {
+		return fmt.Errorf("create schedule series fail:%w", err)
+	}
+
+	series.ID = helper.ConvertUUIDToString(res.ID)
+	return nil
+}
+
+func (ssr *ScheduleSeriesRepository) GetScheduleSeriesByID(id string) (*models.ScheduleSeries, error) {
+	ctx := context.Background()
+	seriesID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid series id: %w", err)
+	}
+
+	res, err := ssr.queries.GetScheduleSeriesByID(ctx, seriesID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule series: %w", err)
+	}
+
+	series := toScheduleSeriesModel(res)
+	return &series, nil
+}
+
+func (ssr *ScheduleSeriesRepository) UpdateScheduleSeries(series *models.ScheduleSeries) error {
+	ctx := context.Background()
+	params, err := updateScheduleSeriesParams(series)
+	if err != nil {
+		return err
+	}
+
+	_, err = ssr.queries.UpdateScheduleSeries(ctx, params)
+	if err != nil {
+		return fmt.Errorf("update schedule series fail:%w", err)
+	}
+	return nil
+}
+
+func (ssr *ScheduleSeriesRepository) DeleteScheduleSeries(id string) error {
+	ctx := context.Background()
+	seriesID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid series id:%w", err)
+	}
+
+	err = ssr.queries.DeleteScheduleSeries(ctx, seriesID)
+	if err != nil {
+		return fmt.Errorf("delete schedule series fail:%w", err)
+	}
+	return nil
+}
+
+func (ssr *ScheduleSeriesRepository) GetAllScheduleSeries() ([]models.ScheduleSeries, error) {
+	ctx := context.Background()
+
+	res, err := ssr.queries.GetAllScheduleSeries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get all schedule series: %w", err)
+	}
+
+	var series []models.ScheduleSeries
+	for _, result := range res {
+		series = append(series, toScheduleSeriesModel(result))
+	}
+	return series, nil
+}
+
+func (ssr *ScheduleSeriesRepository) GetScheduleSeriesByTeacherID(teacherID string) ([]models.ScheduleSeries, error) {
+	ctx := context.Background()
+	teacherUUID, err := helper.ConvertStringToUUID(teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid teacher ID: %w", err)
+	}
+
+	res, err := ssr.queries.GetScheduleSeriesByTeacherID(ctx, teacherUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule series by teacher ID: %w", err)
+	}
+
+	var series []models.ScheduleSeries
+	for _, result := range res {
+		series = append(series, toScheduleSeriesModel(result))
+	}
+	return series, nil
+}
+
+func (ssr *ScheduleSeriesRepository) GetScheduleSeriesByClassID(classID string) ([]models.ScheduleSeries, error) {
+	ctx := context.Background()
+	classUUID, err := helper.ConvertStringToUUID(classID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid class ID: %w", err)
+	}
+
+	res, err := ssr.queries.GetScheduleSeriesByClassID(ctx, classUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule series by class ID: %w", err)
+	}
+
+	var series []models.ScheduleSeries
+	for _, result := range res {
+		series = append(series, toScheduleSeriesModel(result))
+	}
+	return series, nil
+}
+
+func (ssr *ScheduleSeriesRepository) GetScheduleSeriesByRoomID(roomID string) ([]models.ScheduleSeries, error) {
+	ctx := context.Background()
+	roomUUID, err := helper.ConvertStringToUUID(roomID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid room ID: %w", err)
+	}
+
+	res, err := ssr.queries.GetScheduleSeriesByRoomID(ctx, roomUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule series by room ID: %w", err)
+	}
+
+	var series []models.ScheduleSeries
+	for _, result := range res {
+		series = append(series, toScheduleSeriesModel(result))
+	}
+	return series, nil
+}
+
+func (ssr *ScheduleSeriesRepository) DetachOccurrence(series *models.ScheduleSeries, occurrence *models.Schedule) error {
+	ctx := context.Background()
+	seriesParams, err := updateScheduleSeriesParams(series)
+	if err != nil {
+		return err
+	}
+
+	scheduleParams, err := createScheduleParams(occurrence)
+	if err != nil {
+		return err
+	}
+
+	tx, err := ssr.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction fail:%w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := ssr.queries.WithTx(tx)
+	if _, err := qtx.UpdateScheduleSeries(ctx, seriesParams); err != nil {
+		return fmt.Errorf("update schedule series fail:%w", err)
+	}
+
+	res, err := qtx.CreateSchedule(ctx, scheduleParams)
+	if err != nil {
+		return fmt.Errorf("create schuedle fail:%w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction fail:%w", err)
+	}
+
+	occurrence.ID = helper.ConvertUUIDToString(res.ID)
+	return nil
+}
+
+func (ssr *ScheduleSeriesRepository) SplitScheduleSeries(series *models.ScheduleSeries, following *models.ScheduleSeries) error {
+	ctx := context.Background()
+	seriesParams, err := updateScheduleSeriesParams(series)
+	if err != nil {
+		return err
+	}
+
+	followingParams, err := updateScheduleSeriesParams(following)
+	if err != nil {
+		return err
+	}
+
+	tx, err := ssr.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction fail:%w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := ssr.queries.WithTx(tx)
+	if _, err := qtx.UpdateScheduleSeries(ctx, seriesParams); err != nil {
+		return fmt.Errorf("update schedule series fail:%w", err)
+	}
+
+	res, err := qtx.CreateScheduleSeries(ctx, createScheduleSeriesParams(followingParams))
+	if err != nil {
+		return fmt.Errorf("create schedule series fail:%w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction fail:%w", err)
+	}
+
+	following.ID = helper.ConvertUUIDToString(res.ID)
+	return nil
+}
+
+func (ssr *ScheduleSeriesRepository) MoveSchedules(schedules []models.Schedule, series []models.ScheduleSeries, occurrences []models.Schedule) error {
+	ctx := context.Background()
+
+	tx, err := ssr.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction fail:%w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := ssr.queries.WithTx(tx)
+	for i := range schedules {
+		params, err := updateScheduleParams(&schedules[i])
+		if err != nil {
+			return err
+		}
+
+		if _, err := qtx.UpdateSchedule(ctx, params); err != nil {
+			return fmt.Errorf("update schuedle fail:%w", err)
+		}
+	}
+
+	for i := range series {
+		params, err := updateScheduleSeriesParams(&series[i])
+		if err != nil {
+			return err
+		}
+
+		if _, err := qtx.UpdateScheduleSeries(ctx, params); err != nil {
+			return fmt.Errorf("update schedule series fail:%w", err)
+		}
+	}
+
+	for i := range occurrences {
+		params, err := createScheduleParams(&occurrences[i])
+		if err != nil {
+			return err
+		}
+
+		res, err := qtx.CreateSchedule(ctx, params)
+		if err != nil {
+			return fmt.Errorf("create schuedle fail:%w", err)
+		}
+		occurrences[i].ID = helper.ConvertUUIDToString(res.ID)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction fail:%w", err)
+	}
+	return nil
+}
+
+// createScheduleSeriesParams drops the ID from the converted series params
+func createScheduleSeriesParams(params tutorial.UpdateScheduleSeriesParams) tutorial.CreateScheduleSeriesParams {
+	return tutorial.CreateScheduleSeriesParams{
+		TeacherID:       params.TeacherID,
+		LessonID:        params.LessonID,
+		ClassID:         params.ClassID,
+		StartDate:       params.StartDate,
+		Time:            params.Time,
+		EndTime:         params.EndTime,
+		Weekdays:        params.Weekdays,
+		UntilDate:       params.UntilDate,
+		OccurrenceCount: params.OccurrenceCount,
+		ExceptionDates:  params.ExceptionDates,
+		RoomID:          params.RoomID,
+	}
+}
+
+// updateScheduleSeriesParams converts the series into query parameters. The ID
+// is only converted when present, so the same params back inserts as well.
+func updateScheduleSeriesParams(series *models.ScheduleSeries) (tutorial.UpdateScheduleSeriesParams, error) {
+	seriesID, err := helper.ConvertNullableStringToUUID(series.ID)
+	if err != nil {
+		return tutorial.UpdateScheduleSeriesParams{}, fmt.Errorf("invalid series id:%w", err)
+	}
+
+	teacherID, err := helper.ConvertStringToUUID(series.TeacherID)
+	if err != nil {
+		return tutorial.UpdateScheduleSeriesParams{}, fmt.Errorf("invalid teacher id:%w", err)
+	}
+
+	lessonID, err := helper.ConvertStringToUUID(series.LessonID)
+	if err != nil {
+		return tutorial.UpdateScheduleSeriesParams{}, fmt.Errorf("invalid lesson id:%w", err)
+	}
+
+	classID, err := helper.ConvertStringToUUID(series.ClassID)
+	if err != nil {
+		return tutorial.UpdateScheduleSeriesParams{}, fmt.Errorf("invalid class id:%w", err)
+	}
+
+	roomID, err := helper.ConvertNullableStringToUUID(series.RoomID)
+	if err != nil {
+		return tutorial.UpdateScheduleSeriesParams{}, fmt.Errorf("invalid room id:%w", err)
+	}
+
+	weekdays := make([]int32, 0, len(series.Weekdays))
+	for _, weekday := range series.Weekdays {
+		weekdays = append(weekdays, int32(weekday))
+	}
+
+	exceptionDates := make([]pgtype.Date, 0, len(series.ExceptionDates))
+	for _, date := range series.ExceptionDates {
+		exceptionDates = append(exceptionDates, pgtype.Date{Time: date, Valid: true})
+	}
+
+	return tutorial.UpdateScheduleSeriesParams{
+		ID:              seriesID,
+		TeacherID:       teacherID,
+		LessonID:        lessonID,
+		ClassID:         classID,
+		StartDate:       pgtype.Date{Time: series.StartDate, Valid: true},
+		Time:            helper.ConvertTimeToPgTime(series.Time),
+		EndTime:         helper.ConvertTimeToPgTime(series.EndTime),
+		Weekdays:        weekdays,
+		UntilDate:       helper.ConvertNullableTimeToPgDate(series.Until),
+		OccurrenceCount: pgtype.Int4{Int32: int32(series.Count), Valid: series.Count > 0},
+		ExceptionDates:  exceptionDates,
+		RoomID:          roomID,
+	}, nil
+}
+
+func toScheduleSeriesModel(result tutorial.ScheduleSeries) models.ScheduleSeries {
+	weekdays := make([]time.Weekday, 0, len(result.Weekdays))
+	for _, weekday := range result.Weekdays {
+		weekdays = append(weekdays, time.Weekday(weekday))
+	}
+
+	exceptionDates := make([]time.Time, 0, len(result.ExceptionDates))
+	for _, date := range result.ExceptionDates {
+		exceptionDates = append(exceptionDates, date.Time)
+	}
+
+	return models.ScheduleSeries{
+		ID:             helper.ConvertUUIDToString(result.ID),
+		TeacherID:      helper.ConvertUUIDToString(result.TeacherID),
+		LessonID:       helper.ConvertUUIDToString(result.LessonID),
+		ClassID:        helper.ConvertUUIDToString(result.ClassID),
+		StartDate:      result.StartDate.Time,
+		Time:           helper.ConvertPgTimeToTime(result.Time),
+		EndTime:        helper.ConvertPgTimeToTime(result.EndTime),
+		Weekdays:       weekdays,
+		Until:          helper.ConvertPgDateToNullableTime(result.UntilDate),
+		Count:          int(result.OccurrenceCount.Int32),
+		ExceptionDates: exceptionDates,
+		RoomID:         helper.ConvertUUIDToString(result.RoomID),
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"Education_Dashboard/internal/models"
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -26,33 +25,47 @@ func NewSchuedleRepository(db *pgxpool.Pool) models.ScheduleRepository {
 
 func (sr *SchuedleRepository) CreateSchedule(schedule *models.Schedule) error {
 	ctx := context.Background()
-	lessonID, err := helper.ConvertStringToUUID(schedule.LessonID)
+	params, err := createScheduleParams(schedule)
 	if err != nil {
-		return fmt.Errorf("invalid lesson id:%w", err)
+		return err
 	}
 
-	teacherID, err := helper.ConvertStringToUUID(schedule.TeacherID)
+	res, err := sr.queries.CreateSchedule(ctx, params)
 	if err != nil {
-		return fmt.Errorf("invalid teacher id:%w", err)
+		return fmt.Errorf("create schuedle fail:%w", err)
 	}
+	schedule.ID = helper.ConvertUUIDToString(res.ID)
+	schedule.Status = res.Status
+	return nil
+}
 
-	classID, err := helper.ConvertStringToUUID(schedule.ClassID)
+func (sr *SchuedleRepository) CreateSchedules(schedules []models.Schedule) error {
+	ctx := context.Background()
+
+	tx, err := sr.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("invalid class id:%w", err)
+		return fmt.Errorf("begin transaction fail:%w", err)
 	}
-	params := tutorial.CreateScheduleParams{
-		Date:      pgtype.Date{Time: schedule.Date, Valid: true},
-		Time:      pgtype.Time{Microseconds: int64(schedule.Time.Hour()*3600000000 + schedule.Time.Minute()*60000000), Valid: true},
-		TeacherID: teacherID,
-		LessonID:  lessonID,
-		ClassID:   classID,
+	defer tx.Rollback(ctx)
+
+	qtx := sr.queries.WithTx(tx)
+	for i := range schedules {
+		params, err := createScheduleParams(&schedules[i])
+		if err != nil {
+			return err
+		}
+
+		res, err := qtx.CreateSchedule(ctx, params)
+		if err != nil {
+			return fmt.Errorf("create schuedle fail:%w", err)
+		}
+		schedules[i].ID = helper.ConvertUUIDToString(res.ID)
+		schedules[i].Status = res.Status
 	}
 
-	res, err := sr.queries.CreateSchedule(ctx, params)
-	if err != nil {
-		return fmt.Errorf("create schuedle fail:%w", err)
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction fail:%w", err)
 	}
-	schedule.ID = helper.ConvertUUIDToString(res.ID)
 	return nil
 }
 
@@ -68,47 +81,16 @@ func (sr *SchuedleRepository) GetScheduleByID(id string) (*models.Schedule, erro
 		return nil, fmt.Errorf("failed to get schedule: %w", err)
 	}
 
-	schedule := &models.Schedule{
-		ID:        helper.ConvertUUIDToString(res.ID),
-		Date:      res.Date.Time,
-		Time:      time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(res.Time.Microseconds) * time.Microsecond),
-		TeacherID: helper.ConvertUUIDToString(res.TeacherID),
-		LessonID:  helper.ConvertUUIDToString(res.LessonID),
-		ClassID:   helper.ConvertUUIDToString(res.ClassID),
-	}
+	schedule := toScheduleModel(res)
 
-	return schedule, nil
+	return &schedule, nil
 }
 func (sr *SchuedleRepository) UpdateSchedule(schedule *models.Schedule) error {
 
 	ctx := context.Background()
-	schuedleID, err := helper.ConvertStringToUUID(schedule.ID)
-	if err != nil {
-		return fmt.Errorf("invalid schuedle id:%w", err)
-	}
-
-	lessonID, err := helper.ConvertStringToUUID(schedule.LessonID)
-	if err != nil {
-		return fmt.Errorf("invalid lesson id:%w", err)
-	}
-
-	teacherID, err := helper.ConvertStringToUUID(schedule.TeacherID)
+	params, err := updateScheduleParams(schedule)
 	if err != nil {
-		return fmt.Errorf("invalid teacher id:%w", err)
-	}
-
-	classID, err := helper.ConvertStringToUUID(schedule.ClassID)
-	if err != nil {
-		return fmt.Errorf("invalid class id:%w", err)
-	}
-
-	params := tutorial.UpdateScheduleParams{
-		ID:        schuedleID,
-		Date:      pgtype.Date{Time: schedule.Date, Valid: true},
-		Time:      pgtype.Time{Microseconds: int64(schedule.Time.Hour()*3600000000 + schedule.Time.Minute()*60000000), Valid: true},
-		TeacherID: teacherID,
-		LessonID:  lessonID,
-		ClassID:   classID,
+		return err
 	}
 
 	_, err = sr.queries.UpdateSchedule(ctx, params)
@@ -142,15 +124,7 @@ func (sr *SchuedleRepository) GetAllSchedules() ([]models.Schedule, error) {
 
 	var schedules []models.Schedule
 	for _, result := range res {
-		schedule := models.Schedule{
-			ID:        helper.ConvertUUIDToString(result.ID),
-			Date:      result.Date.Time,
-			Time:      time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(result.Time.Microseconds) * time.Microsecond),
-			TeacherID: helper.ConvertUUIDToString(result.TeacherID),
-			LessonID:  helper.ConvertUUIDToString(result.LessonID),
-			ClassID:   helper.ConvertUUIDToString(result.ClassID),
-		}
-		schedules = append(schedules, schedule)
+		schedules = append(schedules, toScheduleModel(result))
 	}
 
 	return schedules, nil
@@ -170,15 +144,7 @@ func (sr *SchuedleRepository) GetSchedulesByTeacherID(teacherID string) ([]model
 
 	var schedules []models.Schedule
 	for _, result := range results {
-		schedule := models.Schedule{
-			ID:        helper.ConvertUUIDToString(result.ID),
-			Date:      result.Date.Time,
-			Time:      time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(result.Time.Microseconds) * time.Microsecond),
-			TeacherID: helper.ConvertUUIDToString(result.TeacherID),
-			LessonID:  helper.ConvertUUIDToString(result.LessonID),
-			ClassID:   helper.ConvertUUIDToString(result.ClassID),
-		}
-		schedules = append(schedules, schedule)
+		schedules = append(schedules, toScheduleModel(result))
 	}
 
 	return schedules, nil
@@ -199,16 +165,216 @@ func (sr *SchuedleRepository) GetSchedulesByClassID(classID string) ([]models.Sc
 
 	var schedules []models.Schedule
 	for _, result := range results {
-		schedule := models.Schedule{
-			ID:        helper.ConvertUUIDToString(result.ID),
-			Date:      result.Date.Time,
-			Time:      time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(result.Time.Microseconds) * time.Microsecond),
-			TeacherID: helper.ConvertUUIDToString(result.TeacherID),
-			LessonID:  helper.ConvertUUIDToString(result.LessonID),
-			ClassID:   helper.ConvertUUIDToString(result.ClassID),
+		schedules = append(schedules, toScheduleModel(result))
+	}
+
+	return schedules, nil
+}
+
+func (sr *SchuedleRepository) GetSchedulesByRoomID(roomID string) ([]models.Schedule, error) {
+	ctx := context.Background()
+
+	roomUUID, err := helper.ConvertStringToUUID(roomID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid room ID: %w", err)
+	}
+
+	results, err := sr.queries.GetSchedulesByRoomID(ctx, roomUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedules by room ID: %w", err)
+	}
+
+	var schedules []models.Schedule
+	for _, result := range results {
+		schedules = append(schedules, toScheduleModel(result))
+	}
+
+	return schedules, nil
+}
+
+func (sr *SchuedleRepository) GetSchedulesByIDs(ids []string) ([]models.Schedule, error) {
+	ctx := context.Background()
+
+	scheduleUUIDs := make([]pgtype.UUID, 0, len(ids))
+	for _, id := range ids {
+		scheduleUUID, err := helper.ConvertStringToUUID(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule ID: %w", err)
 		}
-		schedules = append(schedules, schedule)
+		scheduleUUIDs = append(scheduleUUIDs, scheduleUUID)
+	}
+
+	results, err := sr.queries.GetSchedulesByIDs(ctx, scheduleUUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedules by IDs: %w", err)
+	}
+
+	var schedules []models.Schedule
+	for _, result := range results {
+		schedules = append(schedules, toScheduleModel(result))
 	}
 
 	return schedules, nil
 }
+
+func (sr *SchuedleRepository) GetSchedulesBySubstituteTeacherID(teacherID string) ([]models.Schedule, error) {
+	ctx := context.Background()
+
+	teacherUUID, err := helper.ConvertStringToUUID(teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid teacher ID: %w", err)
+	}
+
+	results, err := sr.queries.GetSchedulesBySubstituteTeacherID(ctx, teacherUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedules by substitute teacher ID: %w", err)
+	}
+
+	var schedules []models.Schedule
+	for _, result := range results {
+		schedules = append(schedules, toScheduleModel(result))
+	}
+
+	return schedules, nil
+}
+
+func toScheduleModel(result tutorial.Schedule) models.Schedule {
+	return models.Schedule{
+		ID:        helper.ConvertUUIDToString(result.ID),
+		Date:      result.Date.Time,
+		Time:      helper.ConvertPgTimeToTime(result.Time),
+		EndTime:   helper.ConvertPgTimeToTime(result.EndTime),
+		TeacherID: helper.ConvertUUIDToString(result.TeacherID),
+		LessonID:  helper.ConvertUUIDToString(result.LessonID),
+		ClassID:   helper.ConvertUUIDToString(result.ClassID),
+		RoomID:    helper.ConvertUUIDToString(result.RoomID),
+
+		SubstituteTeacherID: helper.ConvertUUIDToString(result.SubstituteTeacherID),
+
+		SeriesID:       helper.ConvertUUIDToString(result.SeriesID),
+		OccurrenceDate: helper.ConvertPgDateToNullableTime(result.OccurrenceDate),
+
+		Status:             result.Status,
+		CancellationReason: result.CancellationReason,
+		CancelledBy:        helper.ConvertUUIDToString(result.CancelledBy),
+		CancelledAt:        helper.ConvertPgTimestampToNullableTime(result.CancelledAt),
+
+		PeriodID: helper.ConvertUUIDToString(result.PeriodID),
+	}
+}
+
+func createScheduleParams(schedule *models.Schedule) (tutorial.CreateScheduleParams, error) {
+	lessonID, err := helper.ConvertStringToUUID(schedule.LessonID)
+	if err != nil {
+		return tutorial.CreateScheduleParams{}, fmt.Errorf("invalid lesson id:%w", err)
+	}
+
+	teacherID, err := helper.ConvertStringToUUID(schedule.TeacherID)
+	if err != nil {
+		return tutorial.CreateScheduleParams{}, fmt.Errorf("invalid teacher id:%w", err)
+	}
+
+	classID, err := helper.ConvertStringToUUID(schedule.ClassID)
+	if err != nil {
+		return tutorial.CreateScheduleParams{}, fmt.Errorf("invalid class id:%w", err)
+	}
+
+	seriesID, err := helper.ConvertNullableStringToUUID(schedule.SeriesID)
+	if err != nil {
+		return tutorial.CreateScheduleParams{}, fmt.Errorf("invalid series id:%w", err)
+	}
+
+	roomID, err := helper.ConvertNullableStringToUUID(schedule.RoomID)
+	if err != nil {
+		return tutorial.CreateScheduleParams{}, fmt.Errorf("invalid room id:%w", err)
+	}
+
+	periodID, err := helper.ConvertNullableStringToUUID(schedule.PeriodID)
+	if err != nil {
+		return tutorial.CreateScheduleParams{}, fmt.Errorf("invalid period id:%w", err)
+	}
+
+	return tutorial.CreateScheduleParams{
+		Date:           pgtype.Date{Time: schedule.Date, Valid: true},
+		Time:           helper.ConvertTimeToPgTime(schedule.Time),
+		EndTime:        helper.ConvertTimeToPgTime(schedule.EndTime),
+		TeacherID:      teacherID,
+		LessonID:       lessonID,
+		ClassID:        classID,
+		SeriesID:       seriesID,
+		OccurrenceDate: helper.ConvertNullableTimeToPgDate(schedule.OccurrenceDate),
+		RoomID:         roomID,
+		PeriodID:       periodID,
+	}, nil
+}
+
+func updateScheduleParams(schedule *models.Schedule) (tutorial.UpdateScheduleParams, error) {
+	schuedleID, err := helper.ConvertStringToUUID(schedule.ID)
+	if err != nil {
+		return tutorial.UpdateScheduleParams{}, fmt.Errorf("invalid schuedle id:%w", err)
+	}
+
+	lessonID, err := helper.ConvertStringToUUID(schedule.LessonID)
+	if err != nil {
+		return tutorial.UpdateScheduleParams{}, fmt.Errorf("invalid lesson id:%w", err)
+	}
+
+	teacherID, err := helper.ConvertStringToUUID(schedule.TeacherID)
+	if err != nil {
+		return tutorial.UpdateScheduleParams{}, fmt.Errorf("invalid teacher id:%w", err)
+	}
+
+	classID, err := helper.ConvertStringToUUID(schedule.ClassID)
+	if err != nil {
+		return tutorial.UpdateScheduleParams{}, fmt.Errorf("invalid class id:%w", err)
+	}
+
+	seriesID, err := helper.ConvertNullableStringToUUID(schedule.SeriesID)
+	if err != nil {
+		return tutorial.UpdateScheduleParams{}, fmt.Errorf("invalid series id:%w", err)
+	}
+
+	roomID, err := helper.ConvertNullableStringToUUID(schedule.RoomID)
+	if err != nil {
+		return tutorial.UpdateScheduleParams{}, fmt.Errorf("invalid room id:%w", err)
+	}
+
+	substituteID, err := helper.ConvertNullableStringToUUID(schedule.SubstituteTeacherID)
+	if err != nil {
+		return tutorial.UpdateScheduleParams{}, fmt.Errorf("invalid substitute teacher id:%w", err)
+	}
+
+	cancelledBy, err := helper.ConvertNullableStringToUUID(schedule.CancelledBy)
+	if err != nil {
+		return tutorial.UpdateScheduleParams{}, fmt.Errorf("invalid cancelled by id:%w", err)
+	}
+
+	periodID, err := helper.ConvertNullableStringToUUID(schedule.PeriodID)
+	if err != nil {
+		return tutorial.UpdateScheduleParams{}, fmt.Errorf("invalid period id:%w", err)
+	}
+
+	status := schedule.Status
+	if status == "" {
+		status = models.ScheduleScheduled
+	}
+
+	return tutorial.UpdateScheduleParams{
+		ID:                  schuedleID,
+		Date:                pgtype.Date{Time: schedule.Date, Valid: true},
+		Time:                helper.ConvertTimeToPgTime(schedule.Time),
+		EndTime:             helper.ConvertTimeToPgTime(schedule.EndTime),
+		TeacherID:           teacherID,
+		LessonID:            lessonID,
+		ClassID:             classID,
+		SeriesID:            seriesID,
+		OccurrenceDate:      helper.ConvertNullableTimeToPgDate(schedule.OccurrenceDate),
+		RoomID:              roomID,
+		SubstituteTeacherID: substituteID,
+		Status:              status,
+		CancellationReason:  schedule.CancellationReason,
+		CancelledBy:         cancelledBy,
+		CancelledAt:         helper.ConvertNullableTimeToPgTimestamp(schedule.CancelledAt),
+		PeriodID:            periodID,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TeacherAbsenceRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewTeacherAbsenceRepository(db *pgxpool.Pool) models.TeacherAbsenceRepository {
+	return &TeacherAbsenceRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (tar *TeacherAbsenceRepository) CreateTeacherAbsence(absence *models.TeacherAbsence) error {
+	ctx := context.Background()
+
+	teacherID, err := helper.ConvertStringToUUID(absence.TeacherID)
+	if err != nil {
+		return fmt.Errorf("invalid teacher id:%w", err)
+	}
+
+	params := tutorial.CreateTeacherAbsenceParams{
+		TeacherID: teacherID,
+		StartDate: pgtype.Date{Time: absence.StartDate, Valid: true},
+		EndDate:   pgtype.Date{Time: absence.EndDate, Valid: true},
+		Reason:    absence.Reason,
+	}
+
+	res, err := tar.queries.CreateTeacherAbsence(ctx, params)
+	if err != nil {
+		return fmt.Errorf("create teacher absence fail:%w", err)
+	}
+
+	absence.ID = helper.ConvertUUIDToString(res.ID)
+	absence.CreatedAt = helper.ConvertPgTimestampToTime(res.CreatedAt)
+	return nil
+}
+
+func (tar *TeacherAbsenceRepository) GetTeacherAbsenceByID(id string) (*models.TeacherAbsence, error) {
+	ctx := context.Background()
+
+	absenceID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid teacher absence ID: %w", err)
+	}
+
+	result, err := tar.queries.GetTeacherAbsenceByID(ctx, absenceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher absence: %w", err)
+	}
+
+	absence := toTeacherAbsenceModel(result)
+	return &absence, nil
+}
+
+func (tar *TeacherAbsenceRepository) DeleteTeacherAbsence(id string) error {
+	ctx := context.Background()
+
+	absenceID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid teacher absence id:%w", err)
+	}
+
+	err = tar.queries.DeleteTeacherAbsence(ctx, absenceID)
+	if err != nil {
+		return fmt.Errorf("delete teacher absence fail:%w", err)
+	}
+	return nil
+}
+
+func (tar *TeacherAbsenceRepository) GetTeacherAbsencesByTeacherID(teacherID string) ([]models.TeacherAbsence, error) {
+	ctx := context.Background()
+
+	teacherUUID, err := helper.ConvertStringToUUID(teacherID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid teacher ID: %w", err)
+	}
+
+	results, err := tar.queries.GetTeacherAbsencesByTeacherID(ctx, teacherUUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher absences: %w", err)
+	}
+
+	return toTeacherAbsenceModels(results), nil
+}
+
+func (tar *TeacherAbsenceRepository) GetTeacherAbsencesBetween(from, to time.Time) ([]models.TeacherAbsence, error) {
+	ctx := context.Background()
+
+	params := tutorial.GetTeacherAbsencesBetweenParams{
+		FromDate: pgtype.Date{Time: from, Valid: true},
+		ToDate:   pgtype.Date{Time: to, Valid: true},
+	}
+
+	results, err := tar.queries.GetTeacherAbsencesBetween(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teacher absences: %w", err)
+	}
+
+	return toTeacherAbsenceModels(results), nil
+}
+
+func toTeacherAbsenceModels(results []tutorial.TeacherAbsence) []models.TeacherAbsence {
+	var absences []models.TeacherAbsence
+	for _, result := range results {
+		absences = append(absences, toTeacherAbsenceModel(result))
+	}
+	return absences
+}
+
+func toTeacherAbsenceModel(result tutorial.TeacherAbsence) models.TeacherAbsence {
+	return models.TeacherAbsence{
+		ID:        helper.ConvertUUIDToString(result.ID),
+		TeacherID: helper.ConvertUUIDToString(result.TeacherID),
+		StartDate: result.StartDate.Time,
+		EndDate:   result.EndDate.Time,
+		Reason:    result.Reason,
+		CreatedAt: helper.ConvertPgTimestampToTime(result.CreatedAt),
+	}
+}
@@ -0,0 +1,112 @@
+package repo
+
+import (
+	"Education_Dashboard/internal/helper"
+	"Education_Dashboard/internal/infrastructure/db/postgresql/sqlc/tutorial"
+	"Education_Dashboard/internal/models"
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WorkloadLimitRepository struct {
+	db      *pgxpool.Pool
+	queries *tutorial.Queries
+}
+
+func NewWorkloadLimitRepository(db *pgxpool.Pool) models.WorkloadLimitRepository {
+	return &WorkloadLimitRepository{
+		db:      db,
+		queries: tutorial.New(db),
+	}
+}
+
+func (wr *WorkloadLimitRepository) CreateWorkloadLimit(limit *models.WorkloadLimit) error {
+	ctx := context.Background()
+
+	teacherID, err := helper.ConvertNullableStringToUUID(limit.TeacherID)
+	if err != nil {
+		return fmt.Errorf("invalid teacher id:%w", err)
+	}
+
+	params := tutorial.CreateWorkloadLimitParams{
+		TeacherID:             teacherID,
+		MaxWeeklyMinutes:      toWeeklyMinutes(limit.MaxWeeklyHours),
+		MaxConsecutiveLessons: int32(limit.MaxConsecutiveLessons),
+	}
+
+	res, err := wr.queries.CreateWorkloadLimit(ctx, params)
+	if err != nil {
+		return fmt.Errorf("create workload limit fail:%w", err)
+	}
+
+	limit.ID = helper.ConvertUUIDToString(res.ID)
+	limit.UpdatedAt = helper.ConvertPgTimestampToTime(res.UpdatedAt)
+	return nil
+}
+
+func (wr *WorkloadLimitRepository) UpdateWorkloadLimit(limit *models.WorkloadLimit) error {
+	ctx := context.Background()
+
+	limitID, err := helper.ConvertStringToUUID(limit.ID)
+	if err != nil {
+		return fmt.Errorf("invalid workload limit id:%w", err)
+	}
+
+	params := tutorial.UpdateWorkloadLimitParams{
+		ID:                    limitID,
+		MaxWeeklyMinutes:      toWeeklyMinutes(limit.MaxWeeklyHours),
+		MaxConsecutiveLessons: int32(limit.MaxConsecutiveLessons),
+	}
+
+	res, err := wr.queries.UpdateWorkloadLimit(ctx, params)
+	if err != nil {
+		return fmt.Errorf("update workload limit fail:%w", err)
+	}
+
+	limit.UpdatedAt = helper.ConvertPgTimestampToTime(res.UpdatedAt)
+	return nil
+}
+
+func (wr *WorkloadLimitRepository) DeleteWorkloadLimit(id string) error {
+	ctx := context.Background()
+
+	limitID, err := helper.ConvertStringToUUID(id)
+	if err != nil {
+		return fmt.Errorf("invalid workload limit id:%w", err)
+	}
+
+	err = wr.queries.DeleteWorkloadLimit(ctx, limitID)
+	if err != nil {
+		return fmt.Errorf("delete workload limit fail:%w", err)
+	}
+	return nil
+}
+
+func (wr *WorkloadLimitRepository) GetAllWorkloadLimits() ([]models.WorkloadLimit, error) {
+	ctx := context.Background()
+
+	results, err := wr.queries.GetAllWorkloadLimits(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workload limits: %w", err)
+	}
+
+	var limits []models.WorkloadLimit
+	for _, result := range results {
+		limits = append(limits, models.WorkloadLimit{
+			ID:                    helper.ConvertUUIDToString(result.ID),
+			TeacherID:             helper.ConvertUUIDToString(result.TeacherID),
+			MaxWeeklyHours:        float64(result.MaxWeeklyMinutes) / 60,
+			MaxConsecutiveLessons: int(result.MaxConsecutiveLessons),
+			UpdatedAt:             helper.ConvertPgTimestampToTime(result.UpdatedAt),
+		})
+	}
+	return limits, nil
+}
+
+// toWeeklyMinutes stores weekly hours as whole minutes
+func toWeeklyMinutes(hours float64) int32 {
+	return int32(math.Round(hours * 60))
+}
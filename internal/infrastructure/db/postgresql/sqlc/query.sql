@@ -1,6 +1,6 @@
 -- name: CreateAttendance :one
-INSERT INTO attendances (student_id, schedule_id, here, counter)
-VALUES ($1, $2, $3, $4)
+INSERT INTO attendances (student_id, schedule_id, counter, status, minutes_late, note)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING *;
 
 -- name: GetAttendanceByID :one
@@ -10,8 +10,10 @@ SELECT * FROM attendances WHERE id = $1;
 UPDATE attendances
 SET student_id = $2,
     schedule_id = $3,
-    here = $4,
-    counter = $5
+    counter = $4,
+    status = $5,
+    minutes_late = $6,
+    note = $7
 WHERE id = $1
 RETURNING *;
 
@@ -24,6 +26,24 @@ SELECT * FROM attendances WHERE student_id = $1;
 -- name: GetAttendanceByScheduleID :many
 SELECT * FROM attendances WHERE schedule_id = $1;
 
+-- name: GetAttendanceByClassID :many
+SELECT a.* FROM attendances a
+JOIN schedules s ON s.id = a.schedule_id
+WHERE s.class_id = @class_id
+  AND s.date >= @from_date::DATE
+  AND s.date <= @to_date::DATE
+ORDER BY a.student_id;
+
+-- name: UpsertAttendance :one
+INSERT INTO attendances (student_id, schedule_id, counter, status, minutes_late, note)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (student_id, schedule_id) DO UPDATE
+SET counter = EXCLUDED.counter,
+    status = EXCLUDED.status,
+    minutes_late = EXCLUDED.minutes_late,
+    note = EXCLUDED.note
+RETURNING *;
+
 
 
 
@@ -89,8 +109,8 @@ SELECT * FROM lessons;
 
 
 -- name: CreateSchedule :one
-INSERT INTO schedules (date, time, teacher_id, lesson_id, class_id)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO schedules (date, time, end_time, teacher_id, lesson_id, class_id, series_id, occurrence_date, room_id, period_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING *;
 
 -- name: GetScheduleByID :one
@@ -100,9 +120,19 @@ SELECT * FROM schedules WHERE id = $1;
 UPDATE schedules
 SET date = $2,
     time = $3,
-    teacher_id = $4,
-    lesson_id = $5,
-    class_id = $6
+    end_time = $4,
+    teacher_id = $5,
+    lesson_id = $6,
+    class_id = $7,
+    series_id = $8,
+    occurrence_date = $9,
+    room_id = $10,
+    substitute_teacher_id = $11,
+    status = $12,
+    cancellation_reason = $13,
+    cancelled_by = $14,
+    cancelled_at = $15,
+    period_id = $16
 WHERE id = $1
 RETURNING *;
 
@@ -117,3 +147,524 @@ SELECT * FROM schedules WHERE teacher_id = $1;
 
 -- name: GetSchedulesByClassID :many
 SELECT * FROM schedules WHERE class_id = $1;
+
+-- name: GetSchedulesByRoomID :many
+SELECT * FROM schedules WHERE room_id = $1;
+
+-- name: GetSchedulesBySubstituteTeacherID :many
+SELECT * FROM schedules WHERE substitute_teacher_id = $1;
+
+-- name: GetSchedulesByIDs :many
+SELECT * FROM schedules WHERE id = ANY(@ids::UUID[]);
+
+
+
+
+-- name: CreateScheduleSeries :one
+INSERT INTO schedule_series (teacher_id, lesson_id, class_id, start_date, time, end_time, weekdays, until_date, occurrence_count, exception_dates, room_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING *;
+
+-- name: GetScheduleSeriesByID :one
+SELECT * FROM schedule_series WHERE id = $1;
+
+-- name: UpdateScheduleSeries :one
+UPDATE schedule_series
+SET teacher_id = $2,
+    lesson_id = $3,
+    class_id = $4,
+    start_date = $5,
+    time = $6,
+    end_time = $7,
+    weekdays = $8,
+    until_date = $9,
+    occurrence_count = $10,
+    exception_dates = $11,
+    room_id = $12
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteScheduleSeries :exec
+DELETE FROM schedule_series WHERE id = $1;
+
+-- name: GetAllScheduleSeries :many
+SELECT * FROM schedule_series;
+
+-- name: GetScheduleSeriesByTeacherID :many
+SELECT * FROM schedule_series WHERE teacher_id = $1;
+
+-- name: GetScheduleSeriesByClassID :many
+SELECT * FROM schedule_series WHERE class_id = $1;
+
+-- name: GetScheduleSeriesByRoomID :many
+SELECT * FROM schedule_series WHERE room_id = $1;
+
+
+
+
+-- name: CreateRoom :one
+INSERT INTO rooms (name, capacity, features)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetRoomByID :one
+SELECT * FROM rooms WHERE id = $1;
+
+-- name: UpdateRoom :one
+UPDATE rooms
+SET name = $2,
+    capacity = $3,
+    features = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteRoom :exec
+DELETE FROM rooms WHERE id = $1;
+
+-- name: GetAllRooms :many
+SELECT * FROM rooms;
+
+
+
+
+-- name: CreateCalendarFeed :one
+INSERT INTO calendar_feeds (token, owner_type, owner_id, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetCalendarFeedByID :one
+SELECT * FROM calendar_feeds WHERE id = $1;
+
+-- name: GetCalendarFeedByToken :one
+SELECT * FROM calendar_feeds WHERE token = $1;
+
+-- name: GetCalendarFeedsByCreator :many
+SELECT * FROM calendar_feeds WHERE created_by = $1;
+
+-- name: DeleteCalendarFeed :exec
+DELETE FROM calendar_feeds WHERE id = $1;
+
+
+
+
+-- name: CreateTerm :one
+INSERT INTO terms (name, start_date, end_date)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetTermByID :one
+SELECT * FROM terms WHERE id = $1;
+
+-- name: UpdateTerm :one
+UPDATE terms
+SET name = $2,
+    start_date = $3,
+    end_date = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteTerm :exec
+DELETE FROM terms WHERE id = $1;
+
+-- name: GetAllTerms :many
+SELECT * FROM terms ORDER BY start_date;
+
+
+
+
+-- name: CreateClosure :one
+INSERT INTO closures (name, kind, start_date, end_date)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetClosureByID :one
+SELECT * FROM closures WHERE id = $1;
+
+-- name: UpdateClosure :one
+UPDATE closures
+SET name = $2,
+    kind = $3,
+    start_date = $4,
+    end_date = $5
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteClosure :exec
+DELETE FROM closures WHERE id = $1;
+
+-- name: GetAllClosures :many
+SELECT * FROM closures ORDER BY start_date;
+
+-- name: GetClosuresBetween :many
+SELECT * FROM closures
+WHERE end_date >= @from_date AND start_date <= @to_date
+ORDER BY start_date;
+
+
+
+
+-- name: CreateTeacherAbsence :one
+INSERT INTO teacher_absences (teacher_id, start_date, end_date, reason)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetTeacherAbsenceByID :one
+SELECT * FROM teacher_absences WHERE id = $1;
+
+-- name: DeleteTeacherAbsence :exec
+DELETE FROM teacher_absences WHERE id = $1;
+
+-- name: GetTeacherAbsencesByTeacherID :many
+SELECT * FROM teacher_absences WHERE teacher_id = $1 ORDER BY start_date;
+
+-- name: GetTeacherAbsencesBetween :many
+SELECT * FROM teacher_absences
+WHERE end_date >= @from_date AND start_date <= @to_date
+ORDER BY start_date;
+
+
+
+
+-- name: CreateTeacherAvailability :one
+INSERT INTO teacher_availability (teacher_id, kind, weekday, date, start_time, end_time, note)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetTeacherAvailabilityByID :one
+SELECT * FROM teacher_availability WHERE id = $1;
+
+-- name: UpdateTeacherAvailability :one
+UPDATE teacher_availability
+SET kind = $2,
+    weekday = $3,
+    date = $4,
+    start_time = $5,
+    end_time = $6,
+    note = $7
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteTeacherAvailability :exec
+DELETE FROM teacher_availability WHERE id = $1;
+
+-- name: GetTeacherAvailabilityByTeacherID :many
+SELECT * FROM teacher_availability
+WHERE teacher_id = $1
+ORDER BY date NULLS FIRST, weekday, start_time;
+
+
+
+-- name: CreateWorkloadLimit :one
+INSERT INTO workload_limits (teacher_id, max_weekly_minutes, max_consecutive_lessons)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateWorkloadLimit :one
+UPDATE workload_limits
+SET max_weekly_minutes = $2,
+    max_consecutive_lessons = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteWorkloadLimit :exec
+DELETE FROM workload_limits WHERE id = $1;
+
+-- name: GetAllWorkloadLimits :many
+SELECT * FROM workload_limits
+ORDER BY teacher_id NULLS FIRST;
+
+
+
+-- name: CreateBellSchedule :one
+INSERT INTO bell_schedules (name, active)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetBellScheduleByID :one
+SELECT * FROM bell_schedules WHERE id = $1;
+
+-- name: UpdateBellSchedule :one
+UPDATE bell_schedules
+SET name = $2,
+    active = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: DeactivateBellSchedules :exec
+UPDATE bell_schedules SET active = FALSE WHERE active AND id <> $1;
+
+-- name: DeleteBellSchedule :exec
+DELETE FROM bell_schedules WHERE id = $1;
+
+-- name: GetAllBellSchedules :many
+SELECT * FROM bell_schedules ORDER BY created_at;
+
+-- name: CreateBellPeriod :one
+INSERT INTO bell_periods (bell_schedule_id, name, position, weekdays, start_time, end_time)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: UpdateBellPeriod :one
+UPDATE bell_periods
+SET name = $2,
+    position = $3,
+    weekdays = $4,
+    start_time = $5,
+    end_time = $6
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteBellPeriod :exec
+DELETE FROM bell_periods WHERE id = $1;
+
+-- name: GetBellPeriodsByScheduleID :many
+SELECT * FROM bell_periods
+WHERE bell_schedule_id = $1
+ORDER BY position, start_time;
+
+
+
+-- name: CreateExam :one
+INSERT INTO exams (lesson_id, class_id, title, date, start_time, end_time, room_id, invigilator_ids)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetExamByID :one
+SELECT * FROM exams WHERE id = $1;
+
+-- name: UpdateExam :one
+UPDATE exams
+SET lesson_id = $2,
+    class_id = $3,
+    title = $4,
+    date = $5,
+    start_time = $6,
+    end_time = $7,
+    room_id = $8,
+    invigilator_ids = $9
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteExam :exec
+DELETE FROM exams WHERE id = $1;
+
+-- name: GetExamsBetween :many
+SELECT * FROM exams
+WHERE date >= @from_date AND date <= @to_date
+ORDER BY date, start_time;
+
+-- name: GetExamRules :one
+SELECT * FROM exam_rules WHERE id = 1;
+
+-- name: UpdateExamRules :one
+UPDATE exam_rules
+SET max_exams_per_day = $1,
+    min_gap_minutes = $2,
+    updated_at = NOW()
+WHERE id = 1
+RETURNING *;
+
+-- name: GetAttendancePolicy :one
+SELECT * FROM attendance_policy WHERE id = 1;
+
+-- name: UpdateAttendancePolicy :one
+UPDATE attendance_policy
+SET present_weight = $1,
+    late_weight = $2,
+    remote_weight = $3,
+    excused_weight = $4,
+    count_excused = $5,
+    updated_at = NOW()
+WHERE id = 1
+RETURNING *;
+
+-- name: CreateExcuse :one
+INSERT INTO excuses (student_id, submitted_by, reason, schedule_ids, from_date, to_date, document_url)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetExcuseByID :one
+SELECT * FROM excuses WHERE id = $1;
+
+-- name: GetExcusesByStudentID :many
+SELECT * FROM excuses WHERE student_id = $1 ORDER BY created_at DESC;
+
+-- name: GetExcusesByStatus :many
+SELECT * FROM excuses WHERE status = $1 ORDER BY created_at;
+
+-- name: GetApprovedExcusesForLesson :many
+SELECT * FROM excuses
+WHERE status = 'approved'
+  AND (@schedule_id::UUID = ANY(schedule_ids)
+       OR @lesson_date::DATE BETWEEN from_date AND to_date)
+ORDER BY created_at;
+
+-- name: UpdateExcuseReview :one
+UPDATE excuses
+SET status = $2,
+    reviewed_by = $3,
+    review_note = $4,
+    reviewed_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
+
+-- name: UpdateAttendanceStatus :exec
+UPDATE attendances
+SET status = $2,
+    minutes_late = $3,
+    counter = $4
+WHERE id = $1;
+
+-- name: CreateAttendanceAudit :one
+INSERT INTO attendance_audits (attendance_id, excuse_id, changed_by, old_status, new_status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetAttendanceAuditsByAttendanceID :many
+SELECT * FROM attendance_audits WHERE attendance_id = $1 ORDER BY changed_at;
+
+-- name: CreateAttendanceThreshold :one
+INSERT INTO attendance_thresholds (lesson_id, min_rate, min_lessons)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetAttendanceThresholdByID :one
+SELECT * FROM attendance_thresholds WHERE id = $1;
+
+-- name: GetAttendanceThresholds :many
+SELECT * FROM attendance_thresholds ORDER BY lesson_id NULLS FIRST, created_at;
+
+-- name: UpdateAttendanceThreshold :one
+UPDATE attendance_thresholds
+SET min_rate = $2,
+    min_lessons = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteAttendanceThreshold :exec
+DELETE FROM attendance_thresholds WHERE id = $1;
+
+-- name: CreateAttendanceAlert :one
+INSERT INTO attendance_alerts (student_id, lesson_id, threshold_id, rate, min_rate, recipient_ids, guardian_phone)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetOpenAttendanceAlerts :many
+SELECT * FROM attendance_alerts WHERE status = 'open' ORDER BY created_at;
+
+-- name: GetOpenAttendanceAlertsByStudentID :many
+SELECT * FROM attendance_alerts WHERE student_id = $1 AND status = 'open' ORDER BY created_at;
+
+-- name: GetOpenAttendanceAlertsByRecipientID :many
+SELECT * FROM attendance_alerts WHERE @recipient_id::UUID = ANY(recipient_ids) AND status = 'open' ORDER BY created_at;
+
+-- name: ResolveAttendanceAlert :exec
+UPDATE attendance_alerts
+SET status = 'resolved',
+    resolved_at = NOW()
+WHERE id = $1 AND status = 'open';
+
+-- name: CreateCheckInSession :one
+INSERT INTO check_in_sessions (schedule_id, opened_by, secret, code_seconds, late_after_minutes, opened_at, closes_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetCheckInSessionByID :one
+SELECT * FROM check_in_sessions WHERE id = $1;
+
+-- name: GetCheckInSessionsByScheduleID :many
+SELECT * FROM check_in_sessions WHERE schedule_id = $1 ORDER BY opened_at DESC;
+
+-- name: CloseCheckInSession :one
+UPDATE check_in_sessions
+SET closed_at = $2
+WHERE id = $1 AND closed_at IS NULL
+RETURNING *;
+
+-- name: CreateCheckIn :one
+INSERT INTO check_ins (session_id, student_id, code, status, minutes_late, checked_in_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: DeleteCheckIn :exec
+DELETE FROM check_ins WHERE id = $1;
+
+-- name: GetCheckInsBySessionID :many
+SELECT * FROM check_ins WHERE session_id = $1 ORDER BY checked_in_at;
+
+-- name: GetAttendanceReport :many
+-- Weighted attendance rates of the non-cancelled lessons in the range,
+-- grouped by student, class, lesson or the teacher who taught the lesson,
+-- per week, per month or over the whole range
+SELECT (CASE @group_by::TEXT
+        WHEN 'student' THEN a.student_id
+        WHEN 'class' THEN s.class_id
+        WHEN 'lesson' THEN s.lesson_id
+        ELSE COALESCE(s.substitute_teacher_id, s.teacher_id)
+    END)::UUID AS group_id,
+    (CASE WHEN @period::TEXT = 'none' THEN @from_date::DATE ELSE date_trunc(@period::TEXT, s.date)::DATE END)::DATE AS period_start,
+    COUNT(*) FILTER (WHERE a.status <> 'excused' OR p.count_excused) AS lessons,
+    COUNT(*) FILTER (WHERE a.status = 'present') AS present,
+    COUNT(*) FILTER (WHERE a.status = 'late') AS late,
+    COUNT(*) FILTER (WHERE a.status = 'remote') AS remote,
+    COUNT(*) FILTER (WHERE a.status = 'excused') AS excused,
+    COUNT(*) FILTER (WHERE a.status = 'absent') AS absent,
+    COALESCE(SUM(a.minutes_late), 0)::BIGINT AS minutes_late,
+    COALESCE(SUM(CASE a.status
+        WHEN 'present' THEN p.present_weight
+        WHEN 'late' THEN p.late_weight
+        WHEN 'remote' THEN p.remote_weight
+        WHEN 'excused' THEN CASE WHEN p.count_excused THEN p.excused_weight ELSE 0 END
+        ELSE 0
+    END) / NULLIF(COUNT(*) FILTER (WHERE a.status <> 'excused' OR p.count_excused), 0) * 100, 0)::FLOAT8 AS rate
+FROM attendances a
+JOIN schedules s ON s.id = a.schedule_id
+CROSS JOIN attendance_policy p
+WHERE s.status <> 'cancelled'
+  AND s.date >= @from_date::DATE
+  AND s.date <= @to_date::DATE
+  AND (sqlc.narg(class_id)::UUID IS NULL OR s.class_id = sqlc.narg(class_id)::UUID)
+  AND (sqlc.narg(lesson_id)::UUID IS NULL OR s.lesson_id = sqlc.narg(lesson_id)::UUID)
+  AND (sqlc.narg(teacher_id)::UUID IS NULL OR COALESCE(s.substitute_teacher_id, s.teacher_id) = sqlc.narg(teacher_id)::UUID)
+  AND (sqlc.narg(student_id)::UUID IS NULL OR a.student_id = sqlc.narg(student_id)::UUID)
+GROUP BY 1, 2
+ORDER BY 2, 1;
+
+-- name: GetUncheckedSchedules :many
+SELECT s.* FROM schedules s
+LEFT JOIN roll_call_checks r ON r.schedule_id = s.id
+WHERE r.schedule_id IS NULL
+  AND s.status <> 'cancelled'
+  AND s.date >= @from_date::DATE
+  AND s.date <= @to_date::DATE
+ORDER BY s.date, s.time;
+
+-- name: CreateMissingAttendance :one
+-- Leaves attendance taken in the meantime as it is and returns no row
+INSERT INTO attendances (student_id, schedule_id, counter, status, minutes_late, note)
+VALUES ($1, $2, 0, $3, 0, $4)
+ON CONFLICT (student_id, schedule_id) DO NOTHING
+RETURNING *;
+
+-- name: CreateRollCallCheck :one
+INSERT INTO roll_call_checks (schedule_id, teacher_id, missing_student_ids, checked_at)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetRollCallCheck :one
+SELECT * FROM roll_call_checks WHERE schedule_id = $1;
+
+-- name: GetOpenRollCallReminders :many
+SELECT * FROM roll_call_checks
+WHERE resolved_at IS NULL AND cardinality(missing_student_ids) > 0
+ORDER BY checked_at;
+
+-- name: GetOpenRollCallRemindersByTeacherID :many
+SELECT * FROM roll_call_checks
+WHERE teacher_id = $1 AND resolved_at IS NULL AND cardinality(missing_student_ids) > 0
+ORDER BY checked_at;
+
+-- name: ResolveRollCallCheck :exec
+UPDATE roll_call_checks SET resolved_at = NOW() WHERE schedule_id = $1;
@@ -2,11 +2,15 @@ CREATE TABLE attendances (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     student_id UUID NOT NULL,     -- Keycloak user id
     schedule_id UUID NOT NULL,    -- Schedule tablosu ile bağlantı
-    here BOOLEAN NOT NULL DEFAULT FALSE,
     counter INT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'absent',   -- present, absent, late, excused, remote
+    minutes_late INT NOT NULL DEFAULT 0,
+    note TEXT NOT NULL DEFAULT '',
     CONSTRAINT fk_schedule FOREIGN KEY(schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
 );
 
+CREATE UNIQUE INDEX idx_attendances_student_schedule ON attendances(student_id, schedule_id);  -- öğrenci başına ders için tek kayıt
+
 
 CREATE TABLE homeworks (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -33,9 +37,295 @@ CREATE TABLE schedules (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     date DATE NOT NULL,
     time TIME NOT NULL,
+    end_time TIME NOT NULL,
     teacher_id UUID NOT NULL,      -- Keycloak teacher user ID
     lesson_id UUID NOT NULL,       -- Lesson tablosu ile bağlantı
     class_id UUID NOT NULL,        -- Class tablosu ile bağlantı
+    series_id UUID,                -- Tekrarlayan seri (tek başına düzenlenmiş tekrarlar)
+    occurrence_date DATE,          -- Serideki asıl tarih
+    room_id UUID,                  -- Derslik
+    substitute_teacher_id UUID,    -- Asıl öğretmen yokken derse giren öğretmen
+    status TEXT NOT NULL DEFAULT 'scheduled', -- scheduled, cancelled, completed
+    cancellation_reason TEXT NOT NULL DEFAULT '',
+    cancelled_by UUID,             -- İptal eden kullanıcı
+    cancelled_at TIMESTAMP,
+    period_id UUID,                -- Zil çizelgesindeki ders saati
     CONSTRAINT fk_lesson FOREIGN KEY(lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
-    CONSTRAINT fk_class FOREIGN KEY(class_id) REFERENCES classes(id) ON DELETE CASCADE
+    CONSTRAINT fk_class FOREIGN KEY(class_id) REFERENCES classes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_series FOREIGN KEY(series_id) REFERENCES schedule_series(id) ON DELETE SET NULL,
+    CONSTRAINT fk_room FOREIGN KEY(room_id) REFERENCES rooms(id) ON DELETE SET NULL,
+    CONSTRAINT fk_period FOREIGN KEY(period_id) REFERENCES bell_periods(id) ON DELETE SET NULL,
+    CONSTRAINT chk_schedule_end_after_start CHECK (end_time > time),
+    CONSTRAINT chk_schedule_status CHECK (status IN ('scheduled', 'cancelled', 'completed'))
+);
+
+
+
+CREATE TABLE schedule_series (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    teacher_id UUID NOT NULL,      -- Keycloak teacher user ID
+    lesson_id UUID NOT NULL,
+    class_id UUID NOT NULL,
+    start_date DATE NOT NULL,
+    time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    weekdays INT[] NOT NULL,       -- 0 = Pazar ... 6 = Cumartesi
+    until_date DATE,
+    occurrence_count INT,
+    exception_dates DATE[] NOT NULL DEFAULT '{}',
+    room_id UUID,
+    CONSTRAINT fk_lesson FOREIGN KEY(lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    CONSTRAINT fk_class FOREIGN KEY(class_id) REFERENCES classes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room FOREIGN KEY(room_id) REFERENCES rooms(id) ON DELETE SET NULL,
+    CONSTRAINT chk_series_end_after_start CHECK (end_time > time),
+    CONSTRAINT chk_series_bounded CHECK (until_date IS NOT NULL OR occurrence_count IS NOT NULL)
+);
+
+
+
+CREATE TABLE rooms (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    capacity INT NOT NULL,
+    features TEXT[] NOT NULL DEFAULT '{}',   -- projector, lab, ...
+    CONSTRAINT chk_room_capacity CHECK (capacity > 0)
+);
+
+
+
+CREATE TABLE calendar_feeds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token VARCHAR(64) NOT NULL UNIQUE,   -- Abonelik adresindeki gizli anahtar
+    owner_type VARCHAR(16) NOT NULL,     -- teacher, class, student
+    owner_id UUID NOT NULL,
+    created_by UUID NOT NULL,            -- Keycloak user ID
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_feed_owner_type CHECK (owner_type IN ('teacher', 'class', 'student'))
+);
+
+
+
+CREATE TABLE terms (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    CONSTRAINT chk_term_dates CHECK (end_date >= start_date)
+);
+
+
+
+CREATE TABLE closures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL,            -- holiday (resmi tatil), closure (okul kapalı)
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,               -- tek günlük kapanışlarda start_date ile aynı
+    CONSTRAINT chk_closure_kind CHECK (kind IN ('holiday', 'closure')),
+    CONSTRAINT chk_closure_dates CHECK (end_date >= start_date)
+);
+
+
+
+CREATE TABLE teacher_absences (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    teacher_id UUID NOT NULL,             -- Keycloak teacher user ID
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_absence_dates CHECK (end_date >= start_date)
+);
+
+
+
+CREATE TABLE teacher_availability (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    teacher_id UUID NOT NULL,             -- Keycloak teacher user ID
+    kind VARCHAR(16) NOT NULL,            -- available, unavailable
+    weekday INT,                          -- haftalık pencereler: 0 = Pazar ... 6 = Cumartesi
+    date DATE,                            -- tek seferlik pencereler
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_availability_kind CHECK (kind IN ('available', 'unavailable')),
+    CONSTRAINT chk_availability_weekday CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT chk_availability_repeat CHECK ((weekday IS NULL) <> (date IS NULL)),
+    CONSTRAINT chk_availability_times CHECK (end_time > start_time)
+);
+
+
+
+CREATE TABLE workload_limits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    teacher_id UUID,                      -- boş ise okul geneli varsayılan
+    max_weekly_minutes INT NOT NULL DEFAULT 0,      -- 0 = sınır yok
+    max_consecutive_lessons INT NOT NULL DEFAULT 0, -- 0 = sınır yok
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_workload_limits CHECK (max_weekly_minutes >= 0 AND max_consecutive_lessons >= 0)
+);
+
+
+
+CREATE TABLE bell_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT FALSE,  -- aynı anda yalnızca bir çizelge etkin
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
+
+
+
+CREATE TABLE bell_periods (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    bell_schedule_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,           -- örn. 1. ders
+    position INT NOT NULL,                -- çizelgedeki sıra
+    weekdays INT[] NOT NULL,              -- 0 = Pazar ... 6 = Cumartesi
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    CONSTRAINT fk_bell_schedule FOREIGN KEY(bell_schedule_id) REFERENCES bell_schedules(id) ON DELETE CASCADE,
+    CONSTRAINT chk_bell_period_times CHECK (end_time > start_time)
+);
+
+
+
+CREATE TABLE exams (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    lesson_id UUID NOT NULL,              -- Lesson tablosu ile bağlantı
+    class_id UUID NOT NULL,               -- Class tablosu ile bağlantı
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    date DATE NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    room_id UUID,                         -- Sınav salonu
+    invigilator_ids UUID[] NOT NULL DEFAULT '{}', -- Gözetmen öğretmenler
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_lesson FOREIGN KEY(lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    CONSTRAINT fk_class FOREIGN KEY(class_id) REFERENCES classes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room FOREIGN KEY(room_id) REFERENCES rooms(id) ON DELETE SET NULL,
+    CONSTRAINT chk_exam_times CHECK (end_time > start_time)
+);
+
+
+
+CREATE TABLE exam_rules (
+    id INT PRIMARY KEY DEFAULT 1,         -- tek satır
+    max_exams_per_day INT NOT NULL DEFAULT 1,  -- sınıf başına günlük sınav, 0 = sınır yok
+    min_gap_minutes INT NOT NULL DEFAULT 0,    -- aynı gün iki sınav arası en az süre
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_exam_rules_single CHECK (id = 1),
+    CONSTRAINT chk_exam_rules CHECK (max_exams_per_day >= 0 AND min_gap_minutes >= 0)
+);
+
+
+CREATE TABLE attendance_policy (
+    id INT PRIMARY KEY DEFAULT 1,                   -- tek satır
+    present_weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    late_weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    remote_weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    excused_weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    count_excused BOOLEAN NOT NULL DEFAULT FALSE,   -- false: mazeretli dersler orana katılmaz
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_attendance_policy_single CHECK (id = 1)
+);
+
+
+CREATE TABLE excuses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    student_id UUID NOT NULL,                  -- Keycloak user id
+    submitted_by UUID NOT NULL,                -- öğrenci ya da okul adına giren personel
+    reason TEXT NOT NULL,
+    schedule_ids UUID[] NOT NULL DEFAULT '{}', -- mazeret verilen dersler
+    from_date DATE,                            -- ya da tarih aralığı
+    to_date DATE,
+    document_url TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending',    -- pending, approved, rejected
+    reviewed_by UUID,
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+
+CREATE TABLE attendance_audits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    attendance_id UUID NOT NULL,               -- Attendance tablosu ile bağlantı
+    excuse_id UUID,                            -- değişikliği yapan mazeret
+    changed_by UUID NOT NULL,
+    old_status TEXT NOT NULL,
+    new_status TEXT NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_attendance FOREIGN KEY(attendance_id) REFERENCES attendances(id) ON DELETE CASCADE,
+    CONSTRAINT fk_excuse FOREIGN KEY(excuse_id) REFERENCES excuses(id) ON DELETE SET NULL
+);
+
+
+CREATE TABLE attendance_thresholds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    lesson_id UUID,                            -- NULL: genel devam oranı
+    min_rate DOUBLE PRECISION NOT NULL,        -- yüzde, altı uyarı
+    min_lessons INT NOT NULL DEFAULT 1,        -- değerlendirme için en az ders
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_lesson FOREIGN KEY(lesson_id) REFERENCES lessons(id) ON DELETE CASCADE
+);
+
+
+CREATE TABLE attendance_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    student_id UUID NOT NULL,                  -- Keycloak user id
+    lesson_id UUID,                            -- NULL: genel devam oranı
+    threshold_id UUID,
+    rate DOUBLE PRECISION NOT NULL,            -- uyarı anındaki oran
+    min_rate DOUBLE PRECISION NOT NULL,
+    recipient_ids UUID[] NOT NULL DEFAULT '{}', -- sınıf öğretmenleri ve yöneticiler
+    guardian_phone TEXT NOT NULL DEFAULT '',   -- velinin telefonu
+    status TEXT NOT NULL DEFAULT 'open',       -- open, resolved
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    CONSTRAINT fk_lesson FOREIGN KEY(lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    CONSTRAINT fk_threshold FOREIGN KEY(threshold_id) REFERENCES attendance_thresholds(id) ON DELETE SET NULL
+);
+
+
+CREATE TABLE check_in_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    schedule_id UUID NOT NULL,                 -- Schedule tablosu ile bağlantı
+    opened_by UUID NOT NULL,                   -- oturumu açan öğretmen
+    secret TEXT NOT NULL,                      -- dönen kodların anahtarı
+    code_seconds INT NOT NULL DEFAULT 30,      -- kodun geçerlilik süresi
+    late_after_minutes INT NOT NULL DEFAULT 5, -- ders başından sonra geç sayılma
+    opened_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    closes_at TIMESTAMP NOT NULL,
+    closed_at TIMESTAMP,
+    CONSTRAINT fk_schedule FOREIGN KEY(schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+
+CREATE TABLE check_ins (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL,
+    student_id UUID NOT NULL,                  -- Keycloak user id
+    code TEXT NOT NULL,                        -- kullanılan kod
+    status TEXT NOT NULL,                      -- present, late
+    minutes_late INT NOT NULL DEFAULT 0,
+    checked_in_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_session FOREIGN KEY(session_id) REFERENCES check_in_sessions(id) ON DELETE CASCADE,
+    CONSTRAINT uq_check_in_student UNIQUE (session_id, student_id)
+);
+
+-- lessons whose roll call was checked after they ended; the students in
+-- missing_student_ids had no attendance and were marked absent, and the
+-- teacher is reminded until the check is resolved
+CREATE TABLE roll_call_checks (
+    schedule_id UUID PRIMARY KEY,
+    teacher_id UUID NOT NULL,
+    missing_student_ids UUID[] NOT NULL DEFAULT '{}',
+    checked_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    CONSTRAINT fk_schedule FOREIGN KEY(schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_roll_call_checks_teacher ON roll_call_checks(teacher_id);
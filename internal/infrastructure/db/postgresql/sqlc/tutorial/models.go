@@ -9,11 +9,148 @@ import (
 )
 
 type Attendance struct {
+	ID          pgtype.UUID
+	StudentID   pgtype.UUID
+	ScheduleID  pgtype.UUID
+	Counter     int32
+	Status      string
+	MinutesLate int32
+	Note        string
+}
+
+type AttendanceAlert struct {
+	ID            pgtype.UUID
+	StudentID     pgtype.UUID
+	LessonID      pgtype.UUID
+	ThresholdID   pgtype.UUID
+	Rate          float64
+	MinRate       float64
+	RecipientIds  []pgtype.UUID
+	GuardianPhone string
+	Status        string
+	CreatedAt     pgtype.Timestamp
+	ResolvedAt    pgtype.Timestamp
+}
+
+type AttendanceAudit struct {
+	ID           pgtype.UUID
+	AttendanceID pgtype.UUID
+	ExcuseID     pgtype.UUID
+	ChangedBy    pgtype.UUID
+	OldStatus    string
+	NewStatus    string
+	ChangedAt    pgtype.Timestamp
+}
+
+type AttendancePolicy struct {
+	ID            int32
+	PresentWeight float64
+	LateWeight    float64
+	RemoteWeight  float64
+	ExcusedWeight float64
+	CountExcused  bool
+	UpdatedAt     pgtype.Timestamp
+}
+
+type AttendanceThreshold struct {
 	ID         pgtype.UUID
-	StudentID  pgtype.UUID
-	ScheduleID pgtype.UUID
-	Here       bool
-	Counter    int32
+	LessonID   pgtype.UUID
+	MinRate    float64
+	MinLessons int32
+	CreatedAt  pgtype.Timestamp
+	UpdatedAt  pgtype.Timestamp
+}
+
+type BellPeriod struct {
+	ID             pgtype.UUID
+	BellScheduleID pgtype.UUID
+	Name           string
+	Position       int32
+	Weekdays       []int32
+	StartTime      pgtype.Time
+	EndTime        pgtype.Time
+}
+
+type BellSchedule struct {
+	ID        pgtype.UUID
+	Name      string
+	Active    bool
+	CreatedAt pgtype.Timestamp
+}
+
+type CalendarFeed struct {
+	ID        pgtype.UUID
+	Token     string
+	OwnerType string
+	OwnerID   pgtype.UUID
+	CreatedBy pgtype.UUID
+	CreatedAt pgtype.Timestamp
+}
+
+type CheckIn struct {
+	ID          pgtype.UUID
+	SessionID   pgtype.UUID
+	StudentID   pgtype.UUID
+	Code        string
+	Status      string
+	MinutesLate int32
+	CheckedInAt pgtype.Timestamp
+}
+
+type CheckInSession struct {
+	ID               pgtype.UUID
+	ScheduleID       pgtype.UUID
+	OpenedBy         pgtype.UUID
+	Secret           string
+	CodeSeconds      int32
+	LateAfterMinutes int32
+	OpenedAt         pgtype.Timestamp
+	ClosesAt         pgtype.Timestamp
+	ClosedAt         pgtype.Timestamp
+}
+
+type Closure struct {
+	ID        pgtype.UUID
+	Name      string
+	Kind      string
+	StartDate pgtype.Date
+	EndDate   pgtype.Date
+}
+
+type Exam struct {
+	ID             pgtype.UUID
+	LessonID       pgtype.UUID
+	ClassID        pgtype.UUID
+	Title          string
+	Date           pgtype.Date
+	StartTime      pgtype.Time
+	EndTime        pgtype.Time
+	RoomID         pgtype.UUID
+	InvigilatorIds []pgtype.UUID
+	CreatedAt      pgtype.Timestamp
+}
+
+type ExamRule struct {
+	ID             int32
+	MaxExamsPerDay int32
+	MinGapMinutes  int32
+	UpdatedAt      pgtype.Timestamp
+}
+
+type Excuse struct {
+	ID          pgtype.UUID
+	StudentID   pgtype.UUID
+	SubmittedBy pgtype.UUID
+	Reason      string
+	ScheduleIds []pgtype.UUID
+	FromDate    pgtype.Date
+	ToDate      pgtype.Date
+	DocumentUrl string
+	Status      string
+	ReviewedBy  pgtype.UUID
+	ReviewNote  string
+	ReviewedAt  pgtype.Timestamp
+	CreatedAt   pgtype.Timestamp
 }
 
 type Homework struct {
@@ -31,11 +168,87 @@ type Lesson struct {
 	LessonName string
 }
 
+type RollCallCheck struct {
+	ScheduleID        pgtype.UUID
+	TeacherID         pgtype.UUID
+	MissingStudentIds []pgtype.UUID
+	CheckedAt         pgtype.Timestamp
+	ResolvedAt        pgtype.Timestamp
+}
+
+type Room struct {
+	ID       pgtype.UUID
+	Name     string
+	Capacity int32
+	Features []string
+}
+
 type Schedule struct {
+	ID                  pgtype.UUID
+	Date                pgtype.Date
+	Time                pgtype.Time
+	EndTime             pgtype.Time
+	TeacherID           pgtype.UUID
+	LessonID            pgtype.UUID
+	ClassID             pgtype.UUID
+	SeriesID            pgtype.UUID
+	OccurrenceDate      pgtype.Date
+	RoomID              pgtype.UUID
+	SubstituteTeacherID pgtype.UUID
+	Status              string
+	CancellationReason  string
+	CancelledBy         pgtype.UUID
+	CancelledAt         pgtype.Timestamp
+	PeriodID            pgtype.UUID
+}
+
+type ScheduleSeries struct {
+	ID              pgtype.UUID
+	TeacherID       pgtype.UUID
+	LessonID        pgtype.UUID
+	ClassID         pgtype.UUID
+	StartDate       pgtype.Date
+	Time            pgtype.Time
+	EndTime         pgtype.Time
+	Weekdays        []int32
+	UntilDate       pgtype.Date
+	OccurrenceCount pgtype.Int4
+	ExceptionDates  []pgtype.Date
+	RoomID          pgtype.UUID
+}
+
+type TeacherAbsence struct {
 	ID        pgtype.UUID
-	Date      pgtype.Date
-	Time      pgtype.Time
 	TeacherID pgtype.UUID
-	LessonID  pgtype.UUID
-	ClassID   pgtype.UUID
+	StartDate pgtype.Date
+	EndDate   pgtype.Date
+	Reason    string
+	CreatedAt pgtype.Timestamp
+}
+
+type TeacherAvailability struct {
+	ID        pgtype.UUID
+	TeacherID pgtype.UUID
+	Kind      string
+	Weekday   pgtype.Int4
+	Date      pgtype.Date
+	StartTime pgtype.Time
+	EndTime   pgtype.Time
+	Note      string
+	CreatedAt pgtype.Timestamp
+}
+
+type Term struct {
+	ID        pgtype.UUID
+	Name      string
+	StartDate pgtype.Date
+	EndDate   pgtype.Date
+}
+
+type WorkloadLimit struct {
+	ID                    pgtype.UUID
+	TeacherID             pgtype.UUID
+	MaxWeeklyMinutes      int32
+	MaxConsecutiveLessons int32
+	UpdatedAt             pgtype.Timestamp
 }
@@ -11,33 +11,462 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const closeCheckInSession = `-- name: CloseCheckInSession :one
+UPDATE check_in_sessions
+SET closed_at = $2
+WHERE id = $1 AND closed_at IS NULL
+RETURNING id, schedule_id, opened_by, secret, code_seconds, late_after_minutes, opened_at, closes_at, closed_at
+`
+
+type CloseCheckInSessionParams struct {
+	ID       pgtype.UUID
+	ClosedAt pgtype.Timestamp
+}
+
+func (q *Queries) CloseCheckInSession(ctx context.Context, arg CloseCheckInSessionParams) (CheckInSession, error) {
+	row := q.db.QueryRow(ctx, closeCheckInSession, arg.ID, arg.ClosedAt)
+	var i CheckInSession
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduleID,
+		&i.OpenedBy,
+		&i.Secret,
+		&i.CodeSeconds,
+		&i.LateAfterMinutes,
+		&i.OpenedAt,
+		&i.ClosesAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
 const createAttendance = `-- name: CreateAttendance :one
-INSERT INTO attendances (student_id, schedule_id, here, counter)
-VALUES ($1, $2, $3, $4)
-RETURNING id, student_id, schedule_id, here, counter
+INSERT INTO attendances (student_id, schedule_id, counter, status, minutes_late, note)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, student_id, schedule_id, counter, status, minutes_late, note
 `
 
 type CreateAttendanceParams struct {
-	StudentID  pgtype.UUID
-	ScheduleID pgtype.UUID
-	Here       bool
-	Counter    int32
+	StudentID   pgtype.UUID
+	ScheduleID  pgtype.UUID
+	Counter     int32
+	Status      string
+	MinutesLate int32
+	Note        string
 }
 
 func (q *Queries) CreateAttendance(ctx context.Context, arg CreateAttendanceParams) (Attendance, error) {
 	row := q.db.QueryRow(ctx, createAttendance,
 		arg.StudentID,
 		arg.ScheduleID,
-		arg.Here,
 		arg.Counter,
+		arg.Status,
+		arg.MinutesLate,
+		arg.Note,
 	)
 	var i Attendance
 	err := row.Scan(
 		&i.ID,
 		&i.StudentID,
 		&i.ScheduleID,
-		&i.Here,
 		&i.Counter,
+		&i.Status,
+		&i.MinutesLate,
+		&i.Note,
+	)
+	return i, err
+}
+
+const createAttendanceAlert = `-- name: CreateAttendanceAlert :one
+INSERT INTO attendance_alerts (student_id, lesson_id, threshold_id, rate, min_rate, recipient_ids, guardian_phone)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, student_id, lesson_id, threshold_id, rate, min_rate, recipient_ids, guardian_phone, status, created_at, resolved_at
+`
+
+type CreateAttendanceAlertParams struct {
+	StudentID     pgtype.UUID
+	LessonID      pgtype.UUID
+	ThresholdID   pgtype.UUID
+	Rate          float64
+	MinRate       float64
+	RecipientIds  []pgtype.UUID
+	GuardianPhone string
+}
+
+func (q *Queries) CreateAttendanceAlert(ctx context.Context, arg CreateAttendanceAlertParams) (AttendanceAlert, error) {
+	row := q.db.QueryRow(ctx, createAttendanceAlert,
+		arg.StudentID,
+		arg.LessonID,
+		arg.ThresholdID,
+		arg.Rate,
+		arg.MinRate,
+		arg.RecipientIds,
+		arg.GuardianPhone,
+	)
+	var i AttendanceAlert
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.LessonID,
+		&i.ThresholdID,
+		&i.Rate,
+		&i.MinRate,
+		&i.RecipientIds,
+		&i.GuardianPhone,
+		&i.Status,
+		&i.CreatedAt,
+		&i.ResolvedAt,
+	)
+	return i, err
+}
+
+const createAttendanceAudit = `-- name: CreateAttendanceAudit :one
+INSERT INTO attendance_audits (attendance_id, excuse_id, changed_by, old_status, new_status)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, attendance_id, excuse_id, changed_by, old_status, new_status, changed_at
+`
+
+type CreateAttendanceAuditParams struct {
+	AttendanceID pgtype.UUID
+	ExcuseID     pgtype.UUID
+	ChangedBy    pgtype.UUID
+	OldStatus    string
+	NewStatus    string
+}
+
+func (q *Queries) CreateAttendanceAudit(ctx context.Context, arg CreateAttendanceAuditParams) (AttendanceAudit, error) {
+	row := q.db.QueryRow(ctx, createAttendanceAudit,
+		arg.AttendanceID,
+		arg.ExcuseID,
+		arg.ChangedBy,
+		arg.OldStatus,
+		arg.NewStatus,
+	)
+	var i AttendanceAudit
+	err := row.Scan(
+		&i.ID,
+		&i.AttendanceID,
+		&i.ExcuseID,
+		&i.ChangedBy,
+		&i.OldStatus,
+		&i.NewStatus,
+		&i.ChangedAt,
+	)
+	return i, err
+}
+
+const createAttendanceThreshold = `-- name: CreateAttendanceThreshold :one
+INSERT INTO attendance_thresholds (lesson_id, min_rate, min_lessons)
+VALUES ($1, $2, $3)
+RETURNING id, lesson_id, min_rate, min_lessons, created_at, updated_at
+`
+
+type CreateAttendanceThresholdParams struct {
+	LessonID   pgtype.UUID
+	MinRate    float64
+	MinLessons int32
+}
+
+func (q *Queries) CreateAttendanceThreshold(ctx context.Context, arg CreateAttendanceThresholdParams) (AttendanceThreshold, error) {
+	row := q.db.QueryRow(ctx, createAttendanceThreshold, arg.LessonID, arg.MinRate, arg.MinLessons)
+	var i AttendanceThreshold
+	err := row.Scan(
+		&i.ID,
+		&i.LessonID,
+		&i.MinRate,
+		&i.MinLessons,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createBellPeriod = `-- name: CreateBellPeriod :one
+INSERT INTO bell_periods (bell_schedule_id, name, position, weekdays, start_time, end_time)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, bell_schedule_id, name, position, weekdays, start_time, end_time
+`
+
+type CreateBellPeriodParams struct {
+	BellScheduleID pgtype.UUID
+	Name           string
+	Position       int32
+	Weekdays       []int32
+	StartTime      pgtype.Time
+	EndTime        pgtype.Time
+}
+
+func (q *Queries) CreateBellPeriod(ctx context.Context, arg CreateBellPeriodParams) (BellPeriod, error) {
+	row := q.db.QueryRow(ctx, createBellPeriod,
+		arg.BellScheduleID,
+		arg.Name,
+		arg.Position,
+		arg.Weekdays,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	var i BellPeriod
+	err := row.Scan(
+		&i.ID,
+		&i.BellScheduleID,
+		&i.Name,
+		&i.Position,
+		&i.Weekdays,
+		&i.StartTime,
+		&i.EndTime,
+	)
+	return i, err
+}
+
+const createBellSchedule = `-- name: CreateBellSchedule :one
+INSERT INTO bell_schedules (name, active)
+VALUES ($1, $2)
+RETURNING id, name, active, created_at
+`
+
+type CreateBellScheduleParams struct {
+	Name   string
+	Active bool
+}
+
+func (q *Queries) CreateBellSchedule(ctx context.Context, arg CreateBellScheduleParams) (BellSchedule, error) {
+	row := q.db.QueryRow(ctx, createBellSchedule, arg.Name, arg.Active)
+	var i BellSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createCalendarFeed = `-- name: CreateCalendarFeed :one
+INSERT INTO calendar_feeds (token, owner_type, owner_id, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING id, token, owner_type, owner_id, created_by, created_at
+`
+
+type CreateCalendarFeedParams struct {
+	Token     string
+	OwnerType string
+	OwnerID   pgtype.UUID
+	CreatedBy pgtype.UUID
+}
+
+func (q *Queries) CreateCalendarFeed(ctx context.Context, arg CreateCalendarFeedParams) (CalendarFeed, error) {
+	row := q.db.QueryRow(ctx, createCalendarFeed,
+		arg.Token,
+		arg.OwnerType,
+		arg.OwnerID,
+		arg.CreatedBy,
+	)
+	var i CalendarFeed
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.OwnerType,
+		&i.OwnerID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createCheckIn = `-- name: CreateCheckIn :one
+INSERT INTO check_ins (session_id, student_id, code, status, minutes_late, checked_in_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, session_id, student_id, code, status, minutes_late, checked_in_at
+`
+
+type CreateCheckInParams struct {
+	SessionID   pgtype.UUID
+	StudentID   pgtype.UUID
+	Code        string
+	Status      string
+	MinutesLate int32
+	CheckedInAt pgtype.Timestamp
+}
+
+func (q *Queries) CreateCheckIn(ctx context.Context, arg CreateCheckInParams) (CheckIn, error) {
+	row := q.db.QueryRow(ctx, createCheckIn,
+		arg.SessionID,
+		arg.StudentID,
+		arg.Code,
+		arg.Status,
+		arg.MinutesLate,
+		arg.CheckedInAt,
+	)
+	var i CheckIn
+	err := row.Scan(
+		&i.ID,
+		&i.SessionID,
+		&i.StudentID,
+		&i.Code,
+		&i.Status,
+		&i.MinutesLate,
+		&i.CheckedInAt,
+	)
+	return i, err
+}
+
+const createCheckInSession = `-- name: CreateCheckInSession :one
+INSERT INTO check_in_sessions (schedule_id, opened_by, secret, code_seconds, late_after_minutes, opened_at, closes_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, schedule_id, opened_by, secret, code_seconds, late_after_minutes, opened_at, closes_at, closed_at
+`
+
+type CreateCheckInSessionParams struct {
+	ScheduleID       pgtype.UUID
+	OpenedBy         pgtype.UUID
+	Secret           string
+	CodeSeconds      int32
+	LateAfterMinutes int32
+	OpenedAt         pgtype.Timestamp
+	ClosesAt         pgtype.Timestamp
+}
+
+func (q *Queries) CreateCheckInSession(ctx context.Context, arg CreateCheckInSessionParams) (CheckInSession, error) {
+	row := q.db.QueryRow(ctx, createCheckInSession,
+		arg.ScheduleID,
+		arg.OpenedBy,
+		arg.Secret,
+		arg.CodeSeconds,
+		arg.LateAfterMinutes,
+		arg.OpenedAt,
+		arg.ClosesAt,
+	)
+	var i CheckInSession
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduleID,
+		&i.OpenedBy,
+		&i.Secret,
+		&i.CodeSeconds,
+		&i.LateAfterMinutes,
+		&i.OpenedAt,
+		&i.ClosesAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const createClosure = `-- name: CreateClosure :one
+INSERT INTO closures (name, kind, start_date, end_date)
+VALUES ($1, $2, $3, $4)
+RETURNING id, name, kind, start_date, end_date
+`
+
+type CreateClosureParams struct {
+	Name      string
+	Kind      string
+	StartDate pgtype.Date
+	EndDate   pgtype.Date
+}
+
+func (q *Queries) CreateClosure(ctx context.Context, arg CreateClosureParams) (Closure, error) {
+	row := q.db.QueryRow(ctx, createClosure,
+		arg.Name,
+		arg.Kind,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	var i Closure
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.StartDate,
+		&i.EndDate,
+	)
+	return i, err
+}
+
+const createExam = `-- name: CreateExam :one
+INSERT INTO exams (lesson_id, class_id, title, date, start_time, end_time, room_id, invigilator_ids)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING id, lesson_id, class_id, title, date, start_time, end_time, room_id, invigilator_ids, created_at
+`
+
+type CreateExamParams struct {
+	LessonID       pgtype.UUID
+	ClassID        pgtype.UUID
+	Title          string
+	Date           pgtype.Date
+	StartTime      pgtype.Time
+	EndTime        pgtype.Time
+	RoomID         pgtype.UUID
+	InvigilatorIds []pgtype.UUID
+}
+
+func (q *Queries) CreateExam(ctx context.Context, arg CreateExamParams) (Exam, error) {
+	row := q.db.QueryRow(ctx, createExam,
+		arg.LessonID,
+		arg.ClassID,
+		arg.Title,
+		arg.Date,
+		arg.StartTime,
+		arg.EndTime,
+		arg.RoomID,
+		arg.InvigilatorIds,
+	)
+	var i Exam
+	err := row.Scan(
+		&i.ID,
+		&i.LessonID,
+		&i.ClassID,
+		&i.Title,
+		&i.Date,
+		&i.StartTime,
+		&i.EndTime,
+		&i.RoomID,
+		&i.InvigilatorIds,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createExcuse = `-- name: CreateExcuse :one
+INSERT INTO excuses (student_id, submitted_by, reason, schedule_ids, from_date, to_date, document_url)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, student_id, submitted_by, reason, schedule_ids, from_date, to_date, document_url, status, reviewed_by, review_note, reviewed_at, created_at
+`
+
+type CreateExcuseParams struct {
+	StudentID   pgtype.UUID
+	SubmittedBy pgtype.UUID
+	Reason      string
+	ScheduleIds []pgtype.UUID
+	FromDate    pgtype.Date
+	ToDate      pgtype.Date
+	DocumentUrl string
+}
+
+func (q *Queries) CreateExcuse(ctx context.Context, arg CreateExcuseParams) (Excuse, error) {
+	row := q.db.QueryRow(ctx, createExcuse,
+		arg.StudentID,
+		arg.SubmittedBy,
+		arg.Reason,
+		arg.ScheduleIds,
+		arg.FromDate,
+		arg.ToDate,
+		arg.DocumentUrl,
+	)
+	var i Excuse
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.SubmittedBy,
+		&i.Reason,
+		&i.ScheduleIds,
+		&i.FromDate,
+		&i.ToDate,
+		&i.DocumentUrl,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -92,97 +521,2029 @@ func (q *Queries) CreateLesson(ctx context.Context, lessonName string) (Lesson,
 	return i, err
 }
 
+const createMissingAttendance = `-- name: CreateMissingAttendance :one
+INSERT INTO attendances (student_id, schedule_id, counter, status, minutes_late, note)
+VALUES ($1, $2, 0, $3, 0, $4)
+ON CONFLICT (student_id, schedule_id) DO NOTHING
+RETURNING id, student_id, schedule_id, counter, status, minutes_late, note
+`
+
+type CreateMissingAttendanceParams struct {
+	StudentID  pgtype.UUID
+	ScheduleID pgtype.UUID
+	Status     string
+	Note       string
+}
+
+// Leaves attendance taken in the meantime as it is and returns no row
+func (q *Queries) CreateMissingAttendance(ctx context.Context, arg CreateMissingAttendanceParams) (Attendance, error) {
+	row := q.db.QueryRow(ctx, createMissingAttendance,
+		arg.StudentID,
+		arg.ScheduleID,
+		arg.Status,
+		arg.Note,
+	)
+	var i Attendance
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.ScheduleID,
+		&i.Counter,
+		&i.Status,
+		&i.MinutesLate,
+		&i.Note,
+	)
+	return i, err
+}
+
+const createRollCallCheck = `-- name: CreateRollCallCheck :one
+INSERT INTO roll_call_checks (schedule_id, teacher_id, missing_student_ids, checked_at)
+VALUES ($1, $2, $3, $4)
+RETURNING schedule_id, teacher_id, missing_student_ids, checked_at, resolved_at
+`
+
+type CreateRollCallCheckParams struct {
+	ScheduleID        pgtype.UUID
+	TeacherID         pgtype.UUID
+	MissingStudentIds []pgtype.UUID
+	CheckedAt         pgtype.Timestamp
+}
+
+func (q *Queries) CreateRollCallCheck(ctx context.Context, arg CreateRollCallCheckParams) (RollCallCheck, error) {
+	row := q.db.QueryRow(ctx, createRollCallCheck,
+		arg.ScheduleID,
+		arg.TeacherID,
+		arg.MissingStudentIds,
+		arg.CheckedAt,
+	)
+	var i RollCallCheck
+	err := row.Scan(
+		&i.ScheduleID,
+		&i.TeacherID,
+		&i.MissingStudentIds,
+		&i.CheckedAt,
+		&i.ResolvedAt,
+	)
+	return i, err
+}
+
+const createRoom = `-- name: CreateRoom :one
+INSERT INTO rooms (name, capacity, features)
+VALUES ($1, $2, $3)
+RETURNING id, name, capacity, features
+`
+
+type CreateRoomParams struct {
+	Name     string
+	Capacity int32
+	Features []string
+}
+
+func (q *Queries) CreateRoom(ctx context.Context, arg CreateRoomParams) (Room, error) {
+	row := q.db.QueryRow(ctx, createRoom, arg.Name, arg.Capacity, arg.Features)
+	var i Room
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Capacity,
+		&i.Features,
+	)
+	return i, err
+}
+
 const createSchedule = `-- name: CreateSchedule :one
-INSERT INTO schedules (date, time, teacher_id, lesson_id, class_id)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, date, time, teacher_id, lesson_id, class_id
+INSERT INTO schedules (date, time, end_time, teacher_id, lesson_id, class_id, series_id, occurrence_date, room_id, period_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id, date, time, end_time, teacher_id, lesson_id, class_id, series_id, occurrence_date, room_id, substitute_teacher_id, status, cancellation_reason, cancelled_by, cancelled_at, period_id
 `
 
 type CreateScheduleParams struct {
-	Date      pgtype.Date
-	Time      pgtype.Time
-	TeacherID pgtype.UUID
-	LessonID  pgtype.UUID
-	ClassID   pgtype.UUID
+	Date           pgtype.Date
+	Time           pgtype.Time
+	EndTime        pgtype.Time
+	TeacherID      pgtype.UUID
+	LessonID       pgtype.UUID
+	ClassID        pgtype.UUID
+	SeriesID       pgtype.UUID
+	OccurrenceDate pgtype.Date
+	RoomID         pgtype.UUID
+	PeriodID       pgtype.UUID
 }
 
 func (q *Queries) CreateSchedule(ctx context.Context, arg CreateScheduleParams) (Schedule, error) {
 	row := q.db.QueryRow(ctx, createSchedule,
 		arg.Date,
 		arg.Time,
+		arg.EndTime,
 		arg.TeacherID,
 		arg.LessonID,
 		arg.ClassID,
+		arg.SeriesID,
+		arg.OccurrenceDate,
+		arg.RoomID,
+		arg.PeriodID,
 	)
 	var i Schedule
 	err := row.Scan(
-		&i.ID,
-		&i.Date,
-		&i.Time,
+		&i.ID,
+		&i.Date,
+		&i.Time,
+		&i.EndTime,
+		&i.TeacherID,
+		&i.LessonID,
+		&i.ClassID,
+		&i.SeriesID,
+		&i.OccurrenceDate,
+		&i.RoomID,
+		&i.SubstituteTeacherID,
+		&i.Status,
+		&i.CancellationReason,
+		&i.CancelledBy,
+		&i.CancelledAt,
+		&i.PeriodID,
+	)
+	return i, err
+}
+
+const createScheduleSeries = `-- name: CreateScheduleSeries :one
+INSERT INTO schedule_series (teacher_id, lesson_id, class_id, start_date, time, end_time, weekdays, until_date, occurrence_count, exception_dates, room_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id, teacher_id, lesson_id, class_id, start_date, time, end_time, weekdays, until_date, occurrence_count, exception_dates, room_id
+`
+
+type CreateScheduleSeriesParams struct {
+	TeacherID       pgtype.UUID
+	LessonID        pgtype.UUID
+	ClassID         pgtype.UUID
+	StartDate       pgtype.Date
+	Time            pgtype.Time
+	EndTime         pgtype.Time
+	Weekdays        []int32
+	UntilDate       pgtype.Date
+	OccurrenceCount pgtype.Int4
+	ExceptionDates  []pgtype.Date
+	RoomID          pgtype.UUID
+}
+
+func (q *Queries) CreateScheduleSeries(ctx context.Context, arg CreateScheduleSeriesParams) (ScheduleSeries, error) {
+	row := q.db.QueryRow(ctx, createScheduleSeries,
+		arg.TeacherID,
+		arg.LessonID,
+		arg.ClassID,
+		arg.StartDate,
+		arg.Time,
+		arg.EndTime,
+		arg.Weekdays,
+		arg.UntilDate,
+		arg.OccurrenceCount,
+		arg.ExceptionDates,
+		arg.RoomID,
+	)
+	var i ScheduleSeries
+	err := row.Scan(
+		&i.ID,
+		&i.TeacherID,
+		&i.LessonID,
+		&i.ClassID,
+		&i.StartDate,
+		&i.Time,
+		&i.EndTime,
+		&i.Weekdays,
+		&i.UntilDate,
+		&i.OccurrenceCount,
+		&i.ExceptionDates,
+		&i.RoomID,
+	)
+	return i, err
+}
+
+const createTeacherAbsence = `-- name: CreateTeacherAbsence :one
+INSERT INTO teacher_absences (teacher_id, start_date, end_date, reason)
+VALUES ($1, $2, $3, $4)
+RETURNING id, teacher_id, start_date, end_date, reason, created_at
+`
+
+type CreateTeacherAbsenceParams struct {
+	TeacherID pgtype.UUID
+	StartDate pgtype.Date
+	EndDate   pgtype.Date
+	Reason    string
+}
+
+func (q *Queries) CreateTeacherAbsence(ctx context.Context, arg CreateTeacherAbsenceParams) (TeacherAbsence, error) {
+	row := q.db.QueryRow(ctx, createTeacherAbsence,
+		arg.TeacherID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Reason,
+	)
+	var i TeacherAbsence
+	err := row.Scan(
+		&i.ID,
+		&i.TeacherID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createTeacherAvailability = `-- name: CreateTeacherAvailability :one
+INSERT INTO teacher_availability (teacher_id, kind, weekday, date, start_time, end_time, note)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, teacher_id, kind, weekday, date, start_time, end_time, note, created_at
+`
+
+type CreateTeacherAvailabilityParams struct {
+	TeacherID pgtype.UUID
+	Kind      string
+	Weekday   pgtype.Int4
+	Date      pgtype.Date
+	StartTime pgtype.Time
+	EndTime   pgtype.Time
+	Note      string
+}
+
+func (q *Queries) CreateTeacherAvailability(ctx context.Context, arg CreateTeacherAvailabilityParams) (TeacherAvailability, error) {
+	row := q.db.QueryRow(ctx, createTeacherAvailability,
+		arg.TeacherID,
+		arg.Kind,
+		arg.Weekday,
+		arg.Date,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Note,
+	)
+	var i TeacherAvailability
+	err := row.Scan(
+		&i.ID,
+		&i.TeacherID,
+		&i.Kind,
+		&i.Weekday,
+		&i.Date,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Note,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createTerm = `-- name: CreateTerm :one
+INSERT INTO terms (name, start_date, end_date)
+VALUES ($1, $2, $3)
+RETURNING id, name, start_date, end_date
+`
+
+type CreateTermParams struct {
+	Name      string
+	StartDate pgtype.Date
+	EndDate   pgtype.Date
+}
+
+func (q *Queries) CreateTerm(ctx context.Context, arg CreateTermParams) (Term, error) {
+	row := q.db.QueryRow(ctx, createTerm, arg.Name, arg.StartDate, arg.EndDate)
+	var i Term
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StartDate,
+		&i.EndDate,
+	)
+	return i, err
+}
+
+const createWorkloadLimit = `-- name: CreateWorkloadLimit :one
+INSERT INTO workload_limits (teacher_id, max_weekly_minutes, max_consecutive_lessons)
+VALUES ($1, $2, $3)
+RETURNING id, teacher_id, max_weekly_minutes, max_consecutive_lessons, updated_at
+`
+
+type CreateWorkloadLimitParams struct {
+	TeacherID             pgtype.UUID
+	MaxWeeklyMinutes      int32
+	MaxConsecutiveLessons int32
+}
+
+func (q *Queries) CreateWorkloadLimit(ctx context.Context, arg CreateWorkloadLimitParams) (WorkloadLimit, error) {
+	row := q.db.QueryRow(ctx, createWorkloadLimit, arg.TeacherID, arg.MaxWeeklyMinutes, arg.MaxConsecutiveLessons)
+	var i WorkloadLimit
+	err := row.Scan(
+		&i.ID,
+		&i.TeacherID,
+		&i.MaxWeeklyMinutes,
+		&i.MaxConsecutiveLessons,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const deactivateBellSchedules = `-- name: DeactivateBellSchedules :exec
+UPDATE bell_schedules SET active = FALSE WHERE active AND id <> $1
+`
+
+func (q *Queries) DeactivateBellSchedules(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deactivateBellSchedules, id)
+	return err
+}
+
+const deleteAttendance = `-- name: DeleteAttendance :exec
+DELETE FROM attendances WHERE id = $1
+`
+
+func (q *Queries) DeleteAttendance(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteAttendance, id)
+	return err
+}
+
+const deleteAttendanceThreshold = `-- name: DeleteAttendanceThreshold :exec
+DELETE FROM attendance_thresholds WHERE id = $1
+`
+
+func (q *Queries) DeleteAttendanceThreshold(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteAttendanceThreshold, id)
+	return err
+}
+
+const deleteBellPeriod = `-- name: DeleteBellPeriod :exec
+DELETE FROM bell_periods WHERE id = $1
+`
+
+func (q *Queries) DeleteBellPeriod(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteBellPeriod, id)
+	return err
+}
+
+const deleteBellSchedule = `-- name: DeleteBellSchedule :exec
+DELETE FROM bell_schedules WHERE id = $1
+`
+
+func (q *Queries) DeleteBellSchedule(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteBellSchedule, id)
+	return err
+}
+
+const deleteCalendarFeed = `-- name: DeleteCalendarFeed :exec
+DELETE FROM calendar_feeds WHERE id = $1
+`
+
+func (q *Queries) DeleteCalendarFeed(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCalendarFeed, id)
+	return err
+}
+
+const deleteCheckIn = `-- name: DeleteCheckIn :exec
+DELETE FROM check_ins WHERE id = $1
+`
+
+func (q *Queries) DeleteCheckIn(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCheckIn, id)
+	return err
+}
+
+const deleteClosure = `-- name: DeleteClosure :exec
+DELETE FROM closures WHERE id = $1
+`
+
+func (q *Queries) DeleteClosure(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteClosure, id)
+	return err
+}
+
+const deleteExam = `-- name: DeleteExam :exec
+DELETE FROM exams WHERE id = $1
+`
+
+func (q *Queries) DeleteExam(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteExam, id)
+	return err
+}
+
+const deleteHomework = `-- name: DeleteHomework :exec
+DELETE FROM homeworks WHERE id = $1
+`
+
+func (q *Queries) DeleteHomework(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteHomework, id)
+	return err
+}
+
+const deleteLesson = `-- name: DeleteLesson :exec
+DELETE FROM lessons WHERE id = $1
+`
+
+func (q *Queries) DeleteLesson(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteLesson, id)
+	return err
+}
+
+const deleteRoom = `-- name: DeleteRoom :exec
+DELETE FROM rooms WHERE id = $1
+`
+
+func (q *Queries) DeleteRoom(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteRoom, id)
+	return err
+}
+
+const deleteSchedule = `-- name: DeleteSchedule :exec
+DELETE FROM schedules WHERE id = $1
+`
+
+func (q *Queries) DeleteSchedule(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteSchedule, id)
+	return err
+}
+
+const deleteScheduleSeries = `-- name: DeleteScheduleSeries :exec
+DELETE FROM schedule_series WHERE id = $1
+`
+
+func (q *Queries) DeleteScheduleSeries(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteScheduleSeries, id)
+	return err
+}
+
+const deleteTeacherAbsence = `-- name: DeleteTeacherAbsence :exec
+DELETE FROM teacher_absences WHERE id = $1
+`
+
+func (q *Queries) DeleteTeacherAbsence(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteTeacherAbsence, id)
+	return err
+}
+
+const deleteTeacherAvailability = `-- name: DeleteTeacherAvailability :exec
+DELETE FROM teacher_availability WHERE id = $1
+`
+
+func (q *Queries) DeleteTeacherAvailability(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteTeacherAvailability, id)
+	return err
+}
+
+const deleteTerm = `-- name: DeleteTerm :exec
+DELETE FROM terms WHERE id = $1
+`
+
+func (q *Queries) DeleteTerm(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteTerm, id)
+	return err
+}
+
+const deleteWorkloadLimit = `-- name: DeleteWorkloadLimit :exec
+DELETE FROM workload_limits WHERE id = $1
+`
+
+func (q *Queries) DeleteWorkloadLimit(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteWorkloadLimit, id)
+	return err
+}
+
+const getAllBellSchedules = `-- name: GetAllBellSchedules :many
+SELECT id, name, active, created_at FROM bell_schedules ORDER BY created_at
+`
+
+func (q *Queries) GetAllBellSchedules(ctx context.Context) ([]BellSchedule, error) {
+	rows, err := q.db.Query(ctx, getAllBellSchedules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BellSchedule
+	for rows.Next() {
+		var i BellSchedule
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Active,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllClosures = `-- name: GetAllClosures :many
+SELECT id, name, kind, start_date, end_date FROM closures ORDER BY start_date
+`
+
+func (q *Queries) GetAllClosures(ctx context.Context) ([]Closure, error) {
+	rows, err := q.db.Query(ctx, getAllClosures)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Closure
+	for rows.Next() {
+		var i Closure
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Kind,
+			&i.StartDate,
+			&i.EndDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllHomeworks = `-- name: GetAllHomeworks :many
+SELECT id, teacher_id, lesson_id, class_id, title, content, due_date FROM homeworks
+`
+
+func (q *Queries) GetAllHomeworks(ctx context.Context) ([]Homework, error) {
+	rows, err := q.db.Query(ctx, getAllHomeworks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Homework
+	for rows.Next() {
+		var i Homework
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeacherID,
+			&i.LessonID,
+			&i.ClassID,
+			&i.Title,
+			&i.Content,
+			&i.DueDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllLessons = `-- name: GetAllLessons :many
+SELECT id, lesson_name FROM lessons
+`
+
+func (q *Queries) GetAllLessons(ctx context.Context) ([]Lesson, error) {
+	rows, err := q.db.Query(ctx, getAllLessons)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Lesson
+	for rows.Next() {
+		var i Lesson
+		if err := rows.Scan(&i.ID, &i.LessonName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllRooms = `-- name: GetAllRooms :many
+SELECT id, name, capacity, features FROM rooms
+`
+
+func (q *Queries) GetAllRooms(ctx context.Context) ([]Room, error) {
+	rows, err := q.db.Query(ctx, getAllRooms)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Room
+	for rows.Next() {
+		var i Room
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Capacity,
+			&i.Features,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllScheduleSeries = `-- name: GetAllScheduleSeries :many
+SELECT id, teacher_id, lesson_id, class_id, start_date, time, end_time, weekdays, until_date, occurrence_count, exception_dates, room_id FROM schedule_series
+`
+
+func (q *Queries) GetAllScheduleSeries(ctx context.Context) ([]ScheduleSeries, error) {
+	rows, err := q.db.Query(ctx, getAllScheduleSeries)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScheduleSeries
+	for rows.Next() {
+		var i ScheduleSeries
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeacherID,
+			&i.LessonID,
+			&i.ClassID,
+			&i.StartDate,
+			&i.Time,
+			&i.EndTime,
+			&i.Weekdays,
+			&i.UntilDate,
+			&i.OccurrenceCount,
+			&i.ExceptionDates,
+			&i.RoomID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllSchedules = `-- name: GetAllSchedules :many
+SELECT id, date, time, end_time, teacher_id, lesson_id, class_id, series_id, occurrence_date, room_id, substitute_teacher_id, status, cancellation_reason, cancelled_by, cancelled_at, period_id FROM schedules
+`
+
+func (q *Queries) GetAllSchedules(ctx context.Context) ([]Schedule, error) {
+	rows, err := q.db.Query(ctx, getAllSchedules)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Schedule
+	for rows.Next() {
+		var i Schedule
+		if err := rows.Scan(
+			&i.ID,
+			&i.Date,
+			&i.Time,
+			&i.EndTime,
+			&i.TeacherID,
+			&i.LessonID,
+			&i.ClassID,
+			&i.SeriesID,
+			&i.OccurrenceDate,
+			&i.RoomID,
+			&i.SubstituteTeacherID,
+			&i.Status,
+			&i.CancellationReason,
+			&i.CancelledBy,
+			&i.CancelledAt,
+			&i.PeriodID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllTerms = `-- name: GetAllTerms :many
+SELECT id, name, start_date, end_date FROM terms ORDER BY start_date
+`
+
+func (q *Queries) GetAllTerms(ctx context.Context) ([]Term, error) {
+	rows, err := q.db.Query(ctx, getAllTerms)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Term
+	for rows.Next() {
+		var i Term
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.StartDate,
+			&i.EndDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllWorkloadLimits = `-- name: GetAllWorkloadLimits :many
+SELECT id, teacher_id, max_weekly_minutes, max_consecutive_lessons, updated_at FROM workload_limits
+ORDER BY teacher_id NULLS FIRST
+`
+
+func (q *Queries) GetAllWorkloadLimits(ctx context.Context) ([]WorkloadLimit, error) {
+	rows, err := q.db.Query(ctx, getAllWorkloadLimits)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []WorkloadLimit
+	for rows.Next() {
+		var i WorkloadLimit
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeacherID,
+			&i.MaxWeeklyMinutes,
+			&i.MaxConsecutiveLessons,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getApprovedExcusesForLesson = `-- name: GetApprovedExcusesForLesson :many
+SELECT id, student_id, submitted_by, reason, schedule_ids, from_date, to_date, document_url, status, reviewed_by, review_note, reviewed_at, created_at FROM excuses
+WHERE status = 'approved'
+  AND ($1::UUID = ANY(schedule_ids)
+       OR $2::DATE BETWEEN from_date AND to_date)
+ORDER BY created_at
+`
+
+type GetApprovedExcusesForLessonParams struct {
+	ScheduleID pgtype.UUID
+	LessonDate pgtype.Date
+}
+
+func (q *Queries) GetApprovedExcusesForLesson(ctx context.Context, arg GetApprovedExcusesForLessonParams) ([]Excuse, error) {
+	rows, err := q.db.Query(ctx, getApprovedExcusesForLesson, arg.ScheduleID, arg.LessonDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Excuse
+	for rows.Next() {
+		var i Excuse
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.SubmittedBy,
+			&i.Reason,
+			&i.ScheduleIds,
+			&i.FromDate,
+			&i.ToDate,
+			&i.DocumentUrl,
+			&i.Status,
+			&i.ReviewedBy,
+			&i.ReviewNote,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttendanceAuditsByAttendanceID = `-- name: GetAttendanceAuditsByAttendanceID :many
+SELECT id, attendance_id, excuse_id, changed_by, old_status, new_status, changed_at FROM attendance_audits WHERE attendance_id = $1 ORDER BY changed_at
+`
+
+func (q *Queries) GetAttendanceAuditsByAttendanceID(ctx context.Context, attendanceID pgtype.UUID) ([]AttendanceAudit, error) {
+	rows, err := q.db.Query(ctx, getAttendanceAuditsByAttendanceID, attendanceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AttendanceAudit
+	for rows.Next() {
+		var i AttendanceAudit
+		if err := rows.Scan(
+			&i.ID,
+			&i.AttendanceID,
+			&i.ExcuseID,
+			&i.ChangedBy,
+			&i.OldStatus,
+			&i.NewStatus,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttendanceByClassID = `-- name: GetAttendanceByClassID :many
+SELECT a.id, a.student_id, a.schedule_id, a.counter, a.status, a.minutes_late, a.note FROM attendances a
+JOIN schedules s ON s.id = a.schedule_id
+WHERE s.class_id = $1
+  AND s.date >= $2::DATE
+  AND s.date <= $3::DATE
+ORDER BY a.student_id
+`
+
+type GetAttendanceByClassIDParams struct {
+	ClassID  pgtype.UUID
+	FromDate pgtype.Date
+	ToDate   pgtype.Date
+}
+
+func (q *Queries) GetAttendanceByClassID(ctx context.Context, arg GetAttendanceByClassIDParams) ([]Attendance, error) {
+	rows, err := q.db.Query(ctx, getAttendanceByClassID, arg.ClassID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attendance
+	for rows.Next() {
+		var i Attendance
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.ScheduleID,
+			&i.Counter,
+			&i.Status,
+			&i.MinutesLate,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttendanceByID = `-- name: GetAttendanceByID :one
+SELECT id, student_id, schedule_id, counter, status, minutes_late, note FROM attendances WHERE id = $1
+`
+
+func (q *Queries) GetAttendanceByID(ctx context.Context, id pgtype.UUID) (Attendance, error) {
+	row := q.db.QueryRow(ctx, getAttendanceByID, id)
+	var i Attendance
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.ScheduleID,
+		&i.Counter,
+		&i.Status,
+		&i.MinutesLate,
+		&i.Note,
+	)
+	return i, err
+}
+
+const getAttendanceByScheduleID = `-- name: GetAttendanceByScheduleID :many
+SELECT id, student_id, schedule_id, counter, status, minutes_late, note FROM attendances WHERE schedule_id = $1
+`
+
+func (q *Queries) GetAttendanceByScheduleID(ctx context.Context, scheduleID pgtype.UUID) ([]Attendance, error) {
+	rows, err := q.db.Query(ctx, getAttendanceByScheduleID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attendance
+	for rows.Next() {
+		var i Attendance
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.ScheduleID,
+			&i.Counter,
+			&i.Status,
+			&i.MinutesLate,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttendanceByStudentID = `-- name: GetAttendanceByStudentID :many
+SELECT id, student_id, schedule_id, counter, status, minutes_late, note FROM attendances WHERE student_id = $1
+`
+
+func (q *Queries) GetAttendanceByStudentID(ctx context.Context, studentID pgtype.UUID) ([]Attendance, error) {
+	rows, err := q.db.Query(ctx, getAttendanceByStudentID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attendance
+	for rows.Next() {
+		var i Attendance
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.ScheduleID,
+			&i.Counter,
+			&i.Status,
+			&i.MinutesLate,
+			&i.Note,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttendancePolicy = `-- name: GetAttendancePolicy :one
+SELECT id, present_weight, late_weight, remote_weight, excused_weight, count_excused, updated_at FROM attendance_policy WHERE id = 1
+`
+
+func (q *Queries) GetAttendancePolicy(ctx context.Context) (AttendancePolicy, error) {
+	row := q.db.QueryRow(ctx, getAttendancePolicy)
+	var i AttendancePolicy
+	err := row.Scan(
+		&i.ID,
+		&i.PresentWeight,
+		&i.LateWeight,
+		&i.RemoteWeight,
+		&i.ExcusedWeight,
+		&i.CountExcused,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getAttendanceReport = `-- name: GetAttendanceReport :many
+SELECT (CASE $1::TEXT
+        WHEN 'student' THEN a.student_id
+        WHEN 'class' THEN s.class_id
+        WHEN 'lesson' THEN s.lesson_id
+        ELSE COALESCE(s.substitute_teacher_id, s.teacher_id)
+    END)::UUID AS group_id,
+    (CASE WHEN $2::TEXT = 'none' THEN $3::DATE ELSE date_trunc($2::TEXT, s.date)::DATE END)::DATE AS period_start,
+    COUNT(*) FILTER (WHERE a.status <> 'excused' OR p.count_excused) AS lessons,
+    COUNT(*) FILTER (WHERE a.status = 'present') AS present,
+    COUNT(*) FILTER (WHERE a.status = 'late') AS late,
+    COUNT(*) FILTER (WHERE a.status = 'remote') AS remote,
+    COUNT(*) FILTER (WHERE a.status = 'excused') AS excused,
+    COUNT(*) FILTER (WHERE a.status = 'absent') AS absent,
+    COALESCE(SUM(a.minutes_late), 0)::BIGINT AS minutes_late,
+    COALESCE(SUM(CASE a.status
+        WHEN 'present' THEN p.present_weight
+        WHEN 'late' THEN p.late_weight
+        WHEN 'remote' THEN p.remote_weight
+        WHEN 'excused' THEN CASE WHEN p.count_excused THEN p.excused_weight ELSE 0 END
+        ELSE 0
+    END) / NULLIF(COUNT(*) FILTER (WHERE a.status <> 'excused' OR p.count_excused), 0) * 100, 0)::FLOAT8 AS rate
+FROM attendances a
+JOIN schedules s ON s.id = a.schedule_id
+CROSS JOIN attendance_policy p
+WHERE s.status <> 'cancelled'
+  AND s.date >= $3::DATE
+  AND s.date <= $4::DATE
+  AND ($5::UUID IS NULL OR s.class_id = $5::UUID)
+  AND ($6::UUID IS NULL OR s.lesson_id = $6::UUID)
+  AND ($7::UUID IS NULL OR COALESCE(s.substitute_teacher_id, s.teacher_id) = $7::UUID)
+  AND ($8::UUID IS NULL OR a.student_id = $8::UUID)
+GROUP BY 1, 2
+ORDER BY 2, 1
+`
+
+type GetAttendanceReportParams struct {
+	GroupBy   string
+	Period    string
+	FromDate  pgtype.Date
+	ToDate    pgtype.Date
+	ClassID   pgtype.UUID
+	LessonID  pgtype.UUID
+	TeacherID pgtype.UUID
+	StudentID pgtype.UUID
+}
+
+type GetAttendanceReportRow struct {
+	GroupID     pgtype.UUID
+	PeriodStart pgtype.Date
+	Lessons     int64
+	Present     int64
+	Late        int64
+	Remote      int64
+	Excused     int64
+	Absent      int64
+	MinutesLate int64
+	Rate        float64
+}
+
+// Weighted attendance rates of the non-cancelled lessons in the range,
+// grouped by student, class, lesson or the teacher who taught the lesson,
+// per week, per month or over the whole range
+func (q *Queries) GetAttendanceReport(ctx context.Context, arg GetAttendanceReportParams) ([]GetAttendanceReportRow, error) {
+	rows, err := q.db.Query(ctx, getAttendanceReport,
+		arg.GroupBy,
+		arg.Period,
+		arg.FromDate,
+		arg.ToDate,
+		arg.ClassID,
+		arg.LessonID,
+		arg.TeacherID,
+		arg.StudentID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAttendanceReportRow
+	for rows.Next() {
+		var i GetAttendanceReportRow
+		if err := rows.Scan(
+			&i.GroupID,
+			&i.PeriodStart,
+			&i.Lessons,
+			&i.Present,
+			&i.Late,
+			&i.Remote,
+			&i.Excused,
+			&i.Absent,
+			&i.MinutesLate,
+			&i.Rate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttendanceThresholdByID = `-- name: GetAttendanceThresholdByID :one
+SELECT id, lesson_id, min_rate, min_lessons, created_at, updated_at FROM attendance_thresholds WHERE id = $1
+`
+
+func (q *Queries) GetAttendanceThresholdByID(ctx context.Context, id pgtype.UUID) (AttendanceThreshold, error) {
+	row := q.db.QueryRow(ctx, getAttendanceThresholdByID, id)
+	var i AttendanceThreshold
+	err := row.Scan(
+		&i.ID,
+		&i.LessonID,
+		&i.MinRate,
+		&i.MinLessons,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getAttendanceThresholds = `-- name: GetAttendanceThresholds :many
+SELECT id, lesson_id, min_rate, min_lessons, created_at, updated_at FROM attendance_thresholds ORDER BY lesson_id NULLS FIRST, created_at
+`
+
+func (q *Queries) GetAttendanceThresholds(ctx context.Context) ([]AttendanceThreshold, error) {
+	rows, err := q.db.Query(ctx, getAttendanceThresholds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AttendanceThreshold
+	for rows.Next() {
+		var i AttendanceThreshold
+		if err := rows.Scan(
+			&i.ID,
+			&i.LessonID,
+			&i.MinRate,
+			&i.MinLessons,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBellPeriodsByScheduleID = `-- name: GetBellPeriodsByScheduleID :many
+SELECT id, bell_schedule_id, name, position, weekdays, start_time, end_time FROM bell_periods
+WHERE bell_schedule_id = $1
+ORDER BY position, start_time
+`
+
+func (q *Queries) GetBellPeriodsByScheduleID(ctx context.Context, bellScheduleID pgtype.UUID) ([]BellPeriod, error) {
+	rows, err := q.db.Query(ctx, getBellPeriodsByScheduleID, bellScheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BellPeriod
+	for rows.Next() {
+		var i BellPeriod
+		if err := rows.Scan(
+			&i.ID,
+			&i.BellScheduleID,
+			&i.Name,
+			&i.Position,
+			&i.Weekdays,
+			&i.StartTime,
+			&i.EndTime,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBellScheduleByID = `-- name: GetBellScheduleByID :one
+SELECT id, name, active, created_at FROM bell_schedules WHERE id = $1
+`
+
+func (q *Queries) GetBellScheduleByID(ctx context.Context, id pgtype.UUID) (BellSchedule, error) {
+	row := q.db.QueryRow(ctx, getBellScheduleByID, id)
+	var i BellSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCalendarFeedByID = `-- name: GetCalendarFeedByID :one
+SELECT id, token, owner_type, owner_id, created_by, created_at FROM calendar_feeds WHERE id = $1
+`
+
+func (q *Queries) GetCalendarFeedByID(ctx context.Context, id pgtype.UUID) (CalendarFeed, error) {
+	row := q.db.QueryRow(ctx, getCalendarFeedByID, id)
+	var i CalendarFeed
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.OwnerType,
+		&i.OwnerID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCalendarFeedByToken = `-- name: GetCalendarFeedByToken :one
+SELECT id, token, owner_type, owner_id, created_by, created_at FROM calendar_feeds WHERE token = $1
+`
+
+func (q *Queries) GetCalendarFeedByToken(ctx context.Context, token string) (CalendarFeed, error) {
+	row := q.db.QueryRow(ctx, getCalendarFeedByToken, token)
+	var i CalendarFeed
+	err := row.Scan(
+		&i.ID,
+		&i.Token,
+		&i.OwnerType,
+		&i.OwnerID,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCalendarFeedsByCreator = `-- name: GetCalendarFeedsByCreator :many
+SELECT id, token, owner_type, owner_id, created_by, created_at FROM calendar_feeds WHERE created_by = $1
+`
+
+func (q *Queries) GetCalendarFeedsByCreator(ctx context.Context, createdBy pgtype.UUID) ([]CalendarFeed, error) {
+	rows, err := q.db.Query(ctx, getCalendarFeedsByCreator, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CalendarFeed
+	for rows.Next() {
+		var i CalendarFeed
+		if err := rows.Scan(
+			&i.ID,
+			&i.Token,
+			&i.OwnerType,
+			&i.OwnerID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCheckInSessionByID = `-- name: GetCheckInSessionByID :one
+SELECT id, schedule_id, opened_by, secret, code_seconds, late_after_minutes, opened_at, closes_at, closed_at FROM check_in_sessions WHERE id = $1
+`
+
+func (q *Queries) GetCheckInSessionByID(ctx context.Context, id pgtype.UUID) (CheckInSession, error) {
+	row := q.db.QueryRow(ctx, getCheckInSessionByID, id)
+	var i CheckInSession
+	err := row.Scan(
+		&i.ID,
+		&i.ScheduleID,
+		&i.OpenedBy,
+		&i.Secret,
+		&i.CodeSeconds,
+		&i.LateAfterMinutes,
+		&i.OpenedAt,
+		&i.ClosesAt,
+		&i.ClosedAt,
+	)
+	return i, err
+}
+
+const getCheckInSessionsByScheduleID = `-- name: GetCheckInSessionsByScheduleID :many
+SELECT id, schedule_id, opened_by, secret, code_seconds, late_after_minutes, opened_at, closes_at, closed_at FROM check_in_sessions WHERE schedule_id = $1 ORDER BY opened_at DESC
+`
+
+func (q *Queries) GetCheckInSessionsByScheduleID(ctx context.Context, scheduleID pgtype.UUID) ([]CheckInSession, error) {
+	rows, err := q.db.Query(ctx, getCheckInSessionsByScheduleID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CheckInSession
+	for rows.Next() {
+		var i CheckInSession
+		if err := rows.Scan(
+			&i.ID,
+			&i.ScheduleID,
+			&i.OpenedBy,
+			&i.Secret,
+			&i.CodeSeconds,
+			&i.LateAfterMinutes,
+			&i.OpenedAt,
+			&i.ClosesAt,
+			&i.ClosedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCheckInsBySessionID = `-- name: GetCheckInsBySessionID :many
+SELECT id, session_id, student_id, code, status, minutes_late, checked_in_at FROM check_ins WHERE session_id = $1 ORDER BY checked_in_at
+`
+
+func (q *Queries) GetCheckInsBySessionID(ctx context.Context, sessionID pgtype.UUID) ([]CheckIn, error) {
+	rows, err := q.db.Query(ctx, getCheckInsBySessionID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CheckIn
+	for rows.Next() {
+		var i CheckIn
+		if err := rows.Scan(
+			&i.ID,
+			&i.SessionID,
+			&i.StudentID,
+			&i.Code,
+			&i.Status,
+			&i.MinutesLate,
+			&i.CheckedInAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getClosureByID = `-- name: GetClosureByID :one
+SELECT id, name, kind, start_date, end_date FROM closures WHERE id = $1
+`
+
+func (q *Queries) GetClosureByID(ctx context.Context, id pgtype.UUID) (Closure, error) {
+	row := q.db.QueryRow(ctx, getClosureByID, id)
+	var i Closure
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.StartDate,
+		&i.EndDate,
+	)
+	return i, err
+}
+
+const getClosuresBetween = `-- name: GetClosuresBetween :many
+SELECT id, name, kind, start_date, end_date FROM closures
+WHERE end_date >= $1 AND start_date <= $2
+ORDER BY start_date
+`
+
+type GetClosuresBetweenParams struct {
+	FromDate pgtype.Date
+	ToDate   pgtype.Date
+}
+
+func (q *Queries) GetClosuresBetween(ctx context.Context, arg GetClosuresBetweenParams) ([]Closure, error) {
+	rows, err := q.db.Query(ctx, getClosuresBetween, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Closure
+	for rows.Next() {
+		var i Closure
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Kind,
+			&i.StartDate,
+			&i.EndDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExamByID = `-- name: GetExamByID :one
+SELECT id, lesson_id, class_id, title, date, start_time, end_time, room_id, invigilator_ids, created_at FROM exams WHERE id = $1
+`
+
+func (q *Queries) GetExamByID(ctx context.Context, id pgtype.UUID) (Exam, error) {
+	row := q.db.QueryRow(ctx, getExamByID, id)
+	var i Exam
+	err := row.Scan(
+		&i.ID,
+		&i.LessonID,
+		&i.ClassID,
+		&i.Title,
+		&i.Date,
+		&i.StartTime,
+		&i.EndTime,
+		&i.RoomID,
+		&i.InvigilatorIds,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getExamRules = `-- name: GetExamRules :one
+SELECT id, max_exams_per_day, min_gap_minutes, updated_at FROM exam_rules WHERE id = 1
+`
+
+func (q *Queries) GetExamRules(ctx context.Context) (ExamRule, error) {
+	row := q.db.QueryRow(ctx, getExamRules)
+	var i ExamRule
+	err := row.Scan(
+		&i.ID,
+		&i.MaxExamsPerDay,
+		&i.MinGapMinutes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getExamsBetween = `-- name: GetExamsBetween :many
+SELECT id, lesson_id, class_id, title, date, start_time, end_time, room_id, invigilator_ids, created_at FROM exams
+WHERE date >= $1 AND date <= $2
+ORDER BY date, start_time
+`
+
+type GetExamsBetweenParams struct {
+	FromDate pgtype.Date
+	ToDate   pgtype.Date
+}
+
+func (q *Queries) GetExamsBetween(ctx context.Context, arg GetExamsBetweenParams) ([]Exam, error) {
+	rows, err := q.db.Query(ctx, getExamsBetween, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Exam
+	for rows.Next() {
+		var i Exam
+		if err := rows.Scan(
+			&i.ID,
+			&i.LessonID,
+			&i.ClassID,
+			&i.Title,
+			&i.Date,
+			&i.StartTime,
+			&i.EndTime,
+			&i.RoomID,
+			&i.InvigilatorIds,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExcuseByID = `-- name: GetExcuseByID :one
+SELECT id, student_id, submitted_by, reason, schedule_ids, from_date, to_date, document_url, status, reviewed_by, review_note, reviewed_at, created_at FROM excuses WHERE id = $1
+`
+
+func (q *Queries) GetExcuseByID(ctx context.Context, id pgtype.UUID) (Excuse, error) {
+	row := q.db.QueryRow(ctx, getExcuseByID, id)
+	var i Excuse
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.SubmittedBy,
+		&i.Reason,
+		&i.ScheduleIds,
+		&i.FromDate,
+		&i.ToDate,
+		&i.DocumentUrl,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getExcusesByStatus = `-- name: GetExcusesByStatus :many
+SELECT id, student_id, submitted_by, reason, schedule_ids, from_date, to_date, document_url, status, reviewed_by, review_note, reviewed_at, created_at FROM excuses WHERE status = $1 ORDER BY created_at
+`
+
+func (q *Queries) GetExcusesByStatus(ctx context.Context, status string) ([]Excuse, error) {
+	rows, err := q.db.Query(ctx, getExcusesByStatus, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Excuse
+	for rows.Next() {
+		var i Excuse
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.SubmittedBy,
+			&i.Reason,
+			&i.ScheduleIds,
+			&i.FromDate,
+			&i.ToDate,
+			&i.DocumentUrl,
+			&i.Status,
+			&i.ReviewedBy,
+			&i.ReviewNote,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getExcusesByStudentID = `-- name: GetExcusesByStudentID :many
+SELECT id, student_id, submitted_by, reason, schedule_ids, from_date, to_date, document_url, status, reviewed_by, review_note, reviewed_at, created_at FROM excuses WHERE student_id = $1 ORDER BY created_at DESC
+`
+
+func (q *Queries) GetExcusesByStudentID(ctx context.Context, studentID pgtype.UUID) ([]Excuse, error) {
+	rows, err := q.db.Query(ctx, getExcusesByStudentID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Excuse
+	for rows.Next() {
+		var i Excuse
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.SubmittedBy,
+			&i.Reason,
+			&i.ScheduleIds,
+			&i.FromDate,
+			&i.ToDate,
+			&i.DocumentUrl,
+			&i.Status,
+			&i.ReviewedBy,
+			&i.ReviewNote,
+			&i.ReviewedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getHomeworkByID = `-- name: GetHomeworkByID :one
+SELECT id, teacher_id, lesson_id, class_id, title, content, due_date FROM homeworks WHERE id = $1
+`
+
+func (q *Queries) GetHomeworkByID(ctx context.Context, id pgtype.UUID) (Homework, error) {
+	row := q.db.QueryRow(ctx, getHomeworkByID, id)
+	var i Homework
+	err := row.Scan(
+		&i.ID,
+		&i.TeacherID,
+		&i.LessonID,
+		&i.ClassID,
+		&i.Title,
+		&i.Content,
+		&i.DueDate,
+	)
+	return i, err
+}
+
+const getHomeworksByClassID = `-- name: GetHomeworksByClassID :many
+SELECT id, teacher_id, lesson_id, class_id, title, content, due_date FROM homeworks WHERE class_id = $1
+`
+
+func (q *Queries) GetHomeworksByClassID(ctx context.Context, classID pgtype.UUID) ([]Homework, error) {
+	rows, err := q.db.Query(ctx, getHomeworksByClassID, classID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Homework
+	for rows.Next() {
+		var i Homework
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeacherID,
+			&i.LessonID,
+			&i.ClassID,
+			&i.Title,
+			&i.Content,
+			&i.DueDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getHomeworksByLessonID = `-- name: GetHomeworksByLessonID :many
+SELECT id, teacher_id, lesson_id, class_id, title, content, due_date FROM homeworks WHERE lesson_id = $1
+`
+
+func (q *Queries) GetHomeworksByLessonID(ctx context.Context, lessonID pgtype.UUID) ([]Homework, error) {
+	rows, err := q.db.Query(ctx, getHomeworksByLessonID, lessonID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Homework
+	for rows.Next() {
+		var i Homework
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeacherID,
+			&i.LessonID,
+			&i.ClassID,
+			&i.Title,
+			&i.Content,
+			&i.DueDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getHomeworksByTeacherID = `-- name: GetHomeworksByTeacherID :many
+SELECT id, teacher_id, lesson_id, class_id, title, content, due_date FROM homeworks WHERE teacher_id = $1
+`
+
+func (q *Queries) GetHomeworksByTeacherID(ctx context.Context, teacherID pgtype.UUID) ([]Homework, error) {
+	rows, err := q.db.Query(ctx, getHomeworksByTeacherID, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Homework
+	for rows.Next() {
+		var i Homework
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeacherID,
+			&i.LessonID,
+			&i.ClassID,
+			&i.Title,
+			&i.Content,
+			&i.DueDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLessonByID = `-- name: GetLessonByID :one
+SELECT id, lesson_name FROM lessons WHERE id = $1
+`
+
+func (q *Queries) GetLessonByID(ctx context.Context, id pgtype.UUID) (Lesson, error) {
+	row := q.db.QueryRow(ctx, getLessonByID, id)
+	var i Lesson
+	err := row.Scan(&i.ID, &i.LessonName)
+	return i, err
+}
+
+const getOpenAttendanceAlerts = `-- name: GetOpenAttendanceAlerts :many
+SELECT id, student_id, lesson_id, threshold_id, rate, min_rate, recipient_ids, guardian_phone, status, created_at, resolved_at FROM attendance_alerts WHERE status = 'open' ORDER BY created_at
+`
+
+func (q *Queries) GetOpenAttendanceAlerts(ctx context.Context) ([]AttendanceAlert, error) {
+	rows, err := q.db.Query(ctx, getOpenAttendanceAlerts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AttendanceAlert
+	for rows.Next() {
+		var i AttendanceAlert
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.LessonID,
+			&i.ThresholdID,
+			&i.Rate,
+			&i.MinRate,
+			&i.RecipientIds,
+			&i.GuardianPhone,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOpenAttendanceAlertsByRecipientID = `-- name: GetOpenAttendanceAlertsByRecipientID :many
+SELECT id, student_id, lesson_id, threshold_id, rate, min_rate, recipient_ids, guardian_phone, status, created_at, resolved_at FROM attendance_alerts WHERE $1::UUID = ANY(recipient_ids) AND status = 'open' ORDER BY created_at
+`
+
+func (q *Queries) GetOpenAttendanceAlertsByRecipientID(ctx context.Context, recipientID pgtype.UUID) ([]AttendanceAlert, error) {
+	rows, err := q.db.Query(ctx, getOpenAttendanceAlertsByRecipientID, recipientID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AttendanceAlert
+	for rows.Next() {
+		var i AttendanceAlert
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.LessonID,
+			&i.ThresholdID,
+			&i.Rate,
+			&i.MinRate,
+			&i.RecipientIds,
+			&i.GuardianPhone,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOpenAttendanceAlertsByStudentID = `-- name: GetOpenAttendanceAlertsByStudentID :many
+SELECT id, student_id, lesson_id, threshold_id, rate, min_rate, recipient_ids, guardian_phone, status, created_at, resolved_at FROM attendance_alerts WHERE student_id = $1 AND status = 'open' ORDER BY created_at
+`
+
+func (q *Queries) GetOpenAttendanceAlertsByStudentID(ctx context.Context, studentID pgtype.UUID) ([]AttendanceAlert, error) {
+	rows, err := q.db.Query(ctx, getOpenAttendanceAlertsByStudentID, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AttendanceAlert
+	for rows.Next() {
+		var i AttendanceAlert
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.LessonID,
+			&i.ThresholdID,
+			&i.Rate,
+			&i.MinRate,
+			&i.RecipientIds,
+			&i.GuardianPhone,
+			&i.Status,
+			&i.CreatedAt,
+			&i.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOpenRollCallReminders = `-- name: GetOpenRollCallReminders :many
+SELECT schedule_id, teacher_id, missing_student_ids, checked_at, resolved_at FROM roll_call_checks
+WHERE resolved_at IS NULL AND cardinality(missing_student_ids) > 0
+ORDER BY checked_at
+`
+
+func (q *Queries) GetOpenRollCallReminders(ctx context.Context) ([]RollCallCheck, error) {
+	rows, err := q.db.Query(ctx, getOpenRollCallReminders)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RollCallCheck
+	for rows.Next() {
+		var i RollCallCheck
+		if err := rows.Scan(
+			&i.ScheduleID,
+			&i.TeacherID,
+			&i.MissingStudentIds,
+			&i.CheckedAt,
+			&i.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOpenRollCallRemindersByTeacherID = `-- name: GetOpenRollCallRemindersByTeacherID :many
+SELECT schedule_id, teacher_id, missing_student_ids, checked_at, resolved_at FROM roll_call_checks
+WHERE teacher_id = $1 AND resolved_at IS NULL AND cardinality(missing_student_ids) > 0
+ORDER BY checked_at
+`
+
+func (q *Queries) GetOpenRollCallRemindersByTeacherID(ctx context.Context, teacherID pgtype.UUID) ([]RollCallCheck, error) {
+	rows, err := q.db.Query(ctx, getOpenRollCallRemindersByTeacherID, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RollCallCheck
+	for rows.Next() {
+		var i RollCallCheck
+		if err := rows.Scan(
+			&i.ScheduleID,
+			&i.TeacherID,
+			&i.MissingStudentIds,
+			&i.CheckedAt,
+			&i.ResolvedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRollCallCheck = `-- name: GetRollCallCheck :one
+SELECT schedule_id, teacher_id, missing_student_ids, checked_at, resolved_at FROM roll_call_checks WHERE schedule_id = $1
+`
+
+func (q *Queries) GetRollCallCheck(ctx context.Context, scheduleID pgtype.UUID) (RollCallCheck, error) {
+	row := q.db.QueryRow(ctx, getRollCallCheck, scheduleID)
+	var i RollCallCheck
+	err := row.Scan(
+		&i.ScheduleID,
 		&i.TeacherID,
-		&i.LessonID,
-		&i.ClassID,
+		&i.MissingStudentIds,
+		&i.CheckedAt,
+		&i.ResolvedAt,
 	)
 	return i, err
 }
 
-const deleteAttendance = `-- name: DeleteAttendance :exec
-DELETE FROM attendances WHERE id = $1
+const getRoomByID = `-- name: GetRoomByID :one
+SELECT id, name, capacity, features FROM rooms WHERE id = $1
 `
 
-func (q *Queries) DeleteAttendance(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteAttendance, id)
-	return err
+func (q *Queries) GetRoomByID(ctx context.Context, id pgtype.UUID) (Room, error) {
+	row := q.db.QueryRow(ctx, getRoomByID, id)
+	var i Room
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Capacity,
+		&i.Features,
+	)
+	return i, err
 }
 
-const deleteHomework = `-- name: DeleteHomework :exec
-DELETE FROM homeworks WHERE id = $1
+const getScheduleByID = `-- name: GetScheduleByID :one
+SELECT id, date, time, end_time, teacher_id, lesson_id, class_id, series_id, occurrence_date, room_id, substitute_teacher_id, status, cancellation_reason, cancelled_by, cancelled_at, period_id FROM schedules WHERE id = $1
 `
 
-func (q *Queries) DeleteHomework(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteHomework, id)
-	return err
+func (q *Queries) GetScheduleByID(ctx context.Context, id pgtype.UUID) (Schedule, error) {
+	row := q.db.QueryRow(ctx, getScheduleByID, id)
+	var i Schedule
+	err := row.Scan(
+		&i.ID,
+		&i.Date,
+		&i.Time,
+		&i.EndTime,
+		&i.TeacherID,
+		&i.LessonID,
+		&i.ClassID,
+		&i.SeriesID,
+		&i.OccurrenceDate,
+		&i.RoomID,
+		&i.SubstituteTeacherID,
+		&i.Status,
+		&i.CancellationReason,
+		&i.CancelledBy,
+		&i.CancelledAt,
+		&i.PeriodID,
+	)
+	return i, err
 }
 
-const deleteLesson = `-- name: DeleteLesson :exec
-DELETE FROM lessons WHERE id = $1
+const getScheduleSeriesByClassID = `-- name: GetScheduleSeriesByClassID :many
+SELECT id, teacher_id, lesson_id, class_id, start_date, time, end_time, weekdays, until_date, occurrence_count, exception_dates, room_id FROM schedule_series WHERE class_id = $1
 `
 
-func (q *Queries) DeleteLesson(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteLesson, id)
-	return err
+func (q *Queries) GetScheduleSeriesByClassID(ctx context.Context, classID pgtype.UUID) ([]ScheduleSeries, error) {
+	rows, err := q.db.Query(ctx, getScheduleSeriesByClassID, classID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ScheduleSeries
+	for rows.Next() {
+		var i ScheduleSeries
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeacherID,
+			&i.LessonID,
+			&i.ClassID,
+			&i.StartDate,
+			&i.Time,
+			&i.EndTime,
+			&i.Weekdays,
+			&i.UntilDate,
+			&i.OccurrenceCount,
+			&i.ExceptionDates,
+			&i.RoomID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const deleteSchedule = `-- name: DeleteSchedule :exec
-DELETE FROM schedules WHERE id = $1
+const getScheduleSeriesByID = `-- name: GetScheduleSeriesByID :one
+SELECT id, teacher_id, lesson_id, class_id, start_date, time, end_time, weekdays, until_date, occurrence_count, exception_dates, room_id FROM schedule_series WHERE id = $1
 `
 
-func (q *Queries) DeleteSchedule(ctx context.Context, id pgtype.UUID) error {
-	_, err := q.db.Exec(ctx, deleteSchedule, id)
-	return err
+func (q *Queries) GetScheduleSeriesByID(ctx context.Context, id pgtype.UUID) (ScheduleSeries, error) {
+	row := q.db.QueryRow(ctx, getScheduleSeriesByID, id)
+	var i ScheduleSeries
+	err := row.Scan(
+		&i.ID,
+		&i.TeacherID,
+		&i.LessonID,
+		&i.ClassID,
+		&i.StartDate,
+		&i.Time,
+		&i.EndTime,
+		&i.Weekdays,
+		&i.UntilDate,
+		&i.OccurrenceCount,
+		&i.ExceptionDates,
+		&i.RoomID,
+	)
+	return i, err
 }
 
-const getAllHomeworks = `-- name: GetAllHomeworks :many
-SELECT id, teacher_id, lesson_id, class_id, title, content, due_date FROM homeworks
+const getScheduleSeriesByRoomID = `-- name: GetScheduleSeriesByRoomID :many
+SELECT id, teacher_id, lesson_id, class_id, start_date, time, end_time, weekdays, until_date, occurrence_count, exception_dates, room_id FROM schedule_series WHERE room_id = $1
 `
 
-func (q *Queries) GetAllHomeworks(ctx context.Context) ([]Homework, error) {
-	rows, err := q.db.Query(ctx, getAllHomeworks)
+func (q *Queries) GetScheduleSeriesByRoomID(ctx context.Context, roomID pgtype.UUID) ([]ScheduleSeries, error) {
+	rows, err := q.db.Query(ctx, getScheduleSeriesByRoomID, roomID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Homework
+	var items []ScheduleSeries
 	for rows.Next() {
-		var i Homework
+		var i ScheduleSeries
 		if err := rows.Scan(
 			&i.ID,
 			&i.TeacherID,
 			&i.LessonID,
 			&i.ClassID,
-			&i.Title,
-			&i.Content,
-			&i.DueDate,
+			&i.StartDate,
+			&i.Time,
+			&i.EndTime,
+			&i.Weekdays,
+			&i.UntilDate,
+			&i.OccurrenceCount,
+			&i.ExceptionDates,
+			&i.RoomID,
 		); err != nil {
 			return nil, err
 		}
@@ -194,20 +2555,33 @@ func (q *Queries) GetAllHomeworks(ctx context.Context) ([]Homework, error) {
 	return items, nil
 }
 
-const getAllLessons = `-- name: GetAllLessons :many
-SELECT id, lesson_name FROM lessons
+const getScheduleSeriesByTeacherID = `-- name: GetScheduleSeriesByTeacherID :many
+SELECT id, teacher_id, lesson_id, class_id, start_date, time, end_time, weekdays, until_date, occurrence_count, exception_dates, room_id FROM schedule_series WHERE teacher_id = $1
 `
 
-func (q *Queries) GetAllLessons(ctx context.Context) ([]Lesson, error) {
-	rows, err := q.db.Query(ctx, getAllLessons)
+func (q *Queries) GetScheduleSeriesByTeacherID(ctx context.Context, teacherID pgtype.UUID) ([]ScheduleSeries, error) {
+	rows, err := q.db.Query(ctx, getScheduleSeriesByTeacherID, teacherID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Lesson
+	var items []ScheduleSeries
 	for rows.Next() {
-		var i Lesson
-		if err := rows.Scan(&i.ID, &i.LessonName); err != nil {
+		var i ScheduleSeries
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeacherID,
+			&i.LessonID,
+			&i.ClassID,
+			&i.StartDate,
+			&i.Time,
+			&i.EndTime,
+			&i.Weekdays,
+			&i.UntilDate,
+			&i.OccurrenceCount,
+			&i.ExceptionDates,
+			&i.RoomID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -218,12 +2592,12 @@ func (q *Queries) GetAllLessons(ctx context.Context) ([]Lesson, error) {
 	return items, nil
 }
 
-const getAllSchedules = `-- name: GetAllSchedules :many
-SELECT id, date, time, teacher_id, lesson_id, class_id FROM schedules
+const getSchedulesByClassID = `-- name: GetSchedulesByClassID :many
+SELECT id, date, time, end_time, teacher_id, lesson_id, class_id, series_id, occurrence_date, room_id, substitute_teacher_id, status, cancellation_reason, cancelled_by, cancelled_at, period_id FROM schedules WHERE class_id = $1
 `
 
-func (q *Queries) GetAllSchedules(ctx context.Context) ([]Schedule, error) {
-	rows, err := q.db.Query(ctx, getAllSchedules)
+func (q *Queries) GetSchedulesByClassID(ctx context.Context, classID pgtype.UUID) ([]Schedule, error) {
+	rows, err := q.db.Query(ctx, getSchedulesByClassID, classID)
 	if err != nil {
 		return nil, err
 	}
@@ -235,9 +2609,19 @@ func (q *Queries) GetAllSchedules(ctx context.Context) ([]Schedule, error) {
 			&i.ID,
 			&i.Date,
 			&i.Time,
+			&i.EndTime,
 			&i.TeacherID,
 			&i.LessonID,
 			&i.ClassID,
+			&i.SeriesID,
+			&i.OccurrenceDate,
+			&i.RoomID,
+			&i.SubstituteTeacherID,
+			&i.Status,
+			&i.CancellationReason,
+			&i.CancelledBy,
+			&i.CancelledAt,
+			&i.PeriodID,
 		); err != nil {
 			return nil, err
 		}
@@ -249,42 +2633,36 @@ func (q *Queries) GetAllSchedules(ctx context.Context) ([]Schedule, error) {
 	return items, nil
 }
 
-const getAttendanceByID = `-- name: GetAttendanceByID :one
-SELECT id, student_id, schedule_id, here, counter FROM attendances WHERE id = $1
-`
-
-func (q *Queries) GetAttendanceByID(ctx context.Context, id pgtype.UUID) (Attendance, error) {
-	row := q.db.QueryRow(ctx, getAttendanceByID, id)
-	var i Attendance
-	err := row.Scan(
-		&i.ID,
-		&i.StudentID,
-		&i.ScheduleID,
-		&i.Here,
-		&i.Counter,
-	)
-	return i, err
-}
-
-const getAttendanceByScheduleID = `-- name: GetAttendanceByScheduleID :many
-SELECT id, student_id, schedule_id, here, counter FROM attendances WHERE schedule_id = $1
+const getSchedulesByIDs = `-- name: GetSchedulesByIDs :many
+SELECT id, date, time, end_time, teacher_id, lesson_id, class_id, series_id, occurrence_date, room_id, substitute_teacher_id, status, cancellation_reason, cancelled_by, cancelled_at, period_id FROM schedules WHERE id = ANY($1::UUID[])
 `
 
-func (q *Queries) GetAttendanceByScheduleID(ctx context.Context, scheduleID pgtype.UUID) ([]Attendance, error) {
-	rows, err := q.db.Query(ctx, getAttendanceByScheduleID, scheduleID)
+func (q *Queries) GetSchedulesByIDs(ctx context.Context, ids []pgtype.UUID) ([]Schedule, error) {
+	rows, err := q.db.Query(ctx, getSchedulesByIDs, ids)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Attendance
+	var items []Schedule
 	for rows.Next() {
-		var i Attendance
+		var i Schedule
 		if err := rows.Scan(
 			&i.ID,
-			&i.StudentID,
-			&i.ScheduleID,
-			&i.Here,
-			&i.Counter,
+			&i.Date,
+			&i.Time,
+			&i.EndTime,
+			&i.TeacherID,
+			&i.LessonID,
+			&i.ClassID,
+			&i.SeriesID,
+			&i.OccurrenceDate,
+			&i.RoomID,
+			&i.SubstituteTeacherID,
+			&i.Status,
+			&i.CancellationReason,
+			&i.CancelledBy,
+			&i.CancelledAt,
+			&i.PeriodID,
 		); err != nil {
 			return nil, err
 		}
@@ -296,25 +2674,36 @@ func (q *Queries) GetAttendanceByScheduleID(ctx context.Context, scheduleID pgty
 	return items, nil
 }
 
-const getAttendanceByStudentID = `-- name: GetAttendanceByStudentID :many
-SELECT id, student_id, schedule_id, here, counter FROM attendances WHERE student_id = $1
+const getSchedulesByRoomID = `-- name: GetSchedulesByRoomID :many
+SELECT id, date, time, end_time, teacher_id, lesson_id, class_id, series_id, occurrence_date, room_id, substitute_teacher_id, status, cancellation_reason, cancelled_by, cancelled_at, period_id FROM schedules WHERE room_id = $1
 `
 
-func (q *Queries) GetAttendanceByStudentID(ctx context.Context, studentID pgtype.UUID) ([]Attendance, error) {
-	rows, err := q.db.Query(ctx, getAttendanceByStudentID, studentID)
+func (q *Queries) GetSchedulesByRoomID(ctx context.Context, roomID pgtype.UUID) ([]Schedule, error) {
+	rows, err := q.db.Query(ctx, getSchedulesByRoomID, roomID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Attendance
+	var items []Schedule
 	for rows.Next() {
-		var i Attendance
+		var i Schedule
 		if err := rows.Scan(
 			&i.ID,
-			&i.StudentID,
-			&i.ScheduleID,
-			&i.Here,
-			&i.Counter,
+			&i.Date,
+			&i.Time,
+			&i.EndTime,
+			&i.TeacherID,
+			&i.LessonID,
+			&i.ClassID,
+			&i.SeriesID,
+			&i.OccurrenceDate,
+			&i.RoomID,
+			&i.SubstituteTeacherID,
+			&i.Status,
+			&i.CancellationReason,
+			&i.CancelledBy,
+			&i.CancelledAt,
+			&i.PeriodID,
 		); err != nil {
 			return nil, err
 		}
@@ -326,46 +2715,36 @@ func (q *Queries) GetAttendanceByStudentID(ctx context.Context, studentID pgtype
 	return items, nil
 }
 
-const getHomeworkByID = `-- name: GetHomeworkByID :one
-SELECT id, teacher_id, lesson_id, class_id, title, content, due_date FROM homeworks WHERE id = $1
-`
-
-func (q *Queries) GetHomeworkByID(ctx context.Context, id pgtype.UUID) (Homework, error) {
-	row := q.db.QueryRow(ctx, getHomeworkByID, id)
-	var i Homework
-	err := row.Scan(
-		&i.ID,
-		&i.TeacherID,
-		&i.LessonID,
-		&i.ClassID,
-		&i.Title,
-		&i.Content,
-		&i.DueDate,
-	)
-	return i, err
-}
-
-const getHomeworksByClassID = `-- name: GetHomeworksByClassID :many
-SELECT id, teacher_id, lesson_id, class_id, title, content, due_date FROM homeworks WHERE class_id = $1
+const getSchedulesBySubstituteTeacherID = `-- name: GetSchedulesBySubstituteTeacherID :many
+SELECT id, date, time, end_time, teacher_id, lesson_id, class_id, series_id, occurrence_date, room_id, substitute_teacher_id, status, cancellation_reason, cancelled_by, cancelled_at, period_id FROM schedules WHERE substitute_teacher_id = $1
 `
 
-func (q *Queries) GetHomeworksByClassID(ctx context.Context, classID pgtype.UUID) ([]Homework, error) {
-	rows, err := q.db.Query(ctx, getHomeworksByClassID, classID)
+func (q *Queries) GetSchedulesBySubstituteTeacherID(ctx context.Context, substituteTeacherID pgtype.UUID) ([]Schedule, error) {
+	rows, err := q.db.Query(ctx, getSchedulesBySubstituteTeacherID, substituteTeacherID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Homework
+	var items []Schedule
 	for rows.Next() {
-		var i Homework
+		var i Schedule
 		if err := rows.Scan(
 			&i.ID,
+			&i.Date,
+			&i.Time,
+			&i.EndTime,
 			&i.TeacherID,
 			&i.LessonID,
 			&i.ClassID,
-			&i.Title,
-			&i.Content,
-			&i.DueDate,
+			&i.SeriesID,
+			&i.OccurrenceDate,
+			&i.RoomID,
+			&i.SubstituteTeacherID,
+			&i.Status,
+			&i.CancellationReason,
+			&i.CancelledBy,
+			&i.CancelledAt,
+			&i.PeriodID,
 		); err != nil {
 			return nil, err
 		}
@@ -377,27 +2756,36 @@ func (q *Queries) GetHomeworksByClassID(ctx context.Context, classID pgtype.UUID
 	return items, nil
 }
 
-const getHomeworksByLessonID = `-- name: GetHomeworksByLessonID :many
-SELECT id, teacher_id, lesson_id, class_id, title, content, due_date FROM homeworks WHERE lesson_id = $1
+const getSchedulesByTeacherID = `-- name: GetSchedulesByTeacherID :many
+SELECT id, date, time, end_time, teacher_id, lesson_id, class_id, series_id, occurrence_date, room_id, substitute_teacher_id, status, cancellation_reason, cancelled_by, cancelled_at, period_id FROM schedules WHERE teacher_id = $1
 `
 
-func (q *Queries) GetHomeworksByLessonID(ctx context.Context, lessonID pgtype.UUID) ([]Homework, error) {
-	rows, err := q.db.Query(ctx, getHomeworksByLessonID, lessonID)
+func (q *Queries) GetSchedulesByTeacherID(ctx context.Context, teacherID pgtype.UUID) ([]Schedule, error) {
+	rows, err := q.db.Query(ctx, getSchedulesByTeacherID, teacherID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Homework
+	var items []Schedule
 	for rows.Next() {
-		var i Homework
+		var i Schedule
 		if err := rows.Scan(
 			&i.ID,
+			&i.Date,
+			&i.Time,
+			&i.EndTime,
 			&i.TeacherID,
 			&i.LessonID,
 			&i.ClassID,
-			&i.Title,
-			&i.Content,
-			&i.DueDate,
+			&i.SeriesID,
+			&i.OccurrenceDate,
+			&i.RoomID,
+			&i.SubstituteTeacherID,
+			&i.Status,
+			&i.CancellationReason,
+			&i.CancelledBy,
+			&i.CancelledAt,
+			&i.PeriodID,
 		); err != nil {
 			return nil, err
 		}
@@ -409,27 +2797,51 @@ func (q *Queries) GetHomeworksByLessonID(ctx context.Context, lessonID pgtype.UU
 	return items, nil
 }
 
-const getHomeworksByTeacherID = `-- name: GetHomeworksByTeacherID :many
-SELECT id, teacher_id, lesson_id, class_id, title, content, due_date FROM homeworks WHERE teacher_id = $1
+const getTeacherAbsenceByID = `-- name: GetTeacherAbsenceByID :one
+SELECT id, teacher_id, start_date, end_date, reason, created_at FROM teacher_absences WHERE id = $1
 `
 
-func (q *Queries) GetHomeworksByTeacherID(ctx context.Context, teacherID pgtype.UUID) ([]Homework, error) {
-	rows, err := q.db.Query(ctx, getHomeworksByTeacherID, teacherID)
+func (q *Queries) GetTeacherAbsenceByID(ctx context.Context, id pgtype.UUID) (TeacherAbsence, error) {
+	row := q.db.QueryRow(ctx, getTeacherAbsenceByID, id)
+	var i TeacherAbsence
+	err := row.Scan(
+		&i.ID,
+		&i.TeacherID,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTeacherAbsencesBetween = `-- name: GetTeacherAbsencesBetween :many
+SELECT id, teacher_id, start_date, end_date, reason, created_at FROM teacher_absences
+WHERE end_date >= $1 AND start_date <= $2
+ORDER BY start_date
+`
+
+type GetTeacherAbsencesBetweenParams struct {
+	FromDate pgtype.Date
+	ToDate   pgtype.Date
+}
+
+func (q *Queries) GetTeacherAbsencesBetween(ctx context.Context, arg GetTeacherAbsencesBetweenParams) ([]TeacherAbsence, error) {
+	rows, err := q.db.Query(ctx, getTeacherAbsencesBetween, arg.FromDate, arg.ToDate)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Homework
+	var items []TeacherAbsence
 	for rows.Next() {
-		var i Homework
+		var i TeacherAbsence
 		if err := rows.Scan(
 			&i.ID,
 			&i.TeacherID,
-			&i.LessonID,
-			&i.ClassID,
-			&i.Title,
-			&i.Content,
-			&i.DueDate,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Reason,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -441,55 +2853,83 @@ func (q *Queries) GetHomeworksByTeacherID(ctx context.Context, teacherID pgtype.
 	return items, nil
 }
 
-const getLessonByID = `-- name: GetLessonByID :one
-SELECT id, lesson_name FROM lessons WHERE id = $1
+const getTeacherAbsencesByTeacherID = `-- name: GetTeacherAbsencesByTeacherID :many
+SELECT id, teacher_id, start_date, end_date, reason, created_at FROM teacher_absences WHERE teacher_id = $1 ORDER BY start_date
 `
 
-func (q *Queries) GetLessonByID(ctx context.Context, id pgtype.UUID) (Lesson, error) {
-	row := q.db.QueryRow(ctx, getLessonByID, id)
-	var i Lesson
-	err := row.Scan(&i.ID, &i.LessonName)
-	return i, err
+func (q *Queries) GetTeacherAbsencesByTeacherID(ctx context.Context, teacherID pgtype.UUID) ([]TeacherAbsence, error) {
+	rows, err := q.db.Query(ctx, getTeacherAbsencesByTeacherID, teacherID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TeacherAbsence
+	for rows.Next() {
+		var i TeacherAbsence
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeacherID,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getScheduleByID = `-- name: GetScheduleByID :one
-SELECT id, date, time, teacher_id, lesson_id, class_id FROM schedules WHERE id = $1
+const getTeacherAvailabilityByID = `-- name: GetTeacherAvailabilityByID :one
+SELECT id, teacher_id, kind, weekday, date, start_time, end_time, note, created_at FROM teacher_availability WHERE id = $1
 `
 
-func (q *Queries) GetScheduleByID(ctx context.Context, id pgtype.UUID) (Schedule, error) {
-	row := q.db.QueryRow(ctx, getScheduleByID, id)
-	var i Schedule
+func (q *Queries) GetTeacherAvailabilityByID(ctx context.Context, id pgtype.UUID) (TeacherAvailability, error) {
+	row := q.db.QueryRow(ctx, getTeacherAvailabilityByID, id)
+	var i TeacherAvailability
 	err := row.Scan(
 		&i.ID,
-		&i.Date,
-		&i.Time,
 		&i.TeacherID,
-		&i.LessonID,
-		&i.ClassID,
+		&i.Kind,
+		&i.Weekday,
+		&i.Date,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Note,
+		&i.CreatedAt,
 	)
 	return i, err
 }
 
-const getSchedulesByClassID = `-- name: GetSchedulesByClassID :many
-SELECT id, date, time, teacher_id, lesson_id, class_id FROM schedules WHERE class_id = $1
+const getTeacherAvailabilityByTeacherID = `-- name: GetTeacherAvailabilityByTeacherID :many
+SELECT id, teacher_id, kind, weekday, date, start_time, end_time, note, created_at FROM teacher_availability
+WHERE teacher_id = $1
+ORDER BY date NULLS FIRST, weekday, start_time
 `
 
-func (q *Queries) GetSchedulesByClassID(ctx context.Context, classID pgtype.UUID) ([]Schedule, error) {
-	rows, err := q.db.Query(ctx, getSchedulesByClassID, classID)
+func (q *Queries) GetTeacherAvailabilityByTeacherID(ctx context.Context, teacherID pgtype.UUID) ([]TeacherAvailability, error) {
+	rows, err := q.db.Query(ctx, getTeacherAvailabilityByTeacherID, teacherID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Schedule
+	var items []TeacherAvailability
 	for rows.Next() {
-		var i Schedule
+		var i TeacherAvailability
 		if err := rows.Scan(
 			&i.ID,
-			&i.Date,
-			&i.Time,
 			&i.TeacherID,
-			&i.LessonID,
-			&i.ClassID,
+			&i.Kind,
+			&i.Weekday,
+			&i.Date,
+			&i.StartTime,
+			&i.EndTime,
+			&i.Note,
+			&i.CreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -501,12 +2941,39 @@ func (q *Queries) GetSchedulesByClassID(ctx context.Context, classID pgtype.UUID
 	return items, nil
 }
 
-const getSchedulesByTeacherID = `-- name: GetSchedulesByTeacherID :many
-SELECT id, date, time, teacher_id, lesson_id, class_id FROM schedules WHERE teacher_id = $1
+const getTermByID = `-- name: GetTermByID :one
+SELECT id, name, start_date, end_date FROM terms WHERE id = $1
 `
 
-func (q *Queries) GetSchedulesByTeacherID(ctx context.Context, teacherID pgtype.UUID) ([]Schedule, error) {
-	rows, err := q.db.Query(ctx, getSchedulesByTeacherID, teacherID)
+func (q *Queries) GetTermByID(ctx context.Context, id pgtype.UUID) (Term, error) {
+	row := q.db.QueryRow(ctx, getTermByID, id)
+	var i Term
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StartDate,
+		&i.EndDate,
+	)
+	return i, err
+}
+
+const getUncheckedSchedules = `-- name: GetUncheckedSchedules :many
+SELECT s.id, s.date, s.time, s.end_time, s.teacher_id, s.lesson_id, s.class_id, s.series_id, s.occurrence_date, s.room_id, s.substitute_teacher_id, s.status, s.cancellation_reason, s.cancelled_by, s.cancelled_at, s.period_id FROM schedules s
+LEFT JOIN roll_call_checks r ON r.schedule_id = s.id
+WHERE r.schedule_id IS NULL
+  AND s.status <> 'cancelled'
+  AND s.date >= $1::DATE
+  AND s.date <= $2::DATE
+ORDER BY s.date, s.time
+`
+
+type GetUncheckedSchedulesParams struct {
+	FromDate pgtype.Date
+	ToDate   pgtype.Date
+}
+
+func (q *Queries) GetUncheckedSchedules(ctx context.Context, arg GetUncheckedSchedulesParams) ([]Schedule, error) {
+	rows, err := q.db.Query(ctx, getUncheckedSchedules, arg.FromDate, arg.ToDate)
 	if err != nil {
 		return nil, err
 	}
@@ -518,9 +2985,19 @@ func (q *Queries) GetSchedulesByTeacherID(ctx context.Context, teacherID pgtype.
 			&i.ID,
 			&i.Date,
 			&i.Time,
+			&i.EndTime,
 			&i.TeacherID,
 			&i.LessonID,
 			&i.ClassID,
+			&i.SeriesID,
+			&i.OccurrenceDate,
+			&i.RoomID,
+			&i.SubstituteTeacherID,
+			&i.Status,
+			&i.CancellationReason,
+			&i.CancelledBy,
+			&i.CancelledAt,
+			&i.PeriodID,
 		); err != nil {
 			return nil, err
 		}
@@ -532,22 +3009,47 @@ func (q *Queries) GetSchedulesByTeacherID(ctx context.Context, teacherID pgtype.
 	return items, nil
 }
 
+const resolveAttendanceAlert = `-- name: ResolveAttendanceAlert :exec
+UPDATE attendance_alerts
+SET status = 'resolved',
+    resolved_at = NOW()
+WHERE id = $1 AND status = 'open'
+`
+
+func (q *Queries) ResolveAttendanceAlert(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, resolveAttendanceAlert, id)
+	return err
+}
+
+const resolveRollCallCheck = `-- name: ResolveRollCallCheck :exec
+UPDATE roll_call_checks SET resolved_at = NOW() WHERE schedule_id = $1
+`
+
+func (q *Queries) ResolveRollCallCheck(ctx context.Context, scheduleID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, resolveRollCallCheck, scheduleID)
+	return err
+}
+
 const updateAttendance = `-- name: UpdateAttendance :one
 UPDATE attendances
 SET student_id = $2,
     schedule_id = $3,
-    here = $4,
-    counter = $5
+    counter = $4,
+    status = $5,
+    minutes_late = $6,
+    note = $7
 WHERE id = $1
-RETURNING id, student_id, schedule_id, here, counter
+RETURNING id, student_id, schedule_id, counter, status, minutes_late, note
 `
 
 type UpdateAttendanceParams struct {
-	ID         pgtype.UUID
-	StudentID  pgtype.UUID
-	ScheduleID pgtype.UUID
-	Here       bool
-	Counter    int32
+	ID          pgtype.UUID
+	StudentID   pgtype.UUID
+	ScheduleID  pgtype.UUID
+	Counter     int32
+	Status      string
+	MinutesLate int32
+	Note        string
 }
 
 func (q *Queries) UpdateAttendance(ctx context.Context, arg UpdateAttendanceParams) (Attendance, error) {
@@ -555,16 +3057,343 @@ func (q *Queries) UpdateAttendance(ctx context.Context, arg UpdateAttendancePara
 		arg.ID,
 		arg.StudentID,
 		arg.ScheduleID,
-		arg.Here,
 		arg.Counter,
+		arg.Status,
+		arg.MinutesLate,
+		arg.Note,
 	)
 	var i Attendance
 	err := row.Scan(
 		&i.ID,
 		&i.StudentID,
 		&i.ScheduleID,
-		&i.Here,
 		&i.Counter,
+		&i.Status,
+		&i.MinutesLate,
+		&i.Note,
+	)
+	return i, err
+}
+
+const updateAttendancePolicy = `-- name: UpdateAttendancePolicy :one
+UPDATE attendance_policy
+SET present_weight = $1,
+    late_weight = $2,
+    remote_weight = $3,
+    excused_weight = $4,
+    count_excused = $5,
+    updated_at = NOW()
+WHERE id = 1
+RETURNING id, present_weight, late_weight, remote_weight, excused_weight, count_excused, updated_at
+`
+
+type UpdateAttendancePolicyParams struct {
+	PresentWeight float64
+	LateWeight    float64
+	RemoteWeight  float64
+	ExcusedWeight float64
+	CountExcused  bool
+}
+
+func (q *Queries) UpdateAttendancePolicy(ctx context.Context, arg UpdateAttendancePolicyParams) (AttendancePolicy, error) {
+	row := q.db.QueryRow(ctx, updateAttendancePolicy,
+		arg.PresentWeight,
+		arg.LateWeight,
+		arg.RemoteWeight,
+		arg.ExcusedWeight,
+		arg.CountExcused,
+	)
+	var i AttendancePolicy
+	err := row.Scan(
+		&i.ID,
+		&i.PresentWeight,
+		&i.LateWeight,
+		&i.RemoteWeight,
+		&i.ExcusedWeight,
+		&i.CountExcused,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateAttendanceStatus = `-- name: UpdateAttendanceStatus :exec
+UPDATE attendances
+SET status = $2,
+    minutes_late = $3,
+    counter = $4
+WHERE id = $1
+`
+
+type UpdateAttendanceStatusParams struct {
+	ID          pgtype.UUID
+	Status      string
+	MinutesLate int32
+	Counter     int32
+}
+
+func (q *Queries) UpdateAttendanceStatus(ctx context.Context, arg UpdateAttendanceStatusParams) error {
+	_, err := q.db.Exec(ctx, updateAttendanceStatus,
+		arg.ID,
+		arg.Status,
+		arg.MinutesLate,
+		arg.Counter,
+	)
+	return err
+}
+
+const updateAttendanceThreshold = `-- name: UpdateAttendanceThreshold :one
+UPDATE attendance_thresholds
+SET min_rate = $2,
+    min_lessons = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, lesson_id, min_rate, min_lessons, created_at, updated_at
+`
+
+type UpdateAttendanceThresholdParams struct {
+	ID         pgtype.UUID
+	MinRate    float64
+	MinLessons int32
+}
+
+func (q *Queries) UpdateAttendanceThreshold(ctx context.Context, arg UpdateAttendanceThresholdParams) (AttendanceThreshold, error) {
+	row := q.db.QueryRow(ctx, updateAttendanceThreshold, arg.ID, arg.MinRate, arg.MinLessons)
+	var i AttendanceThreshold
+	err := row.Scan(
+		&i.ID,
+		&i.LessonID,
+		&i.MinRate,
+		&i.MinLessons,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateBellPeriod = `-- name: UpdateBellPeriod :one
+UPDATE bell_periods
+SET name = $2,
+    position = $3,
+    weekdays = $4,
+    start_time = $5,
+    end_time = $6
+WHERE id = $1
+RETURNING id, bell_schedule_id, name, position, weekdays, start_time, end_time
+`
+
+type UpdateBellPeriodParams struct {
+	ID        pgtype.UUID
+	Name      string
+	Position  int32
+	Weekdays  []int32
+	StartTime pgtype.Time
+	EndTime   pgtype.Time
+}
+
+func (q *Queries) UpdateBellPeriod(ctx context.Context, arg UpdateBellPeriodParams) (BellPeriod, error) {
+	row := q.db.QueryRow(ctx, updateBellPeriod,
+		arg.ID,
+		arg.Name,
+		arg.Position,
+		arg.Weekdays,
+		arg.StartTime,
+		arg.EndTime,
+	)
+	var i BellPeriod
+	err := row.Scan(
+		&i.ID,
+		&i.BellScheduleID,
+		&i.Name,
+		&i.Position,
+		&i.Weekdays,
+		&i.StartTime,
+		&i.EndTime,
+	)
+	return i, err
+}
+
+const updateBellSchedule = `-- name: UpdateBellSchedule :one
+UPDATE bell_schedules
+SET name = $2,
+    active = $3
+WHERE id = $1
+RETURNING id, name, active, created_at
+`
+
+type UpdateBellScheduleParams struct {
+	ID     pgtype.UUID
+	Name   string
+	Active bool
+}
+
+func (q *Queries) UpdateBellSchedule(ctx context.Context, arg UpdateBellScheduleParams) (BellSchedule, error) {
+	row := q.db.QueryRow(ctx, updateBellSchedule, arg.ID, arg.Name, arg.Active)
+	var i BellSchedule
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Active,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateClosure = `-- name: UpdateClosure :one
+UPDATE closures
+SET name = $2,
+    kind = $3,
+    start_date = $4,
+    end_date = $5
+WHERE id = $1
+RETURNING id, name, kind, start_date, end_date
+`
+
+type UpdateClosureParams struct {
+	ID        pgtype.UUID
+	Name      string
+	Kind      string
+	StartDate pgtype.Date
+	EndDate   pgtype.Date
+}
+
+func (q *Queries) UpdateClosure(ctx context.Context, arg UpdateClosureParams) (Closure, error) {
+	row := q.db.QueryRow(ctx, updateClosure,
+		arg.ID,
+		arg.Name,
+		arg.Kind,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	var i Closure
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.StartDate,
+		&i.EndDate,
+	)
+	return i, err
+}
+
+const updateExam = `-- name: UpdateExam :one
+UPDATE exams
+SET lesson_id = $2,
+    class_id = $3,
+    title = $4,
+    date = $5,
+    start_time = $6,
+    end_time = $7,
+    room_id = $8,
+    invigilator_ids = $9
+WHERE id = $1
+RETURNING id, lesson_id, class_id, title, date, start_time, end_time, room_id, invigilator_ids, created_at
+`
+
+type UpdateExamParams struct {
+	ID             pgtype.UUID
+	LessonID       pgtype.UUID
+	ClassID        pgtype.UUID
+	Title          string
+	Date           pgtype.Date
+	StartTime      pgtype.Time
+	EndTime        pgtype.Time
+	RoomID         pgtype.UUID
+	InvigilatorIds []pgtype.UUID
+}
+
+func (q *Queries) UpdateExam(ctx context.Context, arg UpdateExamParams) (Exam, error) {
+	row := q.db.QueryRow(ctx, updateExam,
+		arg.ID,
+		arg.LessonID,
+		arg.ClassID,
+		arg.Title,
+		arg.Date,
+		arg.StartTime,
+		arg.EndTime,
+		arg.RoomID,
+		arg.InvigilatorIds,
+	)
+	var i Exam
+	err := row.Scan(
+		&i.ID,
+		&i.LessonID,
+		&i.ClassID,
+		&i.Title,
+		&i.Date,
+		&i.StartTime,
+		&i.EndTime,
+		&i.RoomID,
+		&i.InvigilatorIds,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateExamRules = `-- name: UpdateExamRules :one
+UPDATE exam_rules
+SET max_exams_per_day = $1,
+    min_gap_minutes = $2,
+    updated_at = NOW()
+WHERE id = 1
+RETURNING id, max_exams_per_day, min_gap_minutes, updated_at
+`
+
+type UpdateExamRulesParams struct {
+	MaxExamsPerDay int32
+	MinGapMinutes  int32
+}
+
+func (q *Queries) UpdateExamRules(ctx context.Context, arg UpdateExamRulesParams) (ExamRule, error) {
+	row := q.db.QueryRow(ctx, updateExamRules, arg.MaxExamsPerDay, arg.MinGapMinutes)
+	var i ExamRule
+	err := row.Scan(
+		&i.ID,
+		&i.MaxExamsPerDay,
+		&i.MinGapMinutes,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateExcuseReview = `-- name: UpdateExcuseReview :one
+UPDATE excuses
+SET status = $2,
+    reviewed_by = $3,
+    review_note = $4,
+    reviewed_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING id, student_id, submitted_by, reason, schedule_ids, from_date, to_date, document_url, status, reviewed_by, review_note, reviewed_at, created_at
+`
+
+type UpdateExcuseReviewParams struct {
+	ID         pgtype.UUID
+	Status     string
+	ReviewedBy pgtype.UUID
+	ReviewNote string
+}
+
+func (q *Queries) UpdateExcuseReview(ctx context.Context, arg UpdateExcuseReviewParams) (Excuse, error) {
+	row := q.db.QueryRow(ctx, updateExcuseReview,
+		arg.ID,
+		arg.Status,
+		arg.ReviewedBy,
+		arg.ReviewNote,
+	)
+	var i Excuse
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.SubmittedBy,
+		&i.Reason,
+		&i.ScheduleIds,
+		&i.FromDate,
+		&i.ToDate,
+		&i.DocumentUrl,
+		&i.Status,
+		&i.ReviewedBy,
+		&i.ReviewNote,
+		&i.ReviewedAt,
+		&i.CreatedAt,
 	)
 	return i, err
 }
@@ -633,24 +3462,77 @@ func (q *Queries) UpdateLesson(ctx context.Context, arg UpdateLessonParams) (Les
 	return i, err
 }
 
+const updateRoom = `-- name: UpdateRoom :one
+UPDATE rooms
+SET name = $2,
+    capacity = $3,
+    features = $4
+WHERE id = $1
+RETURNING id, name, capacity, features
+`
+
+type UpdateRoomParams struct {
+	ID       pgtype.UUID
+	Name     string
+	Capacity int32
+	Features []string
+}
+
+func (q *Queries) UpdateRoom(ctx context.Context, arg UpdateRoomParams) (Room, error) {
+	row := q.db.QueryRow(ctx, updateRoom,
+		arg.ID,
+		arg.Name,
+		arg.Capacity,
+		arg.Features,
+	)
+	var i Room
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Capacity,
+		&i.Features,
+	)
+	return i, err
+}
+
 const updateSchedule = `-- name: UpdateSchedule :one
 UPDATE schedules
 SET date = $2,
     time = $3,
-    teacher_id = $4,
-    lesson_id = $5,
-    class_id = $6
+    end_time = $4,
+    teacher_id = $5,
+    lesson_id = $6,
+    class_id = $7,
+    series_id = $8,
+    occurrence_date = $9,
+    room_id = $10,
+    substitute_teacher_id = $11,
+    status = $12,
+    cancellation_reason = $13,
+    cancelled_by = $14,
+    cancelled_at = $15,
+    period_id = $16
 WHERE id = $1
-RETURNING id, date, time, teacher_id, lesson_id, class_id
+RETURNING id, date, time, end_time, teacher_id, lesson_id, class_id, series_id, occurrence_date, room_id, substitute_teacher_id, status, cancellation_reason, cancelled_by, cancelled_at, period_id
 `
 
 type UpdateScheduleParams struct {
-	ID        pgtype.UUID
-	Date      pgtype.Date
-	Time      pgtype.Time
-	TeacherID pgtype.UUID
-	LessonID  pgtype.UUID
-	ClassID   pgtype.UUID
+	ID                  pgtype.UUID
+	Date                pgtype.Date
+	Time                pgtype.Time
+	EndTime             pgtype.Time
+	TeacherID           pgtype.UUID
+	LessonID            pgtype.UUID
+	ClassID             pgtype.UUID
+	SeriesID            pgtype.UUID
+	OccurrenceDate      pgtype.Date
+	RoomID              pgtype.UUID
+	SubstituteTeacherID pgtype.UUID
+	Status              string
+	CancellationReason  string
+	CancelledBy         pgtype.UUID
+	CancelledAt         pgtype.Timestamp
+	PeriodID            pgtype.UUID
 }
 
 func (q *Queries) UpdateSchedule(ctx context.Context, arg UpdateScheduleParams) (Schedule, error) {
@@ -658,18 +3540,253 @@ func (q *Queries) UpdateSchedule(ctx context.Context, arg UpdateScheduleParams)
 		arg.ID,
 		arg.Date,
 		arg.Time,
+		arg.EndTime,
 		arg.TeacherID,
 		arg.LessonID,
 		arg.ClassID,
+		arg.SeriesID,
+		arg.OccurrenceDate,
+		arg.RoomID,
+		arg.SubstituteTeacherID,
+		arg.Status,
+		arg.CancellationReason,
+		arg.CancelledBy,
+		arg.CancelledAt,
+		arg.PeriodID,
 	)
 	var i Schedule
 	err := row.Scan(
 		&i.ID,
 		&i.Date,
 		&i.Time,
+		&i.EndTime,
+		&i.TeacherID,
+		&i.LessonID,
+		&i.ClassID,
+		&i.SeriesID,
+		&i.OccurrenceDate,
+		&i.RoomID,
+		&i.SubstituteTeacherID,
+		&i.Status,
+		&i.CancellationReason,
+		&i.CancelledBy,
+		&i.CancelledAt,
+		&i.PeriodID,
+	)
+	return i, err
+}
+
+const updateScheduleSeries = `-- name: UpdateScheduleSeries :one
+UPDATE schedule_series
+SET teacher_id = $2,
+    lesson_id = $3,
+    class_id = $4,
+    start_date = $5,
+    time = $6,
+    end_time = $7,
+    weekdays = $8,
+    until_date = $9,
+    occurrence_count = $10,
+    exception_dates = $11,
+    room_id = $12
+WHERE id = $1
+RETURNING id, teacher_id, lesson_id, class_id, start_date, time, end_time, weekdays, until_date, occurrence_count, exception_dates, room_id
+`
+
+type UpdateScheduleSeriesParams struct {
+	ID              pgtype.UUID
+	TeacherID       pgtype.UUID
+	LessonID        pgtype.UUID
+	ClassID         pgtype.UUID
+	StartDate       pgtype.Date
+	Time            pgtype.Time
+	EndTime         pgtype.Time
+	Weekdays        []int32
+	UntilDate       pgtype.Date
+	OccurrenceCount pgtype.Int4
+	ExceptionDates  []pgtype.Date
+	RoomID          pgtype.UUID
+}
+
+func (q *Queries) UpdateScheduleSeries(ctx context.Context, arg UpdateScheduleSeriesParams) (ScheduleSeries, error) {
+	row := q.db.QueryRow(ctx, updateScheduleSeries,
+		arg.ID,
+		arg.TeacherID,
+		arg.LessonID,
+		arg.ClassID,
+		arg.StartDate,
+		arg.Time,
+		arg.EndTime,
+		arg.Weekdays,
+		arg.UntilDate,
+		arg.OccurrenceCount,
+		arg.ExceptionDates,
+		arg.RoomID,
+	)
+	var i ScheduleSeries
+	err := row.Scan(
+		&i.ID,
 		&i.TeacherID,
 		&i.LessonID,
 		&i.ClassID,
+		&i.StartDate,
+		&i.Time,
+		&i.EndTime,
+		&i.Weekdays,
+		&i.UntilDate,
+		&i.OccurrenceCount,
+		&i.ExceptionDates,
+		&i.RoomID,
+	)
+	return i, err
+}
+
+const updateTeacherAvailability = `-- name: UpdateTeacherAvailability :one
+UPDATE teacher_availability
+SET kind = $2,
+    weekday = $3,
+    date = $4,
+    start_time = $5,
+    end_time = $6,
+    note = $7
+WHERE id = $1
+RETURNING id, teacher_id, kind, weekday, date, start_time, end_time, note, created_at
+`
+
+type UpdateTeacherAvailabilityParams struct {
+	ID        pgtype.UUID
+	Kind      string
+	Weekday   pgtype.Int4
+	Date      pgtype.Date
+	StartTime pgtype.Time
+	EndTime   pgtype.Time
+	Note      string
+}
+
+func (q *Queries) UpdateTeacherAvailability(ctx context.Context, arg UpdateTeacherAvailabilityParams) (TeacherAvailability, error) {
+	row := q.db.QueryRow(ctx, updateTeacherAvailability,
+		arg.ID,
+		arg.Kind,
+		arg.Weekday,
+		arg.Date,
+		arg.StartTime,
+		arg.EndTime,
+		arg.Note,
+	)
+	var i TeacherAvailability
+	err := row.Scan(
+		&i.ID,
+		&i.TeacherID,
+		&i.Kind,
+		&i.Weekday,
+		&i.Date,
+		&i.StartTime,
+		&i.EndTime,
+		&i.Note,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const updateTerm = `-- name: UpdateTerm :one
+UPDATE terms
+SET name = $2,
+    start_date = $3,
+    end_date = $4
+WHERE id = $1
+RETURNING id, name, start_date, end_date
+`
+
+type UpdateTermParams struct {
+	ID        pgtype.UUID
+	Name      string
+	StartDate pgtype.Date
+	EndDate   pgtype.Date
+}
+
+func (q *Queries) UpdateTerm(ctx context.Context, arg UpdateTermParams) (Term, error) {
+	row := q.db.QueryRow(ctx, updateTerm,
+		arg.ID,
+		arg.Name,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	var i Term
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StartDate,
+		&i.EndDate,
+	)
+	return i, err
+}
+
+const updateWorkloadLimit = `-- name: UpdateWorkloadLimit :one
+UPDATE workload_limits
+SET max_weekly_minutes = $2,
+    max_consecutive_lessons = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, teacher_id, max_weekly_minutes, max_consecutive_lessons, updated_at
+`
+
+type UpdateWorkloadLimitParams struct {
+	ID                    pgtype.UUID
+	MaxWeeklyMinutes      int32
+	MaxConsecutiveLessons int32
+}
+
+func (q *Queries) UpdateWorkloadLimit(ctx context.Context, arg UpdateWorkloadLimitParams) (WorkloadLimit, error) {
+	row := q.db.QueryRow(ctx, updateWorkloadLimit, arg.ID, arg.MaxWeeklyMinutes, arg.MaxConsecutiveLessons)
+	var i WorkloadLimit
+	err := row.Scan(
+		&i.ID,
+		&i.TeacherID,
+		&i.MaxWeeklyMinutes,
+		&i.MaxConsecutiveLessons,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertAttendance = `-- name: UpsertAttendance :one
+INSERT INTO attendances (student_id, schedule_id, counter, status, minutes_late, note)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (student_id, schedule_id) DO UPDATE
+SET counter = EXCLUDED.counter,
+    status = EXCLUDED.status,
+    minutes_late = EXCLUDED.minutes_late,
+    note = EXCLUDED.note
+RETURNING id, student_id, schedule_id, counter, status, minutes_late, note
+`
+
+type UpsertAttendanceParams struct {
+	StudentID   pgtype.UUID
+	ScheduleID  pgtype.UUID
+	Counter     int32
+	Status      string
+	MinutesLate int32
+	Note        string
+}
+
+func (q *Queries) UpsertAttendance(ctx context.Context, arg UpsertAttendanceParams) (Attendance, error) {
+	row := q.db.QueryRow(ctx, upsertAttendance,
+		arg.StudentID,
+		arg.ScheduleID,
+		arg.Counter,
+		arg.Status,
+		arg.MinutesLate,
+		arg.Note,
+	)
+	var i Attendance
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.ScheduleID,
+		&i.Counter,
+		&i.Status,
+		&i.MinutesLate,
+		&i.Note,
 	)
 	return i, err
 }
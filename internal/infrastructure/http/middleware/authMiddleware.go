@@ -80,6 +80,11 @@ func (am AuthMiddleware) AuthMiddleware() fiber.Handler {
 
 		c.Locals("userID", userID)
 		c.Locals("userRoles", roles)
+
+		// OIDC zoneinfo claim, the user's preferred display time zone
+		if zoneinfo, ok := claims["zoneinfo"].(string); ok {
+			c.Locals("userTimezone", zoneinfo)
+		}
 		c.Locals("isAuthenticated", true)
 
 		return c.Next()
@@ -8,7 +8,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func SetupRoutes(app *fiber.App, kh handler.KeycloakHandler, kch handler.KeycloakClassHandler, sh *handlers.ScheduleHandler, ah *handlers.AttendanceHandler, lh *handlers.LessonHandler, hwh *handlers.HomeworkHandler, authMiddleware middleware.AuthMiddleware) {
+func SetupRoutes(app *fiber.App, kh handler.KeycloakHandler, kch handler.KeycloakClassHandler, sh *handlers.ScheduleHandler, ah *handlers.AttendanceHandler, lh *handlers.LessonHandler, hwh *handlers.HomeworkHandler, rh *handlers.RoomHandler, th *handlers.TimetableHandler, ch *handlers.CalendarHandler, ih *handlers.ImportHandler, ach *handlers.AcademicCalendarHandler, subh *handlers.SubstitutionHandler, avh *handlers.AvailabilityHandler, wlh *handlers.WorkloadHandler, bsh *handlers.BellScheduleHandler, sth *handlers.StudentTimetableHandler, eh *handlers.ExamHandler, exh *handlers.ExcuseHandler, aah *handlers.AttendanceAlertHandler, cih *handlers.CheckInHandler, arh *handlers.AttendanceReportHandler, aeh *handlers.AttendanceExportHandler, rch *handlers.RollCallHandler, authMiddleware middleware.AuthMiddleware) {
 	api := app.Group("/v1/api")
 
 	// Auth routes
@@ -40,24 +40,41 @@ func SetupRoutes(app *fiber.App, kh handler.KeycloakHandler, kch handler.Keycloa
 	schedule.Use(authMiddleware.AuthMiddleware())
 	schedule.Post("/create", authMiddleware.HasRole("admin", "teacher"), sh.CreateScheduleHandler)
 	schedule.Get("/all", authMiddleware.HasRole("admin", "teacher", "student"), sh.GetAllSchedulesHandler)
-	schedule.Get("/:id", authMiddleware.HasRole("admin", "teacher", "student"), sh.GetScheduleByIDHandler)
 	schedule.Put("/update/:id", authMiddleware.HasRole("admin", "teacher"), sh.UpdateScheduleHandler)
 	schedule.Delete("/delete/:id", authMiddleware.HasRole("admin", "teacher"), sh.DeleteScheduleHandler)
 	schedule.Post("/check-conflicts", authMiddleware.HasRole("admin", "teacher"), sh.CheckConflictsHandler)
+	schedule.Post("/find-slots", authMiddleware.HasRole("admin", "teacher"), sh.FindSlotsHandler)
 	schedule.Put("/reschedule/:id", authMiddleware.HasRole("admin", "teacher"), sh.RescheduleHandler)
+	schedule.Post("/reschedule/bulk", authMiddleware.HasRole("admin"), sh.BulkRescheduleHandler)
+	schedule.Put("/cancel/:id", authMiddleware.HasRole("admin", "teacher"), sh.CancelScheduleHandler)
 	schedule.Get("/week", authMiddleware.HasRole("admin", "teacher", "student"), sh.GetWeekSchedulesHandler)
 	schedule.Get("/upcoming/:teacherId", authMiddleware.HasRole("admin", "teacher", "student"), sh.GetUpcomingSchedulesHandler)
+	schedule.Get("/room/:roomID", authMiddleware.HasRole("admin", "teacher", "student"), sh.GetSchedulesByRoomIDHandler)
+	schedule.Post("/series/create", authMiddleware.HasRole("admin", "teacher"), sh.CreateScheduleSeriesHandler)
+	schedule.Get("/series/all", authMiddleware.HasRole("admin", "teacher", "student"), sh.GetAllScheduleSeriesHandler)
+	schedule.Get("/series/:id", authMiddleware.HasRole("admin", "teacher", "student"), sh.GetScheduleSeriesByIDHandler)
+	schedule.Put("/series/update/:id", authMiddleware.HasRole("admin", "teacher"), sh.UpdateScheduleSeriesHandler)
+	schedule.Delete("/series/delete/:id", authMiddleware.HasRole("admin", "teacher"), sh.DeleteScheduleSeriesHandler)
+	schedule.Post("/series/materialize/:id", authMiddleware.HasRole("admin", "teacher"), sh.MaterializeOccurrenceHandler)
+	schedule.Put("/series/cancel/:id", authMiddleware.HasRole("admin", "teacher"), sh.CancelOccurrenceHandler)
+	schedule.Post("/import", authMiddleware.HasRole("admin"), ih.ImportSchedulesHandler)
+	schedule.Get("/:id", authMiddleware.HasRole("admin", "teacher", "student"), sh.GetScheduleByIDHandler)
 
 	// Attendance routes
 	attendance := api.Group("/attendance")
 	attendance.Use(authMiddleware.AuthMiddleware())
 	attendance.Post("/create", authMiddleware.HasRole("teacher"), ah.CreateAttendanceHandler)
 	attendance.Post("/mark", authMiddleware.HasRole("teacher"), ah.MarkAttendanceHandler)
+	attendance.Post("/roll-call/:scheduleID", authMiddleware.HasRole("teacher"), ah.TakeRollCallHandler)
+	attendance.Get("/roll-call/:scheduleID", authMiddleware.HasRole("admin", "teacher"), ah.GetRosterHandler)
+	attendance.Get("/policy", authMiddleware.HasRole("admin", "teacher"), ah.GetAttendancePolicyHandler)
+	attendance.Put("/policy", authMiddleware.HasRole("admin"), ah.SetAttendancePolicyHandler)
 
 	attendance.Get("/:id", authMiddleware.HasRole("admin", "teacher", "student"), ah.GetAttendanceByIDHandler)
 	attendance.Put("/update/:id", authMiddleware.HasRole("teacher"), ah.UpdateAttendanceHandler)
 	attendance.Delete("/delete/:id", authMiddleware.HasRole("teacher"), ah.DeleteAttendanceHandler)
 	attendance.Get("/student/:studentID", authMiddleware.HasRole("student", "teacher", "admin"), ah.GetAttendanceByStudentIDHandler)
+	attendance.Get("/student/:studentID/summary", authMiddleware.HasRole("student", "teacher", "admin"), ah.GetAttendanceSummaryHandler)
 
 	// Lesson routes
 	lesson := api.Group("/lesson")
@@ -83,4 +100,143 @@ func SetupRoutes(app *fiber.App, kh handler.KeycloakHandler, kch handler.Keycloa
 	homework.Get("/overdue", authMiddleware.HasRole("admin", "teacher", "student"), hwh.GetOverdueHomeworksHandler)
 	homework.Get("/due-soon", authMiddleware.HasRole("admin", "teacher", "student"), hwh.GetHomeworksDueSoonHandler)
 	homework.Put("/extend/:id", authMiddleware.HasRole("teacher"), hwh.ExtendDueDateHandler)
+
+	// Room routes
+	room := api.Group("/room")
+	room.Use(authMiddleware.AuthMiddleware())
+	room.Post("/create", authMiddleware.HasRole("admin"), rh.CreateRoomHandler)
+	room.Get("/all", authMiddleware.HasRole("admin", "teacher", "student"), rh.GetAllRoomsHandler)
+	room.Get("/search", authMiddleware.HasRole("admin", "teacher"), rh.FindRoomsHandler)
+	room.Get("/:id", authMiddleware.HasRole("admin", "teacher", "student"), rh.GetRoomByIDHandler)
+	room.Put("/update/:id", authMiddleware.HasRole("admin"), rh.UpdateRoomHandler)
+	room.Delete("/delete/:id", authMiddleware.HasRole("admin"), rh.DeleteRoomHandler)
+
+	// Timetable routes
+	timetable := api.Group("/timetable")
+	timetable.Use(authMiddleware.AuthMiddleware())
+	timetable.Post("/generate", authMiddleware.HasRole("admin"), th.GenerateTimetableHandler)
+	timetable.Post("/commit", authMiddleware.HasRole("admin"), th.CommitTimetableHandler)
+
+	// Calendar routes, the feed itself is authorized by its token
+	api.Get("/calendar/feed/:token", ch.GetFeedHandler)
+	calendar := api.Group("/calendar/feeds")
+	calendar.Use(authMiddleware.AuthMiddleware())
+	calendar.Post("/", authMiddleware.HasRole("admin", "teacher", "student"), ch.CreateFeedHandler)
+	calendar.Get("/", authMiddleware.HasRole("admin", "teacher", "student"), ch.GetMyFeedsHandler)
+	calendar.Delete("/:id", authMiddleware.HasRole("admin", "teacher", "student"), ch.RevokeFeedHandler)
+
+	// Academic calendar routes
+	academic := api.Group("/academic-calendar")
+	academic.Use(authMiddleware.AuthMiddleware())
+	academic.Post("/terms/create", authMiddleware.HasRole("admin"), ach.CreateTermHandler)
+	academic.Get("/terms/all", authMiddleware.HasRole("admin", "teacher", "student"), ach.GetAllTermsHandler)
+	academic.Get("/terms/:id", authMiddleware.HasRole("admin", "teacher", "student"), ach.GetTermByIDHandler)
+	academic.Put("/terms/update/:id", authMiddleware.HasRole("admin"), ach.UpdateTermHandler)
+	academic.Delete("/terms/delete/:id", authMiddleware.HasRole("admin"), ach.DeleteTermHandler)
+	academic.Post("/closures/create", authMiddleware.HasRole("admin"), ach.CreateClosureHandler)
+	academic.Get("/closures/all", authMiddleware.HasRole("admin", "teacher", "student"), ach.GetAllClosuresHandler)
+	academic.Get("/closures/:id", authMiddleware.HasRole("admin", "teacher", "student"), ach.GetClosureByIDHandler)
+	academic.Put("/closures/update/:id", authMiddleware.HasRole("admin"), ach.UpdateClosureHandler)
+	academic.Delete("/closures/delete/:id", authMiddleware.HasRole("admin"), ach.DeleteClosureHandler)
+
+	// Substitution routes
+	substitution := api.Group("/substitution")
+	substitution.Use(authMiddleware.AuthMiddleware())
+	substitution.Post("/absences/create", authMiddleware.HasRole("admin"), subh.MarkTeacherAbsentHandler)
+	substitution.Get("/absences/teacher/:teacherId", authMiddleware.HasRole("admin", "teacher"), subh.GetTeacherAbsencesHandler)
+	substitution.Get("/absences/:id/affected", authMiddleware.HasRole("admin"), subh.GetAffectedSchedulesHandler)
+	substitution.Delete("/absences/delete/:id", authMiddleware.HasRole("admin"), subh.DeleteTeacherAbsenceHandler)
+	substitution.Post("/propose", authMiddleware.HasRole("admin"), subh.ProposeSubstitutesHandler)
+	substitution.Post("/assign", authMiddleware.HasRole("admin"), subh.AssignSubstituteHandler)
+	substitution.Delete("/remove/:scheduleId", authMiddleware.HasRole("admin"), subh.RemoveSubstituteHandler)
+
+	// Availability routes, teachers manage their own windows
+	availability := api.Group("/availability")
+	availability.Use(authMiddleware.AuthMiddleware())
+	availability.Post("/create", authMiddleware.HasRole("admin", "teacher"), avh.CreateAvailabilityWindowHandler)
+	availability.Get("/me", authMiddleware.HasRole("teacher"), avh.GetMyAvailabilityHandler)
+	availability.Get("/teacher/:teacherId", authMiddleware.HasRole("admin", "teacher"), avh.GetTeacherAvailabilityHandler)
+	availability.Put("/update/:id", authMiddleware.HasRole("admin", "teacher"), avh.UpdateAvailabilityWindowHandler)
+	availability.Delete("/delete/:id", authMiddleware.HasRole("admin", "teacher"), avh.DeleteAvailabilityWindowHandler)
+
+	// Workload routes
+	workload := api.Group("/workload")
+	workload.Use(authMiddleware.AuthMiddleware())
+	workload.Post("/limits", authMiddleware.HasRole("admin"), wlh.SetWorkloadLimitHandler)
+	workload.Get("/limits", authMiddleware.HasRole("admin"), wlh.GetWorkloadLimitsHandler)
+	workload.Delete("/limits/:id", authMiddleware.HasRole("admin"), wlh.DeleteWorkloadLimitHandler)
+	workload.Get("/report", authMiddleware.HasRole("admin"), wlh.GetWorkloadReportHandler)
+
+	// Bell schedule routes
+	bellSchedule := api.Group("/bell-schedule")
+	bellSchedule.Use(authMiddleware.AuthMiddleware())
+	bellSchedule.Post("/create", authMiddleware.HasRole("admin"), bsh.CreateBellScheduleHandler)
+	bellSchedule.Get("/all", authMiddleware.HasRole("admin", "teacher", "student"), bsh.GetAllBellSchedulesHandler)
+	bellSchedule.Get("/active", authMiddleware.HasRole("admin", "teacher", "student"), bsh.GetActiveBellScheduleHandler)
+	bellSchedule.Put("/update/:id", authMiddleware.HasRole("admin"), bsh.UpdateBellScheduleHandler)
+	bellSchedule.Delete("/delete/:id", authMiddleware.HasRole("admin"), bsh.DeleteBellScheduleHandler)
+	bellSchedule.Get("/:id", authMiddleware.HasRole("admin", "teacher", "student"), bsh.GetBellScheduleByIDHandler)
+
+	// Exam routes
+	exam := api.Group("/exam")
+	exam.Use(authMiddleware.AuthMiddleware())
+	exam.Post("/create", authMiddleware.HasRole("admin", "teacher"), eh.CreateExamHandler)
+	exam.Get("/rules", authMiddleware.HasRole("admin", "teacher"), eh.GetExamRulesHandler)
+	exam.Put("/rules", authMiddleware.HasRole("admin"), eh.SetExamRulesHandler)
+	exam.Get("/class/:classID", authMiddleware.HasRole("admin", "teacher", "student"), eh.GetClassExamCalendarHandler)
+	exam.Put("/update/:id", authMiddleware.HasRole("admin", "teacher"), eh.UpdateExamHandler)
+	exam.Delete("/delete/:id", authMiddleware.HasRole("admin", "teacher"), eh.DeleteExamHandler)
+	exam.Get("/:id", authMiddleware.HasRole("admin", "teacher", "student"), eh.GetExamByIDHandler)
+
+	// Excuse routes
+	excuse := api.Group("/excuse")
+	excuse.Use(authMiddleware.AuthMiddleware())
+	excuse.Post("/submit", authMiddleware.HasRole("admin", "teacher", "student"), exh.SubmitExcuseHandler)
+	excuse.Get("/pending", authMiddleware.HasRole("admin", "teacher"), exh.GetPendingExcusesHandler)
+	excuse.Get("/student/:studentID", authMiddleware.HasRole("admin", "teacher"), exh.GetExcusesByStudentIDHandler)
+	excuse.Get("/audit/:attendanceID", authMiddleware.HasRole("admin", "teacher"), exh.GetAttendanceAuditsHandler)
+	excuse.Post("/approve/:id", authMiddleware.HasRole("admin", "teacher"), exh.ApproveExcuseHandler)
+	excuse.Post("/reject/:id", authMiddleware.HasRole("admin", "teacher"), exh.RejectExcuseHandler)
+	excuse.Get("/:id", authMiddleware.HasRole("admin", "teacher"), exh.GetExcuseByIDHandler)
+
+	// Attendance alert routes
+	attendanceAlert := api.Group("/attendance-alert")
+	attendanceAlert.Use(authMiddleware.AuthMiddleware())
+	attendanceAlert.Post("/thresholds", authMiddleware.HasRole("admin"), aah.CreateThresholdHandler)
+	attendanceAlert.Get("/thresholds", authMiddleware.HasRole("admin", "teacher"), aah.GetThresholdsHandler)
+	attendanceAlert.Put("/thresholds/:id", authMiddleware.HasRole("admin"), aah.UpdateThresholdHandler)
+	attendanceAlert.Delete("/thresholds/:id", authMiddleware.HasRole("admin"), aah.DeleteThresholdHandler)
+	attendanceAlert.Post("/evaluate", authMiddleware.HasRole("admin"), aah.EvaluateAlertsHandler)
+	attendanceAlert.Get("/at-risk", authMiddleware.HasRole("admin", "teacher"), aah.GetAtRiskStudentsHandler)
+
+	// Check-in routes, teachers open a session and students check themselves in
+	checkIn := api.Group("/check-in")
+	checkIn.Use(authMiddleware.AuthMiddleware())
+	checkIn.Post("/open/:scheduleID", authMiddleware.HasRole("admin", "teacher"), cih.OpenSessionHandler)
+	checkIn.Post("/submit", authMiddleware.HasRole("student"), cih.CheckInHandler)
+	checkIn.Get("/:id/code", authMiddleware.HasRole("admin", "teacher"), cih.GetCurrentCodeHandler)
+	checkIn.Get("/:id/check-ins", authMiddleware.HasRole("admin", "teacher"), cih.GetCheckInsHandler)
+	checkIn.Post("/:id/close", authMiddleware.HasRole("admin", "teacher"), cih.CloseSessionHandler)
+
+	// Attendance report routes
+	attendanceReport := api.Group("/attendance-report")
+	attendanceReport.Use(authMiddleware.AuthMiddleware())
+	attendanceReport.Get("/", authMiddleware.HasRole("admin", "teacher"), arh.GetAttendanceReportHandler)
+	attendanceReport.Get("/export/:classID", authMiddleware.HasRole("admin", "teacher"), aeh.ExportAttendanceHandler)
+
+	// Roll call reminders, raised when students were marked absent because
+	// no attendance was taken
+	rollCall := api.Group("/roll-call-reminder")
+	rollCall.Use(authMiddleware.AuthMiddleware())
+	rollCall.Get("/", authMiddleware.HasRole("admin", "teacher"), rch.GetRemindersHandler)
+	rollCall.Post("/check", authMiddleware.HasRole("admin"), rch.CheckEndedLessonsHandler)
+	rollCall.Post("/:scheduleID/resolve", authMiddleware.HasRole("admin", "teacher"), rch.ResolveReminderHandler)
+
+	// Personal routes, resolved from the token
+	me := api.Group("/me")
+	me.Use(authMiddleware.AuthMiddleware())
+	me.Get("/timetable", authMiddleware.HasRole("student"), sth.GetMyTimetableHandler)
+	me.Get("/exams", authMiddleware.HasRole("student"), eh.GetMyExamCalendarHandler)
+	me.Get("/excuses", authMiddleware.HasRole("student"), exh.GetMyExcusesHandler)
+	me.Get("/attendance-alerts", authMiddleware.HasRole("admin", "teacher"), aah.GetMyAlertsHandler)
 }
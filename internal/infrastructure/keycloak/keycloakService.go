@@ -24,13 +24,14 @@ var (
 )
 
 func NewKeycloakAuthService(hostname, clientId, clientSecret, realm string) (models.KeycloakService, models.ClassService) {
-	return &KeycloakAuthService{
+	service := &KeycloakAuthService{
 		Gocloak:      gocloak.NewClient(hostname),
 		ClientId:     clientId,
 		ClientSecret: clientSecret,
 		Realm:        realm,
 		Hostname:     hostname,
-	}, nil
+	}
+	return service, service
 }
 
 func (kc *KeycloakAuthService) Login(login models.Login) (*models.LoginResponse, error) {
@@ -332,6 +333,15 @@ func (kc *KeycloakAuthService) UpdateClass(class *models.Class) error {
 }
 
 func (kc *KeycloakAuthService) GetClassesByTeacherID(teacherID string) ([]models.Class, error) {
+	return kc.getUserClasses(teacherID)
+}
+
+// GetClassesByStudentID returns the classes (Keycloak groups) the student is a member of
+func (kc *KeycloakAuthService) GetClassesByStudentID(studentID string) ([]models.Class, error) {
+	return kc.getUserClasses(studentID)
+}
+
+func (kc *KeycloakAuthService) getUserClasses(userID string) ([]models.Class, error) {
 	ctx := context.Background()
 	adminToken, err := kc.Gocloak.LoginAdmin(ctx, ADMIN_USERNAME, ADMIN_PASSWORD, KEYCLOAK_ADMIN_REALM)
 	if err != nil {
@@ -340,7 +350,7 @@ func (kc *KeycloakAuthService) GetClassesByTeacherID(teacherID string) ([]models
 
 	groupParams := gocloak.GetGroupsParams{}
 
-	groupById, err := kc.Gocloak.GetUserGroups(ctx, adminToken.AccessToken, kc.Realm, teacherID, groupParams)
+	groupById, err := kc.Gocloak.GetUserGroups(ctx, adminToken.AccessToken, kc.Realm, userID, groupParams)
 	if err != nil {
 		return nil, fmt.Errorf("get groups by id fail:%w", err)
 
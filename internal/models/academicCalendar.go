@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Closure kinds
+const (
+	ClosureHoliday = "holiday"
+	ClosureSchool  = "closure"
+)
+
+// Term is a teaching period. Once terms exist, lessons can only be
+// scheduled on days inside one of them.
+type Term struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// Closure is a public holiday or a day (or range of days) the school is
+// closed. No lessons can be scheduled on it.
+type Closure struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Kind      string    `json:"kind"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+}
+
+// ClosureDay annotates a single day of a schedule view
+type ClosureDay struct {
+	Date time.Time `json:"date"`
+	Name string    `json:"name"`
+	Kind string    `json:"kind"`
+}
+
+type AcademicCalendarRepository interface {
+	CreateTerm(term *Term) error
+	GetTermByID(id string) (*Term, error)
+	UpdateTerm(term *Term) error
+	DeleteTerm(id string) error
+	GetAllTerms() ([]Term, error)
+	CreateClosure(closure *Closure) error
+	GetClosureByID(id string) (*Closure, error)
+	UpdateClosure(closure *Closure) error
+	DeleteClosure(id string) error
+	GetAllClosures() ([]Closure, error)
+	GetClosuresBetween(from, to time.Time) ([]Closure, error)
+}
+
+type AcademicCalendarService interface {
+	CreateTerm(term *Term) error
+	GetTermByID(id string) (*Term, error)
+	UpdateTerm(term *Term) error
+	DeleteTerm(id string) error
+	GetAllTerms() ([]Term, error)
+	CreateClosure(closure *Closure) error
+	GetClosureByID(id string) (*Closure, error)
+	UpdateClosure(closure *Closure) error
+	DeleteClosure(id string) error
+	GetAllClosures() ([]Closure, error)
+	// CheckDate returns an error when no lesson may be held on the date
+	CheckDate(date time.Time) error
+	// GetClosureDays lists the closed days in [from, to)
+	GetClosureDays(from, to time.Time) ([]ClosureDay, error)
+}
@@ -1,21 +1,98 @@
 package models
 
+import "time"
+
+// Attendance statuses
+const (
+	AttendancePresent = "present"
+	AttendanceAbsent  = "absent"
+	AttendanceLate    = "late"
+	AttendanceExcused = "excused"
+	AttendanceRemote  = "remote"
+)
+
+// AttendanceStatuses lists every attendance status in display order
+var AttendanceStatuses = []string{AttendancePresent, AttendanceLate, AttendanceRemote, AttendanceExcused, AttendanceAbsent}
 
 type Attendance struct {
-	ID         string `json:"id"`
-	StudentID  string `json:"student_id"`
-	ScheduleID string `json:"schedule_id"`
-	Here       bool   `json:"here"`
-	Counter    int    `json:"counter"`
+	ID          string `json:"id"`
+	StudentID   string `json:"student_id"`
+	ScheduleID  string `json:"schedule_id"`
+	Status      string `json:"status"`
+	MinutesLate int    `json:"minutes_late"`
+	Note        string `json:"note"`
+	Counter     int    `json:"counter"`
+}
+
+// AttendanceMark is the attendance taken for one student in one lesson
+type AttendanceMark struct {
+	Status      string `json:"status"`
+	MinutesLate int    `json:"minutes_late"`
+	Note        string `json:"note"`
+}
+
+// RollCallMark is the attendance of one student in a roll call
+type RollCallMark struct {
+	StudentID string `json:"student_id"`
+	AttendanceMark
+}
+
+// RosterEntry is a student of the lesson's class with their attendance, nil
+// until attendance is taken
+type RosterEntry struct {
+	StudentID  string      `json:"student_id"`
+	FirstName  string      `json:"first_name"`
+	LastName   string      `json:"last_name"`
+	Attendance *Attendance `json:"attendance"`
+}
+
+// Roster lists every student of the lesson's class
+type Roster struct {
+	ScheduleID string        `json:"schedule_id"`
+	ClassID    string        `json:"class_id"`
+	Students   []RosterEntry `json:"students"`
+}
+
+// AttendancePolicy sets how much a lesson in each status counts towards the
+// attendance rate, from 0 to 1; absent lessons count 0. Excused lessons are
+// left out of the rate unless CountExcused is set.
+type AttendancePolicy struct {
+	PresentWeight float64   `json:"present_weight"`
+	LateWeight    float64   `json:"late_weight"`
+	RemoteWeight  float64   `json:"remote_weight"`
+	ExcusedWeight float64   `json:"excused_weight"`
+	CountExcused  bool      `json:"count_excused"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// AttendanceSummary is a student's weighted attendance rate with the number
+// of lessons in each status. Lessons counts the lessons the rate is based on.
+type AttendanceSummary struct {
+	StudentID   string         `json:"student_id"`
+	Rate        float64        `json:"rate"`
+	Lessons     int            `json:"lessons"`
+	Breakdown   map[string]int `json:"breakdown"`
+	MinutesLate int            `json:"minutes_late"`
 }
 
 type AttendanceRepository interface {
-	CreateAttendance(attendance *Attendance) error
+	// CreateAttendance and UpdateAttendance store the audit of the excuse
+	// applied to the attendance, if any, in the same transaction
+	CreateAttendance(attendance *Attendance, audit *AttendanceAudit) error
 	GetAttendanceByID(id string) (*Attendance, error)
-	UpdateAttendance(attendance *Attendance) error
+	UpdateAttendance(attendance *Attendance, audit *AttendanceAudit) error
 	DeleteAttendance(id string) error
 	GetAttendanceByStudentID(studentID string) ([]Attendance, error)
 	GetAttendanceByScheduleID(scheduleID string) ([]Attendance, error)
+	// GetAttendanceByClassID returns the attendances of the class's lessons
+	// from one date to another, inclusive, ordered by student
+	GetAttendanceByClassID(classID string, from, to time.Time) ([]Attendance, error)
+	// SaveAttendances creates or updates the attendances of the students in
+	// one transaction, together with the audits of the excuses applied to
+	// them, keyed by student ID
+	SaveAttendances(attendances []Attendance, audits map[string]AttendanceAudit) error
+	GetAttendancePolicy() (*AttendancePolicy, error)
+	UpdateAttendancePolicy(policy *AttendancePolicy) error
 }
 
 type AttendanceService interface {
@@ -24,7 +101,14 @@ type AttendanceService interface {
 	UpdateAttendance(attendance *Attendance) error
 	DeleteAttendance(id string) error
 	GetAttendanceRateByStudent(studentID string) (float64, error)
-	MarkAttendance(studentID, scheduleID string, isPresent bool) error
+	GetAttendanceSummaryByStudent(studentID string) (*AttendanceSummary, error)
+	MarkAttendance(studentID, scheduleID string, mark AttendanceMark) error
 	GetAttendanceByStudentID(studentID string) ([]Attendance, error)
 	GetAttendanceByScheduleID(scheduleID string) ([]Attendance, error)
+	// TakeRollCall records the attendance of many students of the lesson at
+	// once; students left out keep their attendance
+	TakeRollCall(scheduleID string, marks []RollCallMark) (*Roster, error)
+	GetRoster(scheduleID string) (*Roster, error)
+	GetAttendancePolicy() (*AttendancePolicy, error)
+	SetAttendancePolicy(policy *AttendancePolicy) error
 }
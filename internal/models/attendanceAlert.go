@@ -0,0 +1,89 @@
+package models
+
+import "time"
+
+// Attendance alert statuses
+const (
+	AlertOpen     = "open"
+	AlertResolved = "resolved"
+)
+
+// Attendance trends of at-risk students
+const (
+	TrendImproving = "improving"
+	TrendDeclining = "declining"
+	TrendSteady    = "steady"
+)
+
+// AttendanceThreshold is the lowest attendance rate accepted, in percent,
+// across all lessons or in one lesson when LessonID is set. A student is
+// evaluated once MinLessons lessons count towards the rate.
+type AttendanceThreshold struct {
+	ID         string    `json:"id"`
+	LessonID   string    `json:"lesson_id,omitempty"`
+	MinRate    float64   `json:"min_rate"`
+	MinLessons int       `json:"min_lessons"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// AttendanceAlert is raised when a student's rate falls below a threshold and
+// resolved when it recovers. Recipients are the homeroom teachers of the
+// student's classes and the admins; guardians have no account, so the alert
+// carries the family phone to reach them.
+type AttendanceAlert struct {
+	ID            string     `json:"id"`
+	StudentID     string     `json:"student_id"`
+	LessonID      string     `json:"lesson_id,omitempty"`
+	ThresholdID   string     `json:"threshold_id,omitempty"`
+	Rate          float64    `json:"rate"`
+	MinRate       float64    `json:"min_rate"`
+	RecipientIDs  []string   `json:"recipient_ids"`
+	GuardianPhone string     `json:"guardian_phone,omitempty"`
+	Status        string     `json:"status"`
+	CreatedAt     time.Time  `json:"created_at"`
+	ResolvedAt    *time.Time `json:"resolved_at,omitempty"`
+}
+
+// AtRiskStudent is a student with open alerts, their overall rate now and
+// the rate before the trend window; PreviousRate is nil when the student had
+// no lessons then.
+type AtRiskStudent struct {
+	StudentID    string            `json:"student_id"`
+	FirstName    string            `json:"first_name"`
+	LastName     string            `json:"last_name"`
+	Rate         float64           `json:"rate"`
+	PreviousRate *float64          `json:"previous_rate"`
+	Trend        string            `json:"trend"`
+	Alerts       []AttendanceAlert `json:"alerts"`
+}
+
+type AttendanceAlertRepository interface {
+	CreateThreshold(threshold *AttendanceThreshold) error
+	GetThresholdByID(id string) (*AttendanceThreshold, error)
+	GetThresholds() ([]AttendanceThreshold, error)
+	UpdateThreshold(threshold *AttendanceThreshold) error
+	DeleteThreshold(id string) error
+	CreateAlert(alert *AttendanceAlert) error
+	GetOpenAlerts() ([]AttendanceAlert, error)
+	GetOpenAlertsByStudentID(studentID string) ([]AttendanceAlert, error)
+	GetOpenAlertsByRecipientID(recipientID string) ([]AttendanceAlert, error)
+	ResolveAlert(id string) error
+}
+
+type AttendanceAlertService interface {
+	CreateThreshold(threshold *AttendanceThreshold) error
+	GetThresholds() ([]AttendanceThreshold, error)
+	UpdateThreshold(threshold *AttendanceThreshold) error
+	DeleteThreshold(id string) error
+	// EvaluateStudent raises alerts for the thresholds the student's rate is
+	// below and resolves the ones it recovered from; it returns the new alerts
+	EvaluateStudent(studentID string) ([]AttendanceAlert, error)
+	// EvaluateStudents evaluates the students together, fetching the alert
+	// recipients once
+	EvaluateStudents(studentIDs ...string) ([]AttendanceAlert, error)
+	// EvaluateAll evaluates every student of every class
+	EvaluateAll() ([]AttendanceAlert, error)
+	GetAtRiskStudents() ([]AtRiskStudent, error)
+	GetAlertsByRecipientID(recipientID string) ([]AttendanceAlert, error)
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"io"
+	"time"
+)
+
+// Attendance export formats
+const (
+	ExportCSV  = "csv"
+	ExportXLSX = "xlsx"
+)
+
+// AttendanceStatusCodes are the codes written in the cells of an attendance
+// sheet
+var AttendanceStatusCodes = map[string]string{
+	AttendancePresent: "P",
+	AttendanceLate:    "L",
+	AttendanceRemote:  "R",
+	AttendanceExcused: "E",
+	AttendanceAbsent:  "A",
+}
+
+// AttendanceSheet is the attendance of a class over a date range, with the
+// students as rows and the non-cancelled lessons as columns. Attendances is
+// keyed by student ID, then schedule ID.
+type AttendanceSheet struct {
+	ClassID     string
+	ClassName   string
+	From        time.Time
+	To          time.Time
+	Sessions    []Schedule
+	LessonNames map[string]string
+	Students    []User
+	Attendances map[string]map[string]Attendance
+	Policy      AttendancePolicy
+}
+
+type AttendanceExportService interface {
+	// GetAttendanceSheet loads the sheet before anything is written, so
+	// errors can still be reported to the client
+	GetAttendanceSheet(classID string, from, to time.Time) (*AttendanceSheet, error)
+	// WriteAttendanceSheet writes the sheet to w one student at a time
+	WriteAttendanceSheet(w io.Writer, sheet *AttendanceSheet, format string) error
+}
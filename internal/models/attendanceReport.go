@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// Attendance report groupings
+const (
+	ReportByStudent = "student"
+	ReportByClass   = "class"
+	ReportByLesson  = "lesson"
+	ReportByTeacher = "teacher"
+)
+
+// Attendance report periods; ReportPeriodNone reports the whole range at once
+const (
+	ReportPeriodWeek  = "week"
+	ReportPeriodMonth = "month"
+	ReportPeriodNone  = "none"
+)
+
+// AttendanceReportFilter selects the lessons of an attendance report and how
+// they are grouped. The ID filters are optional.
+type AttendanceReportFilter struct {
+	GroupBy   string    `json:"group_by"`
+	Period    string    `json:"period"`
+	From      time.Time `json:"from"`
+	To        time.Time `json:"to"`
+	ClassID   string    `json:"class_id,omitempty"`
+	LessonID  string    `json:"lesson_id,omitempty"`
+	TeacherID string    `json:"teacher_id,omitempty"`
+	StudentID string    `json:"student_id,omitempty"`
+}
+
+// AttendanceReportRow is the weighted attendance rate of one student, class,
+// lesson or teacher in the period starting at PeriodStart. Lessons counts the
+// lessons the rate is based on.
+type AttendanceReportRow struct {
+	GroupID     string         `json:"group_id"`
+	Name        string         `json:"name"`
+	PeriodStart time.Time      `json:"period_start"`
+	Rate        float64        `json:"rate"`
+	Lessons     int            `json:"lessons"`
+	Breakdown   map[string]int `json:"breakdown"`
+	MinutesLate int            `json:"minutes_late"`
+}
+
+type AttendanceReport struct {
+	AttendanceReportFilter
+	Rows []AttendanceReportRow `json:"rows"`
+}
+
+type AttendanceReportRepository interface {
+	// GetAttendanceReport aggregates the rows in the database, weighted by
+	// the current attendance policy
+	GetAttendanceReport(filter AttendanceReportFilter) ([]AttendanceReportRow, error)
+}
+
+type AttendanceReportService interface {
+	GetAttendanceReport(filter AttendanceReportFilter) (*AttendanceReport, error)
+}
@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Availability window kinds
+const (
+	AvailabilityAvailable   = "available"
+	AvailabilityUnavailable = "unavailable"
+)
+
+// AvailabilityWindow is a span of a day in which a teacher can or cannot
+// teach. Weekly windows set Weekday, one-off windows set Date.
+//
+// Once a teacher has weekly available windows, lessons must fit inside one of
+// them or inside a one-off available window on that date. Unavailable windows
+// always take precedence.
+type AvailabilityWindow struct {
+	ID        string        `json:"id"`
+	TeacherID string        `json:"teacher_id"`
+	Kind      string        `json:"kind"`
+	Weekday   *time.Weekday `json:"weekday,omitempty"`
+	Date      *time.Time    `json:"date,omitempty"`
+	Time      time.Time     `json:"time"`
+	EndTime   time.Time     `json:"end_time"`
+	Note      string        `json:"note"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type AvailabilityRepository interface {
+	CreateAvailabilityWindow(window *AvailabilityWindow) error
+	GetAvailabilityWindowByID(id string) (*AvailabilityWindow, error)
+	UpdateAvailabilityWindow(window *AvailabilityWindow) error
+	DeleteAvailabilityWindow(id string) error
+	GetAvailabilityWindowsByTeacherID(teacherID string) ([]AvailabilityWindow, error)
+}
+
+type AvailabilityService interface {
+	CreateAvailabilityWindow(window *AvailabilityWindow) error
+	UpdateAvailabilityWindow(window *AvailabilityWindow) error
+	DeleteAvailabilityWindow(teacherID, id string) error
+	GetAvailabilityWindows(teacherID string) ([]AvailabilityWindow, error)
+}
@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// BellSchedule is a named set of periods. While a bell schedule is active,
+// lessons start in one of its periods and take the period's end time. Only
+// one bell schedule is active at a time.
+type BellSchedule struct {
+	ID        string       `json:"id"`
+	Name      string       `json:"name"`
+	Active    bool         `json:"active"`
+	Periods   []BellPeriod `json:"periods"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// BellPeriod is a period held on the listed weekdays. Periods sharing a
+// position form one row of the week grid, so a shorter Friday period can sit
+// next to the regular one.
+type BellPeriod struct {
+	ID       string         `json:"id"`
+	Name     string         `json:"name"`
+	Position int            `json:"position"`
+	Weekdays []time.Weekday `json:"weekdays"`
+	Time     time.Time      `json:"time"`
+	EndTime  time.Time      `json:"end_time"`
+}
+
+// WeekGrid lays a week's lessons out by period and day for the dashboard
+type WeekGrid struct {
+	BellScheduleID string        `json:"bell_schedule_id"`
+	Days           []time.Time   `json:"days"`
+	Rows           []WeekGridRow `json:"rows"`
+	// Lessons not held in any period, e.g. created before the bell schedule
+	Unplaced []Schedule `json:"unplaced"`
+}
+
+type WeekGridRow struct {
+	Position int            `json:"position"`
+	Name     string         `json:"name"`
+	Cells    []WeekGridCell `json:"cells"`
+}
+
+// WeekGridCell holds the lessons of one period on one day. Period is nil on
+// days without a period at this position.
+type WeekGridCell struct {
+	Date      time.Time   `json:"date"`
+	Period    *BellPeriod `json:"period,omitempty"`
+	Schedules []Schedule  `json:"schedules"`
+}
+
+type BellScheduleRepository interface {
+	// CreateBellSchedule stores the bell schedule with its periods in one
+	// transaction
+	CreateBellSchedule(bellSchedule *BellSchedule) error
+	GetBellScheduleByID(id string) (*BellSchedule, error)
+	// UpdateBellSchedule updates, adds and removes periods in one
+	// transaction. Periods keep their ID so lessons stay linked to them.
+	UpdateBellSchedule(bellSchedule *BellSchedule) error
+	DeleteBellSchedule(id string) error
+	GetAllBellSchedules() ([]BellSchedule, error)
+}
+
+type BellScheduleService interface {
+	CreateBellSchedule(bellSchedule *BellSchedule) error
+	GetBellScheduleByID(id string) (*BellSchedule, error)
+	UpdateBellSchedule(bellSchedule *BellSchedule) error
+	DeleteBellSchedule(id string) error
+	GetAllBellSchedules() ([]BellSchedule, error)
+	// GetActiveBellSchedule returns nil when lessons use free-form times
+	GetActiveBellSchedule() (*BellSchedule, error)
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Calendar feed owners
+const (
+	FeedOwnerTeacher = "teacher"
+	FeedOwnerClass   = "class"
+	FeedOwnerStudent = "student"
+)
+
+// Homework entry styles in calendar feeds
+const (
+	HomeworkAsEvent = "event"
+	HomeworkAsTodo  = "todo"
+)
+
+// CalendarFeed is a private subscription URL for the timetable of a
+// teacher, class or student. The token alone grants read access.
+type CalendarFeed struct {
+	ID        string    `json:"id"`
+	Token     string    `json:"token"`
+	OwnerType string    `json:"owner_type"`
+	OwnerID   string    `json:"owner_id"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CalendarFeedRepository interface {
+	CreateCalendarFeed(feed *CalendarFeed) error
+	GetCalendarFeedByID(id string) (*CalendarFeed, error)
+	GetCalendarFeedByToken(token string) (*CalendarFeed, error)
+	GetCalendarFeedsByCreator(userID string) ([]CalendarFeed, error)
+	DeleteCalendarFeed(id string) error
+}
+
+type CalendarService interface {
+	CreateFeed(ownerType, ownerID, createdBy string) (*CalendarFeed, error)
+	GetFeedsByUser(userID string) ([]CalendarFeed, error)
+	RevokeFeed(feedID, userID string) error
+	// RenderFeed returns the iCalendar document for the feed token
+	RenderFeed(token, homeworkStyle string) (string, error)
+}
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// CheckInSession lets the students of a lesson check themselves in until
+// ClosesAt with a code that changes every CodeSeconds seconds. Students
+// checking in more than LateAfterMinutes after the lesson starts are late;
+// LateAfterMinutes is left nil for the default and may be 0.
+type CheckInSession struct {
+	ID               string     `json:"id"`
+	ScheduleID       string     `json:"schedule_id"`
+	OpenedBy         string     `json:"opened_by"`
+	Secret           string     `json:"-"`
+	CodeSeconds      int        `json:"code_seconds"`
+	WindowMinutes    int        `json:"window_minutes"`
+	LateAfterMinutes *int       `json:"late_after_minutes"`
+	OpenedAt         time.Time  `json:"opened_at"`
+	ClosesAt         time.Time  `json:"closes_at"`
+	ClosedAt         *time.Time `json:"closed_at,omitempty"`
+}
+
+// CheckInCode is the code to show the class until ExpiresAt; QRPayload
+// carries the session and the code for the students' app to scan
+type CheckInCode struct {
+	SessionID string    `json:"session_id"`
+	Code      string    `json:"code"`
+	QRPayload string    `json:"qr_payload"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CheckInSubmission is a student's check-in, typed as the session and code
+// or scanned as the QR payload
+type CheckInSubmission struct {
+	SessionID string `json:"session_id"`
+	Code      string `json:"code"`
+	QRPayload string `json:"qr_payload"`
+}
+
+// CheckIn records the code a student used and the attendance it gave them
+type CheckIn struct {
+	ID          string    `json:"id"`
+	SessionID   string    `json:"session_id"`
+	StudentID   string    `json:"student_id"`
+	Code        string    `json:"code"`
+	Status      string    `json:"status"`
+	MinutesLate int       `json:"minutes_late"`
+	CheckedInAt time.Time `json:"checked_in_at"`
+}
+
+type CheckInRepository interface {
+	CreateSession(session *CheckInSession) error
+	GetSessionByID(id string) (*CheckInSession, error)
+	GetSessionsByScheduleID(scheduleID string) ([]CheckInSession, error)
+	CloseSession(session *CheckInSession) error
+	// CreateCheckIn fails when the student already checked in to the session
+	CreateCheckIn(checkIn *CheckIn) error
+	DeleteCheckIn(id string) error
+	GetCheckInsBySessionID(sessionID string) ([]CheckIn, error)
+}
+
+type CheckInService interface {
+	// OpenSession starts a check-in for the lesson; an empty teacherID lets
+	// admins open it for any lesson
+	OpenSession(session *CheckInSession, teacherID string) error
+	GetCurrentCode(sessionID, teacherID string) (*CheckInCode, error)
+	CloseSession(sessionID, teacherID string) (*CheckInSession, error)
+	GetCheckIns(sessionID, teacherID string) ([]CheckIn, error)
+	// CheckIn records the student's attendance when the code is current
+	CheckIn(studentID string, submission CheckInSubmission) (*CheckIn, error)
+}
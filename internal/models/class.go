@@ -21,5 +21,6 @@ type ClassService interface {
 	DeleteClass(classID string) error
 	GetAllClasses() ([]Class, error)
 	GetClassesByTeacherID(teacherID string) ([]Class, error)
+	GetClassesByStudentID(studentID string) ([]Class, error)
 	GetStudentsByClassID(classID string) ([]User, error) // New method
 }
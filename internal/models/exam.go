@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// Exam is a sitting of a lesson's exam for a class. Duration is in minutes;
+// EndTime follows from it.
+type Exam struct {
+	ID             string    `json:"id"`
+	LessonID       string    `json:"lesson_id"`
+	ClassID        string    `json:"class_id"`
+	Title          string    `json:"title"`
+	Date           time.Time `json:"date"`
+	Time           time.Time `json:"time"`
+	Duration       int       `json:"duration"`
+	EndTime        time.Time `json:"end_time"`
+	RoomID         string    `json:"room_id,omitempty"`
+	InvigilatorIDs []string  `json:"invigilator_ids"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ExamRules apply to every class. A zero MaxExamsPerDay means no limit.
+type ExamRules struct {
+	MaxExamsPerDay int       `json:"max_exams_per_day"`
+	MinGapMinutes  int       `json:"min_gap_minutes"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ExamEntry is an exam of an exam calendar with its lesson name
+type ExamEntry struct {
+	Exam
+	LessonName string `json:"lesson_name"`
+}
+
+type ExamCalendar struct {
+	From  time.Time   `json:"from"`
+	To    time.Time   `json:"to"`
+	Exams []ExamEntry `json:"exams"`
+}
+
+type ExamRepository interface {
+	CreateExam(exam *Exam) error
+	GetExamByID(id string) (*Exam, error)
+	UpdateExam(exam *Exam) error
+	DeleteExam(id string) error
+	// GetExamsBetween returns the exams dated within [from, to]
+	GetExamsBetween(from, to time.Time) ([]Exam, error)
+	GetExamRules() (*ExamRules, error)
+	UpdateExamRules(rules *ExamRules) error
+}
+
+type ExamService interface {
+	CreateExam(exam *Exam) error
+	GetExamByID(id string) (*Exam, error)
+	UpdateExam(exam *Exam) error
+	DeleteExam(id string) error
+	GetExamRules() (*ExamRules, error)
+	SetExamRules(rules *ExamRules) error
+	// GetClassExamCalendar and GetStudentExamCalendar default to the next
+	// four weeks when from is zero
+	GetClassExamCalendar(classID string, from, to time.Time) (*ExamCalendar, error)
+	GetStudentExamCalendar(studentID string, from, to time.Time) (*ExamCalendar, error)
+}
@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// Excuse statuses
+const (
+	ExcusePending  = "pending"
+	ExcuseApproved = "approved"
+	ExcuseRejected = "rejected"
+)
+
+// Excuse explains a student's absence from the listed lessons, from every
+// lesson between FromDate and ToDate, or both. SubmittedBy is the student or
+// the staff member recording an excuse given by a guardian.
+type Excuse struct {
+	ID          string     `json:"id"`
+	StudentID   string     `json:"student_id"`
+	SubmittedBy string     `json:"submitted_by"`
+	Reason      string     `json:"reason"`
+	ScheduleIDs []string   `json:"schedule_ids"`
+	FromDate    *time.Time `json:"from_date,omitempty"`
+	ToDate      *time.Time `json:"to_date,omitempty"`
+	DocumentURL string     `json:"document_url,omitempty"`
+	Status      string     `json:"status"`
+	ReviewedBy  string     `json:"reviewed_by,omitempty"`
+	ReviewNote  string     `json:"review_note,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// AttendanceAudit records an attendance status changed by an approved excuse
+type AttendanceAudit struct {
+	ID           string    `json:"id"`
+	AttendanceID string    `json:"attendance_id"`
+	ExcuseID     string    `json:"excuse_id,omitempty"`
+	ChangedBy    string    `json:"changed_by"`
+	OldStatus    string    `json:"old_status"`
+	NewStatus    string    `json:"new_status"`
+	ChangedAt    time.Time `json:"changed_at"`
+}
+
+// ExcuseReview is the outcome of reviewing an excuse with the attendance
+// changed by its approval
+type ExcuseReview struct {
+	Excuse Excuse            `json:"excuse"`
+	Audits []AttendanceAudit `json:"audits"`
+}
+
+type ExcuseRepository interface {
+	CreateExcuse(excuse *Excuse) error
+	GetExcuseByID(id string) (*Excuse, error)
+	GetExcusesByStudentID(studentID string) ([]Excuse, error)
+	GetExcusesByStatus(status string) ([]Excuse, error)
+	// GetApprovedExcusesForLesson returns the approved excuses listing the
+	// lesson or covering its date, of every student
+	GetApprovedExcusesForLesson(scheduleID string, date time.Time) ([]Excuse, error)
+	// ReviewExcuse stores the decision on a pending excuse and applies the
+	// attendance changes of the audits in one transaction
+	ReviewExcuse(excuse *Excuse, attendances []Attendance, audits []AttendanceAudit) error
+	GetAttendanceAudits(attendanceID string) ([]AttendanceAudit, error)
+}
+
+type ExcuseService interface {
+	SubmitExcuse(excuse *Excuse) error
+	GetExcuseByID(id string) (*Excuse, error)
+	GetExcusesByStudentID(studentID string) ([]Excuse, error)
+	GetPendingExcuses() ([]Excuse, error)
+	// ApproveExcuse marks the student's absent and late attendance in the
+	// excused lessons as excused. The excuse stays in effect, so attendance
+	// taken later in its lessons is recorded as excused too.
+	ApproveExcuse(id, reviewerID, note string) (*ExcuseReview, error)
+	RejectExcuse(id, reviewerID, note string) (*ExcuseReview, error)
+	GetAttendanceAudits(attendanceID string) ([]AttendanceAudit, error)
+}
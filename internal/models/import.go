@@ -0,0 +1,50 @@
+package models
+
+// Supported schedule import formats
+const (
+	ImportFormatICS = "ics"
+	ImportFormatCSV = "csv"
+)
+
+// Outcome of an imported row
+const (
+	ImportRowAccepted    = "accepted"
+	ImportRowRejected    = "rejected"
+	ImportRowConflicting = "conflicting"
+)
+
+// ImportOptions controls a schedule import. TeacherID and ClassID are used
+// for rows that do not name a teacher or class, e.g. a teacher's own
+// calendar export.
+type ImportOptions struct {
+	Format    string `json:"format"`
+	TeacherID string `json:"teacher_id"`
+	ClassID   string `json:"class_id"`
+	DryRun    bool   `json:"dry_run"`
+}
+
+// ImportRow is one schedule read from the file. Recurring events produce a
+// row per occurrence, all sharing the line of the event.
+type ImportRow struct {
+	Line      int                `json:"line"`
+	UID       string             `json:"uid,omitempty"`
+	Schedule  Schedule           `json:"schedule"`
+	Status    string             `json:"status"`
+	Errors    []string           `json:"errors,omitempty"`
+	Conflicts []ScheduleConflict `json:"conflicts,omitempty"`
+}
+
+type ImportReport struct {
+	DryRun      bool        `json:"dry_run"`
+	Accepted    int         `json:"accepted"`
+	Rejected    int         `json:"rejected"`
+	Conflicting int         `json:"conflicting"`
+	Committed   int         `json:"committed"`
+	Rows        []ImportRow `json:"rows"`
+}
+
+type ImportService interface {
+	// ImportSchedules validates every row of the file and, unless it is a
+	// dry run, stores the accepted rows in one transaction
+	ImportSchedules(data []byte, options ImportOptions) (*ImportReport, error)
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// RollCallCheck records that a lesson's roll call was checked after the
+// lesson ended. The students in MissingStudentIDs had no attendance and were
+// marked absent; while any are listed and the check is not resolved, it is a
+// reminder to the teacher that the roll call is incomplete.
+type RollCallCheck struct {
+	ScheduleID        string     `json:"schedule_id"`
+	TeacherID         string     `json:"teacher_id"`
+	MissingStudentIDs []string   `json:"missing_student_ids"`
+	CheckedAt         time.Time  `json:"checked_at"`
+	ResolvedAt        *time.Time `json:"resolved_at,omitempty"`
+}
+
+type RollCallRepository interface {
+	// GetUncheckedSchedules returns the non-cancelled lessons from one date
+	// to another whose roll call was not checked yet
+	GetUncheckedSchedules(from, to time.Time) ([]Schedule, error)
+	// SaveRollCallCheck stores the absences and the check in one
+	// transaction. Students whose attendance was taken in the meantime keep
+	// it and are left out of the check's missing students. The audits of the
+	// excuses applied to the absences, keyed by student ID, are stored with
+	// them.
+	SaveRollCallCheck(check *RollCallCheck, absences []Attendance, audits map[string]AttendanceAudit) error
+	GetRollCallCheck(scheduleID string) (*RollCallCheck, error)
+	// GetOpenReminders returns the unresolved checks with missing students,
+	// of every teacher when teacherID is empty
+	GetOpenReminders(teacherID string) ([]RollCallCheck, error)
+	ResolveRollCallCheck(scheduleID string) error
+}
+
+type RollCallService interface {
+	// CheckEndedLessons marks the students without attendance in the lessons
+	// that ended absent and returns the reminders raised for their teachers
+	CheckEndedLessons() ([]RollCallCheck, error)
+	GetReminders(teacherID string) ([]RollCallCheck, error)
+	ResolveReminder(scheduleID, teacherID string) error
+}
@@ -0,0 +1,25 @@
+package models
+
+type Room struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Capacity int      `json:"capacity"`
+	Features []string `json:"features"`
+}
+
+type RoomRepository interface {
+	CreateRoom(room *Room) error
+	GetRoomByID(id string) (*Room, error)
+	UpdateRoom(room *Room) error
+	DeleteRoom(id string) error
+	GetAllRooms() ([]Room, error)
+}
+
+type RoomService interface {
+	CreateRoom(room *Room) error
+	GetRoomByID(id string) (*Room, error)
+	UpdateRoom(room *Room) error
+	DeleteRoom(id string) error
+	GetAllRooms() ([]Room, error)
+	FindRooms(minCapacity int, features []string) ([]Room, error)
+}
@@ -9,16 +9,174 @@ type Schedule struct {
 	LessonID  string    `json:"lesson_id"`
 	ClassID   string    `json:"class_id"`
 	Time      time.Time `json:"time"`
+	EndTime   time.Time `json:"end_time"`
+	RoomID    string    `json:"room_id,omitempty"`
+
+	// Period of the active bell schedule the lesson is held in. Lessons
+	// sent with a period take its start and end time.
+	PeriodID string `json:"period_id,omitempty"`
+
+	// Set while another teacher covers the lesson. TeacherID keeps the
+	// original teacher for reporting.
+	SubstituteTeacherID string `json:"substitute_teacher_id,omitempty"`
+
+	// Set for occurrences of a recurring series. Occurrences expanded from
+	// the series rule have no ID until they are edited on their own.
+	SeriesID       string     `json:"series_id,omitempty"`
+	OccurrenceDate *time.Time `json:"occurrence_date,omitempty"`
+
+	// Cancelled lessons are kept for history instead of being deleted
+	Status             string     `json:"status"`
+	CancellationReason string     `json:"cancellation_reason,omitempty"`
+	CancelledBy        string     `json:"cancelled_by,omitempty"`
+	CancelledAt        *time.Time `json:"cancelled_at,omitempty"`
+
+	// Lesson start and end as instants in the caller's display time zone,
+	// filled in for responses only
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+	EndsAt   *time.Time `json:"ends_at,omitempty"`
+}
+
+// ScheduleSeries is a weekly recurring schedule following an RFC 5545
+// RRULE of the form FREQ=WEEKLY;BYDAY=...;UNTIL=... or COUNT=..., with
+// EXDATE style exception dates.
+type ScheduleSeries struct {
+	ID             string         `json:"id"`
+	TeacherID      string         `json:"teacher_id"`
+	LessonID       string         `json:"lesson_id"`
+	ClassID        string         `json:"class_id"`
+	StartDate      time.Time      `json:"start_date"`
+	Time           time.Time      `json:"time"`
+	EndTime        time.Time      `json:"end_time"`
+	Weekdays       []time.Weekday `json:"weekdays"`
+	Until          *time.Time     `json:"until,omitempty"`
+	Count          int            `json:"count,omitempty"`
+	ExceptionDates []time.Time    `json:"exception_dates"`
+	RRule          string         `json:"rrule,omitempty"`
+	RoomID         string         `json:"room_id,omitempty"`
+}
+
+// Schedule statuses
+const (
+	ScheduleScheduled = "scheduled"
+	ScheduleCancelled = "cancelled"
+	ScheduleCompleted = "completed"
+)
+
+// Edit scopes for changing an occurrence of a series
+const (
+	SeriesScopeThis      = "this"
+	SeriesScopeFollowing = "following"
+	SeriesScopeAll       = "all"
+)
+
+// Conflict reasons reported for overlapping schedules
+const (
+	ConflictTeacher = "teacher"
+	ConflictClass   = "class"
+	ConflictRoom    = "room"
+)
+
+// ScheduleConflict is an existing schedule overlapping a requested slot,
+// together with the window in which both take place.
+type ScheduleConflict struct {
+	Schedule     Schedule  `json:"schedule"`
+	Reasons      []string  `json:"reasons"`
+	OverlapStart time.Time `json:"overlap_start"`
+	OverlapEnd   time.Time `json:"overlap_end"`
+}
+
+// BulkRescheduleRequest moves every lesson dated from FromDate through ToDate
+// by the same number of days, so lessons on FromDate land on TargetDate.
+// ClassID and TeacherID narrow the lessons moved.
+type BulkRescheduleRequest struct {
+	FromDate   time.Time  `json:"from_date"`
+	ToDate     *time.Time `json:"to_date,omitempty"`
+	TargetDate time.Time  `json:"target_date"`
+	ClassID    string     `json:"class_id,omitempty"`
+	TeacherID  string     `json:"teacher_id,omitempty"`
+	DryRun     bool       `json:"dry_run"`
+}
+
+// ScheduleMove is one lesson of a bulk reschedule with its new date and
+// everything preventing the move
+type ScheduleMove struct {
+	Schedule Schedule  `json:"schedule"`
+	NewDate  time.Time `json:"new_date"`
+	// The new times differ from the old ones only when the bell schedule
+	// has other periods on the new weekday
+	NewTime     time.Time          `json:"new_time"`
+	NewEndTime  time.Time          `json:"new_end_time"`
+	NewPeriodID string             `json:"new_period_id,omitempty"`
+	Errors      []string           `json:"errors,omitempty"`
+	Conflicts   []ScheduleConflict `json:"conflicts,omitempty"`
+}
+
+type BulkRescheduleReport struct {
+	DryRun      bool           `json:"dry_run"`
+	Applied     bool           `json:"applied"`
+	Total       int            `json:"total"`
+	Conflicting int            `json:"conflicting"`
+	Moves       []ScheduleMove `json:"moves"`
+}
+
+// SlotSearchRequest asks for the next free slots shared by a teacher, a class
+// and optionally a room. Slots are placed into Periods when given, otherwise
+// into the periods of the active bell schedule, or without one anywhere
+// within the school day from Monday to Friday. Duration is in minutes.
+type SlotSearchRequest struct {
+	TeacherID string            `json:"teacher_id"`
+	ClassID   string            `json:"class_id"`
+	RoomID    string            `json:"room_id,omitempty"`
+	Duration  int               `json:"duration"`
+	FromDate  time.Time         `json:"from_date"`
+	ToDate    time.Time         `json:"to_date"`
+	Periods   []TimetablePeriod `json:"periods,omitempty"`
+	Limit     int               `json:"limit"`
+}
+
+// FreeSlot is a time every participant of a slot search is free
+type FreeSlot struct {
+	Date    time.Time `json:"date"`
+	Time    time.Time `json:"time"`
+	EndTime time.Time `json:"end_time"`
 }
 
 type ScheduleRepository interface {
 	CreateSchedule(schedule *Schedule) error
+	// CreateSchedules stores all schedules in one transaction
+	CreateSchedules(schedules []Schedule) error
 	GetScheduleByID(id string) (*Schedule, error)
 	UpdateSchedule(schedule *Schedule) error
 	DeleteSchedule(id string) error
 	GetAllSchedules() ([]Schedule, error)
 	GetSchedulesByTeacherID(teacherID string) ([]Schedule, error)
 	GetSchedulesByClassID(classID string) ([]Schedule, error)
+	GetSchedulesByRoomID(roomID string) ([]Schedule, error)
+	GetSchedulesBySubstituteTeacherID(teacherID string) ([]Schedule, error)
+	// GetSchedulesByIDs loads the listed schedules in one query; unknown IDs
+	// are left out
+	GetSchedulesByIDs(ids []string) ([]Schedule, error)
+}
+
+type ScheduleSeriesRepository interface {
+	CreateScheduleSeries(series *ScheduleSeries) error
+	GetScheduleSeriesByID(id string) (*ScheduleSeries, error)
+	UpdateScheduleSeries(series *ScheduleSeries) error
+	DeleteScheduleSeries(id string) error
+	GetAllScheduleSeries() ([]ScheduleSeries, error)
+	GetScheduleSeriesByTeacherID(teacherID string) ([]ScheduleSeries, error)
+	GetScheduleSeriesByClassID(classID string) ([]ScheduleSeries, error)
+	GetScheduleSeriesByRoomID(roomID string) ([]ScheduleSeries, error)
+	// DetachOccurrence excludes an occurrence from the series and stores its
+	// replacement schedule in one transaction
+	DetachOccurrence(series *ScheduleSeries, occurrence *Schedule) error
+	// SplitScheduleSeries ends the series early and starts the following
+	// series in one transaction
+	SplitScheduleSeries(series *ScheduleSeries, following *ScheduleSeries) error
+	// MoveSchedules updates the schedules, stores the series exception dates
+	// and creates the detached occurrences in one transaction
+	MoveSchedules(schedules []Schedule, series []ScheduleSeries, occurrences []Schedule) error
 }
 
 type ScheduleService interface {
@@ -29,9 +187,48 @@ type ScheduleService interface {
 	GetAllSchedules() ([]Schedule, error)
 	GetSchedulesByTeacherID(teacherID string) ([]Schedule, error)
 	GetSchedulesByClassID(classID string) ([]Schedule, error)
+	GetSchedulesByRoomID(roomID string) ([]Schedule, error)
 	RescheduleSchedule(scheduleID string, newDate time.Time, newTime time.Time) error
-	GetScheduleConflicts(teacherID, classID string, date time.Time, startTime time.Time) ([]Schedule, error)
+	// BulkReschedule moves a day or date range of lessons at once. Nothing
+	// changes unless every lesson can be moved.
+	BulkReschedule(request BulkRescheduleRequest) (*BulkRescheduleReport, error)
+	// CancelSchedule keeps the lesson with its cancellation reason instead of
+	// deleting it
+	CancelSchedule(scheduleID, reason, cancelledBy string) (*Schedule, error)
+	// CancelOccurrence materializes a series occurrence and cancels it
+	CancelOccurrence(seriesID string, occurrenceDate time.Time, reason, cancelledBy string) (*Schedule, error)
+	GetScheduleConflicts(teacherID, classID, roomID string, date time.Time, startTime time.Time, endTime time.Time) ([]ScheduleConflict, error)
 	GetUpcomingSchedules(teacherID string, days int) ([]Schedule, error)
+	// FindFreeSlots returns the earliest free slots common to the teacher,
+	// the class and the room
+	FindFreeSlots(request SlotSearchRequest) ([]FreeSlot, error)
 	GetWeekSchedules(startDate time.Time) ([]Schedule, error)
 	GetTodaySchedules() ([]Schedule, error)
+	// GetSchedulesBetween returns every schedule and series occurrence dated
+	// within [from, to)
+	GetSchedulesBetween(from, to time.Time) ([]Schedule, error)
+	// CheckScheduleDate returns an error when no lesson may be held on the date
+	CheckScheduleDate(date time.Time) error
+	// CheckTeacherAvailability returns an error when the teacher's
+	// availability windows do not allow the lesson
+	CheckTeacherAvailability(teacherID string, schedule Schedule) error
+	GetClosureDays(from, to time.Time) ([]ClosureDay, error)
+	// PlaceInPeriod fits the lesson into the active bell schedule, taking the
+	// period's start and end time
+	PlaceInPeriod(schedule *Schedule) error
+	// GetBellPeriods returns the active bell schedule's periods per weekday,
+	// or nil when lessons use free-form times
+	GetBellPeriods() ([]TimetablePeriod, error)
+	// GetWeekGrid lays the week's lessons out by period and day, or returns
+	// nil when no bell schedule is active
+	GetWeekGrid(startDate time.Time) (*WeekGrid, error)
+	CreateScheduleSeries(series *ScheduleSeries) error
+	GetScheduleSeriesByID(id string) (*ScheduleSeries, error)
+	GetAllScheduleSeries() ([]ScheduleSeries, error)
+	UpdateScheduleSeries(seriesID, scope string, occurrenceDate time.Time, changes *ScheduleSeries) error
+	DeleteScheduleSeries(seriesID, scope string, occurrenceDate time.Time) error
+	MaterializeOccurrence(seriesID string, occurrenceDate time.Time) (*Schedule, error)
+	// GetSeriesOccurrencesBetween returns the occurrences of every series
+	// dated within [from, to) that are not stored as schedules
+	GetSeriesOccurrencesBetween(from, to time.Time) ([]Schedule, error)
 }
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// TeacherAbsence marks a teacher as away for every day in the range
+type TeacherAbsence struct {
+	ID        string    `json:"id"`
+	TeacherID string    `json:"teacher_id"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SubstitutionRequest identifies the lesson to cover, either a stored
+// schedule or an occurrence of a series, and the substitute for it
+type SubstitutionRequest struct {
+	ScheduleID          string    `json:"schedule_id"`
+	SeriesID            string    `json:"series_id"`
+	OccurrenceDate      time.Time `json:"occurrence_date"`
+	SubstituteTeacherID string    `json:"substitute_teacher_id"`
+}
+
+// SubstituteCandidate is a teacher who is free during the lesson. Qualified
+// teachers already teach the lesson elsewhere.
+type SubstituteCandidate struct {
+	TeacherID      string `json:"teacher_id"`
+	Name           string `json:"name"`
+	Qualified      bool   `json:"qualified"`
+	LessonsThatDay int    `json:"lessons_that_day"`
+}
+
+type TeacherAbsenceRepository interface {
+	CreateTeacherAbsence(absence *TeacherAbsence) error
+	GetTeacherAbsenceByID(id string) (*TeacherAbsence, error)
+	DeleteTeacherAbsence(id string) error
+	GetTeacherAbsencesByTeacherID(teacherID string) ([]TeacherAbsence, error)
+	GetTeacherAbsencesBetween(from, to time.Time) ([]TeacherAbsence, error)
+}
+
+type SubstitutionService interface {
+	MarkTeacherAbsent(absence *TeacherAbsence) error
+	GetTeacherAbsences(teacherID string) ([]TeacherAbsence, error)
+	DeleteTeacherAbsence(id string) error
+	// GetAffectedSchedules lists the absent teacher's lessons in the absence,
+	// including series occurrences and lessons already covered
+	GetAffectedSchedules(absenceID string) ([]Schedule, error)
+	ProposeSubstitutes(request SubstitutionRequest) ([]SubstituteCandidate, error)
+	// AssignSubstitute records the substitute on the schedule, storing a
+	// series occurrence as its own schedule first
+	AssignSubstitute(request SubstitutionRequest) (*Schedule, error)
+	RemoveSubstitute(scheduleID string) error
+}
@@ -0,0 +1,94 @@
+package models
+
+import "time"
+
+// LessonRequirement asks for HoursPerWeek periods of a lesson for a class,
+// taught by the given teacher and optionally held in a fixed room.
+type LessonRequirement struct {
+	ClassID      string `json:"class_id"`
+	LessonID     string `json:"lesson_id"`
+	TeacherID    string `json:"teacher_id"`
+	RoomID       string `json:"room_id,omitempty"`
+	HoursPerWeek int    `json:"hours_per_week"`
+}
+
+// TimetablePeriod is a weekly slot lessons can be placed into
+type TimetablePeriod struct {
+	Weekday time.Weekday `json:"weekday"`
+	Time    time.Time    `json:"time"`
+	EndTime time.Time    `json:"end_time"`
+}
+
+// TeacherUnavailability blocks a weekly window for a teacher
+type TeacherUnavailability struct {
+	TeacherID string       `json:"teacher_id"`
+	Weekday   time.Weekday `json:"weekday"`
+	Time      time.Time    `json:"time"`
+	EndTime   time.Time    `json:"end_time"`
+}
+
+type TimetableRequest struct {
+	WeekStart      time.Time               `json:"week_start"`
+	Weeks          int                     `json:"weeks"`
+	Requirements   []LessonRequirement     `json:"requirements"`
+	Periods        []TimetablePeriod       `json:"periods"`
+	Unavailability []TeacherUnavailability `json:"unavailability"`
+}
+
+// DroppedLesson is a generated lesson left out of the preview because it
+// cannot be held on its date, e.g. a holiday or a teacher's day off
+type DroppedLesson struct {
+	Schedule
+	Reason string `json:"reason"`
+}
+
+// UnmetRequirement reports the hours of a requirement the preview falls short
+// of over all covered weeks because of dropped lessons
+type UnmetRequirement struct {
+	ClassID        string `json:"class_id"`
+	LessonID       string `json:"lesson_id"`
+	TeacherID      string `json:"teacher_id"`
+	RequiredHours  int    `json:"required_hours"`
+	ScheduledHours int    `json:"scheduled_hours"`
+}
+
+// TimetablePreview is a generated, not yet stored set of schedules
+type TimetablePreview struct {
+	WeekStart  time.Time          `json:"week_start"`
+	Weeks      int                `json:"weeks"`
+	Schedules  []Schedule         `json:"schedules"`
+	Dropped    []DroppedLesson    `json:"dropped,omitempty"`
+	UnmetHours []UnmetRequirement `json:"unmet_hours,omitempty"`
+}
+
+type TimetableService interface {
+	GenerateTimetable(request *TimetableRequest) (*TimetablePreview, error)
+	CommitTimetable(schedules []Schedule) error
+}
+
+// TimetableEntry is a lesson of a personal timetable together with the names
+// shown on the dashboard and the student's attendance status, empty until
+// attendance is taken
+type TimetableEntry struct {
+	Schedule
+	LessonName            string `json:"lesson_name"`
+	ClassName             string `json:"class_name"`
+	TeacherName           string `json:"teacher_name"`
+	SubstituteTeacherName string `json:"substitute_teacher_name,omitempty"`
+	Attendance            string `json:"attendance,omitempty"`
+}
+
+// StudentTimetable holds the lessons of every class the student belongs to
+// within [From, To]
+type StudentTimetable struct {
+	StudentID string           `json:"student_id"`
+	From      time.Time        `json:"from"`
+	To        time.Time        `json:"to"`
+	Classes   []Class          `json:"classes"`
+	Entries   []TimetableEntry `json:"entries"`
+}
+
+type StudentTimetableService interface {
+	// GetStudentTimetable defaults to the current week when from is zero
+	GetStudentTimetable(studentID string, from, to time.Time) (*StudentTimetable, error)
+}
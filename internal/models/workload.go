@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// WorkloadLimit caps how much a teacher teaches. The limit without a teacher
+// is the school default for teachers without their own limit. Zero means
+// no limit.
+type WorkloadLimit struct {
+	ID                    string    `json:"id"`
+	TeacherID             string    `json:"teacher_id,omitempty"`
+	MaxWeeklyHours        float64   `json:"max_weekly_hours"`
+	MaxConsecutiveLessons int       `json:"max_consecutive_lessons"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// TeacherWorkload sums a teacher's lessons in the report period. Daily hours
+// are keyed by date, weekly hours by the Monday of the week.
+type TeacherWorkload struct {
+	TeacherID             string             `json:"teacher_id"`
+	Name                  string             `json:"name"`
+	Lessons               int                `json:"lessons"`
+	TotalHours            float64            `json:"total_hours"`
+	DailyHours            map[string]float64 `json:"daily_hours"`
+	WeeklyHours           map[string]float64 `json:"weekly_hours"`
+	MaxConsecutiveLessons int                `json:"max_consecutive_lessons"`
+	Limit                 *WorkloadLimit     `json:"limit,omitempty"`
+	Exceeded              []string           `json:"exceeded,omitempty"`
+}
+
+type WorkloadReport struct {
+	From     time.Time         `json:"from"`
+	To       time.Time         `json:"to"`
+	TermID   string            `json:"term_id,omitempty"`
+	Teachers []TeacherWorkload `json:"teachers"`
+}
+
+type WorkloadLimitRepository interface {
+	CreateWorkloadLimit(limit *WorkloadLimit) error
+	UpdateWorkloadLimit(limit *WorkloadLimit) error
+	DeleteWorkloadLimit(id string) error
+	GetAllWorkloadLimits() ([]WorkloadLimit, error)
+}
+
+type WorkloadService interface {
+	// SetWorkloadLimit creates or replaces the limit of the teacher, or the
+	// school default when no teacher is given
+	SetWorkloadLimit(limit *WorkloadLimit) error
+	GetWorkloadLimits() ([]WorkloadLimit, error)
+	DeleteWorkloadLimit(id string) error
+	// GetWorkloadReport covers the term when termID is given, otherwise the
+	// dates from through to. An empty teacherID reports every teacher.
+	GetWorkloadReport(teacherID, termID string, from, to time.Time) (*WorkloadReport, error)
+}
@@ -0,0 +1,2 @@
+ALTER TABLE schedules DROP CONSTRAINT IF EXISTS chk_schedule_end_after_start;
+ALTER TABLE schedules DROP COLUMN IF EXISTS end_time;
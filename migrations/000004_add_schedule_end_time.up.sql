@@ -0,0 +1,12 @@
+-- schedules now span an interval instead of a single start time
+ALTER TABLE schedules ADD COLUMN end_time TIME;
+
+-- existing lessons get the default 40 minute lesson length, cut at midnight
+-- since TIME wraps around
+UPDATE schedules SET end_time = CASE
+    WHEN time >= TIME '23:20' THEN TIME '23:59:59'
+    ELSE time + INTERVAL '40 minutes'
+END;
+
+ALTER TABLE schedules ALTER COLUMN end_time SET NOT NULL;
+ALTER TABLE schedules ADD CONSTRAINT chk_schedule_end_after_start CHECK (end_time > time);
@@ -0,0 +1,4 @@
+ALTER TABLE schedules DROP CONSTRAINT IF EXISTS fk_series;
+ALTER TABLE schedules DROP COLUMN IF EXISTS occurrence_date;
+ALTER TABLE schedules DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS schedule_series CASCADE;
@@ -0,0 +1,23 @@
+-- recurring weekly schedule series (RRULE FREQ=WEEKLY with BYDAY, UNTIL/COUNT and EXDATE)
+CREATE TABLE schedule_series (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    teacher_id UUID NOT NULL,
+    lesson_id UUID NOT NULL,
+    class_id UUID NOT NULL,
+    start_date DATE NOT NULL,
+    time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    weekdays INT[] NOT NULL,
+    until_date DATE,
+    occurrence_count INT,
+    exception_dates DATE[] NOT NULL DEFAULT '{}',
+    CONSTRAINT fk_lesson FOREIGN KEY(lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    CONSTRAINT fk_class FOREIGN KEY(class_id) REFERENCES classes(id) ON DELETE CASCADE,
+    CONSTRAINT chk_series_end_after_start CHECK (end_time > time),
+    CONSTRAINT chk_series_bounded CHECK (until_date IS NOT NULL OR occurrence_count IS NOT NULL)
+);
+
+-- occurrences edited on their own are stored as regular schedules linked to the series
+ALTER TABLE schedules ADD COLUMN series_id UUID;
+ALTER TABLE schedules ADD COLUMN occurrence_date DATE;
+ALTER TABLE schedules ADD CONSTRAINT fk_series FOREIGN KEY(series_id) REFERENCES schedule_series(id) ON DELETE SET NULL;
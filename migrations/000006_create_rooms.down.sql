@@ -0,0 +1,5 @@
+ALTER TABLE schedule_series DROP CONSTRAINT IF EXISTS fk_room;
+ALTER TABLE schedule_series DROP COLUMN IF EXISTS room_id;
+ALTER TABLE schedules DROP CONSTRAINT IF EXISTS fk_room;
+ALTER TABLE schedules DROP COLUMN IF EXISTS room_id;
+DROP TABLE IF EXISTS rooms;
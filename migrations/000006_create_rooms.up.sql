@@ -0,0 +1,14 @@
+-- rooms that schedules can be booked into
+CREATE TABLE rooms (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL UNIQUE,
+    capacity INT NOT NULL,
+    features TEXT[] NOT NULL DEFAULT '{}',
+    CONSTRAINT chk_room_capacity CHECK (capacity > 0)
+);
+
+ALTER TABLE schedules ADD COLUMN room_id UUID;
+ALTER TABLE schedules ADD CONSTRAINT fk_room FOREIGN KEY(room_id) REFERENCES rooms(id) ON DELETE SET NULL;
+
+ALTER TABLE schedule_series ADD COLUMN room_id UUID;
+ALTER TABLE schedule_series ADD CONSTRAINT fk_room FOREIGN KEY(room_id) REFERENCES rooms(id) ON DELETE SET NULL;
@@ -0,0 +1,10 @@
+-- private subscription tokens for iCalendar feeds
+CREATE TABLE calendar_feeds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    token VARCHAR(64) NOT NULL UNIQUE,
+    owner_type VARCHAR(16) NOT NULL,
+    owner_id UUID NOT NULL,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_feed_owner_type CHECK (owner_type IN ('teacher', 'class', 'student'))
+);
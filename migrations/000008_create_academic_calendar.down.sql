@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS closures;
+DROP TABLE IF EXISTS terms;
@@ -0,0 +1,19 @@
+-- academic terms; schedules may only be created inside a term
+CREATE TABLE terms (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    CONSTRAINT chk_term_dates CHECK (end_date >= start_date)
+);
+
+-- public holidays and school closure days, a range covers every day in it
+CREATE TABLE closures (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    CONSTRAINT chk_closure_kind CHECK (kind IN ('holiday', 'closure')),
+    CONSTRAINT chk_closure_dates CHECK (end_date >= start_date)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE schedules DROP COLUMN IF EXISTS substitute_teacher_id;
+DROP TABLE IF EXISTS teacher_absences;
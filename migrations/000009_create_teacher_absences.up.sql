@@ -0,0 +1,13 @@
+-- teacher absences; affected lessons get a substitute while teacher_id keeps
+-- the original teacher for reporting
+CREATE TABLE teacher_absences (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    teacher_id UUID NOT NULL,
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_absence_dates CHECK (end_date >= start_date)
+);
+
+ALTER TABLE schedules ADD COLUMN substitute_teacher_id UUID;
@@ -0,0 +1,18 @@
+-- teacher availability windows, either weekly (weekday) or one-off (date)
+CREATE TABLE teacher_availability (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    teacher_id UUID NOT NULL,
+    kind VARCHAR(16) NOT NULL,
+    weekday INT,
+    date DATE,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_availability_kind CHECK (kind IN ('available', 'unavailable')),
+    CONSTRAINT chk_availability_weekday CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT chk_availability_repeat CHECK ((weekday IS NULL) <> (date IS NULL)),
+    CONSTRAINT chk_availability_times CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_teacher_availability_teacher ON teacher_availability(teacher_id);
@@ -0,0 +1,6 @@
+ALTER TABLE schedules
+    DROP CONSTRAINT IF EXISTS chk_schedule_status,
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS cancelled_by,
+    DROP COLUMN IF EXISTS cancellation_reason,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,7 @@
+-- cancelled lessons stay in history with who cancelled them and why
+ALTER TABLE schedules
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'scheduled',
+    ADD COLUMN cancellation_reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN cancelled_by UUID,
+    ADD COLUMN cancelled_at TIMESTAMP,
+    ADD CONSTRAINT chk_schedule_status CHECK (status IN ('scheduled', 'cancelled', 'completed'));
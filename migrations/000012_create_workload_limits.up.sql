@@ -0,0 +1,11 @@
+-- teaching load limits; the row without teacher_id is the school default
+CREATE TABLE workload_limits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    teacher_id UUID,
+    max_weekly_minutes INT NOT NULL DEFAULT 0,
+    max_consecutive_lessons INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_workload_limits CHECK (max_weekly_minutes >= 0 AND max_consecutive_lessons >= 0)
+);
+
+CREATE UNIQUE INDEX idx_workload_limits_teacher ON workload_limits (COALESCE(teacher_id, '00000000-0000-0000-0000-000000000000'));
@@ -0,0 +1,4 @@
+ALTER TABLE schedules DROP CONSTRAINT IF EXISTS fk_period;
+ALTER TABLE schedules DROP COLUMN IF EXISTS period_id;
+DROP TABLE IF EXISTS bell_periods;
+DROP TABLE IF EXISTS bell_schedules;
@@ -0,0 +1,28 @@
+-- named bell schedules; only one of them is active at a time
+CREATE TABLE bell_schedules (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name VARCHAR(255) NOT NULL,
+    active BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_bell_schedules_active ON bell_schedules (active) WHERE active;
+
+-- periods of a bell schedule, each held on the listed weekdays
+CREATE TABLE bell_periods (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    bell_schedule_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    position INT NOT NULL,
+    weekdays INT[] NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    CONSTRAINT fk_bell_schedule FOREIGN KEY(bell_schedule_id) REFERENCES bell_schedules(id) ON DELETE CASCADE,
+    CONSTRAINT chk_bell_period_times CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_bell_periods_schedule ON bell_periods(bell_schedule_id);
+
+-- lessons placed into a period keep a link to it
+ALTER TABLE schedules ADD COLUMN period_id UUID;
+ALTER TABLE schedules ADD CONSTRAINT fk_period FOREIGN KEY(period_id) REFERENCES bell_periods(id) ON DELETE SET NULL;
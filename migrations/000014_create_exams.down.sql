@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS exam_rules;
+DROP TABLE IF EXISTS exams;
@@ -0,0 +1,32 @@
+-- exams of a lesson for a class, supervised by the invigilators
+CREATE TABLE exams (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    lesson_id UUID NOT NULL,
+    class_id UUID NOT NULL,
+    title VARCHAR(255) NOT NULL DEFAULT '',
+    date DATE NOT NULL,
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    room_id UUID,
+    invigilator_ids UUID[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_lesson FOREIGN KEY(lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    CONSTRAINT fk_class FOREIGN KEY(class_id) REFERENCES classes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_room FOREIGN KEY(room_id) REFERENCES rooms(id) ON DELETE SET NULL,
+    CONSTRAINT chk_exam_times CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_exams_date ON exams(date);
+CREATE INDEX idx_exams_class ON exams(class_id);
+
+-- school wide exam rules, a single row
+CREATE TABLE exam_rules (
+    id INT PRIMARY KEY DEFAULT 1,
+    max_exams_per_day INT NOT NULL DEFAULT 1,
+    min_gap_minutes INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_exam_rules_single CHECK (id = 1),
+    CONSTRAINT chk_exam_rules CHECK (max_exams_per_day >= 0 AND min_gap_minutes >= 0)
+);
+
+INSERT INTO exam_rules DEFAULT VALUES;
@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS attendance_policy;
+
+ALTER TABLE attendances ADD COLUMN here BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE attendances SET here = status IN ('present', 'late', 'remote');
+
+ALTER TABLE attendances
+    DROP CONSTRAINT IF EXISTS chk_attendance_minutes_late,
+    DROP CONSTRAINT IF EXISTS chk_attendance_status,
+    DROP COLUMN IF EXISTS note,
+    DROP COLUMN IF EXISTS minutes_late,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,29 @@
+-- attendance is recorded as a status instead of a present flag
+ALTER TABLE attendances
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'absent',
+    ADD COLUMN minutes_late INT NOT NULL DEFAULT 0,
+    ADD COLUMN note TEXT NOT NULL DEFAULT '';
+
+UPDATE attendances SET status = CASE WHEN here THEN 'present' ELSE 'absent' END;
+
+ALTER TABLE attendances
+    DROP COLUMN here,
+    ADD CONSTRAINT chk_attendance_status CHECK (status IN ('present', 'absent', 'late', 'excused', 'remote')),
+    ADD CONSTRAINT chk_attendance_minutes_late CHECK (minutes_late >= 0);
+
+-- how much each status counts towards the attendance rate, a single row
+CREATE TABLE attendance_policy (
+    id INT PRIMARY KEY DEFAULT 1,
+    present_weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    late_weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    remote_weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    excused_weight DOUBLE PRECISION NOT NULL DEFAULT 1,
+    count_excused BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_attendance_policy_single CHECK (id = 1),
+    CONSTRAINT chk_attendance_policy_weights CHECK (
+        present_weight BETWEEN 0 AND 1 AND late_weight BETWEEN 0 AND 1 AND
+        remote_weight BETWEEN 0 AND 1 AND excused_weight BETWEEN 0 AND 1)
+);
+
+INSERT INTO attendance_policy DEFAULT VALUES;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_attendances_student_schedule;
@@ -0,0 +1,36 @@
+-- one attendance row per student and lesson, so a roll call can upsert it.
+-- Attendances have no timestamps to tell the latest duplicate, so a recorded
+-- status wins over a default absence and duplicates that still disagree stop
+-- the migration until they are resolved by hand.
+DO $$
+DECLARE
+    conflicts TEXT;
+BEGIN
+    SELECT string_agg(format('student %s, schedule %s', student_id, schedule_id), '; ')
+    INTO conflicts
+    FROM (
+        SELECT student_id, schedule_id, status, minutes_late, note,
+               RANK() OVER (PARTITION BY student_id, schedule_id ORDER BY status = 'absent') AS preference
+        FROM attendances
+    ) ranked
+    WHERE preference = 1
+    GROUP BY student_id, schedule_id
+    HAVING COUNT(DISTINCT (status, minutes_late, note)) > 1;
+
+    IF conflicts IS NOT NULL THEN
+        RAISE EXCEPTION 'conflicting duplicate attendances: %', conflicts;
+    END IF;
+END $$;
+
+DELETE FROM attendances
+WHERE id IN (
+    SELECT id
+    FROM (
+        SELECT id,
+               ROW_NUMBER() OVER (PARTITION BY student_id, schedule_id ORDER BY status = 'absent', counter DESC, id) AS keep
+        FROM attendances
+    ) ranked
+    WHERE keep > 1
+);
+
+CREATE UNIQUE INDEX idx_attendances_student_schedule ON attendances(student_id, schedule_id);
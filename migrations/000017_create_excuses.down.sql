@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS attendance_audits;
+DROP TABLE IF EXISTS excuses;
@@ -0,0 +1,36 @@
+-- absence excuses for lessons or a date range, reviewed by staff
+CREATE TABLE excuses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    student_id UUID NOT NULL,
+    submitted_by UUID NOT NULL,
+    reason TEXT NOT NULL,
+    schedule_ids UUID[] NOT NULL DEFAULT '{}',
+    from_date DATE,
+    to_date DATE,
+    document_url TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending',
+    reviewed_by UUID,
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT chk_excuse_status CHECK (status IN ('pending', 'approved', 'rejected')),
+    CONSTRAINT chk_excuse_dates CHECK ((from_date IS NULL) = (to_date IS NULL) AND (from_date IS NULL OR to_date >= from_date))
+);
+
+CREATE INDEX idx_excuses_student ON excuses(student_id);
+CREATE INDEX idx_excuses_status ON excuses(status);
+
+-- attendance changes made by approved excuses
+CREATE TABLE attendance_audits (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    attendance_id UUID NOT NULL,
+    excuse_id UUID,
+    changed_by UUID NOT NULL,
+    old_status TEXT NOT NULL,
+    new_status TEXT NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_attendance FOREIGN KEY(attendance_id) REFERENCES attendances(id) ON DELETE CASCADE,
+    CONSTRAINT fk_excuse FOREIGN KEY(excuse_id) REFERENCES excuses(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_attendance_audits_attendance ON attendance_audits(attendance_id);
@@ -0,0 +1,36 @@
+-- lowest accepted attendance rate, overall when lesson_id is NULL
+CREATE TABLE attendance_thresholds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    lesson_id UUID,
+    min_rate DOUBLE PRECISION NOT NULL,
+    min_lessons INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_lesson FOREIGN KEY(lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    CONSTRAINT chk_attendance_threshold CHECK (min_rate > 0 AND min_rate <= 100 AND min_lessons >= 1)
+);
+
+-- one overall threshold and one per lesson
+CREATE UNIQUE INDEX idx_attendance_thresholds_overall ON attendance_thresholds((lesson_id IS NULL)) WHERE lesson_id IS NULL;
+CREATE UNIQUE INDEX idx_attendance_thresholds_lesson ON attendance_thresholds(lesson_id) WHERE lesson_id IS NOT NULL;
+
+-- students whose attendance fell below a threshold
+CREATE TABLE attendance_alerts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    student_id UUID NOT NULL,
+    lesson_id UUID,
+    threshold_id UUID,
+    rate DOUBLE PRECISION NOT NULL,
+    min_rate DOUBLE PRECISION NOT NULL,
+    recipient_ids UUID[] NOT NULL DEFAULT '{}',
+    guardian_phone TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'open',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    CONSTRAINT fk_lesson FOREIGN KEY(lesson_id) REFERENCES lessons(id) ON DELETE CASCADE,
+    CONSTRAINT fk_threshold FOREIGN KEY(threshold_id) REFERENCES attendance_thresholds(id) ON DELETE SET NULL,
+    CONSTRAINT chk_attendance_alert_status CHECK (status IN ('open', 'resolved'))
+);
+
+CREATE INDEX idx_attendance_alerts_student ON attendance_alerts(student_id);
+CREATE INDEX idx_attendance_alerts_open ON attendance_alerts(status) WHERE status = 'open';
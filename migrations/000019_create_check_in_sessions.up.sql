@@ -0,0 +1,29 @@
+-- self check-in sessions, the code rotates every code_seconds from the secret
+CREATE TABLE check_in_sessions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    schedule_id UUID NOT NULL,
+    opened_by UUID NOT NULL,
+    secret TEXT NOT NULL,
+    code_seconds INT NOT NULL DEFAULT 30,
+    late_after_minutes INT NOT NULL DEFAULT 5,
+    opened_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    closes_at TIMESTAMP NOT NULL,
+    closed_at TIMESTAMP,
+    CONSTRAINT fk_schedule FOREIGN KEY(schedule_id) REFERENCES schedules(id) ON DELETE CASCADE,
+    CONSTRAINT chk_check_in_session CHECK (code_seconds > 0 AND late_after_minutes >= 0 AND closes_at > opened_at)
+);
+
+CREATE INDEX idx_check_in_sessions_schedule ON check_in_sessions(schedule_id);
+
+-- one check-in per student and session, so a code cannot be replayed
+CREATE TABLE check_ins (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    session_id UUID NOT NULL,
+    student_id UUID NOT NULL,
+    code TEXT NOT NULL,
+    status TEXT NOT NULL,
+    minutes_late INT NOT NULL DEFAULT 0,
+    checked_in_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_session FOREIGN KEY(session_id) REFERENCES check_in_sessions(id) ON DELETE CASCADE,
+    CONSTRAINT uq_check_in_student UNIQUE (session_id, student_id)
+);
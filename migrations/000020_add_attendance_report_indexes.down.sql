@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_attendances_schedule;
+DROP INDEX IF EXISTS idx_schedules_date;
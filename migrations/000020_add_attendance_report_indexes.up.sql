@@ -0,0 +1,3 @@
+-- attendance reports filter lessons by date and join attendances on them
+CREATE INDEX IF NOT EXISTS idx_schedules_date ON schedules(date);
+CREATE INDEX IF NOT EXISTS idx_attendances_schedule ON attendances(schedule_id);
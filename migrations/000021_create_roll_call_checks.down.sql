@@ -0,0 +1 @@
+DROP TABLE IF EXISTS roll_call_checks;
@@ -0,0 +1,13 @@
+-- lessons whose roll call was checked after they ended; the students in
+-- missing_student_ids had no attendance and were marked absent, and the
+-- teacher is reminded until the check is resolved
+CREATE TABLE roll_call_checks (
+    schedule_id UUID PRIMARY KEY,
+    teacher_id UUID NOT NULL,
+    missing_student_ids UUID[] NOT NULL DEFAULT '{}',
+    checked_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP,
+    CONSTRAINT fk_schedule FOREIGN KEY(schedule_id) REFERENCES schedules(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_roll_call_checks_teacher ON roll_call_checks(teacher_id);